@@ -1,7 +1,9 @@
 package app
 
 import (
+	"bytes"
 	"errors"
+	"io"
 
 	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
 	"github.com/opensourceways/xihe-server/aiccfinetune/domain/aiccfinetune"
@@ -12,12 +14,14 @@ import (
 
 	types "github.com/opensourceways/xihe-server/domain"
 	orepo "github.com/opensourceways/xihe-server/domain/repository"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
 	"github.com/opensourceways/xihe-server/utils"
 )
 
 const (
-	trainingStatusScheduling     = "scheduling"
-	trainingStatusScheduleFailed = "schedule_failed"
+	trainingStatusScheduling     = jobdomain.JobStatusScheduling
+	trainingStatusScheduleFailed = jobdomain.JobStatusScheduleFailed
 )
 
 type JobDetail = domain.JobDetail
@@ -25,17 +29,17 @@ type AICCFinetuneIndex = domain.AICCFinetuneIndex
 type AICCFinetuneConfig = domain.AICCFinetuneConfig
 
 type AICCFinetuneService interface {
-	Create(*AICCFinetuneCreateCmd) (string, error)
+	Create(*AICCFinetuneCreateCmd) (string, string, error)
 	UpdateJobDetail(*AICCFinetuneIndex, *JobDetail) error
 	List(user types.Account, model domain.ModelName) ([]AICCFinetuneSummaryDTO, error)
 	Get(*AICCFinetuneIndex) (AICCFinetuneDTO, string, error)
 	Delete(*AICCFinetuneIndex) error
-	Terminate(*AICCFinetuneIndex) error
-	GetLogDownloadURL(*AICCFinetuneIndex) (string, string, error)
-	GetOutputDownloadURL(*AICCFinetuneIndex) (string, string, error)
 	CreateAICCFinetuneJob(*AICCFinetuneIndex, string, bool) (bool, error)
 
-	UploadData(*UploadDataCmd) (UploadDataDTO, error)
+	UploadData(*UploadDataCmd) (UploadDataDTO, string, error)
+	ListDatasets(user types.Account, model domain.ModelName) ([]DatasetDTO, error)
+	GetDataset(*DatasetIndex) (DatasetDTO, string, error)
+	DeleteDataset(*DatasetIndex) error
 }
 
 func NewAICCFinetuneService(
@@ -43,31 +47,73 @@ func NewAICCFinetuneService(
 	sender message.AICCFinetuneMessageProducer,
 	uploader uploader.DataFileUploader,
 	repo repository.AICCFinetune,
+	datasetRepo repository.Dataset,
+	datasetCfg *domain.DatasetConfig,
+	internal AICCFinetuneInternalService,
 	maxTrainingRecordNum int,
 ) AICCFinetuneService {
 	return aiccFinetuneService{
+		internal:             internal,
 		af:                   af,
 		sender:               sender,
 		uploader:             domain.NewUploadService(uploader),
+		validator:            domain.NewDatasetValidator(datasetCfg),
 		repo:                 repo,
+		datasetRepo:          datasetRepo,
+		maxDatasetNum:        datasetCfg.MaxDatasetNum,
+		maxFileSize:          datasetCfg.MaxFileSize,
 		maxTrainingRecordNum: maxTrainingRecordNum,
 	}
 }
 
 type aiccFinetuneService struct {
+	internal             AICCFinetuneInternalService
 	af                   aiccfinetune.AICCFinetuneServer
 	sender               message.AICCFinetuneMessageProducer
 	uploader             domain.UploadService
+	validator            domain.DatasetValidator
 	repo                 repository.AICCFinetune
+	datasetRepo          repository.Dataset
+	maxDatasetNum        int
+	maxFileSize          int64
 	maxTrainingRecordNum int
 }
 
 func (s aiccFinetuneService) isJobDone(status string) bool {
-	return status != "" && (s.af.IsJobDone(status) || status == trainingStatusScheduleFailed)
+	return jobdomain.NewJobLifecycle(s.af).IsDone(status)
 }
 
-func (s aiccFinetuneService) Create(cmd *AICCFinetuneCreateCmd) (string, error) {
-	return s.create(cmd.User, cmd.Model, cmd.Task, cmd.toAICCFinetuneConfig())
+func (s aiccFinetuneService) Create(cmd *AICCFinetuneCreateCmd) (string, string, error) {
+	if cmd.Dataset != "" {
+		if code, err := s.checkDataset(cmd); err != nil {
+			return "", code, err
+		}
+	}
+
+	v, err := s.create(cmd.User, cmd.Model, cmd.Task, cmd.toAICCFinetuneConfig())
+
+	return v, "", err
+}
+
+func (s aiccFinetuneService) checkDataset(cmd *AICCFinetuneCreateCmd) (string, error) {
+	v, err := s.datasetRepo.Get(&DatasetIndex{
+		User:      cmd.User,
+		Model:     cmd.Model,
+		DatasetId: cmd.Dataset,
+	})
+	if err != nil {
+		if orepo.IsErrorResourceNotExists(err) {
+			return app.ErrorAICCDatasetNotFound, err
+		}
+
+		return "", err
+	}
+
+	if v.Task.FinetuneTask() != cmd.Task.FinetuneTask() {
+		return app.ErrorAICCDatasetNotFound, errors.New("the dataset is not for the task")
+	}
+
+	return "", nil
 }
 
 func (s aiccFinetuneService) create(
@@ -152,7 +198,7 @@ func (s aiccFinetuneService) Get(info *AICCFinetuneIndex) (dto AICCFinetuneDTO,
 }
 
 func (s aiccFinetuneService) UpdateJobDetail(info *AICCFinetuneIndex, v *JobDetail) error {
-	return s.repo.UpdateJobDetail(info, v)
+	return s.internal.UpdateJobDetails(info, v)
 }
 
 func (s aiccFinetuneService) Delete(info *AICCFinetuneIndex) error {
@@ -175,51 +221,6 @@ func (s aiccFinetuneService) Delete(info *AICCFinetuneIndex) error {
 	return s.repo.Delete(info)
 }
 
-func (s aiccFinetuneService) Terminate(info *AICCFinetuneIndex) error {
-	job, err := s.repo.GetJob(info)
-	if err != nil || job.JobId == "" {
-		return err
-	}
-
-	return s.af.TerminateJob(job.Endpoint, job.JobId)
-}
-
-func (s aiccFinetuneService) GetLogDownloadURL(info *AICCFinetuneIndex) (
-	link string, code string, err error,
-) {
-	detail, endpoint, err := s.repo.GetJobDetail(info)
-	if err != nil {
-		return
-	}
-
-	if detail.LogPath == "" {
-		code = app.ErrorAICCFinetuneNoLog
-		err = errors.New("not ready")
-	} else {
-		link, err = s.af.GetFileDownloadURL(endpoint, detail.LogPath)
-	}
-
-	return
-}
-
-func (s aiccFinetuneService) GetOutputDownloadURL(info *AICCFinetuneIndex) (
-	link string, code string, err error,
-) {
-	detail, endpoint, err := s.repo.GetJobDetail(info)
-	if err != nil {
-		return
-	}
-
-	if detail.OutputPath == "" {
-		code = app.ErrorTrainNoOutput
-		err = errors.New("not ready")
-	} else {
-		link, err = s.af.GetFileDownloadURL(endpoint, detail.OutputPath)
-	}
-
-	return
-}
-
 func (s aiccFinetuneService) CreateAICCFinetuneJob(
 	info *AICCFinetuneIndex, endpoint string, lastChance bool,
 ) (retry bool, err error) {
@@ -229,7 +230,7 @@ func (s aiccFinetuneService) CreateAICCFinetuneJob(
 	}
 
 	if lastChance {
-		err = s.repo.UpdateJobDetail(info, &JobDetail{
+		err = s.internal.UpdateJobDetails(info, &JobDetail{
 			Status: trainingStatusScheduleFailed,
 			Error:  err.Error(),
 		})
@@ -259,6 +260,12 @@ func (s aiccFinetuneService) createAICCFinetuneJob(info *AICCFinetuneIndex, endp
 		return false, nil
 	}
 
+	if data.Dataset != "" {
+		if retry, err = s.useDataset(info, data.Dataset); err != nil {
+			return
+		}
+	}
+
 	v, err := s.af.CreateJob(endpoint, info, &data)
 	if err != nil {
 		retry = true
@@ -273,16 +280,84 @@ func (s aiccFinetuneService) createAICCFinetuneJob(info *AICCFinetuneIndex, endp
 	return
 }
 
-func (s aiccFinetuneService) UploadData(cmd *UploadDataCmd) (dto UploadDataDTO, err error) {
-	err = s.uploader.Upload(cmd.Data, cmd.FileName, cmd.User.Account(), cmd.Model.ModelName(), cmd.Task.FinetuneTask())
+func (s aiccFinetuneService) useDataset(info *AICCFinetuneIndex, id string) (retry bool, err error) {
+	v, err := s.datasetRepo.Get(&DatasetIndex{
+		User:      info.User,
+		Model:     info.Model,
+		DatasetId: id,
+	})
+	if err != nil {
+		if orepo.IsErrorResourceNotExists(err) {
+			err = errors.New("dataset is not exist")
+		} else {
+			retry = true
+		}
 
+		return
+	}
+
+	if err = s.uploader.UseDataset(&v); err != nil {
+		retry = true
+	}
+
+	return
+}
+
+// UploadData validates the data before uploading it if it is a dataset,
+// and keeps it for the later finetunes if it is valid.
+func (s aiccFinetuneService) UploadData(cmd *UploadDataCmd) (
+	dto UploadDataDTO, code string, err error,
+) {
 	dto.FileName = cmd.FileName
 	dto.UploadAt = utils.Now()
-	dto.Status = "failed"
-	if err == nil {
-		dto.Status = "success"
+	dto.Status = uploadStatusFailed
+
+	data, err := io.ReadAll(io.LimitReader(cmd.Data, s.maxFileSize+1))
+	if err != nil {
+		return
+	}
+
+	if int64(len(data)) > s.maxFileSize {
+		code = app.ErrorAICCDataTooBig
+		err = errors.New("too big data")
+
 		return
 	}
+
+	report, checked := s.validator.Validate(data, cmd.FileName, cmd.Task)
+	if !checked {
+		err = s.uploader.Upload(
+			bytes.NewReader(data), cmd.FileName, cmd.User.Account(),
+			cmd.Model.ModelName(), cmd.Task.FinetuneTask(),
+		)
+		if err == nil {
+			dto.Status = uploadStatusSuccess
+		}
+
+		return
+	}
+
+	dto.Report = toDatasetReportDTO(&report)
+
+	if !report.IsValid() {
+		dto.Status = uploadStatusInvalid
+
+		return
+	}
+
+	dataset := domain.Dataset{
+		User:      cmd.User,
+		Model:     cmd.Model,
+		Task:      cmd.Task,
+		FileName:  cmd.FileName,
+		Report:    report,
+		CreatedAt: dto.UploadAt,
+	}
+
+	if dto.DatasetId, code, err = s.saveDataset(&dataset, data); err == nil {
+		dto.Status = uploadStatusSuccess
+	}
+
 	return
 }
 
@@ -291,17 +366,34 @@ type AICCFinetuneInternalService interface {
 }
 
 type aiccfinetuneInternalService struct {
-	repo repository.AICCFinetune
+	repo     repository.AICCFinetune
+	notifier jobapp.JobDetailNotifier
 }
 
 func NewAICCFinetuneInternalService(
 	repo repository.AICCFinetune,
+	notifier jobapp.JobDetailNotifier,
 ) AICCFinetuneInternalService {
 	return aiccfinetuneInternalService{
-		repo: repo,
+		repo:     repo,
+		notifier: notifier,
 	}
 }
 
 func (s aiccfinetuneInternalService) UpdateJobDetails(info *AICCFinetuneIndex, v *JobDetail) error {
-	return s.repo.UpdateJobDetail(info, v)
+	if err := s.repo.UpdateJobDetail(info, v); err != nil {
+		return err
+	}
+
+	s.notifier.NotifyJobDetailUpdated(
+		&jobdomain.JobIndex{
+			Type:  jobdomain.JobTypeAICCFinetune,
+			Owner: info.User,
+			Scope: info.Model.ModelName(),
+			Id:    info.FinetuneId,
+		},
+		v,
+	)
+
+	return nil
 }
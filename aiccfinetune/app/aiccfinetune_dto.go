@@ -145,4 +145,8 @@ type UploadDataDTO struct {
 	UploadAt int64  `json:"upload_at"`
 	FileName string `json:"file_name"`
 	Status   string `json:"status"`
+
+	// DatasetId and Report are set if the data is a dataset
+	DatasetId string            `json:"dataset_id,omitempty"`
+	Report    *DatasetReportDTO `json:"report,omitempty"`
 }
@@ -0,0 +1,134 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain/aiccfinetune"
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
+)
+
+// NewAICCFinetuneJobDriver plugs the aicc finetune into the job subsystem.
+// The scope of job is the name of model.
+func NewAICCFinetuneJobDriver(
+	af aiccfinetune.AICCFinetuneServer,
+	repo repository.AICCFinetune,
+	internal AICCFinetuneInternalService,
+) jobapp.Driver {
+	return aiccFinetuneJobDriver{
+		af:       af,
+		repo:     repo,
+		internal: internal,
+	}
+}
+
+type aiccFinetuneJobDriver struct {
+	af       aiccfinetune.AICCFinetuneServer
+	repo     repository.AICCFinetune
+	internal AICCFinetuneInternalService
+}
+
+func (d aiccFinetuneJobDriver) Type() jobdomain.JobType {
+	return jobdomain.JobTypeAICCFinetune
+}
+
+func (d aiccFinetuneJobDriver) toAICCFinetuneIndex(index *jobdomain.JobIndex) (
+	AICCFinetuneIndex, error,
+) {
+	model, err := domain.NewModelName(index.Scope)
+	if err != nil {
+		return AICCFinetuneIndex{}, err
+	}
+
+	return AICCFinetuneIndex{
+		User:       index.Owner,
+		Model:      model,
+		FinetuneId: index.Id,
+	}, nil
+}
+
+func (d aiccFinetuneJobDriver) GetJob(index *jobdomain.JobIndex) (job jobdomain.Job, err error) {
+	info, err := d.toAICCFinetuneIndex(index)
+	if err != nil {
+		return
+	}
+
+	v, err := d.repo.Get(&info)
+	if err != nil {
+		return
+	}
+
+	job = jobdomain.Job{
+		Id:        index.Id,
+		Name:      v.Name.FinetuneName(),
+		CreatedAt: v.CreatedAt,
+		Info:      v.Job,
+		Detail:    v.JobDetail,
+	}
+
+	return
+}
+
+func (d aiccFinetuneJobDriver) ListJobs(owner types.Account, scope string) ([]jobdomain.Job, error) {
+	model, err := domain.NewModelName(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	v, _, err := d.repo.List(owner, model)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]jobdomain.Job, len(v))
+	for i := range v {
+		item := &v[i]
+
+		r[i] = jobdomain.Job{
+			Id:        item.Id,
+			Name:      item.Name.FinetuneName(),
+			CreatedAt: item.CreatedAt,
+			Detail: jobdomain.JobDetail{
+				Status:   item.Status,
+				Error:    item.Error,
+				Duration: item.Duration,
+			},
+		}
+	}
+
+	return r, nil
+}
+
+func (d aiccFinetuneJobDriver) UpdateJobDetail(index *jobdomain.JobIndex, v *jobdomain.JobDetail) error {
+	info, err := d.toAICCFinetuneIndex(index)
+	if err != nil {
+		return err
+	}
+
+	return d.internal.UpdateJobDetails(&info, v)
+}
+
+func (d aiccFinetuneJobDriver) IsJobDone(status string) bool {
+	return d.af.IsJobDone(status)
+}
+
+func (d aiccFinetuneJobDriver) IsJobSucceeded(status string) bool {
+	return d.af.IsJobSucceeded(status)
+}
+
+func (d aiccFinetuneJobDriver) CanTerminate(status string) bool {
+	return d.af.CanTerminate(status)
+}
+
+func (d aiccFinetuneJobDriver) TerminateJob(job *jobdomain.JobInfo) error {
+	return d.af.TerminateJob(job.Endpoint, job.JobId)
+}
+
+func (d aiccFinetuneJobDriver) GetLogPreviewURL(job *jobdomain.JobInfo) (string, error) {
+	return d.af.GetLogPreviewURL(job.Endpoint, job.JobId)
+}
+
+func (d aiccFinetuneJobDriver) GetFileDownloadURL(endpoint, file string) (string, error) {
+	return d.af.GetFileDownloadURL(endpoint, file)
+}
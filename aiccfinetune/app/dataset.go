@@ -0,0 +1,178 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
+	"github.com/opensourceways/xihe-server/app"
+	types "github.com/opensourceways/xihe-server/domain"
+	orepo "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	uploadStatusFailed  = "failed"
+	uploadStatusInvalid = "invalid"
+	uploadStatusSuccess = "success"
+)
+
+type DatasetIndex = domain.DatasetIndex
+
+type DatasetIssueDTO struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Kind string `json:"kind"`
+	Msg  string `json:"msg"`
+}
+
+type DatasetReportDTO struct {
+	Total     int               `json:"total"`
+	Invalid   int               `json:"invalid"`
+	Duplicate int               `json:"duplicate"`
+	Issues    []DatasetIssueDTO `json:"issues"`
+	Samples   []json.RawMessage `json:"samples"`
+}
+
+func toDatasetReportDTO(r *domain.DatasetReport) *DatasetReportDTO {
+	dto := &DatasetReportDTO{
+		Total:     r.Total,
+		Invalid:   r.Invalid,
+		Duplicate: r.Duplicate,
+		Issues:    make([]DatasetIssueDTO, len(r.Issues)),
+		Samples:   make([]json.RawMessage, len(r.Samples)),
+	}
+
+	for i := range r.Issues {
+		item := &r.Issues[i]
+
+		dto.Issues[i] = DatasetIssueDTO{
+			File: item.File,
+			Line: item.Line,
+			Kind: item.Kind,
+			Msg:  item.Msg,
+		}
+	}
+
+	// the samples are the valid json objects
+	for i := range r.Samples {
+		dto.Samples[i] = json.RawMessage(r.Samples[i])
+	}
+
+	return dto
+}
+
+type DatasetDTO struct {
+	Id        string            `json:"id"`
+	Task      string            `json:"task"`
+	FileName  string            `json:"file_name"`
+	CreatedAt string            `json:"created_at"`
+	Report    *DatasetReportDTO `json:"report"`
+}
+
+func toDatasetDTO(d *domain.Dataset) DatasetDTO {
+	return DatasetDTO{
+		Id:        d.Id,
+		Task:      d.Task.FinetuneTask(),
+		FileName:  d.FileName,
+		CreatedAt: utils.ToDate(d.CreatedAt),
+		Report:    toDatasetReportDTO(&d.Report),
+	}
+}
+
+func (s aiccFinetuneService) saveDataset(d *domain.Dataset, data []byte) (
+	id string, code string, err error,
+) {
+	v, err := s.datasetRepo.List(d.User, d.Model)
+	if err != nil {
+		return
+	}
+
+	if len(v) >= s.maxDatasetNum {
+		code = app.ErrorAICCDatasetExccedMaxNum
+		err = errors.New("exceed max dataset num")
+
+		return
+	}
+
+	if id, err = s.datasetRepo.Save(d); err != nil {
+		return
+	}
+
+	err = s.uploader.UploadDataset(bytes.NewReader(data), d)
+	if err == nil {
+		// the dataset is used by the next finetune as the data uploaded before
+		err = s.uploader.UseDataset(d)
+	}
+
+	if err != nil {
+		if err1 := s.deleteDataset(d); err1 != nil {
+			logrus.Errorf("delete dataset(%s) failed, err:%s", id, err1.Error())
+		}
+
+		id = ""
+	}
+
+	return
+}
+
+// deleteDataset removes the file before the record, so that it can be
+// deleted again if it failed.
+func (s aiccFinetuneService) deleteDataset(d *domain.Dataset) error {
+	if err := s.uploader.DeleteDataset(d); err != nil {
+		return err
+	}
+
+	return s.datasetRepo.Delete(&DatasetIndex{
+		User:      d.User,
+		Model:     d.Model,
+		DatasetId: d.Id,
+	})
+}
+
+func (s aiccFinetuneService) ListDatasets(user types.Account, model domain.ModelName) (
+	[]DatasetDTO, error,
+) {
+	v, err := s.datasetRepo.List(user, model)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]DatasetDTO, len(v))
+	for i := range v {
+		r[i] = toDatasetDTO(&v[i])
+	}
+
+	return r, nil
+}
+
+func (s aiccFinetuneService) GetDataset(index *DatasetIndex) (dto DatasetDTO, code string, err error) {
+	v, err := s.datasetRepo.Get(index)
+	if err != nil {
+		if orepo.IsErrorResourceNotExists(err) {
+			code = app.ErrorAICCDatasetNotFound
+		}
+
+		return
+	}
+
+	dto = toDatasetDTO(&v)
+
+	return
+}
+
+func (s aiccFinetuneService) DeleteDataset(index *DatasetIndex) error {
+	v, err := s.datasetRepo.Get(index)
+	if err != nil {
+		if orepo.IsErrorResourceNotExists(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return s.deleteDataset(&v)
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
 	"github.com/opensourceways/xihe-server/aiccfinetune/infrastructure/aiccfinetuneimpl"
 	"github.com/opensourceways/xihe-server/aiccfinetune/infrastructure/messageadapter"
 )
@@ -9,11 +10,20 @@ type Config struct {
 	aiccfinetuneimpl.Config
 
 	Message messageadapter.Config `json:"message"`
+	Dataset domain.DatasetConfig  `json:"dataset"`
 }
 
 func (cfg *Config) ConfigItems() []interface{} {
 	return []interface{}{
 		&cfg.Config,
 		&cfg.Message,
+		&cfg.Dataset,
 	}
 }
+
+// SetDefault covers the dataset config, which is skipped if the SetDefault
+// of aiccfinetuneimpl.Config is promoted to Config.
+func (cfg *Config) SetDefault() {
+	cfg.Config.SetDefault()
+	cfg.Dataset.SetDefault()
+}
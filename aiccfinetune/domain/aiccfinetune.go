@@ -25,6 +25,10 @@ type AICCFinetuneConfig struct {
 
 	Hyperparameters []KeyValue
 	Env             []KeyValue
+
+	// Dataset is the id of dataset used by the finetune, the data
+	// uploaded last time is used if it is empty.
+	Dataset string
 }
 
 type KeyValue struct {
@@ -32,20 +36,8 @@ type KeyValue struct {
 	Value CustomizedValue
 }
 
-type JobInfo struct {
-	Endpoint  string
-	JobId     string
-	LogDir    string
-	OutputDir string
-}
-
-type JobDetail struct {
-	Status     string
-	Error      string
-	LogPath    string
-	OutputPath string
-	Duration   int
-}
+type JobInfo = types.JobInfo
+type JobDetail = types.JobDetail
 
 type AICCFinetuneSummary struct {
 	Id        string
@@ -10,5 +10,7 @@ type AICCFinetuneServer interface {
 	TerminateJob(endpoint string, jobId string) error
 	GetLogPreviewURL(endpoint, jobId string) (string, error)
 	IsJobDone(status string) bool
+	IsJobSucceeded(status string) bool
+	CanTerminate(status string) bool
 	GetFileDownloadURL(endpoint, file string) (string, error)
 }
@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"fmt"
+
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+const (
+	DatasetIssueEncoding    = "encoding"
+	DatasetIssueFormat      = "format"
+	DatasetIssueField       = "missing_field"
+	DatasetIssueTokenLength = "token_length"
+	DatasetIssueDuplicate   = "duplicate"
+)
+
+// Dataset is the validated data uploaded by user, which can be used by
+// several finetunes of the same task.
+type Dataset struct {
+	Id        string
+	User      types.Account
+	Model     ModelName
+	Task      FinetuneTask
+	FileName  string
+	Report    DatasetReport
+	CreatedAt int64
+}
+
+// Path is where the dataset is kept.
+func (d *Dataset) Path() string {
+	return fmt.Sprintf(
+		"%s/dataset/%s/%s/%s/%s",
+		d.Model.ModelName(),
+		d.Task.FinetuneTask(),
+		d.User.Account(),
+		d.Id,
+		d.FileName,
+	)
+}
+
+type DatasetIndex struct {
+	User      types.Account
+	Model     ModelName
+	DatasetId string
+}
+
+// DatasetReport is the result of validating the rows of dataset. The
+// duplicate rows are counted as invalid rows too.
+type DatasetReport struct {
+	Total     int
+	Invalid   int
+	Duplicate int
+	Issues    []DatasetIssue
+	Samples   []string
+}
+
+func (r *DatasetReport) IsValid() bool {
+	return r.Total > 0 && r.Invalid == 0
+}
+
+type DatasetIssue struct {
+	File string
+	Line int
+	Kind string
+	Msg  string
+}
+
+// DatasetConfig
+type DatasetConfig struct {
+	// SampleNum is the number of valid rows returned for preview.
+	SampleNum int `json:"sample_num"`
+
+	// MaxIssueNum is the max number of issues kept in the report,
+	// the rows are still counted after it is reached.
+	MaxIssueNum int `json:"max_issue_num"`
+
+	// MaxLineSize is the max bytes of a row.
+	MaxLineSize int `json:"max_line_size"`
+
+	// MaxDatasetNum is the max number of datasets kept for each model of user.
+	MaxDatasetNum int `json:"max_dataset_num"`
+
+	// MaxFileSize is the max bytes of the data uploaded.
+	MaxFileSize int64 `json:"max_file_size"`
+
+	Schemas []DatasetSchema `json:"schemas"`
+}
+
+func (cfg *DatasetConfig) SetDefault() {
+	if cfg.SampleNum <= 0 {
+		cfg.SampleNum = 5
+	}
+
+	if cfg.MaxIssueNum <= 0 {
+		cfg.MaxIssueNum = 20
+	}
+
+	if cfg.MaxLineSize <= 0 {
+		cfg.MaxLineSize = 1 << 20
+	}
+
+	if cfg.MaxDatasetNum <= 0 {
+		cfg.MaxDatasetNum = 10
+	}
+
+	if cfg.MaxFileSize <= 0 {
+		cfg.MaxFileSize = 50 * 1024 * 1024
+	}
+}
+
+func (cfg *DatasetConfig) schema(task string) *DatasetSchema {
+	for i := range cfg.Schemas {
+		if cfg.Schemas[i].Task == task {
+			return &cfg.Schemas[i]
+		}
+	}
+
+	return nil
+}
+
+// DatasetSchema is the requirement of each row of the dataset for a task.
+// The tokens of row are estimated by the characters of the required fields,
+// and the bounds are ignored if they are 0.
+type DatasetSchema struct {
+	Task           string   `json:"task"`
+	RequiredFields []string `json:"required_fields"`
+	MinTokens      int      `json:"min_tokens"`
+	MaxTokens      int      `json:"max_tokens"`
+}
@@ -0,0 +1,200 @@
+package domain
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	datasetFileExt = ".jsonl"
+	zipFileExt     = ".zip"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+type DatasetValidator struct {
+	cfg *DatasetConfig
+}
+
+func NewDatasetValidator(cfg *DatasetConfig) DatasetValidator {
+	return DatasetValidator{cfg}
+}
+
+// Validate checks the rows of JSONL files which are uploaded directly or
+// packed in a zip. It returns false if there is no such file to check.
+func (v DatasetValidator) Validate(data []byte, fileName string, task FinetuneTask) (
+	DatasetReport, bool,
+) {
+	c := datasetChecker{
+		cfg:    v.cfg,
+		schema: v.cfg.schema(task.FinetuneTask()),
+		rows:   map[[sha256.Size]byte]string{},
+	}
+
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case datasetFileExt:
+		c.check(fileName, bytes.NewReader(data))
+
+		return c.report, true
+
+	case zipFileExt:
+		checked := c.checkZip(fileName, data)
+
+		return c.report, checked
+
+	default:
+		return DatasetReport{}, false
+	}
+}
+
+type datasetChecker struct {
+	cfg    *DatasetConfig
+	schema *DatasetSchema
+	report DatasetReport
+
+	// rows records the position of each row to find the duplicate ones
+	rows map[[sha256.Size]byte]string
+}
+
+func (c *datasetChecker) checkZip(fileName string, data []byte) bool {
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		c.report.Invalid++
+		c.addIssue(fileName, 0, DatasetIssueFormat, "invalid zip file")
+
+		return true
+	}
+
+	checked := false
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || strings.ToLower(filepath.Ext(f.Name)) != datasetFileExt {
+			continue
+		}
+
+		checked = true
+
+		rc, err := f.Open()
+		if err != nil {
+			c.report.Invalid++
+			c.addIssue(f.Name, 0, DatasetIssueFormat, "can't read the file")
+
+			continue
+		}
+
+		c.check(f.Name, rc)
+
+		rc.Close()
+	}
+
+	return checked
+}
+
+func (c *datasetChecker) check(file string, r io.Reader) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), c.cfg.MaxLineSize)
+
+	line := 0
+	for sc.Scan() {
+		line++
+
+		row := sc.Bytes()
+		if line == 1 {
+			row = bytes.TrimPrefix(row, utf8BOM)
+		}
+
+		if row = bytes.TrimSpace(row); len(row) == 0 {
+			continue
+		}
+
+		c.report.Total++
+
+		if kind, msg := c.checkRow(file, line, row); kind != "" {
+			c.report.Invalid++
+			c.addIssue(file, line, kind, msg)
+
+			continue
+		}
+
+		if len(c.report.Samples) < c.cfg.SampleNum {
+			c.report.Samples = append(c.report.Samples, string(row))
+		}
+	}
+
+	// the rest of file can't be read
+	if err := sc.Err(); err != nil {
+		c.report.Total++
+		c.report.Invalid++
+		c.addIssue(file, line+1, DatasetIssueFormat, err.Error())
+	}
+}
+
+func (c *datasetChecker) checkRow(file string, line int, row []byte) (string, string) {
+	if !utf8.Valid(row) {
+		return DatasetIssueEncoding, "not encoded in utf-8"
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(row, &fields); err != nil {
+		return DatasetIssueFormat, "not a json object"
+	}
+
+	if s := c.schema; s != nil {
+		tokens := 0
+		for _, k := range s.RequiredFields {
+			v, ok := fields[k].(string)
+			if !ok || strings.TrimSpace(v) == "" {
+				return DatasetIssueField, fmt.Sprintf("missing text field %s", k)
+			}
+
+			tokens += utils.StrLen(v)
+		}
+
+		if (s.MinTokens > 0 && tokens < s.MinTokens) || (s.MaxTokens > 0 && tokens > s.MaxTokens) {
+			return DatasetIssueTokenLength, fmt.Sprintf(
+				"%d tokens is out of the range [%d, %d]", tokens, s.MinTokens, s.MaxTokens,
+			)
+		}
+	}
+
+	// the keys are sorted when marshaling, so the rows only differing
+	// in the order of keys or the spaces are the same.
+	v, err := json.Marshal(fields)
+	if err != nil {
+		return DatasetIssueFormat, "not a json object"
+	}
+
+	k := sha256.Sum256(v)
+	if pos, ok := c.rows[k]; ok {
+		c.report.Duplicate++
+
+		return DatasetIssueDuplicate, "same as the row at " + pos
+	}
+
+	c.rows[k] = fmt.Sprintf("%s:%d", file, line)
+
+	return "", ""
+}
+
+func (c *datasetChecker) addIssue(file string, line int, kind, msg string) {
+	if len(c.report.Issues) >= c.cfg.MaxIssueNum {
+		return
+	}
+
+	c.report.Issues = append(c.report.Issues, DatasetIssue{
+		File: file,
+		Line: line,
+		Kind: kind,
+		Msg:  msg,
+	})
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type Dataset interface {
+	Save(*domain.Dataset) (string, error)
+	Get(*domain.DatasetIndex) (domain.Dataset, error)
+	Delete(*domain.DatasetIndex) error
+	List(user types.Account, model domain.ModelName) ([]domain.Dataset, error)
+}
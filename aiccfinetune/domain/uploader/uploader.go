@@ -4,4 +4,6 @@ import "io"
 
 type DataFileUploader interface {
 	UploadAICC(data io.Reader, path string) error
+	CopyAICC(from, to string) error
+	DeleteAICC(path string) error
 }
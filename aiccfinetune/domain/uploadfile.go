@@ -18,16 +18,34 @@ func NewUploadService(v uploader.DataFileUploader) UploadService {
 func (s *UploadService) Upload(
 	data io.Reader, fileName string, user string, model string, task string,
 ) (err error) {
-	obspath := fmt.Sprintf(
+	if err = s.uploader.UploadAICC(data, inputPath(fileName, user, model, task)); err != nil {
+		return
+	}
+	return
+}
+
+func (s *UploadService) UploadDataset(data io.Reader, d *Dataset) error {
+	return s.uploader.UploadAICC(data, d.Path())
+}
+
+// UseDataset puts the dataset at the path from which the finetune reads its input.
+func (s *UploadService) UseDataset(d *Dataset) error {
+	return s.uploader.CopyAICC(
+		d.Path(),
+		inputPath(d.FileName, d.User.Account(), d.Model.ModelName(), d.Task.FinetuneTask()),
+	)
+}
+
+func (s *UploadService) DeleteDataset(d *Dataset) error {
+	return s.uploader.DeleteAICC(d.Path())
+}
+
+func inputPath(fileName string, user string, model string, task string) string {
+	return fmt.Sprintf(
 		"%s/input/%s/%s/%s",
 		model,
 		task,
 		user,
 		fileName,
 	)
-
-	if err = s.uploader.UploadAICC(data, obspath); err != nil {
-		return
-	}
-	return
 }
@@ -13,20 +13,32 @@ import (
 
 func NewAICCFinetune(cfg *Config) aiccfinetune.AICCFinetuneServer {
 	return &aiccFinetuneImpl{
-		doneStatus: sets.New[string](cfg.JobDoneStatus...),
-		endpoint:   cfg.Endpoint,
+		doneStatus:         sets.New[string](cfg.JobDoneStatus...),
+		succeededStatus:    sets.New[string](cfg.JobSucceededStatus...),
+		canTerminateStatus: sets.New[string](cfg.CanTerminateStatus...),
+		endpoint:           cfg.Endpoint,
 	}
 }
 
 type aiccFinetuneImpl struct {
-	doneStatus sets.Set[string]
-	endpoint   string
+	doneStatus         sets.Set[string]
+	succeededStatus    sets.Set[string]
+	canTerminateStatus sets.Set[string]
+	endpoint           string
 }
 
 func (impl *aiccFinetuneImpl) IsJobDone(status string) bool {
 	return impl.doneStatus.Has(status)
 }
 
+func (impl *aiccFinetuneImpl) IsJobSucceeded(status string) bool {
+	return impl.succeededStatus.Has(status)
+}
+
+func (impl *aiccFinetuneImpl) CanTerminate(status string) bool {
+	return impl.canTerminateStatus.Has(status)
+}
+
 func (impl *aiccFinetuneImpl) CreateJob(endpoint string, info *domain.AICCFinetuneIndex, t *domain.AICCFinetune) (
 	job domain.JobInfo, err error,
 ) {
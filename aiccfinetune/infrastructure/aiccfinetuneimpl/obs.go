@@ -43,3 +43,25 @@ func (s *obsService) createObject(f io.Reader, path string) error {
 
 	return err
 }
+
+func (s *obsService) copyObject(from, to string) error {
+	input := &obs.CopyObjectInput{}
+	input.Bucket = s.bucket
+	input.Key = s.genPath(to)
+	input.CopySourceBucket = s.bucket
+	input.CopySourceKey = s.genPath(from)
+
+	_, err := s.cli.CopyObject(input)
+
+	return err
+}
+
+func (s *obsService) deleteObject(path string) error {
+	input := &obs.DeleteObjectInput{}
+	input.Bucket = s.bucket
+	input.Key = s.genPath(path)
+
+	_, err := s.cli.DeleteObject(input)
+
+	return err
+}
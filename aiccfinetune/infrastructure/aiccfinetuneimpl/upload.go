@@ -28,3 +28,11 @@ type service struct {
 func (cs *service) UploadAICC(data io.Reader, path string) error {
 	return cs.obs.createObject(data, path)
 }
+
+func (cs *service) CopyAICC(from, to string) error {
+	return cs.obs.copyObject(from, to)
+}
+
+func (cs *service) DeleteAICC(path string) error {
+	return cs.obs.deleteObject(path)
+}
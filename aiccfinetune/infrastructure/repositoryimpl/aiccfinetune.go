@@ -311,6 +311,7 @@ func (repo aiccFinetuneRepoImpl) genAICCFinetuneDoc(p *domain.AICCFinetune) (bso
 		Model:           p.Model.ModelName(),
 		Env:             repo.toKeyValueDoc(p.Env),
 		Hyperparameters: repo.toKeyValueDoc(p.Hyperparameters),
+		Dataset:         c.Dataset,
 	}
 	return genDoc(docObj)
 }
@@ -78,6 +78,7 @@ func (doc dAICCFinetune) toAICCFinetuneDO(f *domain.AICCFinetune) (err error) {
 	f.JobDetail = jobDetail
 
 	f.CreatedAt = doc.Items[0].CreatedAt
+	f.Dataset = doc.Items[0].Dataset
 
 	if f.Hyperparameters, err = toKeyValues(doc.Items[0].Hyperparameters); err != nil {
 		return
@@ -0,0 +1,194 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+// NewDatasetRepo keeps the datasets in the same doc as the aicc finetunes
+// of the model.
+func NewDatasetRepo(m mongodbClient) repository.Dataset {
+	return datasetRepoImpl{m}
+}
+
+type datasetRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl datasetRepoImpl) Save(d *domain.Dataset) (id string, err error) {
+	id = primitive.NewObjectID().Hex()
+	d.Id = id
+
+	doc, err := genDoc(toDatasetItem(d))
+	if err != nil {
+		return
+	}
+
+	docFilter := aiccFinetuneDocFilter(d.User.Account(), d.Model.ModelName())
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, docFilter, bson.M{
+			fieldUser:     d.User.Account(),
+			fieldModel:    d.Model.ModelName(),
+			fieldItems:    bson.A{},
+			fieldDatasets: bson.A{},
+			fieldVersion:  0,
+		})
+		if err != nil && !impl.cli.IsDocExists(err) {
+			return err
+		}
+
+		return impl.cli.PushArrayElem(ctx, fieldDatasets, docFilter, doc)
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func (impl datasetRepoImpl) Get(index *domain.DatasetIndex) (r domain.Dataset, err error) {
+	var v []dAICCFinetune
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetArrayElem(
+			ctx,
+			fieldDatasets,
+			aiccFinetuneDocFilter(index.User.Account(), index.Model.ModelName()),
+			bson.M{fieldId: index.DatasetId},
+			bson.M{
+				fieldUser:     1,
+				fieldModel:    1,
+				fieldDatasets: 1,
+			},
+			&v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		return
+	}
+
+	if len(v) == 0 || len(v[0].Datasets) == 0 {
+		err = repositories.NewErrorDataNotExists(errDocNotExists)
+	} else {
+		err = v[0].Datasets[0].toDataset(index.User, index.Model, &r)
+	}
+
+	return
+}
+
+func (impl datasetRepoImpl) Delete(index *domain.DatasetIndex) error {
+	f := func(ctx context.Context) error {
+		return impl.cli.PullArrayElem(
+			ctx, fieldDatasets,
+			aiccFinetuneDocFilter(index.User.Account(), index.Model.ModelName()),
+			bson.M{fieldId: index.DatasetId},
+		)
+	}
+
+	return withContext(f)
+}
+
+func (impl datasetRepoImpl) List(user types.Account, model domain.ModelName) ([]domain.Dataset, error) {
+	var v dAICCFinetune
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(
+			ctx,
+			aiccFinetuneDocFilter(user.Account(), model.ModelName()),
+			bson.M{fieldDatasets: 1},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	r := make([]domain.Dataset, len(v.Datasets))
+	for i := range v.Datasets {
+		if err := v.Datasets[i].toDataset(user, model, &r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func toDatasetItem(d *domain.Dataset) datasetItem {
+	report := &d.Report
+
+	issues := make([]dDatasetIssue, len(report.Issues))
+	for i := range report.Issues {
+		item := &report.Issues[i]
+
+		issues[i] = dDatasetIssue{
+			File: item.File,
+			Line: item.Line,
+			Kind: item.Kind,
+			Msg:  item.Msg,
+		}
+	}
+
+	return datasetItem{
+		Id:       d.Id,
+		Task:     d.Task.FinetuneTask(),
+		FileName: d.FileName,
+		Report: dDatasetReport{
+			Total:     report.Total,
+			Invalid:   report.Invalid,
+			Duplicate: report.Duplicate,
+			Issues:    issues,
+			Samples:   report.Samples,
+		},
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func (doc *datasetItem) toDataset(user types.Account, model domain.ModelName, d *domain.Dataset) (err error) {
+	if d.Task, err = domain.NewFinetuneTask(doc.Task); err != nil {
+		return
+	}
+
+	d.Id = doc.Id
+	d.User = user
+	d.Model = model
+	d.FileName = doc.FileName
+	d.CreatedAt = doc.CreatedAt
+
+	report := &doc.Report
+	d.Report = domain.DatasetReport{
+		Total:     report.Total,
+		Invalid:   report.Invalid,
+		Duplicate: report.Duplicate,
+		Samples:   report.Samples,
+	}
+
+	if n := len(report.Issues); n > 0 {
+		d.Report.Issues = make([]domain.DatasetIssue, n)
+
+		for i := range report.Issues {
+			item := &report.Issues[i]
+
+			d.Report.Issues[i] = domain.DatasetIssue{
+				File: item.File,
+				Line: item.Line,
+				Kind: item.Kind,
+				Msg:  item.Msg,
+			}
+		}
+	}
+
+	return
+}
@@ -13,6 +13,7 @@ const (
 	fieldStatus    = "status"
 	fieldItems     = "items"
 	fieldTask      = "task"
+	fieldDatasets  = "datasets"
 )
 
 type dAICCFinetune struct {
@@ -20,7 +21,8 @@ type dAICCFinetune struct {
 	Model   string `bson:"model"   json:"model"`
 	Version int    `bson:"version" json:"-"`
 
-	Items []aiccFinetuneItem `bson:"items"   json:"-"`
+	Items    []aiccFinetuneItem `bson:"items"     json:"-"`
+	Datasets []datasetItem      `bson:"datasets"  json:"-"`
 }
 
 type aiccFinetuneItem struct {
@@ -31,6 +33,7 @@ type aiccFinetuneItem struct {
 	Task            string      `bson:"task"         json:"task"`
 	Env             []dKeyValue `bson:"env"           json:"env"`
 	Hyperparameters []dKeyValue `bson:"parameters"    json:"parameters"`
+	Dataset         string      `bson:"dataset"       json:"dataset"`
 	CreatedAt       int64       `bson:"created_at"    json:"created_at"`
 	Job             dJobInfo    `bson:"job"           json:"-"`
 	JobDetail       dJobDetail  `bson:"detail"        json:"-"`
@@ -55,3 +58,26 @@ type dJobDetail struct {
 	LogPath    string `bson:"log"        json:"log,omitempty"`
 	OutputPath string `bson:"output"     json:"output,omitempty"`
 }
+
+type datasetItem struct {
+	Id        string         `bson:"id"          json:"id"`
+	Task      string         `bson:"task"        json:"task"`
+	FileName  string         `bson:"file_name"   json:"file_name"`
+	Report    dDatasetReport `bson:"report"      json:"report"`
+	CreatedAt int64          `bson:"created_at"  json:"created_at"`
+}
+
+type dDatasetReport struct {
+	Total     int             `bson:"total"      json:"total"`
+	Invalid   int             `bson:"invalid"    json:"invalid"`
+	Duplicate int             `bson:"duplicate"  json:"duplicate"`
+	Issues    []dDatasetIssue `bson:"issues"     json:"issues"`
+	Samples   []string        `bson:"samples"    json:"samples"`
+}
+
+type dDatasetIssue struct {
+	File string `bson:"file"  json:"file"`
+	Line int    `bson:"line"  json:"line"`
+	Kind string `bson:"kind"  json:"kind"`
+	Msg  string `bson:"msg"   json:"msg"`
+}
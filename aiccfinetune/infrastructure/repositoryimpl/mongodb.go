@@ -36,6 +36,8 @@ type mongodbClient interface {
 
 	NewDocIfNotExist(ctx context.Context, filterOfDoc, docInfo bson.M) (string, error)
 
+	PushArrayElem(ctx context.Context, array string, filterOfDoc, value bson.M) error
+
 	GetArrayElem(ctx context.Context, array string, filterOfDoc,
 		filterOfArray bson.M, project bson.M, result interface{}) error
 
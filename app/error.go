@@ -57,10 +57,24 @@ const (
 	ErrorBigModelSensitiveInfo = "bigmodel_sensitive_info"
 	ErrorBigModelRecourseBusy  = "bigmodel_resource_busy"
 
-	ErrorTrainNoLog        = "train_no_log"
 	ErrorTrainNoOutput     = "train_no_output"
+	ErrorTrainOutputTooBig = "train_output_too_big"
 	ErrorTrainNotFound     = "train_not_found"
 	ErrorTrainExccedMaxNum = "train_excced_max_num" // excced max training num for a user
+	ErrorTrainNotSucceeded = "train_not_succeeded"
+	ErrorTrainNotResumable = "train_not_resumable"
+
+	ErrorTrainCheckpointNotFound   = "train_checkpoint_not_found"
+	ErrorTrainExccedMaxCheckpoints = "train_excced_max_checkpoints"
+
+	ErrorTrainInvalidSweep  = "train_invalid_sweep"
+	ErrorTrainSweepNotFound = "train_sweep_not_found"
+
+	ErrorTrainInvalidPipeline  = "train_invalid_pipeline"
+	ErrorTrainPipelineNotFound = "train_pipeline_not_found"
+
+	ErrorTrainScheduleNotFound     = "train_schedule_not_found"
+	ErrorTrainExccedMaxScheduleNum = "train_excced_max_schedule_num"
 
 	ErrorWuKongInvalidId        = "wukong_invalid_id"
 	ErrorWuKongInvalidOwner     = "wukong_invalid_owner"
@@ -71,11 +85,13 @@ const (
 	ErrorWuKongExccedMaxLikeNum = "wukong_excced_max_like_num"
 
 	ErrorFinetuneExpiry           = "finetune_expiry"
-	ErrorFinetuneNotFound         = "finetune_not_found"
 	ErrorFinetuneExccedMaxNum     = "finetune_excced_max_num"
 	ErrorFinetuneNoPermission     = "finetune_no_permission"
 	ErrorFinetuneRunningJobExists = "finetune_running_job_exists"
 
-	ErrorAICCFinetuneNoLog    = "aicc_finetune_no_log"
 	ErrorAICCFinetuneNotFound = "aicc_finetune_not_found"
+	ErrorAICCDatasetNotFound  = "aicc_dataset_not_found"
+
+	ErrorAICCDatasetExccedMaxNum = "aicc_dataset_excced_max_num"
+	ErrorAICCDataTooBig          = "aicc_data_too_big"
 )
@@ -7,20 +7,19 @@ import (
 	"github.com/opensourceways/xihe-server/domain/finetune"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
 	"github.com/opensourceways/xihe-server/utils"
 	"github.com/sirupsen/logrus"
 )
 
 type FinetuneIndex = domain.FinetuneIndex
 type FinetuneConfig = domain.FinetuneConfig
-type FinetuneJobDetail = domain.FinetuneJobDetail
 
 type FinetuneService interface {
 	Create(*FinetuneCreateCmd) (string, string, error)
 	List(user domain.Account) (UserFinetunesDTO, string, error)
 	Delete(*FinetuneIndex) error
-	Terminate(*FinetuneIndex) error
-	GetJobInfo(*FinetuneIndex) (FinetuneJobDTO, string, error)
 }
 
 func NewFinetuneService(
@@ -42,7 +41,7 @@ type finetuneService struct {
 }
 
 func (s finetuneService) isJobDone(status string) bool {
-	return status != "" && (s.fs.IsJobDone(status) || status == trainingStatusScheduleFailed)
+	return jobdomain.NewJobLifecycle(s.fs).IsDone(status)
 }
 
 func (s finetuneService) Create(cmd *FinetuneCreateCmd) (
@@ -149,59 +148,41 @@ func (s finetuneService) Delete(info *FinetuneIndex) error {
 	return s.repo.Delete(info)
 }
 
-func (s finetuneService) Terminate(info *FinetuneIndex) error {
-	job, err := s.repo.GetJob(info)
-	if err != nil || job.JobId == "" {
-		return err
-	}
-
-	if !s.fs.CanTerminate(job.Status) {
-		return errors.New("can't terminate now")
-	}
-
-	return s.fs.TerminateJob(job.JobId)
-}
-
-func (s finetuneService) GetJobInfo(index *FinetuneIndex) (
-	dto FinetuneJobDTO, code string, err error,
-) {
-	job, err := s.repo.GetJob(index)
-	if err != nil {
-		if repository.IsErrorResourceNotExists(err) {
-			code = ErrorFinetuneNotFound
-		}
-
-		return
-	}
-
-	dto.IsDone = s.isJobDone(job.Status)
-
-	if job.JobId != "" {
-		dto.LogPreviewURL, err = s.fs.GetLogPreviewURL(job.JobId)
-	}
-
-	return
-}
-
 // FinetuneInternalService
 type FinetuneInternalService interface {
-	UpdateJobDetail(*FinetuneIndex, *FinetuneJobDetail) error
+	UpdateJobDetail(*FinetuneIndex, *JobDetail) error
 }
 
 func NewFinetuneInternalService(
 	repo repository.Finetune,
+	notifier jobapp.JobDetailNotifier,
 ) FinetuneInternalService {
 	return finetuneInternalService{
-		repo: repo,
+		repo:     repo,
+		notifier: notifier,
 	}
 }
 
 type finetuneInternalService struct {
-	repo repository.Finetune
+	repo     repository.Finetune
+	notifier jobapp.JobDetailNotifier
 }
 
-func (s finetuneInternalService) UpdateJobDetail(info *FinetuneIndex, v *FinetuneJobDetail) error {
-	return s.repo.UpdateJobDetail(info, v)
+func (s finetuneInternalService) UpdateJobDetail(info *FinetuneIndex, v *JobDetail) error {
+	if err := s.repo.UpdateJobDetail(info, v); err != nil {
+		return err
+	}
+
+	s.notifier.NotifyJobDetailUpdated(
+		&jobdomain.JobIndex{
+			Type:  jobdomain.JobTypeFinetune,
+			Owner: info.Owner,
+			Id:    info.Id,
+		},
+		v,
+	)
+
+	return nil
 }
 
 // FinetuneMessageService
@@ -212,16 +193,19 @@ type FinetuneMessageService interface {
 func NewFinetuneMessageService(
 	fs finetune.Finetune,
 	repo repository.Finetune,
+	internal FinetuneInternalService,
 ) FinetuneMessageService {
 	return finetuneMessageService{
-		fs:   fs,
-		repo: repo,
+		fs:       fs,
+		repo:     repo,
+		internal: internal,
 	}
 }
 
 type finetuneMessageService struct {
-	fs   finetune.Finetune
-	repo repository.Finetune
+	fs       finetune.Finetune
+	repo     repository.Finetune
+	internal FinetuneInternalService
 }
 
 func (s finetuneMessageService) CreateFinetuneJob(
@@ -231,7 +215,7 @@ func (s finetuneMessageService) CreateFinetuneJob(
 		return
 	}
 
-	err = s.repo.UpdateJobDetail(info, &FinetuneJobDetail{
+	err = s.internal.UpdateJobDetail(info, &JobDetail{
 		Status: trainingStatusScheduleFailed,
 		Error:  err.Error(),
 	})
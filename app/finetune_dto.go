@@ -60,8 +60,3 @@ type UserFinetunesDTO struct {
 	Expiry int64                `json:"expiry"`
 	Data   []FinetuneSummaryDTO `json:"data"`
 }
-
-type FinetuneJobDTO struct {
-	IsDone        bool
-	LogPreviewURL string
-}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/finetune"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
+)
+
+// NewFinetuneJobDriver plugs the finetune into the job subsystem.
+// The finetune belongs to the user directly, so the scope of job is ignored.
+func NewFinetuneJobDriver(
+	fs finetune.Finetune,
+	repo repository.Finetune,
+	internal FinetuneInternalService,
+) jobapp.Driver {
+	return finetuneJobDriver{
+		fs:       fs,
+		repo:     repo,
+		internal: internal,
+	}
+}
+
+type finetuneJobDriver struct {
+	fs       finetune.Finetune
+	repo     repository.Finetune
+	internal FinetuneInternalService
+}
+
+func (d finetuneJobDriver) Type() jobdomain.JobType {
+	return jobdomain.JobTypeFinetune
+}
+
+func (d finetuneJobDriver) GetJob(index *jobdomain.JobIndex) (jobdomain.Job, error) {
+	v, err := d.repo.Get(&FinetuneIndex{
+		Owner: index.Owner,
+		Id:    index.Id,
+	})
+	if err != nil {
+		return jobdomain.Job{}, err
+	}
+
+	return jobdomain.Job{
+		Id:        index.Id,
+		Name:      v.Name.FinetuneName(),
+		CreatedAt: v.CreatedAt,
+		Info:      v.Job,
+		Detail:    v.JobDetail,
+	}, nil
+}
+
+func (d finetuneJobDriver) ListJobs(owner domain.Account, scope string) ([]jobdomain.Job, error) {
+	v, err := d.repo.List(owner)
+	if err != nil || len(v.Data) == 0 {
+		return nil, err
+	}
+
+	r := make([]jobdomain.Job, len(v.Data))
+	for i := range v.Data {
+		item := &v.Data[i]
+
+		r[i] = jobdomain.Job{
+			Id:        item.Id,
+			Name:      item.Name.FinetuneName(),
+			CreatedAt: item.CreatedAt,
+			Detail: jobdomain.JobDetail{
+				Status:   item.Status,
+				Error:    item.Error,
+				Duration: item.Duration,
+			},
+		}
+	}
+
+	return r, nil
+}
+
+func (d finetuneJobDriver) UpdateJobDetail(index *jobdomain.JobIndex, v *jobdomain.JobDetail) error {
+	return d.internal.UpdateJobDetail(
+		&FinetuneIndex{
+			Owner: index.Owner,
+			Id:    index.Id,
+		},
+		v,
+	)
+}
+
+func (d finetuneJobDriver) IsJobDone(status string) bool {
+	return d.fs.IsJobDone(status)
+}
+
+func (d finetuneJobDriver) IsJobSucceeded(status string) bool {
+	return d.fs.IsJobSucceeded(status)
+}
+
+func (d finetuneJobDriver) CanTerminate(status string) bool {
+	return d.fs.CanTerminate(status)
+}
+
+func (d finetuneJobDriver) TerminateJob(job *jobdomain.JobInfo) error {
+	return d.fs.TerminateJob(job.JobId)
+}
+
+func (d finetuneJobDriver) GetLogPreviewURL(job *jobdomain.JobInfo) (string, error) {
+	return d.fs.GetLogPreviewURL(job.JobId)
+}
+
+// GetFileDownloadURL is not supported, because the finetune only
+// provides the preview of log.
+func (d finetuneJobDriver) GetFileDownloadURL(endpoint, file string) (string, error) {
+	return "", errors.New("unsupported")
+}
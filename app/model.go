@@ -112,6 +112,14 @@ type ModelDetailDTO struct {
 
 	RelatedDatasets []ResourceDTO `json:"related_datasets"`
 	RelatedProjects []ResourceDTO `json:"related_projects"`
+
+	Lineages []ModelLineageDTO `json:"lineages"`
+}
+
+type ModelLineageDTO struct {
+	ProjectId   string `json:"project_id"`
+	TrainingId  string `json:"training_id"`
+	PublishedAt string `json:"published_at"`
 }
 
 type ModelService interface {
@@ -281,6 +289,20 @@ func (s modelService) GetByName(
 	}
 	dto.RelatedProjects = d
 
+	if n := len(v.Lineages); n > 0 {
+		dto.Lineages = make([]ModelLineageDTO, n)
+
+		for i := range v.Lineages {
+			item := &v.Lineages[i]
+
+			dto.Lineages[i] = ModelLineageDTO{
+				ProjectId:   item.ProjectId,
+				TrainingId:  item.TrainingId,
+				PublishedAt: utils.ToDate(item.PublishedAt),
+			}
+		}
+	}
+
 	s.toModelDTO(&v, &dto.ModelDTO)
 
 	return
@@ -8,13 +8,15 @@ import (
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/repository"
 	"github.com/opensourceways/xihe-server/domain/training"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
 	"github.com/opensourceways/xihe-server/utils"
 	"github.com/sirupsen/logrus"
 )
 
 const (
-	trainingStatusScheduling     = "scheduling"
-	trainingStatusScheduleFailed = "schedule_failed"
+	trainingStatusScheduling     = jobdomain.JobStatusScheduling
+	trainingStatusScheduleFailed = jobdomain.JobStatusScheduleFailed
 )
 
 type JobDetail = domain.JobDetail
@@ -24,47 +26,69 @@ type TrainingConfig = domain.TrainingConfig
 type TrainingService interface {
 	Create(*TrainingCreateCmd) (string, error)
 	Recreate(*TrainingIndex) (string, error)
+	Resume(*TrainingResumeCmd) (string, string, error)
 	UpdateJobDetail(*TrainingIndex, *JobDetail) error
 	List(user domain.Account, projectId string) ([]TrainingSummaryDTO, error)
 	Get(*TrainingIndex) (TrainingDTO, string, error)
 	GetLastTrainingConfig(*ResourceIndexCmd) (dto TrainingConfigDTO, code string, err error)
 	Delete(*TrainingIndex) error
-	Terminate(*TrainingIndex) error
-	GetLogDownloadURL(*TrainingIndex) (string, string, error)
-	GetOutputDownloadURL(*TrainingIndex) (string, string, error)
 	CreateTrainingJob(*TrainingIndex, string, bool) (bool, error)
+
+	CreateSweep(*TrainingSweepCreateCmd) (string, string, error)
+	ListSweeps(user domain.Account, projectId string) ([]TrainingSweepSummaryDTO, error)
+	GetSweep(user domain.Account, projectId, id string) (TrainingSweepDTO, string, error)
+
+	CreatePipeline(*TrainingPipelineCreateCmd) (string, string, error)
+	ListPipelines(user domain.Account, projectId string) ([]TrainingPipelineSummaryDTO, error)
+	GetPipeline(user domain.Account, projectId, id string) (TrainingPipelineDTO, string, error)
 }
 
 func NewTrainingService(
 	train training.Training,
 	repo repository.Training,
+	sweepRepo repository.TrainingSweep,
+	metricRepo repository.TrainingMetric,
+	pipelineRepo repository.TrainingPipeline,
 	sender message.MessageProducer,
+	notifier jobapp.JobDetailNotifier,
 	maxTrainingRecordNum int,
 ) TrainingService {
 	return trainingService{
-		train:  train,
-		repo:   repo,
-		sender: sender,
+		train:        train,
+		repo:         repo,
+		sweepRepo:    sweepRepo,
+		metricRepo:   metricRepo,
+		pipelineRepo: pipelineRepo,
+		sender:       sender,
+		notifier:     notifier,
 
 		maxTrainingRecordNum: maxTrainingRecordNum,
 	}
 }
 
 type trainingService struct {
-	log    *logrus.Entry
-	train  training.Training
-	repo   repository.Training
-	sender message.MessageProducer
+	log          *logrus.Entry
+	train        training.Training
+	repo         repository.Training
+	sweepRepo    repository.TrainingSweep
+	metricRepo   repository.TrainingMetric
+	pipelineRepo repository.TrainingPipeline
+	sender       message.MessageProducer
+	notifier     jobapp.JobDetailNotifier
 
 	maxTrainingRecordNum int
 }
 
 func (s trainingService) isJobDone(status string) bool {
-	return status != "" && (s.train.IsJobDone(status) || status == trainingStatusScheduleFailed)
+	return jobdomain.NewJobLifecycle(s.train).IsDone(status)
 }
 
 func (s trainingService) Create(cmd *TrainingCreateCmd) (string, error) {
-	return s.create(cmd.User, cmd.ProjectId, cmd.toTrainingConfig())
+	return s.create(&domain.UserTraining{
+		Owner:          cmd.User,
+		ProjectId:      cmd.ProjectId,
+		TrainingConfig: *cmd.toTrainingConfig(),
+	})
 }
 
 func (s trainingService) Recreate(info *TrainingIndex) (string, error) {
@@ -80,13 +104,15 @@ func (s trainingService) Recreate(info *TrainingIndex) (string, error) {
 		return "", err
 	}
 
-	return s.create(info.Project.Owner, info.Project.Id, &v)
+	return s.create(&domain.UserTraining{
+		Owner:          info.Project.Owner,
+		ProjectId:      info.Project.Id,
+		TrainingConfig: v,
+	})
 }
 
-func (s trainingService) create(
-	user domain.Account, projectId string, config *TrainingConfig,
-) (string, error) {
-	v, version, err := s.repo.List(user, projectId)
+func (s trainingService) create(t *domain.UserTraining) (string, error) {
+	v, version, err := s.repo.List(t.Owner, t.ProjectId)
 	if err != nil {
 		return "", err
 	}
@@ -104,44 +130,46 @@ func (s trainingService) create(
 			}
 		}
 
-		if v[i].Name.TrainingName() == config.Name.TrainingName() {
+		if v[i].Name.TrainingName() == t.Name.TrainingName() {
 			return "", ErrorDuplicateTrainingName{
 				errors.New("duplicate training name"),
 			}
 		}
 	}
 
-	t := domain.UserTraining{
-		Owner:          user,
-		ProjectId:      projectId,
-		CreatedAt:      utils.Now(),
-		TrainingConfig: *config,
-	}
+	return s.save(t, version)
+}
+
+func (s trainingService) save(t *domain.UserTraining, version int) (string, error) {
+	t.CreatedAt = utils.Now()
 
-	r, err := s.repo.Save(&t, version)
+	r, err := s.repo.Save(t, version)
 	if err != nil {
 		return "", err
 	}
 
-	// send message
+	s.sendTrainingCreated(t, r)
+
+	return r, nil
+}
+
+func (s trainingService) sendTrainingCreated(t *domain.UserTraining, trainingId string) {
 	index := TrainingIndex{
 		Project: domain.ResourceIndex{
-			Owner: user,
-			Id:    projectId,
+			Owner: t.Owner,
+			Id:    t.ProjectId,
 		},
-		TrainingId: r,
+		TrainingId: trainingId,
 	}
 
-	err = s.sender.SendTrainingCreated(&domain.TrainingCreatedEvent{
-		Account:        user,
+	err := s.sender.SendTrainingCreated(&domain.TrainingCreatedEvent{
+		Account:        t.Owner,
 		TrainingIndex:  index,
-		TrainingInputs: config.Inputs,
+		TrainingInputs: t.Inputs,
 	})
 	if err != nil {
 		s.log.Errorf("send message of creating training failed, err:%s", err.Error())
 	}
-
-	return r, nil
 }
 
 func (s trainingService) List(user domain.Account, projectId string) ([]TrainingSummaryDTO, error) {
@@ -201,7 +229,49 @@ func (s trainingService) GetLastTrainingConfig(cmd *ResourceIndexCmd) (dto Train
 }
 
 func (s trainingService) UpdateJobDetail(info *TrainingIndex, v *JobDetail) error {
-	return s.repo.UpdateJobDetail(info, v)
+	if err := s.repo.UpdateJobDetail(info, v); err != nil {
+		return err
+	}
+
+	s.notifier.NotifyJobDetailUpdated(
+		&jobdomain.JobIndex{
+			Type:  jobdomain.JobTypeTraining,
+			Owner: info.Project.Owner,
+			Scope: info.Project.Id,
+			Id:    info.TrainingId,
+		},
+		v,
+	)
+
+	if s.isJobDone(v.Status) {
+		s.onTrainingDone(info)
+	}
+
+	return nil
+}
+
+// onTrainingDone advances the sweep or pipeline which the training belongs to.
+func (s trainingService) onTrainingDone(info *TrainingIndex) {
+	t, err := s.repo.Get(info)
+	if err != nil {
+		logrus.Errorf("get training(%s) failed, err:%s", info.TrainingId, err.Error())
+
+		return
+	}
+
+	if err := s.advanceSweepOf(&t); err != nil {
+		logrus.Errorf(
+			"advance sweep of training(%s) failed, err:%s",
+			info.TrainingId, err.Error(),
+		)
+	}
+
+	if err := s.advancePipelineOf(info, &t); err != nil {
+		logrus.Errorf(
+			"advance pipeline of training(%s) failed, err:%s",
+			info.TrainingId, err.Error(),
+		)
+	}
 }
 
 func (s trainingService) Delete(info *TrainingIndex) error {
@@ -224,52 +294,15 @@ func (s trainingService) Delete(info *TrainingIndex) error {
 		}
 	}
 
-	return s.repo.Delete(info)
-}
-
-func (s trainingService) Terminate(info *TrainingIndex) error {
-	job, err := s.repo.GetJob(info)
-	if err != nil || job.JobId == "" {
+	if err := s.repo.Delete(info); err != nil {
 		return err
 	}
 
-	return s.train.TerminateJob(job.Endpoint, job.JobId)
-}
-
-func (s trainingService) GetLogDownloadURL(info *TrainingIndex) (
-	link string, code string, err error,
-) {
-	detail, endpoint, err := s.repo.GetJobDetail(info)
-	if err != nil {
-		return
+	if err := s.metricRepo.Delete(info); err != nil {
+		logrus.Errorf("delete metrics of training:%s failed: %s", info.TrainingId, err.Error())
 	}
 
-	if detail.LogPath == "" {
-		code = ErrorTrainNoLog
-		err = errors.New("not ready")
-	} else {
-		link, err = s.train.GetFileDownloadURL(endpoint, detail.LogPath)
-	}
-
-	return
-}
-
-func (s trainingService) GetOutputDownloadURL(info *TrainingIndex) (
-	link string, code string, err error,
-) {
-	detail, endpoint, err := s.repo.GetJobDetail(info)
-	if err != nil {
-		return
-	}
-
-	if detail.OutputPath == "" {
-		code = ErrorTrainNoOutput
-		err = errors.New("not ready")
-	} else {
-		link, err = s.train.GetFileDownloadURL(endpoint, detail.OutputPath)
-	}
-
-	return
+	return nil
 }
 
 func (s trainingService) CreateTrainingJob(
@@ -281,7 +314,7 @@ func (s trainingService) CreateTrainingJob(
 	}
 
 	if lastChance {
-		err = s.repo.UpdateJobDetail(info, &JobDetail{
+		err = s.UpdateJobDetail(info, &JobDetail{
 			Status: trainingStatusScheduleFailed,
 			Error:  err.Error(),
 		})
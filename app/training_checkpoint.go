@@ -0,0 +1,121 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingCheckpointAddCmd struct {
+	TrainingIndex
+
+	Path domain.InputeFilePath
+	Step int
+}
+
+func (cmd *TrainingCheckpointAddCmd) Validate() error {
+	b := cmd.Project.Owner != nil &&
+		cmd.Project.Id != "" &&
+		cmd.TrainingId != ""
+
+	if !b {
+		return errors.New("invalid training index")
+	}
+
+	if cmd.Path == nil || cmd.Step < 0 {
+		return errors.New("invalid checkpoint")
+	}
+
+	return nil
+}
+
+type TrainingResumeCmd struct {
+	TrainingIndex
+
+	Step int
+}
+
+type TrainingCheckpointService interface {
+	Add(*TrainingCheckpointAddCmd) (string, error)
+}
+
+func NewTrainingCheckpointService(repo repository.Training) TrainingCheckpointService {
+	return trainingCheckpointService{repo}
+}
+
+type trainingCheckpointService struct {
+	repo repository.Training
+}
+
+func (s trainingCheckpointService) Add(cmd *TrainingCheckpointAddCmd) (string, error) {
+	t, err := s.repo.Get(&cmd.TrainingIndex)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			return ErrorTrainNotFound, err
+		}
+
+		return "", err
+	}
+
+	// the job may report the same checkpoint again when it retries.
+	if t.HasCheckpoint(cmd.Step) {
+		return "", nil
+	}
+
+	if len(t.Checkpoints) >= domain.DomainConfig.MaxTrainingCheckpoints {
+		return ErrorTrainExccedMaxCheckpoints, errors.New("exceed max checkpoint num")
+	}
+
+	return "", s.repo.AddCheckpoint(&cmd.TrainingIndex, &domain.TrainingCheckpoint{
+		Path:      cmd.Path,
+		Step:      cmd.Step,
+		CreatedAt: utils.Now(),
+	})
+}
+
+// Resume creates a new training which resumes from the checkpoint of
+// a failed or terminated training.
+func (s trainingService) Resume(cmd *TrainingResumeCmd) (id string, code string, err error) {
+	t, err := s.repo.Get(&cmd.TrainingIndex)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorTrainNotFound
+		}
+
+		return
+	}
+
+	if status := t.JobDetail.Status; !s.isJobDone(status) || s.train.IsJobSucceeded(status) {
+		code = ErrorTrainNotResumable
+		err = errors.New("only failed or terminated training can be resumed")
+
+		return
+	}
+
+	cp := t.Checkpoint(cmd.Step)
+	if cp == nil {
+		code = ErrorTrainCheckpointNotFound
+		err = errors.New("checkpoint not found")
+
+		return
+	}
+
+	c, err := t.ResumeConfig(cp, utils.Now())
+	if err != nil {
+		return
+	}
+
+	id, err = s.create(&domain.UserTraining{
+		Owner:          t.Owner,
+		ProjectId:      t.ProjectId,
+		TrainingConfig: c,
+		ResumedFrom: domain.TrainingResumption{
+			TrainingId: t.Id,
+			Step:       cp.Step,
+		},
+	})
+
+	return
+}
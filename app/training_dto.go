@@ -117,9 +117,23 @@ type TrainingDTO struct {
 	AimPath   string     `json:"aim_path"`
 	EnableAim bool       `json:"enable_aim"`
 
+	ResumedFrom *TrainingResumptionDTO  `json:"resumed_from,omitempty"`
+	Checkpoints []TrainingCheckpointDTO `json:"checkpoints"`
+
 	LogPreviewURL string `json:"-"`
 }
 
+type TrainingResumptionDTO struct {
+	TrainingId string `json:"training_id"`
+	Step       int    `json:"step"`
+}
+
+type TrainingCheckpointDTO struct {
+	Step      int    `json:"step"`
+	Path      string `json:"path"`
+	CreatedAt string `json:"created_at"`
+}
+
 type ComputeDTO struct {
 	Type    string `json:"type"`
 	Version string `json:"version"`
@@ -160,6 +174,24 @@ func (s trainingService) toTrainingDTO(dto *TrainingDTO, ut *domain.UserTraining
 	if t.Desc != nil {
 		dto.Desc = t.Desc.TrainingDesc()
 	}
+
+	if r := &ut.ResumedFrom; !r.IsEmpty() {
+		dto.ResumedFrom = &TrainingResumptionDTO{
+			TrainingId: r.TrainingId,
+			Step:       r.Step,
+		}
+	}
+
+	dto.Checkpoints = make([]TrainingCheckpointDTO, len(ut.Checkpoints))
+	for i := range ut.Checkpoints {
+		cp := &ut.Checkpoints[i]
+
+		dto.Checkpoints[i] = TrainingCheckpointDTO{
+			Step:      cp.Step,
+			Path:      cp.Path.InputeFilePath(),
+			CreatedAt: utils.ToDate(cp.CreatedAt),
+		}
+	}
 }
 
 type ResourceIndexCmd = domain.ResourceIndex
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/domain/training"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
+)
+
+// NewTrainingJobDriver plugs the training into the job subsystem.
+// The scope of job is the id of project.
+func NewTrainingJobDriver(
+	train training.Training,
+	repo repository.Training,
+	ts TrainingService,
+) jobapp.Driver {
+	return trainingJobDriver{
+		train: train,
+		repo:  repo,
+		ts:    ts,
+	}
+}
+
+type trainingJobDriver struct {
+	train training.Training
+	repo  repository.Training
+	ts    TrainingService
+}
+
+func (d trainingJobDriver) Type() jobdomain.JobType {
+	return jobdomain.JobTypeTraining
+}
+
+func (d trainingJobDriver) toTrainingIndex(index *jobdomain.JobIndex) TrainingIndex {
+	return TrainingIndex{
+		Project: domain.ResourceIndex{
+			Owner: index.Owner,
+			Id:    index.Scope,
+		},
+		TrainingId: index.Id,
+	}
+}
+
+func (d trainingJobDriver) GetJob(index *jobdomain.JobIndex) (jobdomain.Job, error) {
+	info := d.toTrainingIndex(index)
+
+	t, err := d.repo.Get(&info)
+	if err != nil {
+		return jobdomain.Job{}, err
+	}
+
+	return jobdomain.Job{
+		Id:        index.Id,
+		Name:      t.Name.TrainingName(),
+		CreatedAt: t.CreatedAt,
+		Info:      t.Job,
+		Detail:    t.JobDetail,
+	}, nil
+}
+
+func (d trainingJobDriver) ListJobs(owner domain.Account, scope string) ([]jobdomain.Job, error) {
+	v, _, err := d.repo.List(owner, scope)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]jobdomain.Job, len(v))
+	for i := range v {
+		item := &v[i]
+
+		r[i] = jobdomain.Job{
+			Id:        item.Id,
+			Name:      item.Name.TrainingName(),
+			CreatedAt: item.CreatedAt,
+			Detail: jobdomain.JobDetail{
+				Status:   item.Status,
+				Error:    item.Error,
+				Duration: item.Duration,
+			},
+		}
+	}
+
+	return r, nil
+}
+
+// UpdateJobDetail keeps the aim path and metrics which are not reported
+// by the common status callback, and goes through the training service
+// so that the sweep or pipeline of training is advanced when it is done.
+func (d trainingJobDriver) UpdateJobDetail(index *jobdomain.JobIndex, v *jobdomain.JobDetail) error {
+	info := d.toTrainingIndex(index)
+
+	detail, _, err := d.repo.GetJobDetail(&info)
+	if err != nil {
+		return err
+	}
+
+	detail.Status = v.Status
+	detail.Error = v.Error
+	detail.LogPath = v.LogPath
+	detail.OutputPath = v.OutputPath
+	detail.Duration = v.Duration
+
+	return d.ts.UpdateJobDetail(&info, &detail)
+}
+
+func (d trainingJobDriver) IsJobDone(status string) bool {
+	return d.train.IsJobDone(status)
+}
+
+func (d trainingJobDriver) IsJobSucceeded(status string) bool {
+	return d.train.IsJobSucceeded(status)
+}
+
+func (d trainingJobDriver) CanTerminate(status string) bool {
+	return !d.train.IsJobDone(status)
+}
+
+func (d trainingJobDriver) TerminateJob(job *jobdomain.JobInfo) error {
+	return d.train.TerminateJob(job.Endpoint, job.JobId)
+}
+
+func (d trainingJobDriver) GetLogPreviewURL(job *jobdomain.JobInfo) (string, error) {
+	return d.train.GetLogPreviewURL(job.Endpoint, job.JobId)
+}
+
+func (d trainingJobDriver) GetFileDownloadURL(endpoint, file string) (string, error) {
+	return d.train.GetFileDownloadURL(endpoint, file)
+}
@@ -0,0 +1,204 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+const (
+	maxMetricsPerReport   = 1000
+	maxTrainingsToCompare = 5
+)
+
+type TrainingMetricAddCmd struct {
+	TrainingIndex
+
+	Metrics []domain.TrainingMetric
+}
+
+func (cmd *TrainingMetricAddCmd) Validate() error {
+	b := cmd.Project.Owner != nil &&
+		cmd.Project.Id != "" &&
+		cmd.TrainingId != ""
+
+	if !b {
+		return errors.New("invalid training index")
+	}
+
+	if n := len(cmd.Metrics); n == 0 || n > maxMetricsPerReport {
+		return errors.New("invalid num of metrics")
+	}
+
+	return nil
+}
+
+type TrainingMetricCompareCmd struct {
+	User        domain.Account
+	ProjectId   string
+	TrainingIds []string
+	Names       []string
+}
+
+func (cmd *TrainingMetricCompareCmd) Validate() error {
+	if n := len(cmd.TrainingIds); n == 0 || n > maxTrainingsToCompare {
+		return errors.New("invalid num of trainings to compare")
+	}
+
+	return nil
+}
+
+type TrainingMetricPointDTO struct {
+	Step  int     `json:"step"`
+	Value float64 `json:"value"`
+}
+
+type TrainingMetricSeriesDTO struct {
+	Name   string                   `json:"name"`
+	Points []TrainingMetricPointDTO `json:"points"`
+}
+
+type TrainingMetricComparisonDTO struct {
+	TrainingId string                    `json:"training_id"`
+	Name       string                    `json:"name"`
+	Series     []TrainingMetricSeriesDTO `json:"series"`
+}
+
+// toTrainingMetricSeriesDTO groups the metrics which are sorted by name and step.
+func toTrainingMetricSeriesDTO(v []domain.TrainingMetric) []TrainingMetricSeriesDTO {
+	r := []TrainingMetricSeriesDTO{}
+
+	for i := range v {
+		item := &v[i]
+		name := item.Name.MetricName()
+
+		if n := len(r); n == 0 || r[n-1].Name != name {
+			r = append(r, TrainingMetricSeriesDTO{Name: name})
+		}
+
+		s := &r[len(r)-1]
+		s.Points = append(s.Points, TrainingMetricPointDTO{
+			Step:  item.Step,
+			Value: item.Value,
+		})
+	}
+
+	return r
+}
+
+type TrainingMetricService interface {
+	Add(*TrainingMetricAddCmd) (string, error)
+	List(info *TrainingIndex, names []string) ([]TrainingMetricSeriesDTO, error)
+	ListSince(info *TrainingIndex, since int64) ([]TrainingMetricSeriesDTO, int64, error)
+	Compare(*TrainingMetricCompareCmd) ([]TrainingMetricComparisonDTO, string, error)
+}
+
+func NewTrainingMetricService(
+	repo repository.TrainingMetric,
+	training repository.Training,
+) TrainingMetricService {
+	return trainingMetricService{
+		repo:     repo,
+		training: training,
+	}
+}
+
+type trainingMetricService struct {
+	repo     repository.TrainingMetric
+	training repository.Training
+}
+
+func (s trainingMetricService) Add(cmd *TrainingMetricAddCmd) (string, error) {
+	if _, err := s.training.GetJob(&cmd.TrainingIndex); err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			return ErrorTrainNotFound, err
+		}
+
+		return "", err
+	}
+
+	now := time.Now().UnixMilli()
+	for i := range cmd.Metrics {
+		cmd.Metrics[i].CreatedAt = now
+	}
+
+	return "", s.repo.Add(&cmd.TrainingIndex, cmd.Metrics)
+}
+
+func (s trainingMetricService) List(info *TrainingIndex, names []string) (
+	[]TrainingMetricSeriesDTO, error,
+) {
+	v, err := s.repo.Find(info, &repository.TrainingMetricOption{Names: names})
+	if err != nil {
+		return nil, err
+	}
+
+	return toTrainingMetricSeriesDTO(v), nil
+}
+
+// ListSince returns the metrics reported after since and the time of
+// the last one which should be passed as since at the next time.
+func (s trainingMetricService) ListSince(info *TrainingIndex, since int64) (
+	[]TrainingMetricSeriesDTO, int64, error,
+) {
+	v, err := s.repo.Find(info, &repository.TrainingMetricOption{Since: since})
+	if err != nil || len(v) == 0 {
+		return nil, since, err
+	}
+
+	for i := range v {
+		if v[i].CreatedAt > since {
+			since = v[i].CreatedAt
+		}
+	}
+
+	return toTrainingMetricSeriesDTO(v), since, nil
+}
+
+func (s trainingMetricService) Compare(cmd *TrainingMetricCompareCmd) (
+	[]TrainingMetricComparisonDTO, string, error,
+) {
+	trainings, _, err := s.training.List(cmd.User, cmd.ProjectId)
+	if err != nil {
+		return nil, "", err
+	}
+
+	names := make(map[string]string, len(trainings))
+	for i := range trainings {
+		names[trainings[i].Id] = trainings[i].Name.TrainingName()
+	}
+
+	opt := repository.TrainingMetricOption{Names: cmd.Names}
+
+	r := make([]TrainingMetricComparisonDTO, len(cmd.TrainingIds))
+	for i, id := range cmd.TrainingIds {
+		name, ok := names[id]
+		if !ok {
+			return nil, ErrorTrainNotFound, errors.New("training not found")
+		}
+
+		v, err := s.repo.Find(
+			&TrainingIndex{
+				Project: domain.ResourceIndex{
+					Owner: cmd.User,
+					Id:    cmd.ProjectId,
+				},
+				TrainingId: id,
+			},
+			&opt,
+		)
+		if err != nil {
+			return nil, "", err
+		}
+
+		r[i] = TrainingMetricComparisonDTO{
+			TrainingId: id,
+			Name:       name,
+			Series:     toTrainingMetricSeriesDTO(v),
+		}
+	}
+
+	return r, "", nil
+}
@@ -0,0 +1,267 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type TrainingPipelineCreateCmd struct {
+	User      domain.Account
+	ProjectId string
+	Name      domain.TrainingName
+	Steps     []domain.PipelineStep
+}
+
+func (cmd *TrainingPipelineCreateCmd) Validate() error {
+	b := cmd.User != nil &&
+		cmd.ProjectId != "" &&
+		cmd.Name != nil &&
+		len(cmd.Steps) > 0
+
+	if !b {
+		return errors.New("invalid cmd of creating training pipeline")
+	}
+
+	return nil
+}
+
+func (cmd *TrainingPipelineCreateCmd) toTrainingPipeline() (domain.TrainingPipeline, error) {
+	return domain.NewTrainingPipeline(
+		cmd.User, cmd.ProjectId, cmd.Name, cmd.Steps, utils.Now(),
+	)
+}
+
+type TrainingPipelineSummaryDTO struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Steps     int    `json:"steps"`
+	CreatedAt string `json:"created_at"`
+}
+
+func (s trainingService) toTrainingPipelineSummaryDTO(
+	p *domain.TrainingPipeline, dto *TrainingPipelineSummaryDTO,
+) {
+	*dto = TrainingPipelineSummaryDTO{
+		Id:        p.Id,
+		Name:      p.Name.TrainingName(),
+		Status:    p.Status(),
+		Steps:     len(p.Steps),
+		CreatedAt: utils.ToDate(p.CreatedAt),
+	}
+}
+
+type TrainingPipelineDTO struct {
+	TrainingPipelineSummaryDTO
+
+	Steps []PipelineStepDTO `json:"steps"`
+}
+
+type PipelineStepDTO struct {
+	Name       string   `json:"name"`
+	DependsOn  []string `json:"depends_on"`
+	Status     string   `json:"status"`
+	Retries    int      `json:"retries"`
+	MaxRetries int      `json:"max_retries"`
+	TrainingId string   `json:"training_id"`
+}
+
+func (s trainingService) toTrainingPipelineDTO(p *domain.TrainingPipeline, dto *TrainingPipelineDTO) {
+	s.toTrainingPipelineSummaryDTO(p, &dto.TrainingPipelineSummaryDTO)
+
+	dto.Steps = make([]PipelineStepDTO, len(p.Steps))
+	for i := range p.Steps {
+		step := &p.Steps[i]
+
+		deps := make([]string, len(step.DependsOn))
+		for j := range step.DependsOn {
+			deps[j] = step.DependsOn[j].Step.TrainingName()
+		}
+
+		dto.Steps[i] = PipelineStepDTO{
+			Name:       step.Name.TrainingName(),
+			DependsOn:  deps,
+			Status:     step.Status,
+			Retries:    step.Retries,
+			MaxRetries: step.MaxRetries,
+			TrainingId: step.TrainingId,
+		}
+	}
+}
+
+func (s trainingService) CreatePipeline(cmd *TrainingPipelineCreateCmd) (
+	id string, code string, err error,
+) {
+	p, err := cmd.toTrainingPipeline()
+	if err != nil {
+		code = ErrorTrainInvalidPipeline
+
+		return
+	}
+
+	v, _, err := s.repo.List(cmd.User, cmd.ProjectId)
+	if err != nil {
+		return
+	}
+
+	if len(v)+len(p.Steps) > s.maxTrainingRecordNum {
+		code = ErrorTrainExccedMaxNum
+		err = ErrorExccedMaxTrainingRecord{
+			errors.New("exceed max training num"),
+		}
+
+		return
+	}
+
+	names := map[string]bool{}
+	for i := range p.Steps {
+		name, err1 := p.StepTrainingName(i)
+		if err1 != nil {
+			code, err = ErrorTrainInvalidPipeline, err1
+
+			return
+		}
+
+		names[name.TrainingName()] = true
+	}
+
+	for i := range v {
+		if !s.isJobDone(v[i].Status) {
+			err = ErrorOnlyOneRunningTraining{
+				errors.New("a training is running"),
+			}
+
+			return
+		}
+
+		if names[v[i].Name.TrainingName()] {
+			err = ErrorDuplicateTrainingName{
+				errors.New("duplicate training name"),
+			}
+
+			return
+		}
+	}
+
+	if id, err = s.pipelineRepo.Save(&p); err != nil {
+		return
+	}
+
+	p.Id = id
+
+	if err1 := s.advancePipeline(&p, 0); err1 != nil {
+		logrus.Errorf("start steps of pipeline(%s) failed, err:%s", id, err1.Error())
+	}
+
+	return
+}
+
+// advancePipelineOf records the result of the step which runs the training
+// and starts the steps which are ready then.
+func (s trainingService) advancePipelineOf(info *TrainingIndex, t *domain.UserTraining) error {
+	p, version, err := s.pipelineRepo.GetByTraining(info)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	i := p.StepOfTraining(t.Id)
+	if i < 0 || !p.Steps[i].IsRunning() {
+		return nil
+	}
+
+	detail := &t.JobDetail
+	p.FinishStep(i, s.train.IsJobSucceeded(detail.Status), detail.OutputPath)
+
+	return s.advancePipeline(&p, version)
+}
+
+func (s trainingService) advancePipeline(p *domain.TrainingPipeline, version int) error {
+	v, repoVersion, err := s.repo.List(p.Owner, p.ProjectId)
+	if err != nil {
+		return err
+	}
+
+	running := 0
+	for i := range v {
+		if !s.isJobDone(v[i].Status) {
+			running++
+		}
+	}
+
+	for _, i := range p.ReadySteps(running) {
+		config, err1 := p.StepConfig(i)
+		if err1 != nil {
+			logrus.Errorf(
+				"abort step(%s) of pipeline(%s), err:%s",
+				p.Steps[i].Name.TrainingName(), p.Id, err1.Error(),
+			)
+
+			p.AbortStep(i)
+
+			continue
+		}
+
+		r, err1 := s.save(&domain.UserTraining{
+			Owner:          p.Owner,
+			ProjectId:      p.ProjectId,
+			TrainingConfig: config,
+		}, repoVersion)
+		if err1 != nil {
+			err = err1
+
+			break
+		}
+
+		p.StartStep(i, r)
+		repoVersion++
+	}
+
+	if err1 := s.pipelineRepo.UpdateSteps(p, version); err1 != nil {
+		return err1
+	}
+
+	return err
+}
+
+func (s trainingService) ListPipelines(user domain.Account, projectId string) (
+	[]TrainingPipelineSummaryDTO, error,
+) {
+	v, err := s.pipelineRepo.List(user, projectId)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]TrainingPipelineSummaryDTO, len(v))
+	for i := range v {
+		s.toTrainingPipelineSummaryDTO(&v[i], &r[i])
+	}
+
+	return r, nil
+}
+
+func (s trainingService) GetPipeline(user domain.Account, projectId, id string) (
+	dto TrainingPipelineDTO, code string, err error,
+) {
+	p, _, err := s.pipelineRepo.Get(user, id)
+	if err != nil || p.ProjectId != projectId {
+		if err == nil {
+			err = errors.New("pipeline not found")
+		}
+
+		code = ErrorTrainPipelineNotFound
+
+		return
+	}
+
+	s.toTrainingPipelineDTO(&p, &dto)
+
+	return
+}
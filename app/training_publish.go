@@ -0,0 +1,248 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/domain/training"
+	spacerepo "github.com/opensourceways/xihe-server/space/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingPublishCmd struct {
+	TrainingIndex
+
+	ModelName  domain.ResourceName
+	ModelTitle domain.ResourceTitle
+	ModelDesc  domain.ResourceDesc
+	RepoType   domain.RepoType
+	Protocol   domain.ProtocolName
+	Dir        domain.Directory
+}
+
+func (cmd *TrainingPublishCmd) Validate() error {
+	b := cmd.Project.Owner != nil &&
+		cmd.Project.Id != "" &&
+		cmd.TrainingId != "" &&
+		cmd.ModelName != nil &&
+		cmd.ModelTitle != nil &&
+		cmd.ModelDesc != nil &&
+		cmd.RepoType != nil &&
+		cmd.Protocol != nil &&
+		cmd.Dir != nil
+
+	if !b {
+		return errors.New("invalid cmd of publishing training")
+	}
+
+	return nil
+}
+
+type TrainingPublishService interface {
+	Publish(*platform.UserInfo, *TrainingPublishCmd, platform.Repository) (ModelDetailDTO, string, error)
+}
+
+func NewTrainingPublishService(
+	train training.Training,
+	repo repository.Training,
+	model repository.Model,
+	modelService ModelService,
+	project spacerepo.Project,
+	repoFile platform.RepoFile,
+	maxFileSize int64,
+) TrainingPublishService {
+	return trainingPublishService{
+		train:        train,
+		repo:         repo,
+		model:        model,
+		modelService: modelService,
+		project:      project,
+		repoFile:     repoFile,
+		maxFileSize:  maxFileSize,
+	}
+}
+
+type trainingPublishService struct {
+	train        training.Training
+	repo         repository.Training
+	model        repository.Model
+	modelService ModelService
+	project      spacerepo.Project
+	repoFile     platform.RepoFile
+	maxFileSize  int64
+}
+
+// Publish commits the output of a succeeded training into the model named by cmd,
+// creating the model if it does not exist.
+func (s trainingPublishService) Publish(
+	u *platform.UserInfo, cmd *TrainingPublishCmd, pr platform.Repository,
+) (dto ModelDetailDTO, code string, err error) {
+	detail, endpoint, err := s.repo.GetJobDetail(&cmd.TrainingIndex)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorTrainNotFound
+		}
+
+		return
+	}
+
+	if !s.train.IsJobSucceeded(detail.Status) {
+		code = ErrorTrainNotSucceeded
+		err = errors.New("training is not succeeded")
+
+		return
+	}
+
+	if detail.OutputPath == "" {
+		code = ErrorTrainNoOutput
+		err = errors.New("no output")
+
+		return
+	}
+
+	file, err := domain.NewFilePath(
+		filepath.Join(cmd.Dir.Directory(), filepath.Base(detail.OutputPath)),
+	)
+	if err != nil {
+		return
+	}
+
+	data, err := s.train.DownloadFile(endpoint, detail.OutputPath, s.maxFileSize)
+	if err != nil {
+		if _, ok := err.(training.ErrorFileTooLarge); ok {
+			code = ErrorTrainOutputTooBig
+		}
+
+		return
+	}
+
+	m, err := s.getOrCreateModel(cmd, pr)
+	if err != nil {
+		return
+	}
+
+	if err = s.commitFile(u, &m, cmd.Dir, file, data); err != nil {
+		return
+	}
+
+	err = s.model.AddLineage(&repository.ModelLineageInfo{
+		ResourceToUpdate: repository.ResourceToUpdate{
+			Owner:     m.Owner,
+			Id:        m.Id,
+			Version:   m.Version,
+			UpdatedAt: utils.Now(),
+		},
+		Lineage: domain.ModelLineage{
+			ProjectId:   cmd.Project.Id,
+			TrainingId:  cmd.TrainingId,
+			PublishedAt: utils.Now(),
+		},
+	})
+	if err != nil {
+		return
+	}
+
+	if err1 := s.linkRelatedDatasets(cmd.Project.Owner, cmd.Project.Id, &m); err1 != nil {
+		logrus.Errorf(
+			"link related datasets of project:%s to model:%s failed, err:%s",
+			cmd.Project.Id, m.Id, err1.Error(),
+		)
+	}
+
+	dto, err = s.modelService.GetByName(m.Owner, m.Name, true)
+
+	return
+}
+
+func (s trainingPublishService) getOrCreateModel(cmd *TrainingPublishCmd, pr platform.Repository) (
+	domain.Model, error,
+) {
+	owner := cmd.Project.Owner
+
+	m, err := s.model.GetByName(owner, cmd.ModelName)
+	if err == nil || !repository.IsErrorResourceNotExists(err) {
+		return m, err
+	}
+
+	_, err = s.modelService.Create(
+		&ModelCreateCmd{
+			Owner:    owner,
+			Name:     cmd.ModelName,
+			Title:    cmd.ModelTitle,
+			Desc:     cmd.ModelDesc,
+			RepoType: cmd.RepoType,
+			Protocol: cmd.Protocol,
+		},
+		pr,
+	)
+	if err != nil {
+		return m, err
+	}
+
+	return s.model.GetByName(owner, cmd.ModelName)
+}
+
+func (s trainingPublishService) commitFile(
+	u *platform.UserInfo, m *domain.Model,
+	dir domain.Directory, file domain.FilePath, data []byte,
+) error {
+	_, exist, err := s.repoFile.GetDirFileInfo(u, &platform.RepoDirFile{
+		RepoName: m.Name,
+		Dir:      dir,
+		File:     file,
+	})
+	if err != nil {
+		return err
+	}
+
+	content := base64.StdEncoding.EncodeToString(data)
+
+	info := platform.RepoFileInfo{
+		RepoId: m.RepoId,
+		Path:   file,
+	}
+	c := platform.RepoFileContent{
+		Content:   &content,
+		IsEncoded: true,
+	}
+
+	if exist {
+		return s.repoFile.Update(u, &info, &c)
+	}
+
+	return s.repoFile.Create(u, &info, &c)
+}
+
+func (s trainingPublishService) linkRelatedDatasets(
+	owner domain.Account, pid string, m *domain.Model,
+) error {
+	p, err := s.project.Get(owner, pid)
+	if err != nil {
+		return err
+	}
+
+	for i := range p.RelatedDatasets {
+		// reload the model, since its version changes after each updating.
+		v, err := s.model.Get(m.Owner, m.Id)
+		if err != nil {
+			return err
+		}
+
+		err = s.modelService.AddRelatedDataset(&v, &p.RelatedDatasets[i])
+		if err != nil {
+			if _, ok := err.(ErrorExceedMaxRelatedResourceNum); ok {
+				return nil
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,347 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingScheduleCreateCmd struct {
+	User      domain.Account
+	ProjectId string
+	Cron      domain.CronExpr
+
+	// TrainingId is the training whose config is used by the schedule.
+	// The config of last training will be used if it is empty.
+	TrainingId string
+}
+
+func (cmd *TrainingScheduleCreateCmd) Validate() error {
+	b := cmd.User != nil &&
+		cmd.ProjectId != "" &&
+		cmd.Cron != nil
+
+	if !b {
+		return errors.New("invalid cmd of creating training schedule")
+	}
+
+	return nil
+}
+
+type TrainingScheduleDTO struct {
+	Id           string           `json:"id"`
+	Cron         string           `json:"cron"`
+	TrainingName string           `json:"training_name"`
+	NextRunAt    string           `json:"next_run_at"`
+	CreatedAt    string           `json:"created_at"`
+	History      []ScheduleRunDTO `json:"history"`
+}
+
+type ScheduleRunDTO struct {
+	RunAt      string `json:"run_at"`
+	Status     string `json:"status"`
+	TrainingId string `json:"training_id"`
+	Reason     string `json:"reason"`
+}
+
+func toTrainingScheduleDTO(s *domain.TrainingSchedule, dto *TrainingScheduleDTO) {
+	*dto = TrainingScheduleDTO{
+		Id:           s.Id,
+		Cron:         s.Cron.CronExpr(),
+		TrainingName: s.Config.Name.TrainingName(),
+		CreatedAt:    utils.ToDate(s.CreatedAt),
+	}
+
+	_, dto.NextRunAt = utils.DateAndTime(s.NextRunAt)
+
+	// the latest run is at first
+	n := len(s.History)
+	dto.History = make([]ScheduleRunDTO, n)
+	for i := range s.History {
+		h := &s.History[n-1-i]
+		v := &dto.History[i]
+
+		_, v.RunAt = utils.DateAndTime(h.RunAt)
+		v.Status = h.Status
+		v.TrainingId = h.TrainingId
+		v.Reason = h.Reason
+	}
+}
+
+type TrainingScheduleService interface {
+	Create(*TrainingScheduleCreateCmd) (string, string, error)
+	List(user domain.Account, projectId string) ([]TrainingScheduleDTO, error)
+	Get(user domain.Account, projectId, id string) (TrainingScheduleDTO, string, error)
+	Delete(user domain.Account, projectId, id string) (string, error)
+}
+
+func NewTrainingScheduleService(
+	repo repository.Training,
+	schedule repository.TrainingSchedule,
+) TrainingScheduleService {
+	return trainingScheduleService{
+		repo:     repo,
+		schedule: schedule,
+	}
+}
+
+type trainingScheduleService struct {
+	repo     repository.Training
+	schedule repository.TrainingSchedule
+}
+
+func (s trainingScheduleService) Create(cmd *TrainingScheduleCreateCmd) (
+	id string, code string, err error,
+) {
+	v, err := s.schedule.List(cmd.User, cmd.ProjectId)
+	if err != nil {
+		return
+	}
+
+	if len(v) >= domain.DomainConfig.MaxSchedulesPerProject {
+		code = ErrorTrainExccedMaxScheduleNum
+		err = errors.New("exceed max schedule num")
+
+		return
+	}
+
+	config, err := s.trainingConfig(cmd)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorTrainNotFound
+		}
+
+		return
+	}
+
+	t := domain.NewTrainingSchedule(
+		cmd.User, cmd.ProjectId, cmd.Cron, &config, utils.Now(),
+	)
+
+	id, err = s.schedule.Save(&t)
+
+	return
+}
+
+func (s trainingScheduleService) trainingConfig(cmd *TrainingScheduleCreateCmd) (
+	domain.TrainingConfig, error,
+) {
+	project := domain.ResourceIndex{
+		Owner: cmd.User,
+		Id:    cmd.ProjectId,
+	}
+
+	if cmd.TrainingId == "" {
+		return s.repo.GetLastTrainingConfig(&project)
+	}
+
+	return s.repo.GetTrainingConfig(&TrainingIndex{
+		Project:    project,
+		TrainingId: cmd.TrainingId,
+	})
+}
+
+func (s trainingScheduleService) List(user domain.Account, projectId string) (
+	[]TrainingScheduleDTO, error,
+) {
+	v, err := s.schedule.List(user, projectId)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]TrainingScheduleDTO, len(v))
+	for i := range v {
+		toTrainingScheduleDTO(&v[i], &r[i])
+	}
+
+	return r, nil
+}
+
+func (s trainingScheduleService) Get(user domain.Account, projectId, id string) (
+	dto TrainingScheduleDTO, code string, err error,
+) {
+	v, code, err := s.get(user, projectId, id)
+	if err == nil {
+		toTrainingScheduleDTO(&v, &dto)
+	}
+
+	return
+}
+
+func (s trainingScheduleService) Delete(user domain.Account, projectId, id string) (
+	code string, err error,
+) {
+	if _, code, err = s.get(user, projectId, id); err != nil {
+		return
+	}
+
+	err = s.schedule.Delete(user, id)
+
+	return
+}
+
+func (s trainingScheduleService) get(user domain.Account, projectId, id string) (
+	v domain.TrainingSchedule, code string, err error,
+) {
+	v, err = s.schedule.Get(user, id)
+	if err == nil && v.ProjectId != projectId {
+		err = repository.NewErrorResourceNotExists(errors.New("no schedule"))
+	}
+
+	if err != nil && repository.IsErrorResourceNotExists(err) {
+		code = ErrorTrainScheduleNotFound
+	}
+
+	return
+}
+
+// TrainingScheduler creates the trainings of the due schedules.
+type TrainingScheduler interface {
+	Schedule()
+}
+
+func NewTrainingScheduler(
+	ts TrainingService,
+	schedule repository.TrainingSchedule,
+	repoFile platform.RepoFile,
+	getPlatformToken func(domain.Account) (string, error),
+) TrainingScheduler {
+	return &trainingScheduler{
+		ts:               ts,
+		schedule:         schedule,
+		repoFile:         repoFile,
+		getPlatformToken: getPlatformToken,
+	}
+}
+
+type trainingScheduler struct {
+	ts               TrainingService
+	schedule         repository.TrainingSchedule
+	repoFile         platform.RepoFile
+	getPlatformToken func(domain.Account) (string, error)
+}
+
+func (s *trainingScheduler) Schedule() {
+	now := utils.Now()
+
+	v, err := s.schedule.FindDue(now)
+	if err != nil {
+		logrus.Errorf("find due training schedules failed, err:%s", err.Error())
+
+		return
+	}
+
+	for i := range v {
+		if err := s.run(&v[i], now); err != nil {
+			logrus.Errorf("run training schedule %s failed, err:%s", v[i].Id, err.Error())
+		}
+	}
+}
+
+func (s *trainingScheduler) run(t *domain.TrainingSchedule, now int64) error {
+	// claim the run by moving to the next run time, so that the schedule
+	// will not run twice when there are many instances.
+	c := *t
+	c.Advance(now)
+
+	if err := s.schedule.UpdateRun(&c); err != nil {
+		if repository.IsErrorConcurrentUpdating(err) {
+			// it has been run by others
+			err = nil
+		}
+
+		return err
+	}
+
+	t.Version++
+
+	commit, err := s.inputsCommit(t)
+	switch {
+	case err != nil:
+		t.Failed("get commit of inputs failed, "+err.Error(), now)
+
+	case !t.IsInputsChanged(commit):
+		t.Skipped("inputs are not changed", now)
+
+	default:
+		s.create(t, commit, now)
+	}
+
+	return s.schedule.UpdateRun(t)
+}
+
+func (s *trainingScheduler) create(t *domain.TrainingSchedule, commit string, now int64) {
+	config, err := t.TrainingConfigAt(now)
+	if err != nil {
+		t.Failed(err.Error(), now)
+
+		return
+	}
+
+	id, err := s.ts.Create(&TrainingCreateCmd{
+		User:           t.Owner,
+		ProjectId:      t.ProjectId,
+		TrainingConfig: config,
+	})
+	if err == nil {
+		t.Created(id, commit, now)
+
+		return
+	}
+
+	switch err.(type) {
+	case ErrorExccedMaxTrainingRecord, ErrorOnlyOneRunningTraining:
+		t.Skipped(err.Error(), now)
+
+	default:
+		t.Failed(err.Error(), now)
+	}
+}
+
+// inputsCommit returns the latest commits of the repos of inputs.
+// The output of other training is ignored since it has no commit.
+func (s *trainingScheduler) inputsCommit(t *domain.TrainingSchedule) (string, error) {
+	inputs := t.Config.Inputs
+	if len(inputs) == 0 {
+		return "", nil
+	}
+
+	token, err := s.getPlatformToken(t.Owner)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := domain.NewDirectory("")
+	if err != nil {
+		return "", err
+	}
+
+	commits := make([]string, 0, len(inputs))
+
+	for i := range inputs {
+		v := &inputs[i]
+
+		if v.Type.ResourceType() == domain.ResourceTypeProject.ResourceType() ||
+			v.Name.ResourceName() == "" {
+			continue
+		}
+
+		sha, err := s.repoFile.GetLastCommit(
+			&platform.UserInfo{User: v.User, Token: token},
+			&platform.RepoDir{RepoName: v.Name, Path: root},
+		)
+		if err != nil {
+			return "", err
+		}
+
+		commits = append(commits, v.Key.CustomizedKey()+":"+sha)
+	}
+
+	return strings.Join(commits, ","), nil
+}
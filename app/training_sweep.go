@@ -0,0 +1,354 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+	"github.com/sirupsen/logrus"
+)
+
+type TrainingSweepCreateCmd struct {
+	TrainingCreateCmd
+
+	Algorithm   domain.SweepAlgorithm
+	Params      []domain.SweepParameter
+	MaxTrials   int
+	Metric      domain.SweepMetric
+	Parallelism int
+}
+
+func (cmd *TrainingSweepCreateCmd) Validate() error {
+	if err := cmd.TrainingCreateCmd.Validate(); err != nil {
+		return err
+	}
+
+	b := cmd.Algorithm != nil &&
+		len(cmd.Params) > 0 &&
+		cmd.Metric.Name != "" &&
+		cmd.Metric.Goal != nil
+
+	if !b {
+		return errors.New("invalid cmd of creating training sweep")
+	}
+
+	if !cmd.Algorithm.IsGrid() && cmd.MaxTrials <= 0 {
+		return errors.New("missing max trials of random search")
+	}
+
+	return nil
+}
+
+func (cmd *TrainingSweepCreateCmd) toTrainingSweep() (domain.TrainingSweep, error) {
+	return domain.NewTrainingSweep(
+		cmd.User, cmd.ProjectId, cmd.Name, cmd.Algorithm, cmd.Params,
+		cmd.MaxTrials, cmd.Metric, cmd.Parallelism, cmd.toTrainingConfig(),
+		utils.Now(),
+	)
+}
+
+type TrainingSweepSummaryDTO struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Algorithm   string `json:"algorithm"`
+	Metric      string `json:"metric"`
+	Goal        string `json:"goal"`
+	Parallelism int    `json:"parallelism"`
+	Trials      int    `json:"trials"`
+	Pending     int    `json:"pending"`
+	CreatedAt   string `json:"created_at"`
+}
+
+func (s trainingService) toTrainingSweepSummaryDTO(
+	t *domain.TrainingSweep, dto *TrainingSweepSummaryDTO,
+) {
+	pending := 0
+	for i := range t.Trials {
+		if t.Trials[i].IsPending() {
+			pending++
+		}
+	}
+
+	*dto = TrainingSweepSummaryDTO{
+		Id:          t.Id,
+		Name:        t.Name.TrainingName(),
+		Algorithm:   t.Algorithm.SweepAlgorithm(),
+		Metric:      t.Metric.Name,
+		Goal:        t.Metric.Goal.MetricGoal(),
+		Parallelism: t.Parallelism,
+		Trials:      len(t.Trials),
+		Pending:     pending,
+		CreatedAt:   utils.ToDate(t.CreatedAt),
+	}
+}
+
+type TrainingSweepDTO struct {
+	TrainingSweepSummaryDTO
+
+	// Trials are ranked by the metric, the best one is the first.
+	// The pending trials are at the end.
+	Ranking []SweepTrialDTO `json:"ranking"`
+}
+
+type SweepTrialDTO struct {
+	Name            string            `json:"name"`
+	Hyperparameters map[string]string `json:"hyperparameters"`
+
+	// Metric is nil if the training has not reported it.
+	Metric   *float64            `json:"metric"`
+	Training *TrainingSummaryDTO `json:"training,omitempty"`
+}
+
+func (s trainingService) toSweepTrialDTO(t *domain.SweepTrial) SweepTrialDTO {
+	kv := make(map[string]string, len(t.Hyperparameters))
+	for _, v := range t.Hyperparameters {
+		if v.Value != nil {
+			kv[v.Key.CustomizedKey()] = v.Value.CustomizedValue()
+		} else {
+			kv[v.Key.CustomizedKey()] = ""
+		}
+	}
+
+	return SweepTrialDTO{
+		Name:            t.Name.TrainingName(),
+		Hyperparameters: kv,
+	}
+}
+
+func (s trainingService) CreateSweep(cmd *TrainingSweepCreateCmd) (
+	id string, code string, err error,
+) {
+	sweep, err := cmd.toTrainingSweep()
+	if err != nil {
+		code = ErrorTrainInvalidSweep
+
+		return
+	}
+
+	v, _, err := s.repo.List(cmd.User, cmd.ProjectId)
+	if err != nil {
+		return
+	}
+
+	if len(v)+len(sweep.Trials) > s.maxTrainingRecordNum {
+		code = ErrorTrainExccedMaxNum
+		err = ErrorExccedMaxTrainingRecord{
+			errors.New("exceed max training num"),
+		}
+
+		return
+	}
+
+	names := map[string]bool{}
+	for i := range sweep.Trials {
+		names[sweep.Trials[i].Name.TrainingName()] = true
+	}
+
+	for i := range v {
+		if !s.isJobDone(v[i].Status) {
+			err = ErrorOnlyOneRunningTraining{
+				errors.New("a training is running"),
+			}
+
+			return
+		}
+
+		if names[v[i].Name.TrainingName()] {
+			err = ErrorDuplicateTrainingName{
+				errors.New("duplicate training name"),
+			}
+
+			return
+		}
+	}
+
+	if id, err = s.sweepRepo.Save(&sweep); err != nil {
+		return
+	}
+
+	sweep.Id = id
+
+	if err1 := s.advanceSweep(&sweep, 0); err1 != nil {
+		logrus.Errorf("start trials of sweep(%s) failed, err:%s", id, err1.Error())
+	}
+
+	return
+}
+
+// advanceSweep starts the pending trials of sweep as long as the num of
+// running trainings in the project doesn't exceed the parallelism of sweep.
+// The trainings are created before the trials are claimed, so they are
+// deleted if the claim fails, and their jobs are started only after it succeeds.
+func (s trainingService) advanceSweep(sweep *domain.TrainingSweep, version int) error {
+	v, repoVersion, err := s.repo.List(sweep.Owner, sweep.ProjectId)
+	if err != nil {
+		return err
+	}
+
+	running := 0
+	for i := range v {
+		if !s.isJobDone(v[i].Status) {
+			running++
+		}
+	}
+
+	next := sweep.NextTrials(running)
+	if len(next) == 0 {
+		return nil
+	}
+
+	created := make([]domain.UserTraining, 0, len(next))
+
+	for _, i := range next {
+		t := domain.UserTraining{
+			Owner:          sweep.Owner,
+			ProjectId:      sweep.ProjectId,
+			SweepId:        sweep.Id,
+			TrainingConfig: sweep.TrialConfig(i),
+			CreatedAt:      utils.Now(),
+		}
+
+		r, err := s.repo.Save(&t, repoVersion)
+		if err != nil {
+			s.deleteTrials(created)
+
+			return err
+		}
+
+		t.Id = r
+		created = append(created, t)
+
+		sweep.Trials[i].TrainingId = r
+		repoVersion++
+	}
+
+	if err := s.sweepRepo.UpdateTrials(sweep, version); err != nil {
+		s.deleteTrials(created)
+
+		return err
+	}
+
+	for i := range created {
+		s.sendTrainingCreated(&created[i], created[i].Id)
+	}
+
+	return nil
+}
+
+// deleteTrials removes the trainings created for the trials which are not claimed.
+func (s trainingService) deleteTrials(v []domain.UserTraining) {
+	for i := range v {
+		t := &v[i]
+
+		err := s.repo.Delete(&domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: t.Owner,
+				Id:    t.ProjectId,
+			},
+			TrainingId: t.Id,
+		})
+		if err != nil {
+			s.log.Errorf(
+				"delete training(%s) of unclaimed trial failed, err:%s",
+				t.Id, err.Error(),
+			)
+		}
+	}
+}
+
+// advanceSweepOf starts the next trials when a trial of sweep is done.
+func (s trainingService) advanceSweepOf(t *domain.UserTraining) error {
+	if t.SweepId == "" {
+		return nil
+	}
+
+	sweep, version, err := s.sweepRepo.Get(t.Owner, t.SweepId)
+	if err != nil || !sweep.HasPendingTrials() {
+		return err
+	}
+
+	return s.advanceSweep(&sweep, version)
+}
+
+func (s trainingService) ListSweeps(user domain.Account, projectId string) (
+	[]TrainingSweepSummaryDTO, error,
+) {
+	v, err := s.sweepRepo.List(user, projectId)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]TrainingSweepSummaryDTO, len(v))
+	for i := range v {
+		s.toTrainingSweepSummaryDTO(&v[i], &r[i])
+	}
+
+	return r, nil
+}
+
+func (s trainingService) GetSweep(user domain.Account, projectId, id string) (
+	dto TrainingSweepDTO, code string, err error,
+) {
+	sweep, _, err := s.sweepRepo.Get(user, id)
+	if err != nil || sweep.ProjectId != projectId {
+		if err == nil {
+			err = errors.New("sweep not found")
+		}
+
+		code = ErrorTrainSweepNotFound
+
+		return
+	}
+
+	v, _, err := s.repo.List(user, projectId)
+	if err != nil {
+		return
+	}
+
+	trainings := make([]domain.TrainingSummary, 0, len(sweep.Trials))
+	for i := range v {
+		if v[i].SweepId == sweep.Id {
+			trainings = append(trainings, v[i])
+		}
+	}
+
+	sweep.Rank(trainings)
+
+	trials := make(map[string]int, len(sweep.Trials))
+	for i := range sweep.Trials {
+		if id := sweep.Trials[i].TrainingId; id != "" {
+			trials[id] = i
+		}
+	}
+
+	s.toTrainingSweepSummaryDTO(&sweep, &dto.TrainingSweepSummaryDTO)
+
+	dto.Ranking = make([]SweepTrialDTO, 0, len(sweep.Trials))
+	for i := range trainings {
+		t := &trainings[i]
+
+		j, ok := trials[t.Id]
+		if !ok {
+			continue
+		}
+
+		item := s.toSweepTrialDTO(&sweep.Trials[j])
+
+		if m, ok := t.Metrics[sweep.Metric.Name]; ok {
+			item.Metric = &m
+		}
+
+		item.Training = new(TrainingSummaryDTO)
+		s.toTrainingSummaryDTO(t, item.Training)
+
+		dto.Ranking = append(dto.Ranking, item)
+	}
+
+	for i := range sweep.Trials {
+		if sweep.Trials[i].IsPending() {
+			dto.Ranking = append(dto.Ranking, s.toSweepTrialDTO(&sweep.Trials[i]))
+		}
+	}
+
+	return
+}
@@ -3,6 +3,7 @@ package app
 import (
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/bigmodels"
+	moderationdomain "github.com/opensourceways/xihe-server/moderation/domain"
 )
 
 func (s bigModelService) AIDetector(cmd *AIDetectorCmd) (code string, ismachine bool, err error) {
@@ -13,8 +14,10 @@ func (s bigModelService) AIDetector(cmd *AIDetectorCmd) (code string, ismachine
 	})
 
 	// audit
-	if err = s.fm.CheckText(cmd.Text.AIDetectorText()); err != nil {
-		code = ErrorBigModelSensitiveInfo
+	if _, err = moderateText(
+		s.moderation, moderationdomain.SurfaceAIDetectorText, cmd.User, cmd.Text.AIDetectorText(),
+	); err != nil {
+		code = s.setCode(err)
 
 		return
 	}
@@ -0,0 +1,290 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	crepository "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// the last used time is not updated on every call to reduce the writes
+const apiKeyLastUsedInterval = 60
+
+// ApiKeyConfig
+type ApiKeyConfig struct {
+	// Expiry is the days during which the api key is valid.
+	Expiry int `json:"expiry"`
+
+	// MaxNum is the max number of api keys of a user.
+	MaxNum int `json:"max_num"`
+}
+
+func (cfg *ApiKeyConfig) SetDefault() {
+	if cfg.Expiry <= 0 {
+		cfg.Expiry = 60
+	}
+
+	if cfg.MaxNum <= 0 {
+		cfg.MaxNum = 10
+	}
+}
+
+type ApiKeyCreateCmd struct {
+	User   types.Account
+	Name   domain.ApiKeyName
+	Scopes []domain.ModelName
+}
+
+type ApiKeyDTO struct {
+	Id         string   `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	Mask       string   `json:"mask"`
+	CreatedAt  string   `json:"created_at"`
+	ExpireAt   string   `json:"expire_at"`
+	LastUsedAt string   `json:"last_used_at"`
+	Expired    bool     `json:"expired"`
+}
+
+// ApiKeyCreatedDTO contains the plain key which can't be got again.
+type ApiKeyCreatedDTO struct {
+	ApiKeyDTO
+
+	Key string `json:"key"`
+}
+
+func toApiKeyDTO(k *domain.ApiKey) ApiKeyDTO {
+	scopes := make([]string, len(k.Scopes))
+	for i := range k.Scopes {
+		scopes[i] = k.Scopes[i].ModelName()
+	}
+
+	dto := ApiKeyDTO{
+		Id:        k.Id,
+		Name:      k.Name.ApiKeyName(),
+		Scopes:    scopes,
+		Mask:      k.Mask,
+		CreatedAt: utils.ToDate(k.CreatedAt),
+		ExpireAt:  utils.ToDate(k.ExpireAt),
+		Expired:   k.IsExpired(),
+	}
+
+	if k.LastUsedAt > 0 {
+		dto.LastUsedAt = utils.ToDate(k.LastUsedAt)
+	}
+
+	return dto
+}
+
+// ApiCaller is the one who calls the api of model. The KeyId is empty if it
+// is authenticated by the token applied for the model.
+type ApiCaller struct {
+	User  types.Account
+	KeyId string
+}
+
+func (c *ApiCaller) limitKey(model domain.ModelName) string {
+	if c.KeyId != "" {
+		return "key:" + c.KeyId + ":" + model.ModelName()
+	}
+
+	return "user:" + c.User.Account() + ":" + model.ModelName()
+}
+
+// ApiKeyService manages the api keys by which the user calls the apis of
+// models, instead of the token applied for each model.
+type ApiKeyService interface {
+	Create(*ApiKeyCreateCmd) (ApiKeyCreatedDTO, string, error)
+	List(types.Account) ([]ApiKeyDTO, error)
+	Rotate(user types.Account, id string) (ApiKeyCreatedDTO, string, error)
+	Revoke(user types.Account, id string) error
+
+	// Authenticate returns the caller if the key can call the api of model.
+	Authenticate(key string, model domain.ModelName) (ApiCaller, string, error)
+}
+
+func NewApiKeyService(
+	cfg *ApiKeyConfig,
+	repo repository.ApiKey,
+	apiService repository.ApiService,
+) ApiKeyService {
+	return apiKeyService{
+		repo:       repo,
+		apiService: apiService,
+		expiry:     int64(cfg.Expiry) * 24 * 3600,
+		maxNum:     cfg.MaxNum,
+	}
+}
+
+type apiKeyService struct {
+	repo       repository.ApiKey
+	apiService repository.ApiService
+	expiry     int64
+	maxNum     int
+}
+
+func (s apiKeyService) Create(cmd *ApiKeyCreateCmd) (dto ApiKeyCreatedDTO, code string, err error) {
+	if len(cmd.Scopes) == 0 {
+		code = ErrorApiKeyNoScope
+		err = errors.New("no scope")
+
+		return
+	}
+
+	// the api of model can be called only after the user applies for it
+	for _, m := range cmd.Scopes {
+		if code, err = s.checkApplied(cmd.User, m); err != nil {
+			return
+		}
+	}
+
+	v, err := s.repo.List(cmd.User)
+	if err != nil {
+		return
+	}
+
+	if len(v) >= s.maxNum {
+		code = ErrorApiKeyExceedMaxNum
+		err = errors.New("too many api keys")
+
+		return
+	}
+
+	k, key, err := domain.NewApiKey(cmd.User, cmd.Name, cmd.Scopes, s.expiry)
+	if err != nil {
+		return
+	}
+
+	if _, err = s.repo.Add(&k); err != nil {
+		return
+	}
+
+	dto = ApiKeyCreatedDTO{
+		ApiKeyDTO: toApiKeyDTO(&k),
+		Key:       key,
+	}
+
+	return
+}
+
+func (s apiKeyService) checkApplied(user types.Account, model domain.ModelName) (string, error) {
+	r, err := s.apiService.GetApiByUserModel(user, model)
+	if err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			return ErrorApiKeyModelNotApplied, fmt.Errorf(
+				"the api of %s is not applied", model.ModelName(),
+			)
+		}
+
+		return "", err
+	}
+
+	if !r.Enabled {
+		return ErrorApiKeyModelNotApplied, fmt.Errorf(
+			"the api of %s is disabled", model.ModelName(),
+		)
+	}
+
+	return "", nil
+}
+
+func (s apiKeyService) List(user types.Account) ([]ApiKeyDTO, error) {
+	v, err := s.repo.List(user)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]ApiKeyDTO, len(v))
+	for i := range v {
+		r[i] = toApiKeyDTO(&v[i])
+	}
+
+	return r, nil
+}
+
+func (s apiKeyService) Rotate(user types.Account, id string) (
+	dto ApiKeyCreatedDTO, code string, err error,
+) {
+	k, err := s.repo.Get(user, id)
+	if err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			code = ErrorApiKeyNotFound
+		}
+
+		return
+	}
+
+	key, err := k.Rotate(s.expiry)
+	if err != nil {
+		return
+	}
+
+	if err = s.repo.UpdateHash(&k); err != nil {
+		return
+	}
+
+	dto = ApiKeyCreatedDTO{
+		ApiKeyDTO: toApiKeyDTO(&k),
+		Key:       key,
+	}
+
+	return
+}
+
+func (s apiKeyService) Revoke(user types.Account, id string) error {
+	return s.repo.Delete(user, id)
+}
+
+func (s apiKeyService) Authenticate(key string, model domain.ModelName) (
+	caller ApiCaller, code string, err error,
+) {
+	k, err := s.repo.GetByHash(domain.HashApiKey(key))
+	if err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			code = ErrorApiKeyInvalid
+			err = errors.New("invalid api key")
+		}
+
+		return
+	}
+
+	if k.IsExpired() {
+		code = ErrorApiKeyExpired
+		err = errors.New("api key expired")
+
+		return
+	}
+
+	if !k.HasScope(model) {
+		code = ErrorApiKeyNoScope
+		err = fmt.Errorf("the api key can't call the api of %s", model.ModelName())
+
+		return
+	}
+
+	// the api may be disabled after the key is created
+	if code, err = s.checkApplied(k.User, model); err != nil {
+		return
+	}
+
+	if now := utils.Now(); now-k.LastUsedAt > apiKeyLastUsedInterval {
+		k.LastUsedAt = now
+
+		if err1 := s.repo.UpdateLastUsed(&k); err1 != nil {
+			logrus.Errorf("update last used time of api key(%s) failed, err:%s", k.Id, err1.Error())
+		}
+	}
+
+	caller = ApiCaller{
+		User:  k.User,
+		KeyId: k.Id,
+	}
+
+	return
+}
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/ratelimiter"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	secondsOfMinute = 60
+	secondsOfDay    = 24 * 3600
+
+	maxApiUsageDays = 90
+)
+
+// ApiLimitConfig
+type ApiLimitConfig struct {
+	// RequestsPerMinute and TokensPerDay are the limits of each api key
+	// and model. The tokens are the characters of prompt and completion.
+	RequestsPerMinute int `json:"requests_per_minute"`
+	TokensPerDay      int `json:"tokens_per_day"`
+
+	// Models overrides the limits of the specified models.
+	Models []ApiModelLimit `json:"models"`
+}
+
+func (cfg *ApiLimitConfig) SetDefault() {
+	if cfg.RequestsPerMinute <= 0 {
+		cfg.RequestsPerMinute = 20
+	}
+
+	if cfg.TokensPerDay <= 0 {
+		cfg.TokensPerDay = 200000
+	}
+
+	for i := range cfg.Models {
+		item := &cfg.Models[i]
+
+		if item.RequestsPerMinute <= 0 {
+			item.RequestsPerMinute = cfg.RequestsPerMinute
+		}
+
+		if item.TokensPerDay <= 0 {
+			item.TokensPerDay = cfg.TokensPerDay
+		}
+	}
+}
+
+func (cfg *ApiLimitConfig) limitOf(model domain.ModelName) (int, int) {
+	for i := range cfg.Models {
+		if item := &cfg.Models[i]; item.Model == model.ModelName() {
+			return item.RequestsPerMinute, item.TokensPerDay
+		}
+	}
+
+	return cfg.RequestsPerMinute, cfg.TokensPerDay
+}
+
+type ApiModelLimit struct {
+	Model             string `json:"model"`
+	RequestsPerMinute int    `json:"requests_per_minute"`
+	TokensPerDay      int    `json:"tokens_per_day"`
+}
+
+type ApiCallCmd struct {
+	ApiCaller
+
+	Model            domain.ModelName
+	PromptTokens     int
+	CompletionTokens int
+}
+
+type ApiUsageDTO struct {
+	Date             string `json:"date"`
+	Model            string `json:"model"`
+	Calls            int    `json:"calls"`
+	PromptTokens     int    `json:"prompt_tokens"`
+	CompletionTokens int    `json:"completion_tokens"`
+}
+
+// ApiUsageService limits the rate of calls of each api key and model, and
+// accounts the usage of the apis of models.
+type ApiUsageService interface {
+	// Allow checks the limits before calling the api of model. It returns
+	// the seconds to wait if the limit is reached.
+	Allow(caller *ApiCaller, model domain.ModelName) (int, string, error)
+
+	// Record accounts the call after it is done.
+	Record(*ApiCallCmd)
+
+	ListUsage(user types.Account, days int) ([]ApiUsageDTO, error)
+}
+
+func NewApiUsageService(
+	cfg *ApiLimitConfig,
+	repo repository.ApiUsage,
+	limiter ratelimiter.RateLimiter,
+) ApiUsageService {
+	return apiUsageService{
+		cfg:     cfg,
+		repo:    repo,
+		limiter: limiter,
+	}
+}
+
+type apiUsageService struct {
+	cfg     *ApiLimitConfig
+	repo    repository.ApiUsage
+	limiter ratelimiter.RateLimiter
+}
+
+func (s apiUsageService) buckets(model domain.ModelName) (requests, tokens ratelimiter.Bucket) {
+	rpm, tpd := s.cfg.limitOf(model)
+
+	requests = ratelimiter.Bucket{
+		Capacity: rpm,
+		Period:   secondsOfMinute,
+	}
+
+	tokens = ratelimiter.Bucket{
+		Capacity: tpd,
+		Period:   secondsOfDay,
+	}
+
+	return
+}
+
+func (s apiUsageService) Allow(caller *ApiCaller, model domain.ModelName) (int, string, error) {
+	requests, tokens := s.buckets(model)
+	key := caller.limitKey(model)
+
+	// the tokens used by the call are unknown until it is done, so only
+	// check that the tokens are not used up.
+	wait, err := s.limiter.Take(key+":tokens", &tokens, 0, false)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if wait > 0 {
+		return wait, ErrorApiExceedTokenQuota, errors.New("the tokens of today are used up")
+	}
+
+	wait, err = s.limiter.Take(key+":requests", &requests, 1, false)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if wait > 0 {
+		return wait, ErrorApiRateLimited, errors.New("too many requests")
+	}
+
+	return 0, "", nil
+}
+
+func (s apiUsageService) Record(cmd *ApiCallCmd) {
+	call := domain.ApiCall{
+		User:             cmd.User,
+		Model:            cmd.Model,
+		PromptTokens:     cmd.PromptTokens,
+		CompletionTokens: cmd.CompletionTokens,
+	}
+
+	_, tokens := s.buckets(cmd.Model)
+
+	if _, err := s.limiter.Take(cmd.limitKey(cmd.Model)+":tokens", &tokens, call.Tokens(), true); err != nil {
+		logrus.Errorf("take tokens of %s failed, err:%s", cmd.User.Account(), err.Error())
+	}
+
+	if err := s.repo.Add(&call, utils.Date()); err != nil {
+		logrus.Errorf("record api usage of %s failed, err:%s", cmd.User.Account(), err.Error())
+	}
+}
+
+func (s apiUsageService) ListUsage(user types.Account, days int) ([]ApiUsageDTO, error) {
+	if days <= 0 || days > maxApiUsageDays {
+		days = maxApiUsageDays
+	}
+
+	since := utils.ToDate(utils.Now() - int64(days-1)*secondsOfDay)
+
+	v, err := s.repo.List(user, since)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]ApiUsageDTO, len(v))
+	for i := range v {
+		item := &v[i]
+
+		r[i] = ApiUsageDTO{
+			Date:             item.Date,
+			Model:            item.Model.ModelName(),
+			Calls:            item.Calls,
+			PromptTokens:     item.PromptTokens,
+			CompletionTokens: item.CompletionTokens,
+		}
+	}
+
+	return r, nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/opensourceways/xihe-server/bigmodel/domain/bigmodel"
 	"github.com/opensourceways/xihe-server/bigmodel/domain/message"
 	types "github.com/opensourceways/xihe-server/domain"
+	moderationapp "github.com/opensourceways/xihe-server/moderation/app"
 	"github.com/sirupsen/logrus"
 )
 
@@ -18,16 +19,19 @@ type AsyncBigModelService interface {
 func NewAsyncBigModelService(
 	fm bigmodel.BigModel,
 	sender message.MessageProducer,
+	moderation moderationapp.ModerationService,
 ) AsyncBigModelService {
 	return &asyncBigModelService{
-		fm:     fm,
-		sender: sender,
+		fm:         fm,
+		sender:     sender,
+		moderation: moderation,
 	}
 }
 
 type asyncBigModelService struct {
-	fm     bigmodel.BigModel
-	sender message.MessageProducer
+	fm         bigmodel.BigModel
+	sender     message.MessageProducer
+	moderation moderationapp.ModerationService
 }
 
 func (s *asyncBigModelService) WuKong(tid uint64, user types.Account, cmd *WuKongCmd) (err error) {
@@ -47,7 +51,7 @@ func (s *asyncBigModelService) WuKong(tid uint64, user types.Account, cmd *WuKon
 		return
 	}
 
-	links, err := s.fm.GenPicturesByWuKong(user, &cmd.WuKongPictureMeta, cmd.EsType)
+	links, err := genWuKongPictures(s.fm, s.moderation, user, &cmd.WuKongPictureMeta, cmd.EsType)
 	if err != nil {
 		if !bigmodel.IsErrorSensitiveInfo(err) {
 			err = errors.New("internal error")
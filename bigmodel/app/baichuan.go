@@ -2,9 +2,41 @@ package app
 
 import (
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	moderationdomain "github.com/opensourceways/xihe-server/moderation/domain"
 )
 
+// BaiChuan moderates the prompt and the reply of baichuan.
 func (s bigModelService) BaiChuan(cmd *BaiChuanCmd) (code string, dto BaiChuanDTO, err error) {
+	text, err := moderateText(
+		s.moderation, moderationdomain.SurfaceChatPrompt, cmd.User, cmd.Text.BaiChuanText(),
+	)
+	if err != nil {
+		code = s.setCode(err)
+
+		return
+	}
+
+	c := *cmd
+	if c.Text, err = domain.NewBaiChuanText(text); err != nil {
+		code = ErrorBigModelInvalidText
+
+		return
+	}
+
+	if code, dto, err = s.baiChuan(&c); err != nil {
+		return
+	}
+
+	if dto.Text, err = moderateText(
+		s.moderation, moderationdomain.SurfaceChatOutput, cmd.User, dto.Text,
+	); err != nil {
+		code = s.setCode(err)
+	}
+
+	return
+}
+
+func (s bigModelService) baiChuan(cmd *BaiChuanCmd) (code string, dto BaiChuanDTO, err error) {
 	_ = s.sender.SendBigModelStarted(&domain.BigModelStartedEvent{
 		Account:      cmd.User,
 		BigModelType: domain.BigmodelBaiChuan,
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"io"
 	"net/url"
-	"sort"
 	"strings"
 	"time"
 
@@ -19,6 +18,8 @@ import (
 	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
 	types "github.com/opensourceways/xihe-server/domain"
 	crepository "github.com/opensourceways/xihe-server/domain/repository"
+	moderationapp "github.com/opensourceways/xihe-server/moderation/app"
+	moderationdomain "github.com/opensourceways/xihe-server/moderation/domain"
 	userapp "github.com/opensourceways/xihe-server/user/app"
 	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
@@ -45,6 +46,10 @@ type BigModelService interface {
 	CancelPublic(types.Account, string) error
 	GetPublicsGlobal(cmd *WuKongListPublicGlobalCmd) (WuKongPublicGlobalDTO, error)
 	ListPublics(types.Account) ([]WuKongPublicDTO, error)
+	ListSimilarPublics(*WuKongListSimilarCmd) ([]WuKongPublicDTO, string, error)
+	ListPublicsByIndexes(types.Account, []domain.WuKongPictureIndex) ([]WuKongPublicDTO, error)
+	UpdatePublicTags(*WuKongUpdateTagsCmd) (string, error)
+	SyncWuKongGallery() (int, error)
 	CancelLike(types.Account, string) error
 	ListLikes(types.Account) ([]WuKongLikeDTO, error)
 	DiggPicture(*WuKongAddDiggCmd) (int, error)
@@ -79,6 +84,9 @@ type BigModelService interface {
 
 	// skywork 13b
 	SkyWork(*SkyWorkCmd) (string, error)
+
+	// chat completion
+	ChatCompletion(*ChatCompletionCmd) (string, error)
 }
 
 func NewBigModelService(
@@ -87,11 +95,13 @@ func NewBigModelService(
 	luojia repository.LuoJia,
 	wukong repository.WuKong,
 	wukongPicture repository.WuKongPicture,
+	gallery repository.WuKongGallery,
 	asynccli async.AsyncTask,
 	sender message.MessageProducer,
 	apiService repository.ApiService,
 	apiInfo repository.ApiInfo,
 	userService userapp.RegService,
+	moderation moderationapp.ModerationService,
 ) BigModelService {
 	return bigModelService{
 		fm:              fm,
@@ -100,12 +110,14 @@ func NewBigModelService(
 		luojia:          luojia,
 		wukong:          wukong,
 		wukongPicture:   wukongPicture,
+		gallery:         gallery,
 		asynccli:        asynccli,
 		wukongSampleId:  fm.GetWuKongSampleId(),
 		bigmodelService: service.NewBigModelService(fm, wukongPicture),
 		apiService:      apiService,
 		apiInfo:         apiInfo,
 		userService:     userService,
+		moderation:      moderation,
 	}
 }
 
@@ -117,10 +129,12 @@ type bigModelService struct {
 	luojia        repository.LuoJia
 	wukong        repository.WuKong
 	wukongPicture repository.WuKongPicture
+	gallery       repository.WuKongGallery
 	asynccli      async.AsyncTask
 	apiService    repository.ApiService
 	apiInfo       repository.ApiInfo
 	userService   userapp.RegService
+	moderation    moderationapp.ModerationService
 
 	bigmodelService service.BigModelService
 
@@ -156,7 +170,9 @@ func (s bigModelService) WuKong(
 		BigModelType: domain.BigmodelWuKong,
 	})
 
-	links, err = s.fm.GenPicturesByWuKong(user, &cmd.WuKongPictureMeta, cmd.EsType)
+	links, err = genWuKongPictures(
+		s.fm, s.moderation, user, &cmd.WuKongPictureMeta, cmd.EsType,
+	)
 	if err != nil {
 		code = s.setCode(err)
 	}
@@ -172,7 +188,9 @@ func (s bigModelService) WuKongHF(cmd *WuKongHFCmd) (
 		BigModelType: domain.BigmodelWuKongHF,
 	})
 
-	links, err = s.fm.GenPicturesByWuKong(cmd.User, &cmd.WuKongPictureMeta, string(domain.BigmodelWuKongHF))
+	links, err = genWuKongPictures(
+		s.fm, s.moderation, cmd.User, &cmd.WuKongPictureMeta, string(domain.BigmodelWuKongHF),
+	)
 	if err != nil {
 		code = s.setCode(err)
 	}
@@ -188,7 +206,9 @@ func (s bigModelService) WukongApi(
 		BigModelType: domain.BigmodelWuKong,
 	})
 
-	links, err = s.fm.GenPicturesByWuKong(user, &cmd.WuKongPictureMeta, string(domain.BigmodelWuKongUser))
+	links, err = genWuKongPictures(
+		s.fm, s.moderation, user, &cmd.WuKongPictureMeta, string(domain.BigmodelWuKongUser),
+	)
 	if err != nil {
 		code = s.setCode(err)
 	}
@@ -201,8 +221,10 @@ func (s bigModelService) WukongApi(
 
 func (s bigModelService) WuKongInferenceAsync(user types.Account, cmd *WuKongCmd) (code string, err error) {
 	// content audit
-	if err = s.fm.CheckText(cmd.Desc.WuKongPictureDesc()); err != nil {
-		code = ErrorBigModelSensitiveInfo
+	if _, err = moderateText(
+		s.moderation, moderationdomain.SurfaceWuKongPrompt, user, cmd.Desc.WuKongPictureDesc(),
+	); err != nil {
+		code = s.setCode(err)
 
 		return
 	}
@@ -496,7 +518,11 @@ func (s bigModelService) AddPublicFromTempPicture(cmd *WuKongAddPublicFromTempCm
 		CreatedAt:         utils.Date(),
 		WuKongPictureMeta: meta,
 	}
-	pid, err = s.wukongPicture.SavePublic(p, version)
+	if pid, err = s.wukongPicture.SavePublic(p, version); err != nil {
+		return
+	}
+
+	s.saveToGallery(p)
 
 	// sender
 	_ = s.sender.SendWuKongPicturePublicized(&domain.WuKongPicturePublicizedEvent{
@@ -560,6 +586,8 @@ func (s bigModelService) AddPublicFromLikePicture(cmd *WuKongAddPublicFromLikeCm
 		return
 	}
 
+	s.saveToGallery(ps)
+
 	// sender
 	_ = s.sender.SendWuKongPicturePublicized(&domain.WuKongPicturePublicizedEvent{
 		Account: cmd.User,
@@ -578,86 +606,15 @@ func (s bigModelService) CancelPublic(user types.Account, pid string) (err error
 		return
 	}
 
-	if err = s.fm.DeleteWuKongPicture(v.OBSPath.OBSPath()); err != nil {
-		return
-	}
-
-	return
-}
+	s.removeFromGallery(&v)
 
-func (s bigModelService) GetPublicsGlobal(cmd *WuKongListPublicGlobalCmd) (r WuKongPublicGlobalDTO, err error) {
-	var v []domain.WuKongPicture
-	if cmd.Level != nil && cmd.Level.IsOfficial() {
-		v, err = s.wukongPicture.GetOfficialPublicsGlobal()
-	} else {
-		v, err = s.wukongPicture.GetPublicsGlobal()
-	}
-	if err != nil {
+	if err = s.fm.DeleteWuKongPicture(v.OBSPath.OBSPath()); err != nil {
 		return
 	}
-	s.sortWuKongPicture(v, cmd.Level)
-
-	var b, e int
-	if b = cmd.CountPerPage * (cmd.PageNum - 1); b >= len(v) {
-		return r, nil
-	}
-	if e = b + cmd.CountPerPage; e > len(v) {
-		e = len(v)
-	}
-
-	d := make([]WuKongPublicDTO, len(v[b:e]))
-	for i := range v[b:e] {
-		item := &v[b:e][i]
-		link := s.fm.GenWuKongLinkFromOBSPath(item.OBSPath.OBSPath())
-		avatarId, _ := s.user.GetUserAvatarId(item.Owner)
-
-		var (
-			a      string
-			isDigg bool
-			isLike bool
-			likeID string
-		)
-
-		if cmd.User != nil {
-			isLike, likeID, _ = s.bigmodelService.IsLike(item, cmd.User)
-			isDigg = s.bigmodelService.IsDigg(cmd.User, item.Diggs)
-		} else {
-			isDigg = false
-		}
-
-		if avatarId != nil {
-			a = avatarId.AvatarId()
-		} else {
-			a = ""
-		}
-
-		d[i].toWuKongPublicDTO(item, a, isLike, likeID, isDigg, link)
-	}
-
-	r = WuKongPublicGlobalDTO{
-		Total:    len(v),
-		Pictures: d,
-	}
 
 	return
 }
 
-func (s bigModelService) sortWuKongPicture(pic []domain.WuKongPicture, picLevel domain.WuKongPictureLevel) {
-	if picLevel == nil {
-		return
-	}
-	if picLevel.IsHot() {
-		sort.Slice(pic, func(i, j int) bool {
-			if pic[i].DiggCount == pic[j].DiggCount {
-				ti, _ := utils.ToUnixTime(pic[i].CreatedAt)
-				tj, _ := utils.ToUnixTime(pic[j].CreatedAt)
-				return ti.After(tj)
-			}
-			return pic[i].DiggCount > pic[j].DiggCount
-		})
-	}
-}
-
 func (s bigModelService) ListPublics(user types.Account) (
 	r []WuKongPublicDTO, err error,
 ) {
@@ -705,6 +662,8 @@ func (s bigModelService) DiggPicture(cmd *WuKongAddDiggCmd) (count int, err erro
 		return
 	}
 
+	s.saveToGallery(&p)
+
 	count = p.DiggCount
 	return
 }
@@ -741,6 +700,8 @@ func (s bigModelService) CancelDiggPicture(cmd *WuKongCancelDiggCmd) (count int,
 		return
 	}
 
+	s.saveToGallery(&p)
+
 	count = p.DiggCount
 	return
 }
@@ -0,0 +1,206 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+	moderationdomain "github.com/opensourceways/xihe-server/moderation/domain"
+)
+
+// ChatCompletion calls the chat model of cmd.Model with the conversation.
+// The reply is streamed through cmd.CH which ends with "done" and is closed
+// after it, so the caller can drain the rest of reply by ranging over it.
+//
+// Both the question and the reply are moderated. The reply is stopped with
+// "done" once the moderation blocks it.
+func (s bigModelService) ChatCompletion(cmd *ChatCompletionCmd) (string, error) {
+	return s.moderateChat(cmd.User, cmd.Text, cmd.CH, func(prompt string, ch chan string) (string, error) {
+		c := *cmd
+		c.Text = prompt
+		c.CH = ch
+
+		return s.chatCompletion(&c)
+	})
+}
+
+// moderateReply ends out with "done" once the reply is done or blocked,
+// without waiting for ch to be closed.
+func (s bigModelService) moderateReply(user types.Account, ch <-chan string, out chan<- string) {
+	// the model will be blocked if the rest of reply is not consumed
+	defer func() {
+		for range ch {
+		}
+	}()
+
+	defer close(out)
+
+	m := s.moderation.NewStream(moderationdomain.SurfaceChatOutput, user.Account())
+
+	send := func(text string, stop bool) bool {
+		if text != "" {
+			out <- text
+		}
+
+		if stop {
+			out <- "done"
+		}
+
+		return stop
+	}
+
+	for msg := range ch {
+		if msg == "done" {
+			break
+		}
+
+		if send(m.Feed(msg)) {
+			return
+		}
+	}
+
+	if !send(m.Flush()) {
+		out <- "done"
+	}
+}
+
+func (s bigModelService) chatCompletion(cmd *ChatCompletionCmd) (code string, err error) {
+	switch cmd.Model.ModelName() {
+	case domain.ModelNameGLM2.ModelName():
+		return s.chatWithGLM2(cmd)
+
+	case domain.ModelNameLLAMA2.ModelName():
+		return s.chatWithLLAMA2(cmd)
+
+	case domain.ModelNameSkyWork.ModelName():
+		return s.chatWithSkyWork(cmd)
+
+	case domain.ModelNameIFlytekSpark.ModelName():
+		return s.chatWithIFlytekSpark(cmd)
+
+	case domain.ModelNameBaiChuan.ModelName():
+		return s.chatWithBaiChuan(cmd)
+	}
+
+	return "", fmt.Errorf("unsupported model: %s", cmd.Model.ModelName())
+}
+
+func (s bigModelService) chatWithGLM2(cmd *ChatCompletionCmd) (string, error) {
+	c := GLM2Cmd{
+		CH:      cmd.CH,
+		User:    cmd.User,
+		History: cmd.History,
+	}
+
+	c.SetDefault()
+	c.Sampling = cmd.sampling()
+	cmd.overrideSampling(&c.Temperature, &c.TopP)
+
+	var err error
+	if c.Text, err = domain.NewGLM2Text(cmd.Text); err != nil {
+		return ErrorBigModelInvalidText, err
+	}
+
+	return s.glm2(&c)
+}
+
+func (s bigModelService) chatWithLLAMA2(cmd *ChatCompletionCmd) (string, error) {
+	c := LLAMA2Cmd{
+		CH:      cmd.CH,
+		User:    cmd.User,
+		History: cmd.History,
+	}
+
+	c.SetDefault()
+	c.Sampling = cmd.sampling()
+	cmd.overrideSampling(&c.Temperature, &c.TopP)
+
+	var err error
+	if c.Text, err = domain.NewLLAMA2Text(cmd.Text); err != nil {
+		return ErrorBigModelInvalidText, err
+	}
+
+	return s.llama2(&c)
+}
+
+func (s bigModelService) chatWithSkyWork(cmd *ChatCompletionCmd) (string, error) {
+	c := SkyWorkCmd{
+		CH:      cmd.CH,
+		User:    cmd.User,
+		History: cmd.History,
+	}
+
+	c.SetDefault()
+	c.Sampling = cmd.sampling()
+	cmd.overrideSampling(&c.Temperature, &c.TopP)
+
+	var err error
+	if c.Text, err = domain.NewSkyWorkText(cmd.Text); err != nil {
+		return ErrorBigModelInvalidText, err
+	}
+
+	return s.skyWork(&c)
+}
+
+func (s bigModelService) chatWithIFlytekSpark(cmd *ChatCompletionCmd) (string, error) {
+	c := IFlytekSparkCmd{
+		CH:      cmd.CH,
+		User:    cmd.User,
+		History: cmd.History,
+	}
+
+	c.SetDefault()
+	c.Sampling = cmd.sampling()
+
+	// iflytekspark doesn't support top_p
+	cmd.overrideSampling(&c.Temperature, nil)
+
+	var err error
+	if c.Text, err = domain.NewIFlytekSparkText(cmd.Text); err != nil {
+		return ErrorBigModelInvalidText, err
+	}
+
+	return s.iflytekSpark(&c)
+}
+
+// chatWithBaiChuan sends the whole reply through the channel at once, since
+// baichuan is not a streaming model. It doesn't support the history either,
+// so only the last question is asked.
+func (s bigModelService) chatWithBaiChuan(cmd *ChatCompletionCmd) (string, error) {
+	c := BaiChuanCmd{
+		User: cmd.User,
+	}
+
+	c.SetDefault()
+	c.Sampling = cmd.sampling()
+	cmd.overrideSampling(&c.Temperature, &c.TopP)
+
+	var err error
+	if c.Text, err = domain.NewBaiChuanText(cmd.Text); err != nil {
+		return ErrorBigModelInvalidText, err
+	}
+
+	code, dto, err := s.baiChuan(&c)
+	if err != nil {
+		return code, err
+	}
+
+	go func() {
+		defer close(cmd.CH)
+
+		cmd.CH <- dto.Text
+		cmd.CH <- "done"
+	}()
+
+	return "", nil
+}
+
+func (cmd *ChatCompletionCmd) overrideSampling(t *domain.Temperature, p *domain.TopP) {
+	if cmd.Temperature != nil {
+		*t = cmd.Temperature
+	}
+
+	if cmd.TopP != nil && p != nil {
+		*p = cmd.TopP
+	}
+}
@@ -0,0 +1,421 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	crepository "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	ConversationExportJSON     = "json"
+	ConversationExportMarkdown = "markdown"
+)
+
+// ConversationConfig
+type ConversationConfig struct {
+	// MaxNum is the max number of conversations of a user.
+	MaxNum int `json:"max_num"`
+
+	// MaxMessages is the max number of messages of a conversation.
+	MaxMessages int `json:"max_messages"`
+
+	// MaxRounds is the max number of the recent rounds which are sent to
+	// the model as the history.
+	MaxRounds int `json:"max_rounds"`
+
+	// Retention is the days after which the inactive conversation is deleted.
+	Retention int `json:"retention"`
+}
+
+func (cfg *ConversationConfig) SetDefault() {
+	if cfg.MaxNum <= 0 {
+		cfg.MaxNum = 100
+	}
+
+	if cfg.MaxMessages <= 0 {
+		cfg.MaxMessages = 200
+	}
+
+	if cfg.MaxRounds <= 0 {
+		cfg.MaxRounds = 10
+	}
+
+	if cfg.Retention <= 0 {
+		cfg.Retention = 180
+	}
+}
+
+type ConversationCreateCmd struct {
+	User  types.Account
+	Title domain.ConversationTitle
+	Model domain.ModelName
+
+	Temperature domain.Temperature
+	TopP        domain.TopP
+}
+
+type ConversationChatCmd struct {
+	CH       chan string
+	User     types.Account
+	Id       string
+	Question string
+}
+
+type ConversationDTO struct {
+	Id          string   `json:"id"`
+	Title       string   `json:"title"`
+	Model       string   `json:"model"`
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"top_p,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type ConversationMessageDTO struct {
+	Role      string `json:"role"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ConversationDetailDTO struct {
+	ConversationDTO
+
+	Messages []ConversationMessageDTO `json:"messages"`
+}
+
+type ConversationExportDTO struct {
+	FileName    string
+	ContentType string
+	Content     []byte
+}
+
+func toConversationDTO(c *domain.Conversation) ConversationDTO {
+	dto := ConversationDTO{
+		Id:    c.Id,
+		Model: c.Model.ModelName(),
+	}
+
+	if c.HasTitle() {
+		dto.Title = c.Title.ConversationTitle()
+	}
+
+	if c.Temperature != nil {
+		v := c.Temperature.Temperature()
+		dto.Temperature = &v
+	}
+
+	if c.TopP != nil {
+		v := c.TopP.TopP()
+		dto.TopP = &v
+	}
+
+	_, dto.CreatedAt = utils.DateAndTime(c.CreatedAt)
+	_, dto.UpdatedAt = utils.DateAndTime(c.UpdatedAt)
+
+	return dto
+}
+
+func toConversationDetailDTO(c *domain.Conversation) ConversationDetailDTO {
+	dto := ConversationDetailDTO{
+		ConversationDTO: toConversationDTO(c),
+		Messages:        make([]ConversationMessageDTO, len(c.Messages)),
+	}
+
+	for i := range c.Messages {
+		item := &c.Messages[i]
+		v := &dto.Messages[i]
+
+		v.Role = item.Role
+		v.Content = item.Content
+		_, v.CreatedAt = utils.DateAndTime(item.CreatedAt)
+	}
+
+	return dto
+}
+
+// ConversationService keeps the conversations of user with the chat models,
+// so that the user can continue them later.
+type ConversationService interface {
+	Create(*ConversationCreateCmd) (ConversationDTO, string, error)
+	List(types.Account) ([]ConversationDTO, error)
+	Get(user types.Account, id string) (ConversationDetailDTO, string, error)
+	Rename(user types.Account, id string, title domain.ConversationTitle) (string, error)
+	Delete(user types.Account, id string) error
+	Export(user types.Account, id, format string) (ConversationExportDTO, string, error)
+
+	// Chat streams the reply through cmd.CH in the same way as ChatCompletion,
+	// and appends the question and reply to the conversation when it is done.
+	Chat(*ConversationChatCmd) (string, error)
+
+	// Clean deletes the conversations which are inactive for the retention.
+	Clean()
+}
+
+func NewConversationService(
+	cfg *ConversationConfig,
+	repo repository.Conversation,
+	bm BigModelService,
+) ConversationService {
+	return conversationService{
+		cfg:  cfg,
+		repo: repo,
+		bm:   bm,
+	}
+}
+
+type conversationService struct {
+	cfg  *ConversationConfig
+	repo repository.Conversation
+	bm   BigModelService
+}
+
+func (s conversationService) Create(cmd *ConversationCreateCmd) (
+	dto ConversationDTO, code string, err error,
+) {
+	n, err := s.repo.Count(cmd.User)
+	if err != nil {
+		return
+	}
+
+	if n >= s.cfg.MaxNum {
+		code = ErrorConversationExceedMaxNum
+		err = errors.New("too many conversations")
+
+		return
+	}
+
+	c := domain.NewConversation(cmd.User, cmd.Title, cmd.Model)
+	c.Temperature = cmd.Temperature
+	c.TopP = cmd.TopP
+
+	if c.Id, err = s.repo.Add(&c); err != nil {
+		return
+	}
+
+	dto = toConversationDTO(&c)
+
+	return
+}
+
+func (s conversationService) List(user types.Account) ([]ConversationDTO, error) {
+	v, err := s.repo.List(user)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]ConversationDTO, len(v))
+	for i := range v {
+		r[i] = toConversationDTO(&v[i])
+	}
+
+	return r, nil
+}
+
+func (s conversationService) get(user types.Account, id string) (
+	c domain.Conversation, code string, err error,
+) {
+	if c, err = s.repo.Get(user, id); err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			code = ErrorConversationNotFound
+		}
+	}
+
+	return
+}
+
+func (s conversationService) Get(user types.Account, id string) (
+	dto ConversationDetailDTO, code string, err error,
+) {
+	c, code, err := s.get(user, id)
+	if err == nil {
+		dto = toConversationDetailDTO(&c)
+	}
+
+	return
+}
+
+func (s conversationService) Rename(
+	user types.Account, id string, title domain.ConversationTitle,
+) (string, error) {
+	c := domain.Conversation{
+		Id:    id,
+		Owner: user,
+		Title: title,
+	}
+
+	if err := s.repo.UpdateTitle(&c); err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			return ErrorConversationNotFound, err
+		}
+
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (s conversationService) Delete(user types.Account, id string) error {
+	return s.repo.Delete(user, id)
+}
+
+func (s conversationService) Export(user types.Account, id, format string) (
+	dto ConversationExportDTO, code string, err error,
+) {
+	c, code, err := s.get(user, id)
+	if err != nil {
+		return
+	}
+
+	name := c.Id
+	if c.HasTitle() {
+		name = c.Title.ConversationTitle()
+	}
+
+	switch format {
+	case ConversationExportJSON:
+		dto.FileName = name + ".json"
+		dto.ContentType = "application/json"
+		dto.Content, err = json.MarshalIndent(toConversationDetailDTO(&c), "", "  ")
+
+	case ConversationExportMarkdown:
+		dto.FileName = name + ".md"
+		dto.ContentType = "text/markdown; charset=utf-8"
+		dto.Content = []byte(toConversationMarkdown(&c))
+
+	default:
+		code = ErrorConversationInvalidFormat
+		err = fmt.Errorf("unsupported format: %s", format)
+	}
+
+	return
+}
+
+func toConversationMarkdown(c *domain.Conversation) string {
+	dto := toConversationDetailDTO(c)
+
+	b := strings.Builder{}
+
+	title := dto.Title
+	if title == "" {
+		title = dto.Id
+	}
+
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "- model: %s\n", dto.Model)
+	fmt.Fprintf(&b, "- created at: %s\n\n", dto.CreatedAt)
+
+	for i := range dto.Messages {
+		item := &dto.Messages[i]
+
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", item.Role, item.Content)
+	}
+
+	return b.String()
+}
+
+func (s conversationService) Chat(cmd *ConversationChatCmd) (code string, err error) {
+	c, code, err := s.get(cmd.User, cmd.Id)
+	if err != nil {
+		return
+	}
+
+	if len(c.Messages)+2 > s.cfg.MaxMessages {
+		code = ErrorConversationExceedMaxMessages
+		err = errors.New("too many messages, please start a new conversation")
+
+		return
+	}
+
+	cc, err := domain.NewChatConversation(c.ChatMessages(cmd.Question, s.cfg.MaxRounds))
+	if err != nil {
+		code = ErrorBigModelInvalidText
+
+		return
+	}
+
+	// the reply is relayed to cmd.CH, so that it can be saved when it is done
+	ch := make(chan string, cap(cmd.CH))
+
+	code, err = s.bm.ChatCompletion(&ChatCompletionCmd{
+		CH:               ch,
+		User:             cmd.User,
+		Model:            c.Model,
+		ChatConversation: cc,
+		Temperature:      c.Temperature,
+		TopP:             c.TopP,
+	})
+	if err != nil {
+		return
+	}
+
+	go s.relay(&c, cmd, ch)
+
+	return
+}
+
+// relay ends cmd.CH once the reply is done, without waiting for ch to be
+// closed, and then saves the reply.
+func (s conversationService) relay(c *domain.Conversation, cmd *ConversationChatCmd, ch chan string) {
+	b := strings.Builder{}
+
+	for msg := range ch {
+		if msg == "done" {
+			break
+		}
+
+		b.WriteString(msg)
+
+		cmd.CH <- msg
+	}
+
+	cmd.CH <- "done"
+	close(cmd.CH)
+
+	// the model will be blocked if the rest of reply is not consumed
+	defer func() {
+		for range ch {
+		}
+	}()
+
+	if b.Len() == 0 {
+		return
+	}
+
+	c.UpdatedAt = utils.Now()
+
+	if err := s.repo.AddMessages(c, domain.NewRound(cmd.Question, b.String())); err != nil {
+		logrus.Errorf("save messages of conversation(%s) failed, err:%s", c.Id, err.Error())
+	}
+
+	if !c.GenTitle(cmd.Question) {
+		return
+	}
+
+	if err := s.repo.UpdateTitle(c); err != nil {
+		logrus.Errorf("update title of conversation(%s) failed, err:%s", c.Id, err.Error())
+	}
+}
+
+func (s conversationService) Clean() {
+	since := utils.Now() - int64(s.cfg.Retention)*secondsOfDay
+
+	n, err := s.repo.DeleteInactive(since)
+	if err != nil {
+		logrus.Errorf("delete inactive conversations failed, err:%s", err.Error())
+
+		return
+	}
+
+	if n > 0 {
+		logrus.Infof("delete %d inactive conversations", n)
+	}
+}
@@ -82,6 +82,12 @@ type WuKongPictureListOption struct {
 type WuKongListPublicGlobalCmd struct {
 	User  types.Account
 	Level domain.WuKongPictureLevel
+
+	// Keyword, Style and Tag are optional to search the pictures.
+	Keyword string
+	Style   string
+	Tag     domain.WuKongPictureTag
+
 	WuKongPictureListOption
 }
 
@@ -181,6 +187,18 @@ type WuKongAddPublicFromLikeCmd struct {
 
 type WuKongCancelDiggCmd WuKongAddDiggCmd
 
+type WuKongListSimilarCmd struct {
+	User types.Account
+	domain.WuKongPictureIndex
+	Num int
+}
+
+type WuKongUpdateTagsCmd struct {
+	User types.Account
+	Id   string
+	Tags []domain.WuKongPictureTag
+}
+
 type WuKongPictureBaseDTO struct {
 	Id        string `json:"id"`
 	Owner     string `json:"owner"` // owner of picture
@@ -198,11 +216,12 @@ type WuKongLikeDTO struct { // like
 }
 
 type WuKongPublicDTO struct { // public
-	Avatar    string `json:"avatar"`
-	IsLike    bool   `json:"is_like"`
-	LikeID    string `json:"like_id"`
-	IsDigg    bool   `json:"is_digg"`
-	DiggCount int    `json:"digg_count"`
+	Avatar    string   `json:"avatar"`
+	IsLike    bool     `json:"is_like"`
+	LikeID    string   `json:"like_id"`
+	IsDigg    bool     `json:"is_digg"`
+	DiggCount int      `json:"digg_count"`
+	Tags      []string `json:"tags"`
 
 	WuKongPictureBaseDTO
 }
@@ -217,6 +236,7 @@ func (dto *WuKongPublicDTO) toWuKongPublicDTO(
 		LikeID:    likeId,
 		IsDigg:    isDigg,
 		DiggCount: p.DiggCount,
+		Tags:      make([]string, len(p.Tags)),
 
 		WuKongPictureBaseDTO: WuKongPictureBaseDTO{
 			Id:        p.Id,
@@ -227,6 +247,10 @@ func (dto *WuKongPublicDTO) toWuKongPublicDTO(
 			CreatedAt: p.CreatedAt,
 		},
 	}
+
+	for i := range p.Tags {
+		dto.Tags[i] = p.Tags[i].WuKongPictureTag()
+	}
 }
 
 type WuKongIsLikeDTO struct {
@@ -427,6 +451,7 @@ type SkyWorkDTO struct {
 type IFlytekSparkCmd struct {
 	CH                chan string
 	User              types.Account
+	History           []domain.History
 	Sampling          bool
 	Text              domain.IFlytekSparkText
 	TopK              domain.TopK
@@ -444,3 +469,20 @@ type IFlytekSparkDTO struct {
 	Reply        string `json:"reply"`
 	StreamStatus string `json:"stream_status"`
 }
+
+// chat completion
+type ChatCompletionCmd struct {
+	CH    chan string
+	User  types.Account
+	Model domain.ModelName
+	domain.ChatConversation
+
+	// Temperature and TopP are optional, the default ones of model
+	// will be used if both of them are not set.
+	Temperature domain.Temperature
+	TopP        domain.TopP
+}
+
+func (cmd *ChatCompletionCmd) sampling() bool {
+	return cmd.Temperature != nil || cmd.TopP != nil
+}
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/bigmodel/domain/bigmodel"
+)
+
+type EndpointAddCmd struct {
+	Pool string
+
+	bigmodel.EndpointOption
+}
+
+type EndpointDrainCmd struct {
+	Pool  string
+	URL   string
+	Drain bool
+}
+
+type EndpointPoolDTO struct {
+	Name      string        `json:"name"`
+	Endpoints []EndpointDTO `json:"endpoints"`
+}
+
+type EndpointDTO struct {
+	URL       string `json:"url"`
+	State     string `json:"state"`
+	Weight    int    `json:"weight"`
+	Capacity  int    `json:"capacity"`
+	InFlight  int    `json:"in_flight"`
+	Requests  int64  `json:"requests"`
+	Failures  int64  `json:"failures"`
+	Latency   int64  `json:"latency"`
+	LastError string `json:"last_error"`
+	ProbedAt  int64  `json:"probed_at"`
+}
+
+func toEndpointDTO(e *bigmodel.EndpointStat) EndpointDTO {
+	return EndpointDTO{
+		URL:       e.URL,
+		State:     e.State,
+		Weight:    e.Weight,
+		Capacity:  e.Capacity,
+		InFlight:  e.InFlight,
+		Requests:  e.Requests,
+		Failures:  e.Failures,
+		Latency:   e.Latency,
+		LastError: e.LastError,
+		ProbedAt:  e.ProbedAt,
+	}
+}
+
+// EndpointService manages the endpoints of models without restart.
+type EndpointService interface {
+	List() []EndpointPoolDTO
+	Add(*EndpointAddCmd) (string, error)
+	Drain(*EndpointDrainCmd) (string, error)
+	Remove(pool, url string) (string, error)
+}
+
+func NewEndpointService(r bigmodel.EndpointRegistry) EndpointService {
+	return endpointService{r}
+}
+
+type endpointService struct {
+	r bigmodel.EndpointRegistry
+}
+
+func (s endpointService) List() []EndpointPoolDTO {
+	v := s.r.ListEndpoints()
+
+	r := make([]EndpointPoolDTO, len(v))
+	for i := range v {
+		item := &v[i]
+
+		es := make([]EndpointDTO, len(item.Endpoints))
+		for j := range item.Endpoints {
+			es[j] = toEndpointDTO(&item.Endpoints[j])
+		}
+
+		r[i] = EndpointPoolDTO{
+			Name:      item.Name,
+			Endpoints: es,
+		}
+	}
+
+	return r
+}
+
+func (s endpointService) Add(cmd *EndpointAddCmd) (string, error) {
+	return s.toCode(s.r.AddEndpoint(cmd.Pool, &cmd.EndpointOption))
+}
+
+func (s endpointService) Drain(cmd *EndpointDrainCmd) (string, error) {
+	return s.toCode(s.r.DrainEndpoint(cmd.Pool, cmd.URL, cmd.Drain))
+}
+
+func (s endpointService) Remove(pool, url string) (string, error) {
+	return s.toCode(s.r.RemoveEndpoint(pool, url))
+}
+
+func (s endpointService) toCode(err error) (string, error) {
+	switch {
+	case err == nil:
+		return "", nil
+
+	case bigmodel.IsErrorEndpointNotFound(err):
+		return ErrorBigModelEndpointNotFound, err
+
+	case bigmodel.IsErrorEndpointExists(err):
+		return ErrorBigModelEndpointExists, err
+
+	default:
+		return "", err
+	}
+}
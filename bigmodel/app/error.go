@@ -11,6 +11,24 @@ const (
 	ErrorBigModelSensitiveInfo     = "bigmodel_sensitive_info"
 	ErrorBigModelRecourseBusy      = "bigmodel_resource_busy"
 	ErrorBigModelConcurrentRequest = "bigmodel_concurrent_request"
+	ErrorBigModelInvalidText       = "bigmodel_invalid_text"
+	ErrorBigModelEndpointNotFound  = "bigmodel_endpoint_not_found"
+	ErrorBigModelEndpointExists    = "bigmodel_endpoint_exists"
+
+	ErrorApiKeyNotFound        = "bigmodel_api_key_not_found"
+	ErrorApiKeyInvalid         = "bigmodel_api_key_invalid"
+	ErrorApiKeyExpired         = "bigmodel_api_key_expired"
+	ErrorApiKeyNoScope         = "bigmodel_api_key_no_scope"
+	ErrorApiKeyExceedMaxNum    = "bigmodel_api_key_exceed_max_num"
+	ErrorApiKeyModelNotApplied = "bigmodel_api_key_model_not_applied"
+
+	ErrorApiRateLimited      = "bigmodel_api_rate_limited"
+	ErrorApiExceedTokenQuota = "bigmodel_api_exceed_token_quota"
+
+	ErrorConversationNotFound          = "bigmodel_conversation_not_found"
+	ErrorConversationExceedMaxNum      = "bigmodel_conversation_exceed_max_num"
+	ErrorConversationExceedMaxMessages = "bigmodel_conversation_exceed_max_messages"
+	ErrorConversationInvalidFormat     = "bigmodel_conversation_invalid_format"
 
 	ErrorWuKongNoPicture        = "bigmodel_no_wukong_picture"
 	ErrorWuKongInvalidId        = "wukong_invalid_id"
@@ -20,4 +38,7 @@ const (
 	ErrorWuKongInvalidLink      = "wukong_invalid_link"
 	ErrorWuKongDuplicateLike    = "wukong_duplicate_like"
 	ErrorWuKongExccedMaxLikeNum = "wukong_excced_max_like_num"
+
+	ErrorWuKongCollectionNotFound = "wukong_collection_not_found"
+	ErrorWuKongCollectionFull     = "wukong_collection_full"
 )
@@ -4,7 +4,22 @@ import (
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 )
 
-func (s bigModelService) GLM2(cmd *GLM2Cmd) (code string, err error) {
+// GLM2 moderates the prompt and the reply of glm2.
+func (s bigModelService) GLM2(cmd *GLM2Cmd) (string, error) {
+	return s.moderateChat(cmd.User, cmd.Text.GLM2Text(), cmd.CH, func(prompt string, ch chan string) (string, error) {
+		c := *cmd
+		c.CH = ch
+
+		var err error
+		if c.Text, err = domain.NewGLM2Text(prompt); err != nil {
+			return ErrorBigModelInvalidText, err
+		}
+
+		return s.glm2(&c)
+	})
+}
+
+func (s bigModelService) glm2(cmd *GLM2Cmd) (code string, err error) {
 	_ = s.sender.SendBigModelStarted(&domain.BigModelStartedEvent{
 		Account:      cmd.User,
 		BigModelType: domain.BigmodelGLM2,
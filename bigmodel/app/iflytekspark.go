@@ -4,7 +4,22 @@ import (
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 )
 
-func (s bigModelService) IFlytekSpark(cmd *IFlytekSparkCmd) (code string, err error) {
+// IFlytekSpark moderates the prompt and the reply of iflytekspark.
+func (s bigModelService) IFlytekSpark(cmd *IFlytekSparkCmd) (string, error) {
+	return s.moderateChat(cmd.User, cmd.Text.IFlytekSparkText(), cmd.CH, func(prompt string, ch chan string) (string, error) {
+		c := *cmd
+		c.CH = ch
+
+		var err error
+		if c.Text, err = domain.NewIFlytekSparkText(prompt); err != nil {
+			return ErrorBigModelInvalidText, err
+		}
+
+		return s.iflytekSpark(&c)
+	})
+}
+
+func (s bigModelService) iflytekSpark(cmd *IFlytekSparkCmd) (code string, err error) {
 	_ = s.sender.SendBigModelStarted(&domain.BigModelStartedEvent{
 		Account:      cmd.User,
 		BigModelType: domain.BigmodelIFlytekSpark,
@@ -13,6 +28,7 @@ func (s bigModelService) IFlytekSpark(cmd *IFlytekSparkCmd) (code string, err er
 	input := &domain.IFlytekSparkInput{
 		Text:              cmd.Text,
 		Sampling:          cmd.Sampling,
+		History:           cmd.History,
 		TopK:              cmd.TopK,
 		Temperature:       cmd.Temperature,
 		RepetitionPenalty: cmd.RepetitionPenalty,
@@ -4,7 +4,22 @@ import (
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 )
 
-func (s bigModelService) LLAMA2(cmd *LLAMA2Cmd) (code string, err error) {
+// LLAMA2 moderates the prompt and the reply of llama2.
+func (s bigModelService) LLAMA2(cmd *LLAMA2Cmd) (string, error) {
+	return s.moderateChat(cmd.User, cmd.Text.LLAMA2Text(), cmd.CH, func(prompt string, ch chan string) (string, error) {
+		c := *cmd
+		c.CH = ch
+
+		var err error
+		if c.Text, err = domain.NewLLAMA2Text(prompt); err != nil {
+			return ErrorBigModelInvalidText, err
+		}
+
+		return s.llama2(&c)
+	})
+}
+
+func (s bigModelService) llama2(cmd *LLAMA2Cmd) (code string, err error) {
 	_ = s.sender.SendBigModelStarted(&domain.BigModelStartedEvent{
 		Account:      cmd.User,
 		BigModelType: domain.BigmodelLLAMA2,
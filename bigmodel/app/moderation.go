@@ -0,0 +1,97 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/bigmodel"
+	types "github.com/opensourceways/xihe-server/domain"
+	moderationapp "github.com/opensourceways/xihe-server/moderation/app"
+	moderationdomain "github.com/opensourceways/xihe-server/moderation/domain"
+)
+
+// moderateText returns the text which can be used on the surface. The error
+// is the one of sensitive info if the text is blocked.
+func moderateText(
+	m moderationapp.ModerationService, surface moderationdomain.Surface,
+	user types.Account, text string,
+) (string, error) {
+	v, code, err := m.Moderate(&moderationapp.ModerateCmd{
+		Surface: surface,
+		User:    user.Account(),
+		Text:    text,
+	})
+	if err != nil && code == moderationapp.ErrorModerationBlocked {
+		err = bigmodel.NewErrorSensitiveInfo(err)
+	}
+
+	return v.Text, err
+}
+
+// genWuKongPictures moderates the description before the pictures are
+// generated, and the pictures after it.
+func genWuKongPictures(
+	fm bigmodel.BigModel, m moderationapp.ModerationService,
+	user types.Account, meta *domain.WuKongPictureMeta, estype string,
+) (map[string]string, error) {
+	desc, err := moderateText(
+		m, moderationdomain.SurfaceWuKongPrompt, user, meta.Desc.WuKongPictureDesc(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p := *meta
+	if p.Desc, err = domain.NewWuKongPictureDesc(desc); err != nil {
+		return nil, err
+	}
+
+	links, err := fm.GenPicturesByWuKong(user, &p, estype)
+	if err != nil {
+		return nil, err
+	}
+
+	urls := make([]string, 0, len(links))
+	for _, v := range links {
+		urls = append(urls, v)
+	}
+
+	code, err := m.ModerateImages(&moderationapp.ModerateImagesCmd{
+		Surface: moderationdomain.SurfaceWuKongPicture,
+		User:    user.Account(),
+		URLs:    urls,
+	})
+	if err != nil {
+		if code == moderationapp.ErrorModerationBlocked {
+			err = bigmodel.NewErrorSensitiveInfo(
+				errors.New("the generated pictures are illegal, please try again"),
+			)
+		}
+
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// moderateChat moderates the prompt before chat is called with it, and the
+// reply which chat streams through ch before it is relayed to out.
+func (s bigModelService) moderateChat(
+	user types.Account, prompt string, out chan string,
+	chat func(prompt string, ch chan string) (string, error),
+) (code string, err error) {
+	text, err := moderateText(s.moderation, moderationdomain.SurfaceChatPrompt, user, prompt)
+	if err != nil {
+		return s.setCode(err), err
+	}
+
+	ch := make(chan string, cap(out))
+
+	if code, err = chat(text, ch); err != nil {
+		return
+	}
+
+	go s.moderateReply(user, ch, out)
+
+	return
+}
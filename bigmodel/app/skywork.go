@@ -4,7 +4,22 @@ import (
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 )
 
-func (s bigModelService) SkyWork(cmd *SkyWorkCmd) (code string, err error) {
+// SkyWork moderates the prompt and the reply of skywork.
+func (s bigModelService) SkyWork(cmd *SkyWorkCmd) (string, error) {
+	return s.moderateChat(cmd.User, cmd.Text.SkyWorkText(), cmd.CH, func(prompt string, ch chan string) (string, error) {
+		c := *cmd
+		c.CH = ch
+
+		var err error
+		if c.Text, err = domain.NewSkyWorkText(prompt); err != nil {
+			return ErrorBigModelInvalidText, err
+		}
+
+		return s.skyWork(&c)
+	})
+}
+
+func (s bigModelService) skyWork(cmd *SkyWorkCmd) (code string, err error) {
 	_ = s.sender.SendBigModelStarted(&domain.BigModelStartedEvent{
 		Account:      cmd.User,
 		BigModelType: domain.BigmodelSkyWork,
@@ -0,0 +1,245 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	crepository "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type WuKongCollectionCreateCmd struct {
+	Name      domain.WuKongCollectionName
+	Desc      domain.WuKongCollectionDesc
+	Featured  bool
+	CreatedBy string
+}
+
+type WuKongCollectionUpdateCmd struct {
+	Id       string
+	Name     domain.WuKongCollectionName
+	Desc     domain.WuKongCollectionDesc
+	Featured *bool
+}
+
+type WuKongCollectionPictureCmd struct {
+	CollectionId string
+	domain.WuKongPictureIndex
+}
+
+type WuKongCollectionDTO struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Desc      string `json:"desc"`
+	Featured  bool   `json:"featured"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type WuKongCollectionDetailDTO struct {
+	WuKongCollectionDTO
+
+	Pictures []WuKongPublicDTO `json:"pictures"`
+}
+
+func toWuKongCollectionDTO(c *domain.WuKongCollection) WuKongCollectionDTO {
+	dto := WuKongCollectionDTO{
+		Id:        c.Id,
+		Name:      c.Name.WuKongCollectionName(),
+		Desc:      c.Desc.WuKongCollectionDesc(),
+		Featured:  c.Featured,
+		CreatedBy: c.CreatedBy,
+	}
+
+	_, dto.CreatedAt = utils.DateAndTime(c.CreatedAt)
+	_, dto.UpdatedAt = utils.DateAndTime(c.UpdatedAt)
+
+	return dto
+}
+
+// WuKongCollectionService manages the collections of public pictures which
+// are curated by the admins.
+type WuKongCollectionService interface {
+	Create(*WuKongCollectionCreateCmd) (WuKongCollectionDTO, error)
+	Update(*WuKongCollectionUpdateCmd) (string, error)
+	Delete(string) error
+	AddPicture(*WuKongCollectionPictureCmd) (string, error)
+	RemovePicture(*WuKongCollectionPictureCmd) (string, error)
+
+	// List lists all the collections if onlyFeatured is false.
+	List(onlyFeatured bool) ([]WuKongCollectionDTO, error)
+
+	// Get returns the collection with its pictures. The collection which is
+	// not featured can't be seen by the users.
+	Get(user types.Account, id string, onlyFeatured bool) (WuKongCollectionDetailDTO, string, error)
+}
+
+func NewWuKongCollectionService(
+	repo repository.WuKongCollection,
+	bm BigModelService,
+) WuKongCollectionService {
+	return wukongCollectionService{
+		repo: repo,
+		bm:   bm,
+	}
+}
+
+type wukongCollectionService struct {
+	repo repository.WuKongCollection
+	bm   BigModelService
+}
+
+func (s wukongCollectionService) Create(cmd *WuKongCollectionCreateCmd) (
+	dto WuKongCollectionDTO, err error,
+) {
+	now := utils.Now()
+
+	c := domain.WuKongCollection{
+		Name:      cmd.Name,
+		Desc:      cmd.Desc,
+		Featured:  cmd.Featured,
+		CreatedBy: cmd.CreatedBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if c.Id, err = s.repo.Add(&c); err != nil {
+		return
+	}
+
+	dto = toWuKongCollectionDTO(&c)
+
+	return
+}
+
+func (s wukongCollectionService) get(id string) (
+	c domain.WuKongCollection, code string, err error,
+) {
+	if c, err = s.repo.Get(id); err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			code = ErrorWuKongCollectionNotFound
+		}
+	}
+
+	return
+}
+
+func (s wukongCollectionService) Update(cmd *WuKongCollectionUpdateCmd) (string, error) {
+	c, code, err := s.get(cmd.Id)
+	if err != nil {
+		return code, err
+	}
+
+	if cmd.Name != nil {
+		c.Name = cmd.Name
+	}
+
+	if cmd.Desc != nil {
+		c.Desc = cmd.Desc
+	}
+
+	if cmd.Featured != nil {
+		c.Featured = *cmd.Featured
+	}
+
+	c.UpdatedAt = utils.Now()
+
+	if err := s.repo.Update(&c); err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			return ErrorWuKongCollectionNotFound, err
+		}
+
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (s wukongCollectionService) Delete(id string) error {
+	return s.repo.Delete(id)
+}
+
+func (s wukongCollectionService) AddPicture(cmd *WuKongCollectionPictureCmd) (string, error) {
+	c, code, err := s.get(cmd.CollectionId)
+	if err != nil {
+		return code, err
+	}
+
+	if c.Has(&cmd.WuKongPictureIndex) {
+		return "", nil
+	}
+
+	if c.IsFull() {
+		return ErrorWuKongCollectionFull, errors.New("too many pictures in the collection")
+	}
+
+	// only the public picture can be added
+	v, err := s.bm.ListPublicsByIndexes(nil, []domain.WuKongPictureIndex{cmd.WuKongPictureIndex})
+	if err != nil {
+		return "", err
+	}
+
+	if len(v) == 0 {
+		return ErrorWuKongInvalidId, errors.New("the picture is not public")
+	}
+
+	if err := s.repo.AddPicture(cmd.CollectionId, &cmd.WuKongPictureIndex); err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			return ErrorWuKongCollectionNotFound, err
+		}
+
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (s wukongCollectionService) RemovePicture(cmd *WuKongCollectionPictureCmd) (string, error) {
+	if err := s.repo.RemovePicture(cmd.CollectionId, &cmd.WuKongPictureIndex); err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			return ErrorWuKongCollectionNotFound, err
+		}
+
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (s wukongCollectionService) List(onlyFeatured bool) ([]WuKongCollectionDTO, error) {
+	v, err := s.repo.List(onlyFeatured)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]WuKongCollectionDTO, len(v))
+	for i := range v {
+		r[i] = toWuKongCollectionDTO(&v[i])
+	}
+
+	return r, nil
+}
+
+func (s wukongCollectionService) Get(user types.Account, id string, onlyFeatured bool) (
+	dto WuKongCollectionDetailDTO, code string, err error,
+) {
+	c, code, err := s.get(id)
+	if err != nil {
+		return
+	}
+
+	if onlyFeatured && !c.Featured {
+		code = ErrorWuKongCollectionNotFound
+		err = errors.New("the collection is not featured")
+
+		return
+	}
+
+	dto.WuKongCollectionDTO = toWuKongCollectionDTO(&c)
+	dto.Pictures, err = s.bm.ListPublicsByIndexes(user, c.Pictures)
+
+	return
+}
@@ -0,0 +1,179 @@
+package app
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	crepository "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+// saveToGallery keeps the gallery in step with the public picture. The
+// failure doesn't fail the caller, and it can be fixed by SyncWuKongGallery.
+func (s bigModelService) saveToGallery(p *domain.WuKongPicture) {
+	if err := s.gallery.Save(p); err != nil {
+		logrus.Errorf(
+			"save wukong picture(%s/%s) to gallery failed, err:%s",
+			p.Owner.Account(), p.Id, err.Error(),
+		)
+	}
+}
+
+func (s bigModelService) removeFromGallery(p *domain.WuKongPicture) {
+	index := p.Index()
+
+	if err := s.gallery.Remove(&index); err != nil {
+		logrus.Errorf(
+			"remove wukong picture(%s/%s) from gallery failed, err:%s",
+			p.Owner.Account(), p.Id, err.Error(),
+		)
+	}
+}
+
+func (s bigModelService) toWuKongPublicDTOs(
+	user types.Account, v []domain.WuKongPicture,
+) []WuKongPublicDTO {
+	r := make([]WuKongPublicDTO, len(v))
+
+	for i := range v {
+		item := &v[i]
+		link := s.fm.GenWuKongLinkFromOBSPath(item.OBSPath.OBSPath())
+		avatarId, _ := s.user.GetUserAvatarId(item.Owner)
+
+		var (
+			a      string
+			isDigg bool
+			isLike bool
+			likeID string
+		)
+
+		if user != nil {
+			isLike, likeID, _ = s.bigmodelService.IsLike(item, user)
+			isDigg = s.bigmodelService.IsDigg(user, item.Diggs)
+		}
+
+		if avatarId != nil {
+			a = avatarId.AvatarId()
+		}
+
+		r[i].toWuKongPublicDTO(item, a, isLike, likeID, isDigg, link)
+	}
+
+	return r
+}
+
+func (s bigModelService) GetPublicsGlobal(cmd *WuKongListPublicGlobalCmd) (r WuKongPublicGlobalDTO, err error) {
+	opt := repository.WuKongGalleryListOption{
+		Keyword:      cmd.Keyword,
+		Style:        cmd.Style,
+		Tag:          cmd.Tag,
+		CountPerPage: cmd.CountPerPage,
+		PageNum:      cmd.PageNum,
+	}
+
+	if cmd.Level != nil {
+		if cmd.Level.IsOfficial() {
+			opt.Level = cmd.Level
+		}
+
+		opt.SortByDigg = cmd.Level.IsHot()
+	}
+
+	v, total, err := s.gallery.List(&opt)
+	if err != nil {
+		return
+	}
+
+	r = WuKongPublicGlobalDTO{
+		Total:    total,
+		Pictures: s.toWuKongPublicDTOs(cmd.User, v),
+	}
+
+	return
+}
+
+// ListSimilarPublics lists the public pictures of the same style, the most
+// digged first.
+func (s bigModelService) ListSimilarPublics(cmd *WuKongListSimilarCmd) (
+	r []WuKongPublicDTO, code string, err error,
+) {
+	p, err := s.wukongPicture.GetPublicByUserName(cmd.Owner, cmd.Id)
+	if err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			code = ErrorWuKongInvalidId
+		}
+
+		return
+	}
+
+	if p.Style == "" {
+		return
+	}
+
+	index := p.Index()
+
+	v, _, err := s.gallery.List(&repository.WuKongGalleryListOption{
+		Style:        p.Style,
+		SortByDigg:   true,
+		Exclude:      &index,
+		CountPerPage: cmd.Num,
+		PageNum:      1,
+	})
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	r = s.toWuKongPublicDTOs(cmd.User, v)
+
+	return
+}
+
+func (s bigModelService) ListPublicsByIndexes(
+	user types.Account, indexes []domain.WuKongPictureIndex,
+) ([]WuKongPublicDTO, error) {
+	v, err := s.gallery.FindByIndexes(indexes)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	return s.toWuKongPublicDTOs(user, v), nil
+}
+
+func (s bigModelService) UpdatePublicTags(cmd *WuKongUpdateTagsCmd) (code string, err error) {
+	p, err := s.wukongPicture.GetPublicByUserName(cmd.User, cmd.Id)
+	if err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			code = ErrorWuKongInvalidId
+		}
+
+		return
+	}
+
+	p.Tags = cmd.Tags
+
+	if err = s.wukongPicture.UpdatePublicPicture(p.Owner, p.Id, p.Version, &p); err != nil {
+		return
+	}
+
+	s.saveToGallery(&p)
+
+	return
+}
+
+// SyncWuKongGallery saves all the public pictures to the gallery. It is used
+// to build the gallery at first or fix it when it is out of step.
+func (s bigModelService) SyncWuKongGallery() (int, error) {
+	v, err := s.wukongPicture.GetPublicsGlobal()
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range v {
+		if err := s.gallery.Save(&v[i]); err != nil {
+			return i, err
+		}
+	}
+
+	return len(v), nil
+}
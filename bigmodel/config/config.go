@@ -1,6 +1,7 @@
 package config
 
 import (
+	"github.com/opensourceways/xihe-server/bigmodel/app"
 	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/bigmodels"
 	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/messageadapter"
 )
@@ -8,12 +9,29 @@ import (
 type Config struct {
 	bigmodels.Config
 
-	Message messageadapter.Config `json:"message"`
+	Message  messageadapter.Config `json:"message"`
+	ApiKey   app.ApiKeyConfig      `json:"api_key"`
+	ApiLimit app.ApiLimitConfig    `json:"api_limit"`
+
+	Conversation app.ConversationConfig `json:"conversation"`
 }
 
 func (cfg *Config) ConfigItems() []interface{} {
 	return []interface{}{
 		&cfg.Config,
 		&cfg.Message,
+		&cfg.ApiKey,
+		&cfg.ApiLimit,
+		&cfg.Conversation,
 	}
 }
+
+// SetDefault calls the SetDefault of the api key, api limit and
+// conversation configs, since common.SetDefault only calls the method of
+// Config, which would be the one of the embedded bigmodels.Config.
+func (cfg *Config) SetDefault() {
+	cfg.Config.SetDefault()
+	cfg.ApiKey.SetDefault()
+	cfg.ApiLimit.SetDefault()
+	cfg.Conversation.SetDefault()
+}
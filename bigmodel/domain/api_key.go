@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	apiKeyPrefix     = "xhk-"
+	apiKeySecretSize = 32
+	apiKeyMaskSize   = 4
+	apiKeyNameMaxLen = 50
+)
+
+// ApiKey is the key by which the user calls the apis of models in the scopes.
+// Only the hash of key is kept, and the key is shown to the user only once.
+type ApiKey struct {
+	Id         string
+	User       types.Account
+	Name       ApiKeyName
+	Scopes     []ModelName
+	Hash       string
+	Mask       string
+	CreatedAt  int64
+	ExpireAt   int64
+	LastUsedAt int64
+}
+
+// NewApiKey creates the api key which expires after expiry seconds, and returns
+// the plain key as well.
+func NewApiKey(user types.Account, name ApiKeyName, scopes []ModelName, expiry int64) (
+	k ApiKey, key string, err error,
+) {
+	k = ApiKey{
+		User:      user,
+		Name:      name,
+		Scopes:    scopes,
+		CreatedAt: utils.Now(),
+	}
+
+	key, err = k.Rotate(expiry)
+
+	return
+}
+
+// Rotate replaces the key with a new one and extends the expiry. The old key
+// is invalid immediately after it is saved.
+func (k *ApiKey) Rotate(expiry int64) (string, error) {
+	b := make([]byte, apiKeySecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	key := apiKeyPrefix + hex.EncodeToString(b)
+
+	k.Hash = HashApiKey(key)
+	k.Mask = key[:len(apiKeyPrefix)+apiKeyMaskSize] + "..." + key[len(key)-apiKeyMaskSize:]
+	k.ExpireAt = utils.Now() + expiry
+
+	return key, nil
+}
+
+func (k *ApiKey) IsExpired() bool {
+	return utils.Now() > k.ExpireAt
+}
+
+func (k *ApiKey) HasScope(model ModelName) bool {
+	for i := range k.Scopes {
+		if k.Scopes[i].ModelName() == model.ModelName() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsApiKey tells whether v is an api key or the token applied for the model.
+func IsApiKey(v string) bool {
+	return strings.HasPrefix(v, apiKeyPrefix)
+}
+
+func HashApiKey(v string) string {
+	h := sha256.Sum256([]byte(v))
+
+	return hex.EncodeToString(h[:])
+}
+
+// ApiKeyName
+type ApiKeyName interface {
+	ApiKeyName() string
+}
+
+func NewApiKeyName(v string) (ApiKeyName, error) {
+	if v == "" || utils.StrLen(v) > apiKeyNameMaxLen {
+		return nil, errors.New("invalid api key name")
+	}
+
+	return apiKeyName(v), nil
+}
+
+type apiKeyName string
+
+func (n apiKeyName) ApiKeyName() string {
+	return string(n)
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+func testApiKey(t *testing.T, expiry int64) (ApiKey, string) {
+	name, err := NewApiKeyName("key")
+	if err != nil {
+		t.Fatalf("NewApiKeyName() failed, err:%s", err.Error())
+	}
+
+	k, key, err := NewApiKey(
+		types.CreateAccount("alice"), name, []ModelName{ModelNameGLM2}, expiry,
+	)
+	if err != nil {
+		t.Fatalf("NewApiKey() failed, err:%s", err.Error())
+	}
+
+	return k, key
+}
+
+func TestNewApiKey(t *testing.T) {
+	k, key := testApiKey(t, 3600)
+
+	cases := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"prefixed", IsApiKey(key), true},
+		{"hash of key", k.Hash == HashApiKey(key), true},
+		{"plain key not kept", k.Hash == key || strings.Contains(k.Mask, key), false},
+		{"mask head", strings.HasPrefix(k.Mask, key[:len(apiKeyPrefix)+apiKeyMaskSize]), true},
+		{"mask tail", strings.HasSuffix(k.Mask, key[len(key)-apiKeyMaskSize:]), true},
+		{"not expired", k.IsExpired(), false},
+	}
+
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestHashApiKey(t *testing.T) {
+	_, key := testApiKey(t, 3600)
+	_, other := testApiKey(t, 3600)
+
+	cases := []struct {
+		name string
+		a    string
+		b    string
+		same bool
+	}{
+		{"same key", key, key, true},
+		{"different keys", key, other, false},
+		{"truncated key", key, key[:len(key)-1], false},
+	}
+
+	for _, c := range cases {
+		if v := HashApiKey(c.a) == HashApiKey(c.b); v != c.same {
+			t.Errorf("%s: same hash = %v, want %v", c.name, v, c.same)
+		}
+	}
+}
+
+func TestApiKeyRotate(t *testing.T) {
+	k, old := testApiKey(t, 3600)
+	k.ExpireAt = 0
+
+	key, err := k.Rotate(3600)
+	if err != nil {
+		t.Fatalf("Rotate() failed, err:%s", err.Error())
+	}
+
+	cases := []struct {
+		name string
+		got  bool
+		want bool
+	}{
+		{"new key", key != old, true},
+		{"old key revoked", k.Hash == HashApiKey(old), false},
+		{"new key valid", k.Hash == HashApiKey(key), true},
+		{"expiry extended", k.IsExpired(), false},
+	}
+
+	for _, c := range cases {
+		if c.got != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, c.got, c.want)
+		}
+	}
+}
+
+func TestApiKeyIsExpired(t *testing.T) {
+	cases := []struct {
+		name   string
+		expiry int64
+		want   bool
+	}{
+		{"valid", 3600, false},
+		{"expired", -1, true},
+	}
+
+	for _, c := range cases {
+		k, _ := testApiKey(t, c.expiry)
+
+		if v := k.IsExpired(); v != c.want {
+			t.Errorf("%s: IsExpired() = %v, want %v", c.name, v, c.want)
+		}
+	}
+}
+
+func TestApiKeyHasScope(t *testing.T) {
+	k, _ := testApiKey(t, 3600)
+
+	cases := []struct {
+		name  string
+		model ModelName
+		want  bool
+	}{
+		{"in scope", ModelNameGLM2, true},
+		{"out of scope", ModelNameLLAMA2, false},
+	}
+
+	for _, c := range cases {
+		if v := k.HasScope(c.model); v != c.want {
+			t.Errorf("%s: HasScope() = %v, want %v", c.name, v, c.want)
+		}
+	}
+}
+
+func TestIsApiKey(t *testing.T) {
+	cases := []struct {
+		name  string
+		token string
+		want  bool
+	}{
+		{"api key", apiKeyPrefix + "abc", true},
+		{"legacy token", "eyJhbGciOiJIUzI1NiJ9", false},
+		{"empty", "", false},
+	}
+
+	for _, c := range cases {
+		if v := IsApiKey(c.token); v != c.want {
+			t.Errorf("%s: IsApiKey() = %v, want %v", c.name, v, c.want)
+		}
+	}
+}
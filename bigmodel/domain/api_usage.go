@@ -0,0 +1,27 @@
+package domain
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// ApiCall is a call of the api of model, the sizes are the number of
+// characters of the prompt and completion.
+type ApiCall struct {
+	User             types.Account
+	Model            ModelName
+	PromptTokens     int
+	CompletionTokens int
+}
+
+func (c *ApiCall) Tokens() int {
+	return c.PromptTokens + c.CompletionTokens
+}
+
+// ApiUsage is the usage of the api of model in a day.
+type ApiUsage struct {
+	Model            ModelName
+	Date             string
+	Calls            int
+	PromptTokens     int
+	CompletionTokens int
+}
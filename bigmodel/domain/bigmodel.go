@@ -30,6 +30,7 @@ type WuKongPicture struct {
 	Level     WuKongPictureLevel
 	Diggs     []string
 	DiggCount int
+	Tags      []WuKongPictureTag
 	Version   int
 	CreatedAt string
 
@@ -49,6 +50,13 @@ func (r *WuKongPicture) SetDefaultDiggs() {
 	r.Diggs = []string{}
 }
 
+func (r *WuKongPicture) Index() WuKongPictureIndex {
+	return WuKongPictureIndex{
+		Owner: r.Owner,
+		Id:    r.Id,
+	}
+}
+
 // ai detector
 type AIDetectorInput struct {
 	Lang Lang
@@ -0,0 +1,51 @@
+package bigmodel
+
+const (
+	EndpointStateUp       = "up"
+	EndpointStateDown     = "down"
+	EndpointStateOpen     = "circuit_open"
+	EndpointStateDraining = "draining"
+)
+
+// EndpointPool is the endpoints which serve the same model.
+type EndpointPool struct {
+	Name      string
+	Endpoints []EndpointStat
+}
+
+type EndpointStat struct {
+	URL      string
+	State    string
+	Weight   int
+	Capacity int
+	InFlight int
+
+	Requests  int64
+	Failures  int64
+	Latency   int64 // the average duration of requests, the unit is millisecond
+	LastError string
+	ProbedAt  int64
+}
+
+type EndpointOption struct {
+	URL string
+
+	// Weight is the proportion of requests among the endpoints of pool.
+	Weight int
+
+	// Capacity is the max number of requests the endpoint serves at once.
+	Capacity int
+}
+
+// EndpointRegistry manages the endpoints of models at runtime. The changes
+// only take effect on the current instance and are lost after restart, so
+// the config should be updated too if they are expected to be kept.
+type EndpointRegistry interface {
+	ListEndpoints() []EndpointPool
+	AddEndpoint(pool string, opt *EndpointOption) error
+
+	// DrainEndpoint stops or resumes dispatching requests to the endpoint.
+	// The requests in flight are not affected.
+	DrainEndpoint(pool, url string, drain bool) error
+	RemoveEndpoint(pool, url string) error
+}
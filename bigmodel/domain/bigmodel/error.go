@@ -28,3 +28,29 @@ func NewErrorBusySource(err error) errorBusySource {
 func IsErrorBusySource(err error) bool {
 	return errors.As(err, &errorBusySource{})
 }
+
+// errorEndpointNotFound
+type errorEndpointNotFound struct {
+	error
+}
+
+func NewErrorEndpointNotFound(err error) errorEndpointNotFound {
+	return errorEndpointNotFound{err}
+}
+
+func IsErrorEndpointNotFound(err error) bool {
+	return errors.As(err, &errorEndpointNotFound{})
+}
+
+// errorEndpointExists
+type errorEndpointExists struct {
+	error
+}
+
+func NewErrorEndpointExists(err error) errorEndpointExists {
+	return errorEndpointExists{err}
+}
+
+func IsErrorEndpointExists(err error) bool {
+	return errors.As(err, &errorEndpointExists{})
+}
@@ -20,8 +20,6 @@ type CodeGeexResp struct {
 type BigModel interface {
 	// common
 	GetIdleEndpoint(bid string) (c int, err error)
-	CheckText(content string) error
-	CheckImages(urls []string) error
 
 	// wukong
 	GetWuKongSampleId() string
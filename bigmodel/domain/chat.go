@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	ChatRoleSystem    = "system"
+	ChatRoleUser      = "user"
+	ChatRoleAssistant = "assistant"
+)
+
+// ChatMessage is a message of the conversation in the OpenAI format.
+type ChatMessage struct {
+	Role    string
+	Content string
+}
+
+// ChatConversation is the conversation in the format of the hosted chat
+// models which take the question and the history of the previous rounds.
+type ChatConversation struct {
+	Text    string
+	History []History
+}
+
+// NewChatConversation translates the messages into the question and history.
+// The system messages are put ahead of the first question, and the
+// successive messages of user are joined as one question.
+func NewChatConversation(msgs []ChatMessage) (c ChatConversation, err error) {
+	var system, question []string
+
+	first := true
+	takeQuestion := func() string {
+		if first {
+			first = false
+			question = append(system, question...)
+		}
+
+		s := strings.Join(question, "\n")
+		question = nil
+
+		return s
+	}
+
+	for i := range msgs {
+		item := &msgs[i]
+
+		switch item.Role {
+		case ChatRoleSystem:
+			if first {
+				system = append(system, item.Content)
+			} else {
+				question = append(question, item.Content)
+			}
+
+		case ChatRoleUser:
+			question = append(question, item.Content)
+
+		case ChatRoleAssistant:
+			h, err1 := NewHistory(takeQuestion(), item.Content)
+			if err1 != nil {
+				err = err1
+
+				return
+			}
+
+			c.History = append(c.History, h)
+
+		default:
+			err = fmt.Errorf("unknown role: %s", item.Role)
+
+			return
+		}
+	}
+
+	if len(question) == 0 {
+		err = errors.New("the last message should be from user")
+
+		return
+	}
+
+	c.Text = takeQuestion()
+
+	return
+}
+
+// Size is the number of characters of the conversation.
+func (c *ChatConversation) Size() int {
+	n := utils.StrLen(c.Text)
+
+	for i := range c.History {
+		h := c.History[i].History()
+		n += utils.StrLen(h[0]) + utils.StrLen(h[1])
+	}
+
+	return n
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"errors"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	conversationTitleMaxLen = 100
+
+	// the title is generated from the first question if it is not set
+	conversationTitleGenLen = 20
+)
+
+// Conversation is the chat between the user and the model, which keeps the
+// messages and the parameters of model.
+type Conversation struct {
+	Id    string
+	Owner types.Account
+	Title ConversationTitle
+	Model ModelName
+
+	// Temperature and TopP are optional, see ChatCompletionCmd.
+	Temperature Temperature
+	TopP        TopP
+
+	Messages  []ConversationMessage
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+type ConversationMessage struct {
+	Role      string
+	Content   string
+	CreatedAt int64
+}
+
+func NewConversation(owner types.Account, title ConversationTitle, model ModelName) Conversation {
+	now := utils.Now()
+
+	return Conversation{
+		Owner:     owner,
+		Title:     title,
+		Model:     model,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (c *Conversation) HasTitle() bool {
+	return c.Title != nil && c.Title.ConversationTitle() != ""
+}
+
+// GenTitle sets the title by the beginning of the question if it is not set.
+// It returns true if the title is set.
+func (c *Conversation) GenTitle(question string) bool {
+	if c.HasTitle() {
+		return false
+	}
+
+	v := []rune(question)
+	if len(v) > conversationTitleGenLen {
+		v = v[:conversationTitleGenLen]
+	}
+
+	t, err := NewConversationTitle(string(v))
+	if err != nil {
+		return false
+	}
+
+	c.Title = t
+
+	return true
+}
+
+// ChatMessages returns the messages of the recent rounds at most, and the
+// question is appended as the last one.
+func (c *Conversation) ChatMessages(question string, rounds int) []ChatMessage {
+	start := len(c.Messages)
+	for n := 0; start > 0; start-- {
+		if c.Messages[start-1].Role == ChatRoleAssistant {
+			if n++; n > rounds {
+				break
+			}
+		}
+	}
+
+	// the history starts with the question of user
+	for start < len(c.Messages) && c.Messages[start].Role != ChatRoleUser {
+		start++
+	}
+
+	r := make([]ChatMessage, 0, len(c.Messages)-start+1)
+	for i := start; i < len(c.Messages); i++ {
+		r = append(r, ChatMessage{
+			Role:    c.Messages[i].Role,
+			Content: c.Messages[i].Content,
+		})
+	}
+
+	return append(r, ChatMessage{
+		Role:    ChatRoleUser,
+		Content: question,
+	})
+}
+
+// NewRound returns the messages of question and reply of a round.
+func NewRound(question, reply string) []ConversationMessage {
+	now := utils.Now()
+
+	return []ConversationMessage{
+		{Role: ChatRoleUser, Content: question, CreatedAt: now},
+		{Role: ChatRoleAssistant, Content: reply, CreatedAt: now},
+	}
+}
+
+// ConversationTitle
+type ConversationTitle interface {
+	ConversationTitle() string
+}
+
+func NewConversationTitle(v string) (ConversationTitle, error) {
+	if utils.StrLen(v) > conversationTitleMaxLen {
+		return nil, errors.New("invalid conversation title")
+	}
+
+	return conversationTitle(v), nil
+}
+
+type conversationTitle string
+
+func (t conversationTitle) ConversationTitle() string {
+	return string(t)
+}
@@ -31,7 +31,12 @@ const (
 	langZH = "zh"
 	langEN = "en"
 
-	modelNameWukong = "wukong"
+	modelNameWukong       = "wukong"
+	modelNameGLM2         = "glm2_6b"
+	modelNameLLAMA2       = "llama2_7b"
+	modelNameSkyWork      = "skywork_13b"
+	modelNameIFlytekSpark = "iflytekspark"
+	modelNameBaiChuan     = "baichuan2_7b_chat"
 )
 
 var (
@@ -53,6 +58,20 @@ var (
 	BigmodelSkyWork       = BigmodelType(bigmodelSkyWork)
 	BigmodelIFlytekSpark  = BigmodelType(bigmodelIFlytekSpark)
 
+	ModelNameGLM2         = modelName(modelNameGLM2)
+	ModelNameLLAMA2       = modelName(modelNameLLAMA2)
+	ModelNameSkyWork      = modelName(modelNameSkyWork)
+	ModelNameIFlytekSpark = modelName(modelNameIFlytekSpark)
+	ModelNameBaiChuan     = modelName(modelNameBaiChuan)
+
+	chatModelNames = map[string]bool{
+		modelNameGLM2:         true,
+		modelNameLLAMA2:       true,
+		modelNameSkyWork:      true,
+		modelNameIFlytekSpark: true,
+		modelNameBaiChuan:     true,
+	}
+
 	wukongPictureLevelMap = map[string]int{
 		"hot":      3,
 		"official": 2,
@@ -272,13 +291,23 @@ type ModelName interface {
 }
 
 func NewModelName(v string) (ModelName, error) {
-	b := v == modelNameWukong
+	b := v == modelNameWukong || chatModelNames[v]
 	if !b {
 		return nil, errors.New("invalid model name")
 	}
 	return modelName(v), nil
 }
 
+// NewChatModelName accepts only the hosted chat models which can be called
+// by the chat completions api.
+func NewChatModelName(v string) (ModelName, error) {
+	if !chatModelNames[v] {
+		return nil, fmt.Errorf("unsupported model: %s", v)
+	}
+
+	return modelName(v), nil
+}
+
 type modelName string
 
 func (m modelName) ModelName() string {
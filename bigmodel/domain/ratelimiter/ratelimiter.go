@@ -0,0 +1,61 @@
+package ratelimiter
+
+import "math"
+
+// Bucket is the token bucket which holds Capacity tokens at most and is
+// refilled fully in Period seconds.
+type Bucket struct {
+	Capacity int
+	Period   int64
+}
+
+func (b *Bucket) IsValid() bool {
+	return b.Capacity > 0 && b.Period > 0
+}
+
+func (b *Bucket) rate() float64 {
+	return float64(b.Capacity) / float64(b.Period)
+}
+
+// Full returns the state of bucket which is not taken yet.
+func (b *Bucket) Full(now int64) BucketState {
+	return BucketState{Tokens: float64(b.Capacity), At: now}
+}
+
+// Take refills the bucket by the time elapsed since the last taking, and
+// takes cost tokens from it. It returns the seconds to wait if the tokens
+// left are not enough. The tokens are taken anyway if force is true, which
+// may leave the bucket in debt.
+func (b *Bucket) Take(s *BucketState, now int64, cost int, force bool) int {
+	rate := b.rate()
+
+	if now > s.At {
+		s.Tokens = math.Min(float64(b.Capacity), s.Tokens+float64(now-s.At)*rate)
+	}
+	s.At = now
+
+	c := float64(cost)
+	if force || (s.Tokens > 0 && s.Tokens >= c) {
+		s.Tokens -= c
+
+		return 0
+	}
+
+	return int(math.Ceil((math.Max(c, 1) - s.Tokens) / rate))
+}
+
+// FullIn returns the seconds after which the bucket is full again.
+func (b *Bucket) FullIn(s *BucketState) int64 {
+	return int64(math.Ceil((float64(b.Capacity) - s.Tokens) / b.rate()))
+}
+
+// BucketState is the tokens left in the bucket at the time of the last taking.
+type BucketState struct {
+	Tokens float64
+	At     int64
+}
+
+type RateLimiter interface {
+	// Take takes cost tokens from the bucket of key. See Bucket.Take.
+	Take(key string, b *Bucket, cost int, force bool) (wait int, err error)
+}
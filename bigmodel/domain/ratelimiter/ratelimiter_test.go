@@ -0,0 +1,74 @@
+package ratelimiter
+
+import "testing"
+
+func TestBucketTake(t *testing.T) {
+	// 10 tokens, refilled 1 token per 6 seconds
+	b := Bucket{Capacity: 10, Period: 60}
+
+	cases := []struct {
+		name       string
+		tokens     float64
+		elapsed    int64
+		cost       int
+		force      bool
+		wait       int
+		wantTokens float64
+	}{
+		{"take from full bucket", 10, 0, 1, false, 0, 9},
+		{"take all", 10, 0, 10, false, 0, 0},
+		{"not enough", 2, 0, 5, false, 18, 2},
+		{"empty", 0, 0, 1, false, 6, 0},
+		{"refilled", 0, 12, 2, false, 0, 0},
+		{"partly refilled", 0, 3, 1, false, 3, 0.5},
+		{"refill no more than capacity", 5, 600, 1, false, 0, 9},
+		{"check without cost", 0.5, 0, 0, false, 0, 0.5},
+		{"check used up", 0, 0, 0, false, 6, 0},
+		{"forced into debt", 2, 0, 5, true, 0, -3},
+		{"in debt", -3, 6, 1, false, 18, -2},
+		{"clock skew", 5, -10, 1, false, 0, 4},
+	}
+
+	for _, c := range cases {
+		s := BucketState{Tokens: c.tokens, At: 100}
+
+		wait := b.Take(&s, 100+c.elapsed, c.cost, c.force)
+		if wait != c.wait {
+			t.Errorf("%s: Take() = %d, want %d", c.name, wait, c.wait)
+		}
+
+		if s.Tokens != c.wantTokens {
+			t.Errorf("%s: tokens = %v, want %v", c.name, s.Tokens, c.wantTokens)
+		}
+	}
+}
+
+func TestBucketFullIn(t *testing.T) {
+	b := Bucket{Capacity: 10, Period: 60}
+
+	cases := []struct {
+		name   string
+		tokens float64
+		want   int64
+	}{
+		{"full", 10, 0},
+		{"empty", 0, 60},
+		{"partly", 9.5, 3},
+		{"in debt", -5, 90},
+	}
+
+	for _, c := range cases {
+		if v := b.FullIn(&BucketState{Tokens: c.tokens}); v != c.want {
+			t.Errorf("%s: FullIn() = %d, want %d", c.name, v, c.want)
+		}
+	}
+}
+
+func TestBucketFull(t *testing.T) {
+	b := Bucket{Capacity: 10, Period: 60}
+
+	s := b.Full(100)
+	if wait := b.Take(&s, 100, 10, false); wait != 0 {
+		t.Errorf("Take() from the full bucket = %d, want 0", wait)
+	}
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type ApiKey interface {
+	Add(*domain.ApiKey) (string, error)
+	Get(user types.Account, id string) (domain.ApiKey, error)
+	GetByHash(hash string) (domain.ApiKey, error)
+	List(types.Account) ([]domain.ApiKey, error)
+	UpdateHash(*domain.ApiKey) error
+	UpdateLastUsed(*domain.ApiKey) error
+	Delete(user types.Account, id string) error
+}
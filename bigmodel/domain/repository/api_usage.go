@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type ApiUsage interface {
+	// Add accumulates the call to the usage of the date.
+	Add(call *domain.ApiCall, date string) error
+
+	// List returns the usages since the date.
+	List(user types.Account, since string) ([]domain.ApiUsage, error)
+}
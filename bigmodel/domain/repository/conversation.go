@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type Conversation interface {
+	Add(*domain.Conversation) (string, error)
+	Get(user types.Account, id string) (domain.Conversation, error)
+
+	// List returns the conversations without messages, the recent first.
+	List(types.Account) ([]domain.Conversation, error)
+	Count(types.Account) (int, error)
+	UpdateTitle(*domain.Conversation) error
+	AddMessages(c *domain.Conversation, msgs []domain.ConversationMessage) error
+	Delete(user types.Account, id string) error
+
+	// DeleteInactive deletes the conversations which are not updated since
+	// the time, and returns the number of them.
+	DeleteInactive(since int64) (int, error)
+}
@@ -35,3 +35,47 @@ type WuKongPicture interface {
 	GetOfficialPublicsGlobal() ([]domain.WuKongPicture, error)
 	UpdatePublicPicture(types.Account, string, int, *domain.WuKongPicture) error
 }
+
+type WuKongGalleryListOption struct {
+	// Keyword is searched in the desc and style of picture.
+	Keyword string
+	Style   string
+	Tag     domain.WuKongPictureTag
+	Level   domain.WuKongPictureLevel
+
+	// SortByDigg sorts the pictures by the digg count, otherwise by the
+	// created time. The latest is first in both cases.
+	SortByDigg bool
+
+	// Exclude is the picture which is not listed.
+	Exclude *domain.WuKongPictureIndex
+
+	CountPerPage int
+	PageNum      int
+}
+
+// WuKongGallery keeps each public picture in one doc so that the gallery
+// can be paginated and searched. It is synced with WuKongPicture.
+type WuKongGallery interface {
+	Save(*domain.WuKongPicture) error
+	Remove(*domain.WuKongPictureIndex) error
+	List(*WuKongGalleryListOption) ([]domain.WuKongPicture, int, error)
+
+	// FindByIndexes returns the pictures in the order of indexes, and the
+	// ones which don't exist are ignored.
+	FindByIndexes([]domain.WuKongPictureIndex) ([]domain.WuKongPicture, error)
+}
+
+type WuKongCollection interface {
+	Add(*domain.WuKongCollection) (string, error)
+	Get(string) (domain.WuKongCollection, error)
+
+	// List returns the collections without pictures, the latest first.
+	List(onlyFeatured bool) ([]domain.WuKongCollection, error)
+
+	// Update updates the name, desc and featured of collection.
+	Update(*domain.WuKongCollection) error
+	AddPicture(id string, index *domain.WuKongPictureIndex) error
+	RemovePicture(id string, index *domain.WuKongPictureIndex) error
+	Delete(string) error
+}
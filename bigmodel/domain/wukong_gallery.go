@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	wukongPictureTagMaxLen = 20
+	wukongPictureTagMaxNum = 5
+
+	wukongCollectionNameMaxLen = 50
+	wukongCollectionDescMaxLen = 200
+	wukongCollectionMaxPicture = 200
+)
+
+// WuKongPictureIndex
+type WuKongPictureIndex struct {
+	Owner types.Account
+	Id    string
+}
+
+// WuKongCollection is the public pictures curated by the admins, and the
+// featured ones are shown on the gallery.
+type WuKongCollection struct {
+	Id        string
+	Name      WuKongCollectionName
+	Desc      WuKongCollectionDesc
+	Featured  bool
+	Pictures  []WuKongPictureIndex
+	CreatedBy string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+func (c *WuKongCollection) Has(index *WuKongPictureIndex) bool {
+	for i := range c.Pictures {
+		item := &c.Pictures[i]
+
+		if item.Id == index.Id && item.Owner.Account() == index.Owner.Account() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *WuKongCollection) IsFull() bool {
+	return len(c.Pictures) >= wukongCollectionMaxPicture
+}
+
+// WuKongPictureTag is defined by the owner of public picture.
+type WuKongPictureTag interface {
+	WuKongPictureTag() string
+}
+
+func NewWuKongPictureTag(v string) (WuKongPictureTag, error) {
+	v = utils.XSSFilter(strings.TrimSpace(v))
+
+	if v == "" || utils.StrLen(v) > wukongPictureTagMaxLen {
+		return nil, fmt.Errorf(
+			"the length of tag should be between 1 and %d", wukongPictureTagMaxLen,
+		)
+	}
+
+	return wukongPictureTag(v), nil
+}
+
+// NewWuKongPictureTags removes the duplicate tags.
+func NewWuKongPictureTags(v []string) ([]WuKongPictureTag, error) {
+	if len(v) > wukongPictureTagMaxNum {
+		return nil, fmt.Errorf("too many tags, at most %d", wukongPictureTagMaxNum)
+	}
+
+	r := make([]WuKongPictureTag, 0, len(v))
+	m := map[string]bool{}
+
+	for i := range v {
+		t, err := NewWuKongPictureTag(v[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if s := t.WuKongPictureTag(); !m[s] {
+			m[s] = true
+			r = append(r, t)
+		}
+	}
+
+	return r, nil
+}
+
+type wukongPictureTag string
+
+func (r wukongPictureTag) WuKongPictureTag() string {
+	return string(r)
+}
+
+// WuKongCollectionName
+type WuKongCollectionName interface {
+	WuKongCollectionName() string
+}
+
+func NewWuKongCollectionName(v string) (WuKongCollectionName, error) {
+	v = utils.XSSFilter(v)
+
+	if v == "" || utils.StrLen(v) > wukongCollectionNameMaxLen {
+		return nil, errors.New("invalid collection name")
+	}
+
+	return wukongCollectionName(v), nil
+}
+
+type wukongCollectionName string
+
+func (r wukongCollectionName) WuKongCollectionName() string {
+	return string(r)
+}
+
+// WuKongCollectionDesc
+type WuKongCollectionDesc interface {
+	WuKongCollectionDesc() string
+}
+
+func NewWuKongCollectionDesc(v string) (WuKongCollectionDesc, error) {
+	v = utils.XSSFilter(v)
+
+	if utils.StrLen(v) > wukongCollectionDescMaxLen {
+		return nil, errors.New("invalid collection desc")
+	}
+
+	return wukongCollectionDesc(v), nil
+}
+
+type wukongCollectionDesc string
+
+func (r wukongCollectionDesc) WuKongCollectionDesc() string {
+	return string(r)
+}
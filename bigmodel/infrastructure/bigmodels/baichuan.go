@@ -10,7 +10,7 @@ import (
 )
 
 type baichuanInfo struct {
-	endpoints chan string
+	endpoints *endpointPool
 }
 
 type baichuanRequest struct {
@@ -34,28 +34,18 @@ func (req baichuanResponse) getText() string {
 	return req.Result[0].TextGenerationText[0]
 }
 
-func newBaiChuanInfo(cfg *Config) (info baichuanInfo, err error) {
+func newBaiChuanInfo(cfg *Config, r *endpointRegistry) (info baichuanInfo, err error) {
 
 	ce := &cfg.Endpoints
 	es, _ := ce.parse(ce.BaiChuan)
 
 	// init endpoints
-	info.endpoints = make(chan string, len(es))
-	for _, e := range es {
-		info.endpoints <- e
-	}
+	info.endpoints = r.newPool(poolBaiChuan, es)
 
 	return
 }
 
 func (s *service) BaiChuan(input *domain.BaiChuanInput) (code, r string, err error) {
-	// input check
-	if err = s.check.check(input.Text.BaiChuanText()); err != nil {
-		code = CodeInputTextAuditError
-
-		return
-	}
-
 	// call bigmodel baichuan
 	var resp baichuanResponse
 	f := func(e string) (err error) {
@@ -76,12 +66,6 @@ func (s *service) BaiChuan(input *domain.BaiChuanInput) (code, r string, err err
 		return
 	}
 
-	if err = s.check.check(resp.getText()); err != nil {
-		code = CodeOutputTextAuditError
-
-		return
-	}
-
 	return "", resp.getText(), nil
 }
 
@@ -2,17 +2,18 @@ package bigmodels
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"strings"
 )
 
 type Config struct {
-	OBS        OBSConfig   `json:"obs"             required:"true"`
-	Cloud      CloudConfig `json:"cloud"           required:"true"`
-	WuKong     WuKong      `json:"wukong"          required:"true"`
-	Endpoints  Endpoints   `json:"endpoints"       required:"true"`
-	Moderation Moderation  `json:"moderation"      required:"true"`
-	CloudGY    CloudConfig `json:"auth_gy"         required:"true"`
+	OBS       OBSConfig   `json:"obs"             required:"true"`
+	Cloud     CloudConfig `json:"cloud"           required:"true"`
+	WuKong    WuKong      `json:"wukong"          required:"true"`
+	Endpoints Endpoints   `json:"endpoints"       required:"true"`
+	CloudGY   CloudConfig `json:"auth_gy"         required:"true"`
+	Balancer  Balancer    `json:"balancer"`
 
 	MaxPictureSizeToDescribe int64 `json:"max_picture_size_to_describe"`
 	MaxPictureSizeToVQA      int64 `json:"max_picture_size_to_vqa"`
@@ -20,6 +21,7 @@ type Config struct {
 
 func (cfg *Config) SetDefault() {
 	cfg.WuKong.setDefault()
+	cfg.Balancer.setDefault()
 
 	if cfg.MaxPictureSizeToDescribe <= 0 {
 		cfg.MaxPictureSizeToDescribe = 2 << 21
@@ -35,6 +37,10 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if err := cfg.Balancer.validate(); err != nil {
+		return err
+	}
+
 	return cfg.Endpoints.validate()
 }
 
@@ -163,14 +169,6 @@ func (e *Endpoints) parse(s string) ([]string, error) {
 	return v, nil
 }
 
-type Moderation struct {
-	Endpoint    string `json:"endpoint"       required:"true"`
-	AccessKey   string `json:"access_key"     required:"true"`
-	SecretKey   string `json:"secret_key"     required:"true"`
-	IAMEndpoint string `json:"iam_endpoint"   required:"true"`
-	Region      string `json:"region"         required:"true"`
-}
-
 type WuKong struct {
 	WuKongSample
 	CloudConfig
@@ -211,6 +209,64 @@ func (cfg *WuKong) validate() error {
 	return nil
 }
 
+// Balancer configures how the requests are dispatched among the endpoints.
+type Balancer struct {
+	// ProbeInterval is the interval between two health probes of endpoint.
+	// The unit is second.
+	ProbeInterval int `json:"probe_interval"`
+
+	// ProbeTimeout is the timeout of a health probe. The unit is second.
+	ProbeTimeout int `json:"probe_timeout"`
+
+	// FailureThreshold is the number of consecutive failed requests after
+	// which the circuit of endpoint is open.
+	FailureThreshold int `json:"failure_threshold"`
+
+	// OpenDuration is the time during which no request is dispatched to
+	// the endpoint whose circuit is open. After that, a trial request is
+	// dispatched to check whether the endpoint recovers. The unit is second.
+	OpenDuration int `json:"open_duration"`
+
+	// Weights specifies the weight of endpoint which is 1 by default.
+	Weights map[string]int `json:"weights"`
+}
+
+func (cfg *Balancer) setDefault() {
+	if cfg.ProbeInterval <= 0 {
+		cfg.ProbeInterval = 10
+	}
+
+	if cfg.ProbeTimeout <= 0 {
+		cfg.ProbeTimeout = 3
+	}
+
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 3
+	}
+
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = 30
+	}
+}
+
+func (cfg *Balancer) validate() error {
+	for k, v := range cfg.Weights {
+		if v <= 0 {
+			return fmt.Errorf("invalid weight of endpoint: %s", k)
+		}
+	}
+
+	return nil
+}
+
+func (cfg *Balancer) weightOf(url string) int {
+	if v, ok := cfg.Weights[url]; ok {
+		return v
+	}
+
+	return 1
+}
+
 type ApiService struct {
 	TokenExpire string
 }
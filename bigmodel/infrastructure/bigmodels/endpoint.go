@@ -0,0 +1,551 @@
+package bigmodels
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain/bigmodel"
+)
+
+// the waiters check again periodically, because the open circuit of
+// endpoint is closed by time rather than by the release of endpoint.
+const acquireRetryInterval = time.Second
+
+// the names of pools are the same as the keys of endpoints in config
+const (
+	poolWuKong           = "wukong"
+	poolWuKong4IMG       = "wukong_4img"
+	poolWuKongHF         = "wukong_hf"
+	poolWuKongUser       = "wukong_user"
+	poolLuoJia           = "luojia"
+	poolLuoJiaHF         = "luojia_hf"
+	poolBaiChuan         = "baichuan"
+	poolGLM2             = "glm"
+	poolLLAMA2           = "llama"
+	poolSkyWork          = "skywork"
+	poolIFlytekspark     = "iflytekspark"
+	poolIFlyteksparkLong = "iflytekspark_long"
+)
+
+func errorBusy() error {
+	return bigmodel.NewErrorBusySource(errors.New("access overload, please try again later"))
+}
+
+// checkStreamResponse closes the response if the endpoint fails, so that
+// the failure is counted before streaming.
+func checkStreamResponse(resp *http.Response) error {
+	if resp.StatusCode < http.StatusInternalServerError {
+		return nil
+	}
+
+	if err := resp.Body.Close(); err != nil {
+		logrus.Errorf("close response body failed, err:%s", err.Error())
+	}
+
+	return fmt.Errorf("endpoint responds with status %d", resp.StatusCode)
+}
+
+// endpoint
+type endpoint struct {
+	url      string
+	weight   int
+	capacity int
+	draining bool
+
+	inflight int
+	current  int // the current weight of smooth weighted round-robin
+
+	down      bool // the last health probe failed
+	failures  int  // the consecutive failed requests
+	openUntil time.Time
+	trial     bool // the trial request is in flight when the circuit is half open
+
+	requests  int64
+	errors    int64
+	latency   time.Duration
+	lastError string
+	probedAt  time.Time
+}
+
+func (e *endpoint) isOpen(threshold int) bool {
+	return e.failures >= threshold
+}
+
+func (e *endpoint) available(now time.Time, threshold int) bool {
+	if e.draining || e.down || e.inflight >= e.capacity {
+		return false
+	}
+
+	if !e.isOpen(threshold) {
+		return true
+	}
+
+	// only one trial request is allowed when the circuit is half open
+	return !now.Before(e.openUntil) && !e.trial
+}
+
+func (e *endpoint) state(now time.Time, threshold int) string {
+	switch {
+	case e.draining:
+		return bigmodel.EndpointStateDraining
+
+	case e.down:
+		return bigmodel.EndpointStateDown
+
+	case e.isOpen(threshold) && now.Before(e.openUntil):
+		return bigmodel.EndpointStateOpen
+
+	default:
+		return bigmodel.EndpointStateUp
+	}
+}
+
+func (e *endpoint) stat(now time.Time, threshold int) bigmodel.EndpointStat {
+	v := bigmodel.EndpointStat{
+		URL:       e.url,
+		State:     e.state(now, threshold),
+		Weight:    e.weight,
+		Capacity:  e.capacity,
+		InFlight:  e.inflight,
+		Requests:  e.requests,
+		Failures:  e.errors,
+		Latency:   e.latency.Milliseconds(),
+		LastError: e.lastError,
+	}
+
+	if !e.probedAt.IsZero() {
+		v.ProbedAt = e.probedAt.Unix()
+	}
+
+	return v
+}
+
+// endpointPool dispatches the requests among the endpoints by weight,
+// and skips the ones which are drained, down or whose circuit is open.
+type endpointPool struct {
+	name string
+	cfg  *Balancer
+
+	lock      sync.Mutex
+	endpoints []*endpoint
+
+	// released is closed and replaced when an endpoint is released
+	released chan struct{}
+}
+
+func newEndpointPool(name string, es []string, cfg *Balancer) *endpointPool {
+	p := &endpointPool{
+		name:     name,
+		cfg:      cfg,
+		released: make(chan struct{}),
+	}
+
+	// the same url may be configured more than once to serve requests at once
+	for _, url := range es {
+		if e := p.find(url); e != nil {
+			e.capacity++
+		} else {
+			p.endpoints = append(p.endpoints, &endpoint{
+				url:      url,
+				weight:   cfg.weightOf(url),
+				capacity: 1,
+			})
+		}
+	}
+
+	return p
+}
+
+func (p *endpointPool) find(url string) *endpoint {
+	for _, e := range p.endpoints {
+		if e.url == url {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// acquire returns an idle endpoint. It waits at most the specified time
+// if there is no idle endpoint, and returns the busy error immediately if
+// the time is 0.
+func (p *endpointPool) acquire(wait time.Duration) (*endpoint, error) {
+	var timeout <-chan time.Time
+	if wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+
+		timeout = t.C
+	}
+
+	for {
+		p.lock.Lock()
+		e := p.pick(time.Now())
+		released := p.released
+		p.lock.Unlock()
+
+		if e != nil {
+			return e, nil
+		}
+
+		if timeout == nil {
+			return nil, errorBusy()
+		}
+
+		select {
+		case <-released:
+		case <-time.After(acquireRetryInterval):
+		case <-timeout:
+			return nil, errorBusy()
+		}
+	}
+}
+
+// pick selects the endpoint by the smooth weighted round-robin.
+func (p *endpointPool) pick(now time.Time) *endpoint {
+	var (
+		best  *endpoint
+		total int
+	)
+
+	threshold := p.cfg.FailureThreshold
+
+	for _, e := range p.endpoints {
+		if !e.available(now, threshold) {
+			continue
+		}
+
+		e.current += e.weight
+		total += e.weight
+
+		if best == nil || e.current > best.current {
+			best = e
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	best.current -= total
+	best.inflight++
+
+	if best.isOpen(threshold) {
+		best.trial = true
+	}
+
+	return best
+}
+
+// release returns the endpoint and records the result of request.
+func (p *endpointPool) release(e *endpoint, err error, duration time.Duration) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e.inflight--
+	e.trial = false
+	e.requests++
+
+	if e.latency == 0 {
+		e.latency = duration
+	} else {
+		// the moving average which weights the recent requests more
+		e.latency += (duration - e.latency) / 5
+	}
+
+	if err == nil {
+		if e.isOpen(p.cfg.FailureThreshold) {
+			logrus.Infof("the circuit of endpoint(%s) of %s is closed", e.url, p.name)
+		}
+
+		e.failures = 0
+	} else {
+		e.errors++
+		e.failures++
+		e.lastError = err.Error()
+
+		if e.isOpen(p.cfg.FailureThreshold) {
+			e.openUntil = time.Now().Add(time.Duration(p.cfg.OpenDuration) * time.Second)
+
+			logrus.Warnf(
+				"the circuit of endpoint(%s) of %s is open, err:%s",
+				e.url, p.name, e.lastError,
+			)
+		}
+	}
+
+	p.notify()
+}
+
+// notify wakes up the waiters, it must be called with the lock held.
+func (p *endpointPool) notify() {
+	close(p.released)
+	p.released = make(chan struct{})
+}
+
+// idle returns the number of requests which can be served at once.
+func (p *endpointPool) idle() int {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	n := 0
+	now := time.Now()
+	for _, e := range p.endpoints {
+		if e.available(now, p.cfg.FailureThreshold) {
+			n += e.capacity - e.inflight
+		}
+	}
+
+	return n
+}
+
+func (p *endpointPool) stats() bigmodel.EndpointPool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := bigmodel.EndpointPool{
+		Name:      p.name,
+		Endpoints: make([]bigmodel.EndpointStat, len(p.endpoints)),
+	}
+
+	now := time.Now()
+	for i, e := range p.endpoints {
+		v.Endpoints[i] = e.stat(now, p.cfg.FailureThreshold)
+	}
+
+	return v
+}
+
+func (p *endpointPool) add(opt *bigmodel.EndpointOption) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.find(opt.URL) != nil {
+		return bigmodel.NewErrorEndpointExists(
+			fmt.Errorf("endpoint(%s) exists in %s", opt.URL, p.name),
+		)
+	}
+
+	e := &endpoint{
+		url:      opt.URL,
+		weight:   opt.Weight,
+		capacity: opt.Capacity,
+	}
+
+	if e.weight <= 0 {
+		e.weight = p.cfg.weightOf(e.url)
+	}
+
+	if e.capacity <= 0 {
+		e.capacity = 1
+	}
+
+	p.endpoints = append(p.endpoints, e)
+
+	p.notify()
+
+	return nil
+}
+
+func (p *endpointPool) drain(url string, drain bool) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	e := p.find(url)
+	if e == nil {
+		return p.errorNotFound(url)
+	}
+
+	e.draining = drain
+
+	if !drain {
+		p.notify()
+	}
+
+	return nil
+}
+
+// remove deletes the endpoint, the requests in flight still release it.
+func (p *endpointPool) remove(url string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	for i, e := range p.endpoints {
+		if e.url == url {
+			p.endpoints = append(p.endpoints[:i], p.endpoints[i+1:]...)
+
+			return nil
+		}
+	}
+
+	return p.errorNotFound(url)
+}
+
+func (p *endpointPool) errorNotFound(url string) error {
+	return bigmodel.NewErrorEndpointNotFound(
+		fmt.Errorf("endpoint(%s) is not found in %s", url, p.name),
+	)
+}
+
+func (p *endpointPool) probe(hc *http.Client) {
+	p.lock.Lock()
+	es := make([]*endpoint, len(p.endpoints))
+	copy(es, p.endpoints)
+	p.lock.Unlock()
+
+	results := make([]error, len(es))
+
+	var wg sync.WaitGroup
+	for i := range es {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			results[i] = probeEndpoint(hc, es[i].url)
+		}(i)
+	}
+
+	wg.Wait()
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	now := time.Now()
+	recovered := false
+
+	for i, e := range es {
+		down := results[i] != nil
+
+		if down && !e.down {
+			logrus.Warnf(
+				"endpoint(%s) of %s is down, err:%s",
+				e.url, p.name, results[i].Error(),
+			)
+		}
+
+		if !down && e.down {
+			logrus.Infof("endpoint(%s) of %s is up", e.url, p.name)
+
+			recovered = true
+		}
+
+		e.down = down
+		e.probedAt = now
+	}
+
+	if recovered {
+		p.notify()
+	}
+}
+
+// probeEndpoint only checks whether the endpoint responds, because the
+// endpoints of models don't provide the same api for health check.
+func probeEndpoint(hc *http.Client, url string) error {
+	resp, err := hc.Get(url)
+	if err != nil {
+		return err
+	}
+
+	return resp.Body.Close()
+}
+
+// endpointRegistry
+type endpointRegistry struct {
+	cfg   *Balancer
+	hc    *http.Client
+	pools map[string]*endpointPool
+}
+
+func newEndpointRegistry(cfg *Balancer) *endpointRegistry {
+	return &endpointRegistry{
+		cfg: cfg,
+		hc: &http.Client{
+			Timeout: time.Duration(cfg.ProbeTimeout) * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // #nosec G402 -- the same as the requests to models
+			},
+		},
+		pools: map[string]*endpointPool{},
+	}
+}
+
+func (r *endpointRegistry) newPool(name string, es []string) *endpointPool {
+	p := newEndpointPool(name, es, r.cfg)
+	r.pools[name] = p
+
+	return p
+}
+
+// run probes the endpoints periodically. It must be called after all the
+// pools are created.
+func (r *endpointRegistry) run() {
+	interval := time.Duration(r.cfg.ProbeInterval) * time.Second
+
+	go func() {
+		for {
+			for _, p := range r.pools {
+				p.probe(r.hc)
+			}
+
+			time.Sleep(interval)
+		}
+	}()
+}
+
+func (r *endpointRegistry) pool(name string) (*endpointPool, error) {
+	if p, ok := r.pools[name]; ok {
+		return p, nil
+	}
+
+	return nil, bigmodel.NewErrorEndpointNotFound(
+		fmt.Errorf("no endpoint pool of %s", name),
+	)
+}
+
+func (r *endpointRegistry) ListEndpoints() []bigmodel.EndpointPool {
+	v := make([]bigmodel.EndpointPool, 0, len(r.pools))
+	for _, p := range r.pools {
+		v = append(v, p.stats())
+	}
+
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Name < v[j].Name
+	})
+
+	return v
+}
+
+func (r *endpointRegistry) AddEndpoint(pool string, opt *bigmodel.EndpointOption) error {
+	p, err := r.pool(pool)
+	if err != nil {
+		return err
+	}
+
+	return p.add(opt)
+}
+
+func (r *endpointRegistry) DrainEndpoint(pool, url string, drain bool) error {
+	p, err := r.pool(pool)
+	if err != nil {
+		return err
+	}
+
+	return p.drain(url, drain)
+}
+
+func (r *endpointRegistry) RemoveEndpoint(pool, url string) error {
+	p, err := r.pool(pool)
+	if err != nil {
+		return err
+	}
+
+	return p.remove(url)
+}
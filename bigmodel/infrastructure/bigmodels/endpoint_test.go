@@ -0,0 +1,153 @@
+package bigmodels
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain/bigmodel"
+)
+
+func testBalancer(weights map[string]int) *Balancer {
+	cfg := &Balancer{Weights: weights}
+	cfg.setDefault()
+
+	return cfg
+}
+
+func TestEndpointPoolPickByWeight(t *testing.T) {
+	cases := []struct {
+		name    string
+		es      []string
+		weights map[string]int
+		picks   int
+		want    map[string]int
+	}{
+		{
+			name:  "default weight",
+			es:    []string{"a", "b"},
+			picks: 4,
+			want:  map[string]int{"a": 2, "b": 2},
+		},
+		{
+			name:    "weighted",
+			es:      []string{"a", "b", "c"},
+			weights: map[string]int{"a": 3, "b": 2},
+			picks:   12,
+			want:    map[string]int{"a": 6, "b": 4, "c": 2},
+		},
+		{
+			name:    "same url more than once",
+			es:      []string{"a", "a", "b"},
+			weights: map[string]int{"b": 3},
+			picks:   8,
+			want:    map[string]int{"a": 2, "b": 6},
+		},
+	}
+
+	for _, c := range cases {
+		p := newEndpointPool("test", c.es, testBalancer(c.weights))
+
+		got := map[string]int{}
+		for i := 0; i < c.picks; i++ {
+			e := p.pick(time.Now())
+			if e == nil {
+				t.Fatalf("%s: pick() = nil", c.name)
+			}
+
+			got[e.url]++
+			p.release(e, nil, time.Millisecond)
+		}
+
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: picked %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestEndpointPoolPickSkips(t *testing.T) {
+	cases := []struct {
+		name   string
+		update func(e *endpoint)
+	}{
+		{"draining", func(e *endpoint) { e.draining = true }},
+		{"down", func(e *endpoint) { e.down = true }},
+		{"full", func(e *endpoint) { e.inflight = e.capacity }},
+		{"circuit open", func(e *endpoint) {
+			e.failures = 3
+			e.openUntil = time.Now().Add(time.Minute)
+		}},
+	}
+
+	for _, c := range cases {
+		p := newEndpointPool("test", []string{"a", "b"}, testBalancer(nil))
+		c.update(p.find("a"))
+
+		for i := 0; i < 3; i++ {
+			e := p.pick(time.Now())
+			if e == nil || e.url != "b" {
+				t.Errorf("%s: pick() = %v, want b", c.name, e)
+
+				break
+			}
+
+			p.release(e, nil, time.Millisecond)
+		}
+	}
+}
+
+func TestEndpointCircuitBreaker(t *testing.T) {
+	failed := errors.New("failed")
+
+	cfg := testBalancer(nil)
+	open := time.Duration(cfg.OpenDuration+1) * time.Second
+
+	cases := []struct {
+		name string
+
+		// failures is the num of consecutive failed requests
+		failures int
+
+		// elapsed is the time after the last failure when the trial is picked
+		elapsed time.Duration
+
+		trial error
+		state string
+	}{
+		{"below threshold", cfg.FailureThreshold - 1, 0, nil, bigmodel.EndpointStateUp},
+		{"open", cfg.FailureThreshold, 0, nil, bigmodel.EndpointStateOpen},
+		{"closed by trial", cfg.FailureThreshold, open, nil, bigmodel.EndpointStateUp},
+		{"reopened by trial", cfg.FailureThreshold, open, failed, bigmodel.EndpointStateOpen},
+	}
+
+	for _, c := range cases {
+		p := newEndpointPool("test", []string{"a", "a"}, cfg)
+
+		for i := 0; i < c.failures; i++ {
+			p.release(p.pick(time.Now()), failed, time.Millisecond)
+		}
+
+		now := time.Now().Add(c.elapsed)
+
+		if c.elapsed > 0 {
+			e := p.pick(now)
+			if e == nil {
+				t.Errorf("%s: pick() = nil, want the trial", c.name)
+
+				continue
+			}
+
+			// only one trial is allowed though the endpoint has capacity
+			if v := p.pick(now); v != nil {
+				t.Errorf("%s: pick() during the trial = %s, want nil", c.name, v.url)
+			}
+
+			p.release(e, c.trial, time.Millisecond)
+		}
+
+		if v := p.find("a").state(time.Now(), cfg.FailureThreshold); v != c.state {
+			t.Errorf("%s: state = %s, want %s", c.name, v, c.state)
+		}
+	}
+}
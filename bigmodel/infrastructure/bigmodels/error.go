@@ -3,8 +3,6 @@ package bigmodels
 import "errors"
 
 const (
-	CodeInputTextAuditError     = "code_input_text_audit_error"
-	CodeOutputTextAuditError    = "code_output_text_audit_error"
 	CodeBaiChuanGenerationError = "code_baichuan_generation_error"
 )
 
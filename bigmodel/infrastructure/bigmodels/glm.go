@@ -8,14 +8,11 @@ import (
 	"strings"
 
 	libutils "github.com/opensourceways/community-robot-lib/utils"
-	"github.com/sirupsen/logrus"
 
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 )
 
 const (
-	skipStepGLM = 5
-
 	doneStatusGLM      = "DONE"
 	replaceResponseGLM = "data: "
 )
@@ -38,32 +35,23 @@ type glm2Response struct {
 }
 
 type glm2Info struct {
-	endpoints chan string
+	endpoints *endpointPool
 }
 
-func newGLM2Info(cfg *Config) (info glm2Info, err error) {
+func newGLM2Info(cfg *Config, r *endpointRegistry) (info glm2Info, err error) {
 	ce := &cfg.Endpoints
 	es, _ := ce.parse(ce.GLM2)
 
 	// init endpoints
-	info.endpoints = make(chan string, len(es))
-	for _, e := range es {
-		info.endpoints <- e
-	}
+	info.endpoints = r.newPool(poolGLM2, es)
 
 	return
 }
 
 func (s *service) GLM2(ch chan string, input *domain.GLM2Input) (err error) {
-	// input audit
-	if err = s.check.check(input.Text.GLM2Text()); err != nil {
-		logrus.Debugf("content audit not pass: %s", err.Error())
-		return
-	}
-
 	// call bigmodel glm2
-	f := func(ec chan string, e string) (err error) {
-		err = s.genGLM2(ec, ch, e, input)
+	f := func(done func(error), e string) (err error) {
+		err = s.genGLM2(done, ch, e, input)
 
 		return
 	}
@@ -75,7 +63,7 @@ func (s *service) GLM2(ch chan string, input *domain.GLM2Input) (err error) {
 	return
 }
 
-func (s *service) genGLM2(ec, ch chan string, endpoint string, input *domain.GLM2Input) (
+func (s *service) genGLM2(done func(error), ch chan string, endpoint string, input *domain.GLM2Input) (
 	err error,
 ) {
 	t, err := genToken(&s.wukongInfo.cfg.CloudConfig)
@@ -106,20 +94,21 @@ func (s *service) genGLM2(ec, ch chan string, endpoint string, input *domain.GLM
 		return
 	}
 
+	if err = checkStreamResponse(resp); err != nil {
+		return
+	}
+
 	reader := bufio.NewReader(resp.Body)
 
-	var (
-		r     glm2Response
-		count int
-	)
+	var r glm2Response
 	go func() {
 		defer close(ch)
-		defer func() { ec <- endpoint }()
+		defer done(nil)
 		defer resp.Body.Close()
 
 		for {
 			line, err := reader.ReadString('\n')
-			if count != 1 && err != nil {
+			if err != nil {
 				ch <- "done"
 				return
 			}
@@ -136,19 +125,7 @@ func (s *service) genGLM2(ec, ch chan string, endpoint string, input *domain.GLM
 				return
 			}
 
-			if r.Reply != "" && count > skipStepGLM {
-				count = 0
-
-				if err = s.check.check(r.Reply); err != nil {
-					logrus.Debugf("content audit not pass: %s", err.Error())
-					ch <- "done"
-
-					return
-				}
-			}
-
 			ch <- r.Reply
-			count += 1
 		}
 	}()
 
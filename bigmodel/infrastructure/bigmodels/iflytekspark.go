@@ -9,14 +9,11 @@ import (
 	"unicode/utf8"
 
 	libutils "github.com/opensourceways/community-robot-lib/utils"
-	"github.com/sirupsen/logrus"
 
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 )
 
 const (
-	skipStepFly = 5
-
 	doneStatusFly = "DONE"
 	lenThreshold  = 500
 )
@@ -39,11 +36,11 @@ type iflyteksparkResponse struct {
 type iflyteksparkInfo struct {
 	auth CloudConfig
 
-	endpoints     chan string
-	endpointsLong chan string
+	endpoints     *endpointPool
+	endpointsLong *endpointPool
 }
 
-func newiflyteksparkInfo(cfg *Config) (info iflyteksparkInfo, err error) {
+func newiflyteksparkInfo(cfg *Config, r *endpointRegistry) (info iflyteksparkInfo, err error) {
 	ce := &cfg.Endpoints
 	es, err := ce.parse(ce.IFlytekspark)
 	if err != nil {
@@ -57,28 +54,16 @@ func newiflyteksparkInfo(cfg *Config) (info iflyteksparkInfo, err error) {
 	info.auth = cfg.CloudGY
 
 	// init endpoints
-	info.endpoints = make(chan string, len(es))
-	for _, e := range es {
-		info.endpoints <- e
-	}
-
-	info.endpointsLong = make(chan string, len(esLong))
-	for _, e := range esLong {
-		info.endpointsLong <- e
-	}
+	info.endpoints = r.newPool(poolIFlytekspark, es)
+	info.endpointsLong = r.newPool(poolIFlyteksparkLong, esLong)
 
 	return
 }
 
 func (s *service) IFlytekSpark(ch chan string, input *domain.IFlytekSparkInput) (err error) {
-	// input audit
-	if err = s.check.check(input.Text.IFlytekSparkText()); err != nil {
-		return
-	}
-
 	// call bigmodel iflytekspark
-	f := func(ec chan string, e string) (err error) {
-		err = s.geniflytekspark(ec, ch, e, input)
+	f := func(done func(error), e string) (err error) {
+		err = s.geniflytekspark(done, ch, e, input)
 
 		return
 	}
@@ -93,7 +78,7 @@ func (s *service) IFlytekSpark(ch chan string, input *domain.IFlytekSparkInput)
 	return
 }
 
-func (s *service) geniflytekspark(ec, ch chan string, endpoint string, input *domain.IFlytekSparkInput) (
+func (s *service) geniflytekspark(done func(error), ch chan string, endpoint string, input *domain.IFlytekSparkInput) (
 	err error,
 ) {
 	t, err := genToken(&s.iflyteksparkInfo.auth)
@@ -124,22 +109,23 @@ func (s *service) geniflytekspark(ec, ch chan string, endpoint string, input *do
 		return
 	}
 
+	if err = checkStreamResponse(resp); err != nil {
+		return
+	}
+
 	reader := bufio.NewReader(resp.Body)
 
-	var (
-		r     iflyteksparkResponse
-		count int
-	)
+	var r iflyteksparkResponse
 
 	go func() {
 		defer close(ch)
-		defer func() { ec <- endpoint }()
+		defer done(nil)
 		defer resp.Body.Close()
 
 		for {
 			line, err := reader.ReadString('\n')
 
-			if count != 1 && err != nil {
+			if err != nil {
 				ch <- "done"
 
 				return
@@ -158,19 +144,7 @@ func (s *service) geniflytekspark(ec, ch chan string, endpoint string, input *do
 				return
 			}
 
-			if r.Reply != "" && count > skipStepFly {
-				count = 0
-
-				if err = s.check.check(r.Reply); err != nil {
-					logrus.Debugf("content audit not pass: %s", err.Error())
-					ch <- "done"
-
-					return
-				}
-			}
-
 			ch <- r.Reply
-			count += 1
 		}
 	}()
 
@@ -14,8 +14,6 @@ import (
 )
 
 const (
-	skipStepLlama = 10
-
 	doneStatusLlama      = "DONE"
 	replaceResponseLlama = "data: "
 )
@@ -38,32 +36,23 @@ type llama2Response struct {
 }
 
 type llama2Info struct {
-	endpoints chan string
+	endpoints *endpointPool
 }
 
-func newLLAMA2Info(cfg *Config) (info llama2Info, err error) {
+func newLLAMA2Info(cfg *Config, r *endpointRegistry) (info llama2Info, err error) {
 	ce := &cfg.Endpoints
 	es, _ := ce.parse(ce.LLAMA2)
 
 	// init endpoints
-	info.endpoints = make(chan string, len(es))
-	for _, e := range es {
-		info.endpoints <- e
-	}
+	info.endpoints = r.newPool(poolLLAMA2, es)
 
 	return
 }
 
 func (s *service) LLAMA2(ch chan string, input *domain.LLAMA2Input) (err error) {
-	// input audit
-	if err = s.check.check(input.Text.LLAMA2Text()); err != nil {
-		logrus.Debugf("content audit not pass: %s", err.Error())
-		return
-	}
-
 	// call bigmodel llama2
-	f := func(ec chan string, e string) (err error) {
-		err = s.genllama2(ec, ch, e, input)
+	f := func(done func(error), e string) (err error) {
+		err = s.genllama2(done, ch, e, input)
 
 		return
 	}
@@ -75,7 +64,7 @@ func (s *service) LLAMA2(ch chan string, input *domain.LLAMA2Input) (err error)
 	return
 }
 
-func (s *service) genllama2(ec, ch chan string, endpoint string, input *domain.LLAMA2Input) (
+func (s *service) genllama2(done func(error), ch chan string, endpoint string, input *domain.LLAMA2Input) (
 	err error,
 ) {
 	t, err := genToken(&s.wukongInfo.cfg.CloudConfig)
@@ -106,14 +95,15 @@ func (s *service) genllama2(ec, ch chan string, endpoint string, input *domain.L
 		return
 	}
 
+	if err = checkStreamResponse(resp); err != nil {
+		return
+	}
+
 	reader := bufio.NewReader(resp.Body)
 
-	var (
-		r     llama2Response
-		count int
-	)
+	var r llama2Response
 	go func() {
-		defer func() { ec <- endpoint }()
+		defer done(nil)
 		defer resp.Body.Close()
 		defer close(ch)
 
@@ -140,20 +130,7 @@ func (s *service) genllama2(ec, ch chan string, endpoint string, input *domain.L
 				return
 			}
 
-			if r.Reply != "" && count > skipStepLlama {
-				count = 0
-
-				if err = s.check.check(r.Reply); err != nil {
-					logrus.Debugf("content audit not pass: %s", err.Error())
-
-					ch <- "done"
-
-					return
-				}
-			}
-
 			ch <- r.Reply
-			count += 1
 		}
 
 	}()
@@ -12,27 +12,19 @@ import (
 
 type luojiaInfo struct {
 	bucket     string
-	endpoints  chan string
-	endpointHF chan string
+	endpoints  *endpointPool
+	endpointHF *endpointPool
 }
 
-func newLuoJiaInfo(cfg *Config) luojiaInfo {
+func newLuoJiaInfo(cfg *Config, r *endpointRegistry) luojiaInfo {
 	ce := &cfg.Endpoints
 
 	es, _ := ce.parse(ce.LuoJia)
 	eshf, _ := ce.parse(ce.LuoJiaHF)
 
 	v := luojiaInfo{
-		endpoints:  make(chan string, len(es)),
-		endpointHF: make(chan string, len(eshf)),
-	}
-
-	for _, e := range es {
-		v.endpoints <- e
-	}
-
-	for _, e := range eshf {
-		v.endpointHF <- e
+		endpoints:  r.newPool(poolLuoJia, es),
+		endpointHF: r.newPool(poolLuoJiaHF, eshf),
 	}
 
 	v.bucket = cfg.OBS.LuoJiaBucket
@@ -25,13 +25,11 @@ func Init(cfg *Config) error {
 		return err
 	}
 
-	check := initTextCheck(&cfg.Moderation)
-
 	fm = &service{
-		obs:   obs,
-		check: check,
-		cfg:   cfg.Cloud,
-		hc:    utils.NewHttpClient(3),
+		obs:      obs,
+		cfg:      cfg.Cloud,
+		hc:       utils.NewHttpClient(3),
+		registry: newEndpointRegistry(&cfg.Balancer),
 	}
 
 	http.DefaultClient.Transport = &http.Transport{
@@ -39,32 +37,34 @@ func Init(cfg *Config) error {
 	}
 
 	fm.vqaInfo = newVQAInfo(cfg)
-	fm.luojiaInfo = newLuoJiaInfo(cfg)
+	fm.luojiaInfo = newLuoJiaInfo(cfg, fm.registry)
 	fm.aiDetectorInfo = newAIDetectorInfo(cfg)
-	if fm.wukongInfo, err = newWuKongInfo(cfg); err != nil {
+	if fm.wukongInfo, err = newWuKongInfo(cfg, fm.registry); err != nil {
 		return err
 	}
 
-	if fm.baichuanInfo, err = newBaiChuanInfo(cfg); err != nil {
+	if fm.baichuanInfo, err = newBaiChuanInfo(cfg, fm.registry); err != nil {
 		return err
 	}
 
-	if fm.glm2Info, err = newGLM2Info(cfg); err != nil {
+	if fm.glm2Info, err = newGLM2Info(cfg, fm.registry); err != nil {
 		return err
 	}
 
-	if fm.llama2Info, err = newLLAMA2Info(cfg); err != nil {
+	if fm.llama2Info, err = newLLAMA2Info(cfg, fm.registry); err != nil {
 		return err
 	}
 
-	if fm.skyWorkInfo, err = newSkyWorkInfo(cfg); err != nil {
+	if fm.skyWorkInfo, err = newSkyWorkInfo(cfg, fm.registry); err != nil {
 		return err
 	}
 
-	if fm.iflyteksparkInfo, err = newiflyteksparkInfo(cfg); err != nil {
+	if fm.iflyteksparkInfo, err = newiflyteksparkInfo(cfg, fm.registry); err != nil {
 		return err
 	}
 
+	fm.registry.run()
+
 	return err
 }
 
@@ -72,13 +72,18 @@ func NewBigModelService() bigmodel.BigModel {
 	return fm
 }
 
+func NewEndpointRegistry() bigmodel.EndpointRegistry {
+	return fm.registry
+}
+
 type service struct {
-	cfg   CloudConfig
-	obs   obsService
-	check textCheckService
+	cfg CloudConfig
+	obs obsService
 
 	hc utils.HttpClient
 
+	registry *endpointRegistry
+
 	vqaInfo          vqaInfo
 	wukongInfo       wukongInfo
 	luojiaInfo       luojiaInfo
@@ -143,40 +148,51 @@ func genToken(cfg *CloudConfig) (string, error) {
 }
 
 func (s *service) doIfFree(
-	ec chan string,
+	p *endpointPool,
 	f func(string) error,
 ) error {
-	select {
-	case e := <-ec:
-		err := f(e)
-		ec <- e
-
+	e, err := p.acquire(0)
+	if err != nil {
 		return err
-
-	default:
-		return bigmodel.NewErrorBusySource(errors.New("access overload, please try again later"))
 	}
+
+	start := time.Now()
+	err = f(e.url)
+	p.release(e, err, time.Since(start))
+
+	return err
 }
 
+// doWaitAndEndpointNotReturned waits for an idle endpoint, and the endpoint
+// is released by calling done when the streaming response is finished.
 func (s *service) doWaitAndEndpointNotReturned(
-	ec chan string,
-	f func(chan string, string) error,
+	p *endpointPool,
+	f func(done func(error), endpoint string) error,
 ) error {
-	select {
-	case e := <-ec:
-		err := f(ec, e)
+	e, err := p.acquire(waitTime)
+	if err != nil {
 		return err
-	case <-time.After(waitTime):
-		return bigmodel.NewErrorBusySource(errors.New("access overload, please try again later"))
 	}
+
+	start := time.Now()
+	done := func(err error) {
+		p.release(e, err, time.Since(start))
+	}
+
+	// the endpoint is not returned if it fails before streaming
+	if err = f(done, e.url); err != nil {
+		done(err)
+	}
+
+	return err
 }
 
 func (s *service) GetIdleEndpoint(bid string) (int, error) {
 	switch bid {
 	case "wukong":
-		return len(s.wukongInfo.endpoints), nil
+		return s.wukongInfo.endpoints.idle(), nil
 	case "wukong_4img":
-		return len(s.wukongInfo.endpoints4), nil
+		return s.wukongInfo.endpoints4.idle(), nil
 	default:
 		return 0, errors.New("internal error, cannot found this bigmodel")
 	}
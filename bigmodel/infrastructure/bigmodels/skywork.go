@@ -31,31 +31,23 @@ type skyWorkResponse struct {
 }
 
 type skyWorkInfo struct {
-	endpoints chan string
+	endpoints *endpointPool
 }
 
-func newSkyWorkInfo(cfg *Config) (info skyWorkInfo, err error) {
+func newSkyWorkInfo(cfg *Config, r *endpointRegistry) (info skyWorkInfo, err error) {
 	ce := &cfg.Endpoints
 	es, _ := ce.parse(ce.SkyWork)
 
 	// init endpoints
-	info.endpoints = make(chan string, len(es))
-	for _, e := range es {
-		info.endpoints <- e
-	}
+	info.endpoints = r.newPool(poolSkyWork, es)
 
 	return
 }
 
 func (s *service) SkyWork(ch chan string, input *domain.SkyWorkInput) (err error) {
-	// input audit
-	if err = s.check.check(input.Text.SkyWorkText()); err != nil {
-		return
-	}
-
 	// call bigmodel skywork 13b
-	f := func(ec chan string, e string) (err error) {
-		err = s.genSkyWork(ec, ch, e, input)
+	f := func(done func(error), e string) (err error) {
+		err = s.genSkyWork(done, ch, e, input)
 
 		return
 	}
@@ -67,7 +59,7 @@ func (s *service) SkyWork(ch chan string, input *domain.SkyWorkInput) (err error
 	return
 }
 
-func (s *service) genSkyWork(ec, ch chan string, endpoint string, input *domain.SkyWorkInput) (
+func (s *service) genSkyWork(done func(error), ch chan string, endpoint string, input *domain.SkyWorkInput) (
 	err error,
 ) {
 	t, err := genToken(&s.wukongInfo.cfg.CloudConfig)
@@ -98,14 +90,16 @@ func (s *service) genSkyWork(ec, ch chan string, endpoint string, input *domain.
 		return
 	}
 
+	if err = checkStreamResponse(resp); err != nil {
+		return
+	}
+
 	reader := bufio.NewReader(resp.Body)
 
-	var (
-		r     skyWorkResponse
-		count int
-	)
+	var r skyWorkResponse
 	go func() {
-		defer func() { ec <- endpoint }()
+		defer close(ch)
+		defer done(nil)
 		defer resp.Body.Close()
 
 		for {
@@ -131,21 +125,7 @@ func (s *service) genSkyWork(ec, ch chan string, endpoint string, input *domain.
 				return
 			}
 
-			// response audit, skip 6 response
-			if r.Reply != "" && count > 6 {
-				count = 0
-
-				if err = s.check.check(r.Reply); err != nil {
-					logrus.Debugf("content audit not pass: %s", err.Error())
-
-					ch <- "done"
-
-					return
-				}
-			}
-
 			ch <- r.Reply
-			count += 1
 		}
 	}()
 
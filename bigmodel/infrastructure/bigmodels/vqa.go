@@ -60,10 +60,6 @@ func newVQAInfo(cfg *Config) vqaInfo {
 }
 
 func (s *service) Ask(q domain.Question, f string) (string, error) {
-	if err := s.check.check(q.Question()); err != nil {
-		return "", err
-	}
-
 	opt := questionOpt{
 		Picture:  filepath.Join("vqa", f),
 		Question: q.Question(),
@@ -21,13 +21,13 @@ type wukongInfo struct {
 	cli           obsService
 	cfg           WuKong
 	maxBatch      int
-	endpoints     chan string
-	endpoints4    chan string
-	endpointsHF   chan string
-	endpointsUser chan string
+	endpoints     *endpointPool
+	endpoints4    *endpointPool
+	endpointsHF   *endpointPool
+	endpointsUser *endpointPool
 }
 
-func newWuKongInfo(cfg *Config) (wukongInfo, error) {
+func newWuKongInfo(cfg *Config, r *endpointRegistry) (wukongInfo, error) {
 	v := &cfg.WuKong
 
 	cli, err := initOBS(&v.OBSAuthInfo)
@@ -48,28 +48,10 @@ func newWuKongInfo(cfg *Config) (wukongInfo, error) {
 	esus, _ := ce.parse(ce.WuKongUser)
 
 	// init endpoints
-	info.endpoints = make(chan string, len(es))
-	for _, e := range es {
-		info.endpoints <- e
-	}
-
-	// init endpoints4
-	info.endpoints4 = make(chan string, len(es4))
-	for _, e := range es4 {
-		info.endpoints4 <- e
-	}
-
-	// init endpoints_hf
-	info.endpointsHF = make(chan string, len(eshf))
-	for _, e := range eshf {
-		info.endpointsHF <- e
-	}
-
-	// init endpoint_user
-	info.endpointsUser = make(chan string, len(esus))
-	for _, e := range esus {
-		info.endpointsUser <- e
-	}
+	info.endpoints = r.newPool(poolWuKong, es)
+	info.endpoints4 = r.newPool(poolWuKong4IMG, es4)
+	info.endpointsHF = r.newPool(poolWuKongHF, eshf)
+	info.endpointsUser = r.newPool(poolWuKongUser, esus)
 
 	return info, nil
 }
@@ -100,10 +82,6 @@ func (s *service) GetWuKongSampleId() string {
 func (s *service) GenPicturesByWuKong(
 	user types.Account, desc *domain.WuKongPictureMeta, estype string,
 ) (map[string]string, error) {
-	if err := s.check.check(desc.Desc.WuKongPictureDesc()); err != nil {
-		return nil, err
-	}
-
 	var v []string
 
 	f := func(e string) (err error) {
@@ -113,7 +91,7 @@ func (s *service) GenPicturesByWuKong(
 	}
 
 	// select endpoints
-	var es chan string
+	var es *endpointPool
 	switch estype {
 	case string(domain.BigmodelWuKong):
 		es = s.wukongInfo.endpoints
@@ -125,6 +103,10 @@ func (s *service) GenPicturesByWuKong(
 		es = s.wukongInfo.endpointsUser
 	}
 
+	if es == nil {
+		return nil, fmt.Errorf("unknown type of wukong: %s", estype)
+	}
+
 	if err := s.doIfFree(es, f); err != nil {
 		return nil, err
 	}
@@ -142,17 +124,6 @@ func (s *service) GenPicturesByWuKong(
 		r[p] = l
 	}
 
-	checkUrls := make([]string, len(r))
-
-	var i int
-	for _, v := range r {
-		checkUrls[i] = v
-		i++
-	}
-	if err := s.check.checkImages(checkUrls); err != nil {
-		return nil, err
-	}
-
 	return r, nil
 }
 
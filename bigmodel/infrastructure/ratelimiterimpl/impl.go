@@ -0,0 +1,99 @@
+package ratelimiterimpl
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain/ratelimiter"
+	coredis "github.com/opensourceways/xihe-server/common/infrastructure/redis"
+)
+
+const (
+	keyPrefix  = "xihe_bigmodel_ratelimit:"
+	timeout    = 3 * time.Second
+	maxRetries = 5
+
+	fieldTokens = "tokens"
+	fieldTs     = "ts"
+)
+
+func NewRateLimiter() ratelimiter.RateLimiter {
+	return rateLimiter{}
+}
+
+type rateLimiter struct{}
+
+// Take takes the tokens in a transaction which fails if the bucket is
+// changed by the others after it is read, so that the replicas share the
+// bucket. It is retried in that case.
+func (l rateLimiter) Take(key string, b *ratelimiter.Bucket, cost int, force bool) (
+	wait int, err error,
+) {
+	if !b.IsValid() {
+		return 0, errors.New("invalid bucket")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	key = keyPrefix + key
+
+	take := func(tx *redis.Tx) error {
+		now := time.Now().Unix()
+
+		s, err := l.get(ctx, tx, key, b, now)
+		if err != nil {
+			return err
+		}
+
+		wait = b.Take(&s, now, cost, force)
+
+		_, err = tx.TxPipelined(ctx, func(p redis.Pipeliner) error {
+			p.HSet(
+				ctx, key,
+				fieldTokens, strconv.FormatFloat(s.Tokens, 'f', -1, 64),
+				fieldTs, s.At,
+			)
+			p.Expire(ctx, key, time.Duration(b.FullIn(&s)+1)*time.Second)
+
+			return nil
+		})
+
+		return err
+	}
+
+	for i := 0; i < maxRetries; i++ {
+		if err = coredis.DB().Watch(ctx, take, key); !errors.Is(err, redis.TxFailedErr) {
+			return
+		}
+	}
+
+	return
+}
+
+func (l rateLimiter) get(
+	ctx context.Context, tx *redis.Tx, key string, b *ratelimiter.Bucket, now int64,
+) (s ratelimiter.BucketState, err error) {
+	v, err := tx.HMGet(ctx, key, fieldTokens, fieldTs).Result()
+	if err != nil {
+		return
+	}
+
+	tokens, ok1 := v[0].(string)
+	ts, ok2 := v[1].(string)
+	if !ok1 || !ok2 {
+		return b.Full(now), nil
+	}
+
+	if s.Tokens, err = strconv.ParseFloat(tokens, 64); err != nil {
+		return
+	}
+
+	s.At, err = strconv.ParseInt(ts, 10, 64)
+
+	return
+}
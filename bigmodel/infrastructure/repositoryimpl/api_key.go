@@ -0,0 +1,205 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+// NewApiKeyRepo keeps the api keys of a user in one doc.
+func NewApiKeyRepo(m mongodbClient) repository.ApiKey {
+	return apiKeyRepoImpl{m}
+}
+
+type apiKeyRepoImpl struct {
+	cli mongodbClient
+}
+
+func apiKeyDocFilter(user string) bson.M {
+	return bson.M{fieldUser: user}
+}
+
+func (impl apiKeyRepoImpl) Add(k *domain.ApiKey) (id string, err error) {
+	id = newId()
+	k.Id = id
+
+	doc, err := genDoc(toApiKeyItem(k))
+	if err != nil {
+		return
+	}
+
+	docFilter := apiKeyDocFilter(k.User.Account())
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, docFilter, bson.M{
+			fieldUser:  k.User.Account(),
+			fieldItems: bson.A{},
+		})
+		if err != nil && !impl.cli.IsDocExists(err) {
+			return err
+		}
+
+		return impl.cli.PushArrayElem(ctx, fieldItems, docFilter, doc)
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func (impl apiKeyRepoImpl) Get(user types.Account, id string) (domain.ApiKey, error) {
+	return impl.getOne(apiKeyDocFilter(user.Account()), bson.M{fieldId: id})
+}
+
+func (impl apiKeyRepoImpl) GetByHash(hash string) (domain.ApiKey, error) {
+	return impl.getOne(
+		bson.M{fieldItems + "." + fieldHash: hash},
+		bson.M{fieldHash: hash},
+	)
+}
+
+func (impl apiKeyRepoImpl) getOne(docFilter, itemFilter bson.M) (r domain.ApiKey, err error) {
+	var v []dApiKey
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetArrayElem(
+			ctx, fieldItems, docFilter, itemFilter,
+			bson.M{
+				fieldUser:  1,
+				fieldItems: 1,
+			},
+			&v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		return
+	}
+
+	if len(v) == 0 || len(v[0].Items) == 0 {
+		err = repoerr.NewErrorResourceNotExists(errDocNotExists)
+	} else {
+		err = v[0].Items[0].toApiKey(v[0].User, &r)
+	}
+
+	return
+}
+
+func (impl apiKeyRepoImpl) List(user types.Account) ([]domain.ApiKey, error) {
+	var v dApiKey
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(
+			ctx, apiKeyDocFilter(user.Account()),
+			bson.M{fieldUser: 1, fieldItems: 1}, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	r := make([]domain.ApiKey, len(v.Items))
+	for i := range v.Items {
+		if err := v.Items[i].toApiKey(v.User, &r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (impl apiKeyRepoImpl) UpdateHash(k *domain.ApiKey) error {
+	return impl.update(k, bson.M{
+		fieldHash:     k.Hash,
+		fieldMask:     k.Mask,
+		fieldExpireAt: k.ExpireAt,
+	})
+}
+
+func (impl apiKeyRepoImpl) UpdateLastUsed(k *domain.ApiKey) error {
+	return impl.update(k, bson.M{fieldLastUsed: k.LastUsedAt})
+}
+
+func (impl apiKeyRepoImpl) update(k *domain.ApiKey, updateCmd bson.M) error {
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.ModifyArrayElem(
+			ctx, fieldItems,
+			apiKeyDocFilter(k.User.Account()),
+			bson.M{fieldId: k.Id},
+			updateCmd, mongoCmdSet,
+		)
+		if err != nil && impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(errDocNotExists)
+		}
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl apiKeyRepoImpl) Delete(user types.Account, id string) error {
+	f := func(ctx context.Context) error {
+		return impl.cli.PullArrayElem(
+			ctx, fieldItems,
+			apiKeyDocFilter(user.Account()),
+			bson.M{fieldId: id},
+		)
+	}
+
+	return withContext(f)
+}
+
+func toApiKeyItem(k *domain.ApiKey) apiKeyItem {
+	scopes := make([]string, len(k.Scopes))
+	for i := range k.Scopes {
+		scopes[i] = k.Scopes[i].ModelName()
+	}
+
+	return apiKeyItem{
+		Id:         k.Id,
+		Name:       k.Name.ApiKeyName(),
+		Scopes:     scopes,
+		Hash:       k.Hash,
+		Mask:       k.Mask,
+		CreatedAt:  k.CreatedAt,
+		ExpireAt:   k.ExpireAt,
+		LastUsedAt: k.LastUsedAt,
+	}
+}
+
+func (item *apiKeyItem) toApiKey(user string, k *domain.ApiKey) (err error) {
+	if k.User, err = types.NewAccount(user); err != nil {
+		return
+	}
+
+	if k.Name, err = domain.NewApiKeyName(item.Name); err != nil {
+		return
+	}
+
+	k.Scopes = make([]domain.ModelName, len(item.Scopes))
+	for i := range item.Scopes {
+		if k.Scopes[i], err = domain.NewModelName(item.Scopes[i]); err != nil {
+			return
+		}
+	}
+
+	k.Id = item.Id
+	k.Hash = item.Hash
+	k.Mask = item.Mask
+	k.CreatedAt = item.CreatedAt
+	k.ExpireAt = item.ExpireAt
+	k.LastUsedAt = item.LastUsedAt
+
+	return
+}
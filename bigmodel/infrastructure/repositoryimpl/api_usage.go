@@ -0,0 +1,89 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// NewApiUsageRepo keeps the usage of a model by a user in a day in one doc.
+func NewApiUsageRepo(m mongodbClient) repository.ApiUsage {
+	return apiUsageRepoImpl{m}
+}
+
+type apiUsageRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl apiUsageRepoImpl) Add(call *domain.ApiCall, date string) error {
+	filter := bson.M{
+		fieldUser:      call.User.Account(),
+		fieldModelName: call.Model.ModelName(),
+		fieldDate:      date,
+	}
+
+	update := bson.M{
+		mongoCmdInc: bson.M{
+			fieldCalls:    1,
+			fieldPrompt:   call.PromptTokens,
+			fieldComplete: call.CompletionTokens,
+		},
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().UpdateOne(
+			ctx, filter, update, options.Update().SetUpsert(true),
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl apiUsageRepoImpl) List(user types.Account, since string) ([]domain.ApiUsage, error) {
+	var v []dApiUsage
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDocs(
+			ctx,
+			bson.M{
+				fieldUser: user.Account(),
+				fieldDate: bson.M{mongoCmdGte: since},
+			},
+			options.Find().SetSort(bson.D{{Key: fieldDate, Value: -1}}),
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.ApiUsage, len(v))
+	for i := range v {
+		if err := v[i].toApiUsage(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (doc *dApiUsage) toApiUsage(u *domain.ApiUsage) (err error) {
+	if u.Model, err = domain.NewModelName(doc.ModelName); err != nil {
+		return
+	}
+
+	u.Date = doc.Date
+	u.Calls = doc.Calls
+	u.PromptTokens = doc.PromptTokens
+	u.CompletionTokens = doc.CompletionTokens
+
+	return
+}
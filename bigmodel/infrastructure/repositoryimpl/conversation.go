@@ -0,0 +1,261 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+// NewConversationRepo keeps each conversation in one doc, because the
+// messages of all the conversations of a user may exceed the size of doc.
+func NewConversationRepo(m mongodbClient) repository.Conversation {
+	return conversationRepoImpl{m}
+}
+
+type conversationRepoImpl struct {
+	cli mongodbClient
+}
+
+func conversationDocFilter(user, id string) bson.M {
+	return bson.M{
+		fieldId:    id,
+		fieldOwner: user,
+	}
+}
+
+func (impl conversationRepoImpl) Add(c *domain.Conversation) (string, error) {
+	doc, err := genDoc(toConversationDoc(c))
+	if err != nil {
+		return "", err
+	}
+
+	id := newId()
+	doc[fieldId] = id
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, bson.M{fieldId: id}, doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (impl conversationRepoImpl) Get(user types.Account, id string) (r domain.Conversation, err error) {
+	var v dConversation
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, conversationDocFilter(user.Account(), id), nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	err = v.toConversation(&r)
+
+	return
+}
+
+func (impl conversationRepoImpl) List(user types.Account) ([]domain.Conversation, error) {
+	var v []dConversation
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDocs(
+			ctx,
+			bson.M{fieldOwner: user.Account()},
+			options.Find().
+				SetProjection(bson.M{fieldMessages: 0}).
+				SetSort(bson.D{{Key: fieldUpdatedAt, Value: -1}}),
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.Conversation, len(v))
+	for i := range v {
+		if err := v[i].toConversation(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (impl conversationRepoImpl) Count(user types.Account) (n int, err error) {
+	f := func(ctx context.Context) error {
+		v, err := impl.cli.Collection().CountDocuments(
+			ctx, bson.M{fieldOwner: user.Account()},
+		)
+		n = int(v)
+
+		return err
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func (impl conversationRepoImpl) UpdateTitle(c *domain.Conversation) error {
+	return impl.update(c, bson.M{
+		mongoCmdSet: bson.M{
+			fieldTitle: c.Title.ConversationTitle(),
+		},
+	})
+}
+
+func (impl conversationRepoImpl) AddMessages(
+	c *domain.Conversation, msgs []domain.ConversationMessage,
+) error {
+	items := make(bson.A, len(msgs))
+	for i := range msgs {
+		item := &msgs[i]
+
+		items[i] = conversationMessage{
+			Role:      item.Role,
+			Content:   item.Content,
+			CreatedAt: item.CreatedAt,
+		}
+	}
+
+	return impl.update(c, bson.M{
+		mongoCmdPush: bson.M{
+			fieldMessages: bson.M{mongoCmdEach: items},
+		},
+		mongoCmdSet: bson.M{
+			fieldUpdatedAt: c.UpdatedAt,
+		},
+	})
+}
+
+func (impl conversationRepoImpl) update(c *domain.Conversation, update bson.M) error {
+	f := func(ctx context.Context) error {
+		r, err := impl.cli.Collection().UpdateOne(
+			ctx, conversationDocFilter(c.Owner.Account(), c.Id), update,
+		)
+		if err == nil && r.MatchedCount == 0 {
+			err = repoerr.NewErrorResourceNotExists(errDocNotExists)
+		}
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl conversationRepoImpl) Delete(user types.Account, id string) error {
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().DeleteOne(
+			ctx, conversationDocFilter(user.Account(), id),
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl conversationRepoImpl) DeleteInactive(since int64) (n int, err error) {
+	f := func(ctx context.Context) error {
+		v, err := impl.cli.Collection().DeleteMany(
+			ctx, bson.M{fieldUpdatedAt: bson.M{mongoCmdLt: since}},
+		)
+		if err == nil {
+			n = int(v.DeletedCount)
+		}
+
+		return err
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func toConversationDoc(c *domain.Conversation) dConversation {
+	doc := dConversation{
+		Owner:     c.Owner.Account(),
+		ModelName: c.Model.ModelName(),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+
+	if c.HasTitle() {
+		doc.Title = c.Title.ConversationTitle()
+	}
+
+	if c.Temperature != nil {
+		v := c.Temperature.Temperature()
+		doc.Temperature = &v
+	}
+
+	if c.TopP != nil {
+		v := c.TopP.TopP()
+		doc.TopP = &v
+	}
+
+	return doc
+}
+
+func (doc *dConversation) toConversation(c *domain.Conversation) (err error) {
+	if c.Owner, err = types.NewAccount(doc.Owner); err != nil {
+		return
+	}
+
+	if c.Title, err = domain.NewConversationTitle(doc.Title); err != nil {
+		return
+	}
+
+	if c.Model, err = domain.NewChatModelName(doc.ModelName); err != nil {
+		return
+	}
+
+	if doc.Temperature != nil {
+		if c.Temperature, err = domain.NewTemperature(*doc.Temperature); err != nil {
+			return
+		}
+	}
+
+	if doc.TopP != nil {
+		if c.TopP, err = domain.NewTopP(*doc.TopP); err != nil {
+			return
+		}
+	}
+
+	c.Id = doc.Id
+	c.CreatedAt = doc.CreatedAt
+	c.UpdatedAt = doc.UpdatedAt
+
+	if len(doc.Messages) > 0 {
+		c.Messages = make([]domain.ConversationMessage, len(doc.Messages))
+		for i := range doc.Messages {
+			item := &doc.Messages[i]
+
+			c.Messages[i] = domain.ConversationMessage{
+				Role:      item.Role,
+				Content:   item.Content,
+				CreatedAt: item.CreatedAt,
+			}
+		}
+	}
+
+	return
+}
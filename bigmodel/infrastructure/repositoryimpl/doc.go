@@ -15,6 +15,26 @@ const (
 	fieldCallCount = "call_count"
 	fieldToken     = "token"
 	fieldUpdateAt  = "update_at"
+	fieldHash      = "hash"
+	fieldMask      = "mask"
+	fieldExpireAt  = "expire_at"
+	fieldLastUsed  = "last_used_at"
+	fieldDate      = "date"
+	fieldCalls     = "calls"
+	fieldPrompt    = "prompt_tokens"
+	fieldComplete  = "completion_tokens"
+	fieldTitle     = "title"
+	fieldMessages  = "messages"
+	fieldUpdatedAt = "updated_at"
+	fieldDesc      = "desc"
+	fieldStyle     = "style"
+	fieldTags      = "tags"
+	fieldLevel     = "level"
+	fieldDiggCount = "digg_count"
+	fieldCreatedAt = "created_at"
+	fieldName      = "name"
+	fieldFeatured  = "featured"
+	fieldPictures  = "pictures"
 )
 
 type DCompetitorInfo struct {
@@ -64,10 +84,27 @@ type pictureItem struct {
 	Level     int      `bson:"level"      json:"level"`
 	Diggs     []string `bson:"diggs"      json:"diggs"`
 	DiggCount int      `bson:"digg_count" json:"digg_count"`
+	Tags      []string `bson:"tags"       json:"tags"`
 	Version   int      `bson:"version"    json:"-"`
 	CreatedAt string   `bson:"created_at" json:"created_at"`
 }
 
+type dWuKongCollection struct {
+	Id        string               `bson:"id"          json:"id"`
+	Name      string               `bson:"name"        json:"name"`
+	Desc      string               `bson:"desc"        json:"desc"`
+	Featured  bool                 `bson:"featured"    json:"featured"`
+	Pictures  []wukongPictureIndex `bson:"pictures"    json:"pictures"`
+	CreatedBy string               `bson:"created_by"  json:"created_by"`
+	CreatedAt int64                `bson:"created_at"  json:"created_at"`
+	UpdatedAt int64                `bson:"updated_at"  json:"updated_at"`
+}
+
+type wukongPictureIndex struct {
+	Owner string `bson:"owner"  json:"owner"`
+	Id    string `bson:"id"     json:"id"`
+}
+
 type dApiApply struct {
 	User      string `bson:"user"        json:"user"`
 	ModelName string `bson:"model_name"  json:"model_name"`
@@ -79,9 +116,52 @@ type dApiApply struct {
 	Version   int    `bson:"version"     json:"-"`
 }
 
+type dApiKey struct {
+	User  string       `bson:"user"   json:"user"`
+	Items []apiKeyItem `bson:"items"  json:"-"`
+}
+
+type apiKeyItem struct {
+	Id         string   `bson:"id"            json:"id"`
+	Name       string   `bson:"name"          json:"name"`
+	Scopes     []string `bson:"scopes"        json:"scopes"`
+	Hash       string   `bson:"hash"          json:"hash"`
+	Mask       string   `bson:"mask"          json:"mask"`
+	CreatedAt  int64    `bson:"created_at"    json:"created_at"`
+	ExpireAt   int64    `bson:"expire_at"     json:"expire_at"`
+	LastUsedAt int64    `bson:"last_used_at"  json:"last_used_at"`
+}
+
+type dApiUsage struct {
+	User             string `bson:"user"               json:"user"`
+	ModelName        string `bson:"model_name"         json:"model_name"`
+	Date             string `bson:"date"               json:"date"`
+	Calls            int    `bson:"calls"              json:"calls"`
+	PromptTokens     int    `bson:"prompt_tokens"      json:"prompt_tokens"`
+	CompletionTokens int    `bson:"completion_tokens"  json:"completion_tokens"`
+}
+
 type dApiInfo struct {
 	Id       string `bson:"id"        json:"id"`
 	Name     string `bson:"name"      json:"name"`
 	Endpoint string `bson:"endpoint"  json:"endpoint"`
 	Doc      string `bson:"doc"       json:"doc"`
 }
+
+type dConversation struct {
+	Id          string                `bson:"id"           json:"id"`
+	Owner       string                `bson:"owner"        json:"owner"`
+	Title       string                `bson:"title"        json:"title"`
+	ModelName   string                `bson:"model_name"   json:"model_name"`
+	Temperature *float64              `bson:"temperature"  json:"temperature,omitempty"`
+	TopP        *float64              `bson:"top_p"        json:"top_p,omitempty"`
+	Messages    []conversationMessage `bson:"messages"     json:"-"`
+	CreatedAt   int64                 `bson:"created_at"   json:"created_at"`
+	UpdatedAt   int64                 `bson:"updated_at"   json:"updated_at"`
+}
+
+type conversationMessage struct {
+	Role      string `bson:"role"        json:"role"`
+	Content   string `bson:"content"     json:"content"`
+	CreatedAt int64  `bson:"created_at"  json:"created_at"`
+}
@@ -15,6 +15,10 @@ import (
 const (
 	mongoCmdSet  = "$set"
 	mongoCmdPush = "$push"
+	mongoCmdInc  = "$inc"
+	mongoCmdGte  = "$gte"
+	mongoCmdLt   = "$lt"
+	mongoCmdEach = "$each"
 )
 
 var (
@@ -41,6 +45,8 @@ type mongodbClient interface {
 
 	NewDocIfNotExist(ctx context.Context, filterOfDoc, docInfo bson.M) (string, error)
 
+	PushArrayElem(ctx context.Context, array string, filterOfDoc, value bson.M) error
+
 	PushElemToLimitedArray(ctx context.Context, array string, keep int,
 		filterOfDoc, value bson.M) error
 
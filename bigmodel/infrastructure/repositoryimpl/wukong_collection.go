@@ -0,0 +1,214 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	mongoCmdPull = "$pull"
+	mongoCmdNe   = "$ne"
+)
+
+func NewWuKongCollectionRepo(m mongodbClient) repository.WuKongCollection {
+	return wukongCollectionRepoImpl{m}
+}
+
+type wukongCollectionRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl wukongCollectionRepoImpl) Add(c *domain.WuKongCollection) (string, error) {
+	doc, err := genDoc(toWuKongCollectionDoc(c))
+	if err != nil {
+		return "", err
+	}
+
+	id := newId()
+	doc[fieldId] = id
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, bson.M{fieldId: id}, doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (impl wukongCollectionRepoImpl) Get(id string) (r domain.WuKongCollection, err error) {
+	var v dWuKongCollection
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, bson.M{fieldId: id}, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	err = v.toWuKongCollection(&r)
+
+	return
+}
+
+func (impl wukongCollectionRepoImpl) List(onlyFeatured bool) ([]domain.WuKongCollection, error) {
+	filter := bson.M{}
+	if onlyFeatured {
+		filter[fieldFeatured] = true
+	}
+
+	var v []dWuKongCollection
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDocs(
+			ctx, filter,
+			options.Find().
+				SetProjection(bson.M{fieldPictures: 0}).
+				SetSort(bson.D{{Key: fieldCreatedAt, Value: -1}}),
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.WuKongCollection, len(v))
+	for i := range v {
+		if err := v[i].toWuKongCollection(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (impl wukongCollectionRepoImpl) Update(c *domain.WuKongCollection) error {
+	return impl.update(bson.M{fieldId: c.Id}, bson.M{
+		mongoCmdSet: bson.M{
+			fieldName:      c.Name.WuKongCollectionName(),
+			fieldDesc:      c.Desc.WuKongCollectionDesc(),
+			fieldFeatured:  c.Featured,
+			fieldUpdatedAt: c.UpdatedAt,
+		},
+	})
+}
+
+func (impl wukongCollectionRepoImpl) AddPicture(id string, index *domain.WuKongPictureIndex) error {
+	item := toWuKongPictureIndexDoc(index)
+
+	// the picture will not be added twice
+	filter := bson.M{
+		fieldId:       id,
+		fieldPictures: bson.M{mongoCmdNe: item},
+	}
+
+	return impl.update(filter, bson.M{
+		mongoCmdPush: bson.M{fieldPictures: item},
+		mongoCmdSet:  bson.M{fieldUpdatedAt: utils.Now()},
+	})
+}
+
+func (impl wukongCollectionRepoImpl) RemovePicture(id string, index *domain.WuKongPictureIndex) error {
+	return impl.update(bson.M{fieldId: id}, bson.M{
+		mongoCmdPull: bson.M{fieldPictures: toWuKongPictureIndexDoc(index)},
+		mongoCmdSet:  bson.M{fieldUpdatedAt: utils.Now()},
+	})
+}
+
+func (impl wukongCollectionRepoImpl) update(filter, update bson.M) error {
+	f := func(ctx context.Context) error {
+		r, err := impl.cli.Collection().UpdateOne(ctx, filter, update)
+		if err == nil && r.MatchedCount == 0 {
+			err = repoerr.NewErrorResourceNotExists(errDocNotExists)
+		}
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl wukongCollectionRepoImpl) Delete(id string) error {
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().DeleteOne(ctx, bson.M{fieldId: id})
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func toWuKongPictureIndexDoc(index *domain.WuKongPictureIndex) wukongPictureIndex {
+	return wukongPictureIndex{
+		Owner: index.Owner.Account(),
+		Id:    index.Id,
+	}
+}
+
+func toWuKongCollectionDoc(c *domain.WuKongCollection) dWuKongCollection {
+	doc := dWuKongCollection{
+		Name:      c.Name.WuKongCollectionName(),
+		Desc:      c.Desc.WuKongCollectionDesc(),
+		Featured:  c.Featured,
+		Pictures:  make([]wukongPictureIndex, len(c.Pictures)),
+		CreatedBy: c.CreatedBy,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+
+	for i := range c.Pictures {
+		doc.Pictures[i] = toWuKongPictureIndexDoc(&c.Pictures[i])
+	}
+
+	return doc
+}
+
+func (doc *dWuKongCollection) toWuKongCollection(c *domain.WuKongCollection) (err error) {
+	if c.Name, err = domain.NewWuKongCollectionName(doc.Name); err != nil {
+		return
+	}
+
+	if c.Desc, err = domain.NewWuKongCollectionDesc(doc.Desc); err != nil {
+		return
+	}
+
+	if n := len(doc.Pictures); n > 0 {
+		c.Pictures = make([]domain.WuKongPictureIndex, n)
+
+		for i := range doc.Pictures {
+			item := &doc.Pictures[i]
+
+			if c.Pictures[i].Owner, err = types.NewAccount(item.Owner); err != nil {
+				return
+			}
+
+			c.Pictures[i].Id = item.Id
+		}
+	}
+
+	c.Id = doc.Id
+	c.Featured = doc.Featured
+	c.CreatedBy = doc.CreatedBy
+	c.CreatedAt = doc.CreatedAt
+	c.UpdatedAt = doc.UpdatedAt
+
+	return
+}
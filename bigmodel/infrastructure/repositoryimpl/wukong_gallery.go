@@ -0,0 +1,213 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/bigmodel/domain/repository"
+)
+
+const (
+	mongoCmdText   = "$text"
+	mongoCmdSearch = "$search"
+	mongoCmdOr     = "$or"
+	mongoCmdNor    = "$nor"
+)
+
+// NewWuKongGalleryRepo creates the indexes of gallery which are used by
+// the searching and sorting.
+func NewWuKongGalleryRepo(m mongodbClient) (repository.WuKongGallery, error) {
+	impl := wukongGalleryRepoImpl{m}
+
+	if err := withContext(impl.createIndexes); err != nil {
+		return nil, err
+	}
+
+	return impl, nil
+}
+
+type wukongGalleryRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl wukongGalleryRepoImpl) createIndexes(ctx context.Context) error {
+	_, err := impl.cli.Collection().Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: fieldOwner, Value: 1}, {Key: fieldId, Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: fieldCreatedAt, Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: fieldDiggCount, Value: -1}, {Key: fieldCreatedAt, Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: fieldLevel, Value: 1}, {Key: fieldCreatedAt, Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: fieldStyle, Value: 1}, {Key: fieldDiggCount, Value: -1}},
+		},
+		{
+			Keys: bson.D{{Key: fieldTags, Value: 1}, {Key: fieldCreatedAt, Value: -1}},
+		},
+		{
+			// the desc is not always English, so it is not stemmed
+			Keys:    bson.D{{Key: fieldDesc, Value: "text"}, {Key: fieldStyle, Value: "text"}},
+			Options: options.Index().SetDefaultLanguage("none"),
+		},
+	})
+
+	return err
+}
+
+func wukongGalleryFilter(index *domain.WuKongPictureIndex) bson.M {
+	return bson.M{
+		fieldOwner: index.Owner.Account(),
+		fieldId:    index.Id,
+	}
+}
+
+func (impl wukongGalleryRepoImpl) Save(p *domain.WuKongPicture) error {
+	doc, err := toPictureItemDoc(p)
+	if err != nil {
+		return err
+	}
+
+	index := p.Index()
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().UpdateOne(
+			ctx, wukongGalleryFilter(&index),
+			bson.M{mongoCmdSet: doc},
+			options.Update().SetUpsert(true),
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl wukongGalleryRepoImpl) Remove(index *domain.WuKongPictureIndex) error {
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().DeleteOne(ctx, wukongGalleryFilter(index))
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl wukongGalleryRepoImpl) List(opt *repository.WuKongGalleryListOption) (
+	[]domain.WuKongPicture, int, error,
+) {
+	filter := bson.M{}
+
+	if opt.Keyword != "" {
+		filter[mongoCmdText] = bson.M{mongoCmdSearch: opt.Keyword}
+	}
+
+	if opt.Style != "" {
+		filter[fieldStyle] = opt.Style
+	}
+
+	if opt.Tag != nil {
+		filter[fieldTags] = opt.Tag.WuKongPictureTag()
+	}
+
+	if opt.Level != nil {
+		filter[fieldLevel] = opt.Level.Int()
+	}
+
+	if opt.Exclude != nil {
+		filter[mongoCmdNor] = bson.A{wukongGalleryFilter(opt.Exclude)}
+	}
+
+	sort := bson.D{{Key: fieldCreatedAt, Value: -1}, {Key: "_id", Value: -1}}
+	if opt.SortByDigg {
+		sort = append(bson.D{{Key: fieldDiggCount, Value: -1}}, sort...)
+	}
+
+	findOpts := options.Find().SetSort(sort)
+	if opt.CountPerPage > 0 {
+		findOpts.SetLimit(int64(opt.CountPerPage))
+
+		if opt.PageNum > 1 {
+			findOpts.SetSkip(int64(opt.CountPerPage * (opt.PageNum - 1)))
+		}
+	}
+
+	var v []pictureItem
+	var total int64
+
+	f := func(ctx context.Context) (err error) {
+		if total, err = impl.cli.Collection().CountDocuments(ctx, filter); err != nil {
+			return
+		}
+
+		return impl.cli.GetDocs(ctx, filter, findOpts, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, int(total), err
+	}
+
+	r := make([]domain.WuKongPicture, len(v))
+	for i := range v {
+		if err := v[i].toWuKongPicture(&r[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return r, int(total), nil
+}
+
+func (impl wukongGalleryRepoImpl) FindByIndexes(indexes []domain.WuKongPictureIndex) (
+	[]domain.WuKongPicture, error,
+) {
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	cond := make(bson.A, len(indexes))
+	for i := range indexes {
+		cond[i] = wukongGalleryFilter(&indexes[i])
+	}
+
+	var v []pictureItem
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDocs(ctx, bson.M{mongoCmdOr: cond}, nil, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	m := make(map[string]*pictureItem, len(v))
+	for i := range v {
+		m[v[i].Owner+"/"+v[i].Id] = &v[i]
+	}
+
+	r := make([]domain.WuKongPicture, 0, len(v))
+	for i := range indexes {
+		item, ok := m[indexes[i].Owner.Account()+"/"+indexes[i].Id]
+		if !ok {
+			continue
+		}
+
+		p := domain.WuKongPicture{}
+		if err := item.toWuKongPicture(&p); err != nil {
+			return nil, err
+		}
+
+		r = append(r, p)
+	}
+
+	return r, nil
+}
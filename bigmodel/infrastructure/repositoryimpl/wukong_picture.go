@@ -403,6 +403,12 @@ func (r *pictureItem) toWuKongPicture(d *domain.WuKongPicture) (err error) {
 		return
 	}
 
+	if len(r.Tags) > 0 {
+		if d.Tags, err = domain.NewWuKongPictureTags(r.Tags); err != nil {
+			return
+		}
+	}
+
 	d.Level = domain.NewWuKongPictureLevelByNum(r.Level)
 	d.Id = r.Id
 	d.Diggs = r.Diggs
@@ -458,6 +464,13 @@ func toPictureItemDoc(d *domain.WuKongPicture) (bson.M, error) {
 		p.OBSPath = d.OBSPath.OBSPath()
 	}
 
+	if len(d.Tags) > 0 {
+		p.Tags = make([]string, len(d.Tags))
+		for i := range d.Tags {
+			p.Tags[i] = d.Tags[i].WuKongPictureTag()
+		}
+	}
+
 	return genDoc(p)
 }
 
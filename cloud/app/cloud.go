@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/image"
 	"github.com/opensourceways/xihe-server/cloud/domain/message"
 	"github.com/opensourceways/xihe-server/cloud/domain/repository"
 	"github.com/opensourceways/xihe-server/cloud/domain/service"
@@ -17,11 +18,14 @@ type CloudService interface {
 	// cloud
 	ListCloud(*GetCloudConfCmd) ([]CloudDTO, error)
 	SubscribeCloud(*SubscribeCloudCmd) (code string, err error)
+	ReserveCloud(*ReserveCloudCmd) (code string, err error)
+	CancelRequest(*CancelRequestCmd) error
 
 	// pod
 	Get(*PodInfoCmd) (PodInfoDTO, error)
 	ReleaseCloud(*ReleaseCloudCmd) error
 	GetReleasedPod(*GetReleasedPodCmd) (PodInfoDTO, error)
+	ExtendPod(*ExtendPodCmd) (code string, err error)
 }
 
 var _ CloudService = (*cloudService)(nil)
@@ -29,13 +33,17 @@ var _ CloudService = (*cloudService)(nil)
 func NewCloudService(
 	cloudRepo repository.Cloud,
 	podRepo repository.Pod,
+	requestRepo repository.PodRequest,
 	producer message.CloudMessageProducer,
 	whitelistRepo userrepo.WhiteList,
+	image image.Image,
 ) *cloudService {
 	return &cloudService{
 		cloudRepo:        cloudRepo,
 		podRepo:          podRepo,
+		requestRepo:      requestRepo,
 		producer:         producer,
+		image:            image,
 		cloudService:     service.NewCloudService(podRepo, producer),
 		whitelistService: userapp.NewWhiteListService(whitelistRepo),
 	}
@@ -44,7 +52,9 @@ func NewCloudService(
 type cloudService struct {
 	cloudRepo        repository.Cloud
 	podRepo          repository.Pod
+	requestRepo      repository.PodRequest
 	producer         message.CloudMessageProducer
+	image            image.Image
 	cloudService     service.CloudService
 	whitelistService userapp.WhiteListService
 }
@@ -101,28 +111,17 @@ func (s *cloudService) SubscribeCloud(cmd *SubscribeCloudCmd) (code string, err
 	}
 
 	// whitelist
-	if cloudConf.IsNPU() {
-		useNPU, useMultiNPU, err := s.whitelistService.CheckCloudWhitelist(cmd.User)
-		if err != nil {
-			return "", err
-		}
-
-		if (cmd.CardsNum.CloudSpecCardsNum() > 1 && !useMultiNPU) ||
-			(cmd.CardsNum.CloudSpecCardsNum() == 1 && !useNPU) {
-			return errorWhitelistNotAllowed, errors.New("not in cloud whitelist")
-		}
+	if code, err = s.checkWhitelist(&cloudConf, cmd.User, cmd.CardsNum); err != nil {
+		return
 	}
 
-	// check
-	_, ok, err := s.cloudService.CheckUserCanSubscribe(cmd.User, cmd.CloudId)
+	image, code, err := s.getImage(&cloudConf, cmd)
 	if err != nil {
 		return
 	}
 
-	if !ok {
-		code = errorNotAllowed
-		err = errors.New("starting or running pod exist")
-
+	// check
+	if code, err = s.checkUserCanSubscribe(cmd.User, cmd.CloudId); err != nil {
 		return
 	}
 
@@ -137,6 +136,12 @@ func (s *cloudService) SubscribeCloud(cmd *SubscribeCloudCmd) (code string, err
 	deduction := cmd.CardsNum.CloudSpecCardsNum()
 
 	singleCardBusy := deduction == 1 && !c.HasSingleCardIdle()
+	multiCardsBusy := deduction > 1 && !c.HasMultiCardsIdle(deduction)
+
+	if (singleCardBusy || multiCardsBusy) && cmd.Queue {
+		return s.enqueue(&cloudConf, cmd, image)
+	}
+
 	if singleCardBusy {
 		code = errorResourceBusy
 		err = errors.New("no idle resource remain")
@@ -144,7 +149,6 @@ func (s *cloudService) SubscribeCloud(cmd *SubscribeCloudCmd) (code string, err
 		return
 	}
 
-	multiCardsBusy := deduction > 1 && !c.HasMultiCardsIdle(deduction)
 	if multiCardsBusy {
 		code = errorResourceBusy
 		err = errors.New("no idle cards remain")
@@ -153,11 +157,153 @@ func (s *cloudService) SubscribeCloud(cmd *SubscribeCloudCmd) (code string, err
 	}
 
 	// subscribe
-	err = s.cloudService.SubscribeCloud(&c.CloudConf, cmd.User, cmd.ImageAlias, cmd.CardsNum)
+	err = s.cloudService.StartPod(&c.CloudConf, cmd.User, image, cmd.CardsNum)
+
+	return
+}
+
+func (s *cloudService) checkWhitelist(
+	c *domain.CloudConf, user types.Account, cardsNum domain.CloudSpecCardsNum,
+) (string, error) {
+	if !c.IsNPU() {
+		return "", nil
+	}
+
+	useNPU, useMultiNPU, err := s.whitelistService.CheckCloudWhitelist(user)
+	if err != nil {
+		return "", err
+	}
+
+	if (cardsNum.CloudSpecCardsNum() > 1 && !useMultiNPU) ||
+		(cardsNum.CloudSpecCardsNum() == 1 && !useNPU) {
+		return errorWhitelistNotAllowed, errors.New("not in cloud whitelist")
+	}
+
+	return "", nil
+}
+
+func (s *cloudService) checkUserCanSubscribe(user types.Account, cid string) (code string, err error) {
+	_, ok, err := s.cloudService.CheckUserCanSubscribe(user, cid)
+	if err != nil {
+		return
+	}
+
+	if !ok {
+		code = errorNotAllowed
+		err = errors.New("starting or running pod exist")
+
+		return
+	}
+
+	_, err = s.requestRepo.GetUserWaitingRequest(user, cid)
+	if err == nil {
+		code = errorNotAllowed
+		err = errors.New("waiting request exists")
+
+		return
+	}
+
+	if commonrepo.IsErrorResourceNotExists(err) {
+		err = nil
+	}
+
+	return
+}
+
+// getImage returns the custom image if it is specified, otherwise the image of alias.
+func (s *cloudService) getImage(c *domain.CloudConf, cmd *SubscribeCloudCmd) (
+	image domain.ICloudImage, code string, err error,
+) {
+	if cmd.CustomImage == "" {
+		image, err = c.GetImage(cmd.ImageAlias.CloudImageAlias())
+	} else {
+		image, err = s.image.GetImage(cmd.User, cmd.CustomImage, c)
+	}
+
+	if err != nil {
+		code = errorInvalidImage
+	}
+
+	return
+}
+
+func (s *cloudService) enqueue(
+	c *domain.CloudConf, cmd *SubscribeCloudCmd, image domain.ICloudImage,
+) (code string, err error) {
+	v, err := s.requestRepo.GetWaitingRequests(c.Id)
+	if err != nil {
+		return
+	}
+
+	if q := domain.NewPodQueue(v); q.IsFull() {
+		code = errorQueueFull
+		err = errors.New("too many requests in the queue")
+
+		return
+	}
+
+	r := domain.NewQueuedRequest(c.Id, cmd.User, image, cmd.CardsNum)
+	_, err = s.requestRepo.AddRequest(&r)
+
+	return
+}
+
+func (s *cloudService) ReserveCloud(cmd *ReserveCloudCmd) (code string, err error) {
+	cloudConf, err := s.cloudRepo.GetCloudConf(cmd.CloudId)
+	if err != nil {
+		return
+	}
+
+	if _, err = cloudConf.GetSpecDesc(cmd.CardsNum.CloudSpecCardsNum()); err != nil {
+		code = errorInvalidReservation
+
+		return
+	}
+
+	image, code, err := s.getImage(&cloudConf, &cmd.SubscribeCloudCmd)
+	if err != nil {
+		return
+	}
+
+	if code, err = s.checkWhitelist(&cloudConf, cmd.User, cmd.CardsNum); err != nil {
+		return
+	}
+
+	if code, err = s.checkUserCanSubscribe(cmd.User, cmd.CloudId); err != nil {
+		return
+	}
+
+	r, err := domain.NewReservation(cmd.CloudId, cmd.User, image, cmd.CardsNum, cmd.StartAt)
+	if err != nil {
+		code = errorInvalidReservation
+
+		return
+	}
+
+	_, err = s.requestRepo.AddRequest(&r)
 
 	return
 }
 
+func (s *cloudService) CancelRequest(cmd *CancelRequestCmd) error {
+	r, err := s.requestRepo.GetRequest(cmd.RequestId)
+	if err != nil {
+		return err
+	}
+
+	if !r.IsOwner(cmd.User) {
+		return ErrCloudNotAllowed
+	}
+
+	if !r.Status.IsWaiting() {
+		return ErrRequestNotWaiting
+	}
+
+	r.Status = domain.PodRequestStatusCancelled
+
+	return s.requestRepo.UpdateRequestStatus(&r)
+}
+
 func (s *cloudService) ReleaseCloud(cmd *ReleaseCloudCmd) error {
 	podInfo, err := s.podRepo.GetPodInfo(cmd.PodId)
 	if err != nil {
@@ -183,6 +329,33 @@ func (s *cloudService) ReleaseCloud(cmd *ReleaseCloudCmd) error {
 	})
 }
 
+func (s *cloudService) ExtendPod(cmd *ExtendPodCmd) (code string, err error) {
+	podInfo, err := s.podRepo.GetPodInfo(cmd.PodId)
+	if err != nil {
+		return
+	}
+
+	if !podInfo.IsOwner(cmd.User) {
+		err = ErrCloudNotAllowed
+
+		return
+	}
+
+	if err = podInfo.Extend(cmd.Duration); err != nil {
+		if errors.Is(err, domain.ErrExtensionExceeded) {
+			code = errorExtensionExceeded
+		} else if errors.Is(err, domain.ErrPodNotRunning) {
+			code = errorPodNotRunning
+		}
+
+		return
+	}
+
+	err = s.podRepo.UpdatePod(&podInfo)
+
+	return
+}
+
 func (s *cloudService) GetReleasedPod(cmd *GetReleasedPodCmd) (PodInfoDTO, error) {
 	podInfoDto := PodInfoDTO{}
 	podInfo, err := s.podRepo.GetPodInfo(cmd.PodId)
@@ -7,11 +7,21 @@ import (
 	types "github.com/opensourceways/xihe-server/domain"
 )
 
+const (
+	podStatusQueued   = "queued"
+	podStatusReserved = "reserved"
+)
+
 type SubscribeCloudCmd struct {
 	User       types.Account
 	ImageAlias domain.CloudImageAlias
 	CardsNum   domain.CloudSpecCardsNum
 	CloudId    string
+	Queue      bool
+
+	// CustomImage is the id of custom image registered by user,
+	// it takes precedence over the ImageAlias.
+	CustomImage string
 }
 
 type PodInfoCmd SubscribeCloudCmd
@@ -64,11 +74,15 @@ type PodInfoDTO struct {
 	CreatedAt int64  `json:"created_at"`
 	Image     string `json:"image"`
 	Spec      string `json:"spec"`
+
+	RequestId     string `json:"request_id,omitempty"`
+	QueuePosition int    `json:"queue_position,omitempty"`
+	StartAt       int64  `json:"start_at,omitempty"`
 }
 
 func (cmd *SubscribeCloudCmd) Validate() error {
-	b := cmd.User.Account() != "" &&
-		cmd.CloudId != "" && cmd.ImageAlias != nil && cmd.CardsNum != nil
+	b := cmd.User.Account() != "" && cmd.CloudId != "" && cmd.CardsNum != nil &&
+		(cmd.ImageAlias != nil || cmd.CustomImage != "")
 
 	if !b {
 		return errors.New("invalid cmd")
@@ -166,11 +180,7 @@ func (r *PodInfoDTO) toPodInfoDTO(p *domain.PodInfo, c *domain.CloudConf) error
 		r.CreatedAt = p.CreatedAt.Time()
 	}
 
-	alias, err := c.GetImageAlias(p.Image)
-	if err != nil {
-		return err
-	}
-	r.Image = alias.CloudImageAlias()
+	r.Image = toImageOfDTO(p.Image, c)
 
 	spec, err := c.GetSpecDesc(p.CardsNum.CloudSpecCardsNum())
 	if err != nil {
@@ -181,6 +191,15 @@ func (r *PodInfoDTO) toPodInfoDTO(p *domain.PodInfo, c *domain.CloudConf) error
 	return nil
 }
 
+// toImageOfDTO returns the alias of image, or the image itself if it is a custom image.
+func toImageOfDTO(image string, c *domain.CloudConf) string {
+	if alias, err := c.GetImageAlias(image); err == nil {
+		return alias.CloudImageAlias()
+	}
+
+	return image
+}
+
 type ReleaseCloudCmd struct {
 	PodId string
 	User  types.Account
@@ -197,3 +216,74 @@ func (c *ReleaseCloudCmd) Validate() error {
 type GetReleasedPodCmd struct {
 	PodId string
 }
+
+type ExtendPodCmd struct {
+	PodId    string
+	User     types.Account
+	Duration int64
+}
+
+func (c *ExtendPodCmd) Validate() error {
+	if c.PodId == "" || c.Duration <= 0 {
+		return errors.New("invalid cmd")
+	}
+
+	return nil
+}
+
+type ReserveCloudCmd struct {
+	SubscribeCloudCmd
+
+	StartAt int64
+}
+
+func (cmd *ReserveCloudCmd) Validate() error {
+	if err := cmd.SubscribeCloudCmd.Validate(); err != nil {
+		return err
+	}
+
+	if cmd.StartAt <= 0 {
+		return errors.New("invalid start time")
+	}
+
+	return nil
+}
+
+type CancelRequestCmd struct {
+	RequestId string
+	User      types.Account
+}
+
+func (r *PodInfoDTO) toPodInfoDTOOfRequest(
+	req *domain.PodRequest, c *domain.CloudConf, position int,
+) error {
+	*r = PodInfoDTO{
+		RequestId:     req.Id,
+		CloudId:       req.CloudId,
+		Owner:         req.Owner.Account(),
+		CreatedAt:     req.CreatedAt,
+		StartAt:       req.StartAt,
+		QueuePosition: position,
+	}
+
+	if req.IsReservation() && !req.IsDue() {
+		r.Status = podStatusReserved
+	} else {
+		r.Status = podStatusQueued
+	}
+
+	r.Image = toImageOfDTO(req.Image.Image(), c)
+
+	spec, err := c.GetSpecDesc(req.CardsNum.CloudSpecCardsNum())
+	if err != nil {
+		return err
+	}
+	r.Spec = spec.CloudSpecDesc()
+
+	return nil
+}
+
+// IsWaiting checks whether the pod is waiting in the queue or for the reservation.
+func (r *PodInfoDTO) IsWaiting() bool {
+	return r.Status == podStatusQueued || r.Status == podStatusReserved
+}
@@ -6,10 +6,17 @@ const (
 	errorResourceBusy        = "cloud_resource_busy"
 	errorNotAllowed          = "cloud_not_allowed"
 	errorWhitelistNotAllowed = "not_allowed"
+	errorQueueFull           = "cloud_queue_full"
+	errorInvalidReservation  = "cloud_invalid_reservation"
+	errorExtensionExceeded   = "cloud_extension_exceeded"
+	errorPodNotRunning       = "cloud_pod_not_running"
+	errorInvalidImage        = "cloud_invalid_image"
 )
 
 var (
 	ErrCloudReleased   = errors.New("cloud was released")
 	ErrCloudNotAllowed = errors.New("not allowed")
 	ErrPodNotFound     = errors.New("not found")
+
+	ErrRequestNotWaiting = errors.New("request is not waiting")
 )
@@ -53,10 +53,12 @@ func (c *cloudMessageService) CreatePodInstance(p *domain.PodInfo) error {
 		return err
 	}
 
+	// the pod instance survives for the max extension, and it will be released
+	// by the scheduler at the expiry if it is not extended.
 	err = c.manager.Create(
 		&cloud.CloudPodCreateInfo{
 			PodId:         p.Id,
-			SurvivalTime:  survivalTime,
+			SurvivalTime:  domain.MaxSurvivalTime(survivalTime),
 			User:          p.Owner.Account(),
 			CloudType:     p.GetCloudType(),
 			CloudImage:    p.Image,
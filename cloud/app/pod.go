@@ -1,6 +1,20 @@
 package app
 
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
+)
+
 func (s *cloudService) Get(cmd *PodInfoCmd) (dto PodInfoDTO, err error) {
+	r, err := s.requestRepo.GetUserWaitingRequest(cmd.User, cmd.CloudId)
+	if err == nil {
+		return s.getWaiting(&r)
+	}
+
+	if !commonrepo.IsErrorResourceNotExists(err) {
+		return
+	}
+
 	p, _, err := s.cloudService.CheckUserCanSubscribe(cmd.User, cmd.CloudId)
 	if err != nil {
 		return
@@ -15,3 +29,21 @@ func (s *cloudService) Get(cmd *PodInfoCmd) (dto PodInfoDTO, err error) {
 
 	return
 }
+
+func (s *cloudService) getWaiting(r *domain.PodRequest) (dto PodInfoDTO, err error) {
+	cloudConf, err := s.cloudRepo.GetCloudConf(r.CloudId)
+	if err != nil {
+		return
+	}
+
+	v, err := s.requestRepo.GetWaitingRequests(r.CloudId)
+	if err != nil {
+		return
+	}
+
+	q := domain.NewPodQueue(v)
+
+	err = dto.toPodInfoDTOOfRequest(r, &cloudConf, q.Position(r.Id))
+
+	return
+}
@@ -0,0 +1,189 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/message"
+	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	"github.com/opensourceways/xihe-server/cloud/domain/service"
+	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
+	"github.com/sirupsen/logrus"
+)
+
+// CloudScheduleService releases the expired pods and starts the waiting
+// requests when there are idle cards.
+type CloudScheduleService interface {
+	Schedule()
+}
+
+func NewCloudScheduleService(
+	cloudRepo repository.Cloud,
+	podRepo repository.Pod,
+	requestRepo repository.PodRequest,
+	producer message.CloudMessageProducer,
+) CloudScheduleService {
+	return &cloudScheduleService{
+		cloudRepo:    cloudRepo,
+		podRepo:      podRepo,
+		requestRepo:  requestRepo,
+		producer:     producer,
+		cloudService: service.NewCloudService(podRepo, producer),
+	}
+}
+
+type cloudScheduleService struct {
+	cloudRepo    repository.Cloud
+	podRepo      repository.Pod
+	requestRepo  repository.PodRequest
+	producer     message.CloudMessageProducer
+	cloudService service.CloudService
+}
+
+func (s *cloudScheduleService) Schedule() {
+	confs, err := s.cloudRepo.ListCloudConf()
+	if err != nil {
+		logrus.Errorf("list cloud conf failed, err:%s", err.Error())
+
+		return
+	}
+
+	for i := range confs {
+		if err := s.releaseExpiredPods(confs[i].Id); err != nil {
+			logrus.Errorf("release expired pods of %s failed, err:%s", confs[i].Id, err.Error())
+		}
+
+		if err := s.startWaitingRequests(&confs[i]); err != nil {
+			logrus.Errorf("start waiting requests of %s failed, err:%s", confs[i].Id, err.Error())
+		}
+	}
+}
+
+func (s *cloudScheduleService) releaseExpiredPods(cid string) error {
+	v, err := s.podRepo.GetRunningPod(cid)
+	if err != nil {
+		return err
+	}
+
+	for i := range v.PodInfos {
+		p := &v.PodInfos[i]
+		if !p.NeedRelease() {
+			continue
+		}
+
+		p.StatusSetTerminating()
+		if err := s.podRepo.UpdatePod(p); err != nil {
+			logrus.Errorf("update expired pod %s failed, err:%s", p.Id, err.Error())
+
+			continue
+		}
+
+		err := s.producer.ReleaseCloud(&message.ReleaseCloudEvent{
+			PodId:     p.Id,
+			CloudType: p.GetCloudType(),
+		})
+		if err != nil {
+			logrus.Errorf("release expired pod %s failed, err:%s", p.Id, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (s *cloudScheduleService) startWaitingRequests(conf *domain.CloudConf) error {
+	v, err := s.requestRepo.GetWaitingRequests(conf.Id)
+	if err != nil || len(v) == 0 {
+		return err
+	}
+
+	c := domain.Cloud{CloudConf: *conf}
+	if err := s.cloudService.ToCloud(&c); err != nil {
+		return err
+	}
+
+	// the starting pods have no expiry and are not counted in the remain,
+	// so the remain is deducted here for the requests started in this round.
+	single := c.SingleRemain.CloudRemain()
+	multi := c.MultiRemain.CloudRemain()
+
+	// the requests are started in order, the later one can't jump the queue
+	// even if there are enough cards for it.
+	singleBlocked, multiBlocked := false, false
+
+	q := domain.NewPodQueue(v)
+	reqs := q.Requests()
+
+	for i := range reqs {
+		r := &reqs[i]
+		n := r.CardsNum.CloudSpecCardsNum()
+
+		if r.IsSingleCard() {
+			if singleBlocked || single < n {
+				singleBlocked = true
+
+				continue
+			}
+		} else {
+			if multiBlocked || multi < n {
+				multiBlocked = true
+
+				continue
+			}
+		}
+
+		started, err := s.start(conf, r)
+		if err != nil {
+			logrus.Errorf("start request %s failed, err:%s", r.Id, err.Error())
+
+			continue
+		}
+
+		if !started {
+			continue
+		}
+
+		if r.IsSingleCard() {
+			single -= n
+		} else {
+			multi -= n
+		}
+	}
+
+	return nil
+}
+
+func (s *cloudScheduleService) start(conf *domain.CloudConf, r *domain.PodRequest) (bool, error) {
+	// the user may have subscribed the cloud directly when the request was waiting.
+	_, ok, err := s.cloudService.CheckUserCanSubscribe(r.Owner, r.CloudId)
+	if err != nil {
+		return false, err
+	}
+
+	if ok {
+		r.Status = domain.PodRequestStatusStarted
+	} else {
+		r.Status = domain.PodRequestStatusCancelled
+	}
+
+	if err := s.requestRepo.UpdateRequestStatus(r); err != nil {
+		if commonrepo.IsErrorConcurrentUpdating(err) {
+			// it has been cancelled or started by others
+			err = nil
+		}
+
+		return false, err
+	}
+
+	if !ok {
+		return false, nil
+	}
+
+	if err := s.cloudService.StartPod(conf, r.Owner, r.Image, r.CardsNum); err != nil {
+		// keep it in the queue, so it will be started at the next schedule.
+		if err1 := s.requestRepo.ResumeRequest(r); err1 != nil {
+			logrus.Errorf("resume request %s failed, err:%s", r.Id, err1.Error())
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
@@ -10,6 +10,10 @@ const (
 	cloudIdNPU   = "ascend_001"
 	cloudTypeCPU = "cpu"
 	cloudTypeNPU = "npu"
+
+	processorCPU    = "cpu"
+	processorGPU    = "gpu"
+	processorAscend = "ascend"
 )
 
 type CloudConf struct {
@@ -38,6 +42,18 @@ func (c *CloudConf) IsNPU() bool {
 	return c.Id == cloudIdNPU
 }
 
+// ProcessorType returns the kind of processor which is one of cpu, gpu and ascend.
+func (c *CloudConf) ProcessorType() string {
+	switch c.Id {
+	case cloudIdNPU:
+		return processorAscend
+	case cloudIdCPU:
+		return processorCPU
+	default:
+		return processorGPU
+	}
+}
+
 type Cloud struct {
 	CloudConf
 
@@ -0,0 +1,59 @@
+package domain
+
+var config Config
+
+func Init(cfg *Config) {
+	config = *cfg
+}
+
+type Config struct {
+	// PodLifetime is the seconds that a pod can run before being extended.
+	PodLifetime int64 `json:"pod_lifetime"`
+
+	// MaxExtension is the total seconds that a pod can be extended.
+	MaxExtension int64 `json:"max_extension"`
+
+	// MaxReservationAhead is the max seconds between now and
+	// the start time of a reservation.
+	MaxReservationAhead int64 `json:"max_reservation_ahead"`
+
+	// MaxQueueLength is the max number of requests waiting in the queue
+	// of a cloud.
+	MaxQueueLength int `json:"max_queue_length"`
+
+	// ScheduleInterval is the seconds between two rounds of scheduling
+	// the queue and releasing the expired pods.
+	ScheduleInterval int `json:"schedule_interval"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.PodLifetime <= 0 {
+		cfg.PodLifetime = 2 * 60 * 60
+	}
+
+	if cfg.MaxExtension < 0 {
+		cfg.MaxExtension = 0
+	}
+
+	if cfg.MaxReservationAhead <= 0 {
+		cfg.MaxReservationAhead = 7 * 24 * 60 * 60
+	}
+
+	if cfg.MaxQueueLength <= 0 {
+		cfg.MaxQueueLength = 100
+	}
+
+	if cfg.ScheduleInterval <= 0 {
+		cfg.ScheduleInterval = 10
+	}
+}
+
+func ScheduleInterval() int {
+	return config.ScheduleInterval
+}
+
+// MaxSurvivalTime returns the seconds that a pod will survive at most
+// when it can run for survivalTime seconds without being extended.
+func MaxSurvivalTime(survivalTime int64) int64 {
+	return survivalTime + config.MaxExtension
+}
@@ -14,6 +14,16 @@ const (
 	CloudPodStatusRunning     = "running"
 	CloudPodStatusTerminated  = "terminated"
 	CloudPodStatusTerminating = "terminating"
+
+	podRequestStatusWaiting   = "waiting"
+	podRequestStatusStarted   = "started"
+	podRequestStatusCancelled = "cancelled"
+)
+
+var (
+	PodRequestStatusWaiting   = podRequestStatus(podRequestStatusWaiting)
+	PodRequestStatusStarted   = podRequestStatus(podRequestStatusStarted)
+	PodRequestStatusCancelled = podRequestStatus(podRequestStatusCancelled)
 )
 
 var cloudSpecCardsNumRange = map[int]struct{}{
@@ -318,3 +328,31 @@ type cloudImage string
 func (i cloudImage) Image() string {
 	return string(i)
 }
+
+// PodRequestStatus
+type PodRequestStatus interface {
+	PodRequestStatus() string
+	IsWaiting() bool
+}
+
+func NewPodRequestStatus(v string) (PodRequestStatus, error) {
+	b := v == podRequestStatusWaiting ||
+		v == podRequestStatusStarted ||
+		v == podRequestStatusCancelled
+
+	if !b {
+		return nil, errors.New("invalid pod request status")
+	}
+
+	return podRequestStatus(v), nil
+}
+
+type podRequestStatus string
+
+func (s podRequestStatus) PodRequestStatus() string {
+	return string(s)
+}
+
+func (s podRequestStatus) IsWaiting() bool {
+	return string(s) == podRequestStatusWaiting
+}
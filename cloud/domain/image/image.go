@@ -0,0 +1,12 @@
+package image
+
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// Image provides the custom images registered by users.
+type Image interface {
+	// GetImage returns the custom image if the user can use it on the cloud.
+	GetImage(user types.Account, id string, c *domain.CloudConf) (domain.ICloudImage, error)
+}
@@ -1,11 +1,18 @@
 package domain
 
 import (
+	"errors"
+
 	types "github.com/opensourceways/xihe-server/common/domain"
 	otypes "github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/utils"
 )
 
+var (
+	ErrPodNotRunning     = errors.New("pod is not running")
+	ErrExtensionExceeded = errors.New("exceed the max extension")
+)
+
 type Pod struct {
 	Id      string
 	CloudId string
@@ -22,6 +29,7 @@ type PodInfo struct {
 	AccessURL AccessURL
 	CreatedAt types.Time
 	CardsNum  CloudSpecCardsNum
+	Extended  int64
 }
 
 func (r *Pod) IsOwner(owner otypes.Account) bool {
@@ -86,13 +94,39 @@ func (p *PodInfo) SetStatus() {
 }
 
 func (p *PodInfo) SetDefaultExpiry() (err error) {
-	if p.Expiry, err = NewPodExpiry(utils.Now() + 2*60*60); err != nil { // TODO config
+	if p.Expiry, err = NewPodExpiry(utils.Now() + config.PodLifetime); err != nil {
 		return
 	}
 
 	return
 }
 
+// Extend delays the expiry of the running pod, the total extension
+// can't exceed the max extension of policy.
+func (p *PodInfo) Extend(duration int64) error {
+	if duration <= 0 {
+		return errors.New("invalid duration")
+	}
+
+	if !p.CanRelease() {
+		return ErrPodNotRunning
+	}
+
+	if p.Extended+duration > config.MaxExtension {
+		return ErrExtensionExceeded
+	}
+
+	p.Extended += duration
+	p.Expiry, _ = NewPodExpiry(p.Expiry.PodExpiry() + duration)
+
+	return nil
+}
+
+// NeedRelease checks whether the running pod has reached its expiry.
+func (p *PodInfo) NeedRelease() bool {
+	return p.Status.IsRunning() && p.IsExpired()
+}
+
 func (p *PodInfo) SetStartingPodInfo(
 	cid string, owner otypes.Account, image ICloudImage, cardsNum CloudSpecCardsNum,
 ) (err error) {
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type PodRequest interface {
+	AddRequest(*domain.PodRequest) (rid string, err error)
+	GetRequest(rid string) (domain.PodRequest, error)
+	GetWaitingRequests(cid string) ([]domain.PodRequest, error)
+	GetUserWaitingRequest(user types.Account, cid string) (domain.PodRequest, error)
+
+	// UpdateRequestStatus updates the status only when the request is still waiting,
+	// so that a request will not be started twice.
+	UpdateRequestStatus(*domain.PodRequest) error
+
+	// ResumeRequest sets the started request to waiting again,
+	// it is used when the pod failed to start.
+	ResumeRequest(*domain.PodRequest) error
+}
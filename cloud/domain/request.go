@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+
+	otypes "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// PodRequest is a request to start a pod later. It waits in the queue of
+// the cloud until there are idle cards, or until the start time if it is
+// a reservation.
+type PodRequest struct {
+	Id        string
+	CloudId   string
+	Owner     otypes.Account
+	Image     ICloudImage
+	CardsNum  CloudSpecCardsNum
+	StartAt   int64
+	Status    PodRequestStatus
+	CreatedAt int64
+}
+
+func NewQueuedRequest(
+	cid string, owner otypes.Account, image ICloudImage, cardsNum CloudSpecCardsNum,
+) PodRequest {
+	return PodRequest{
+		CloudId:   cid,
+		Owner:     owner,
+		Image:     image,
+		CardsNum:  cardsNum,
+		Status:    PodRequestStatusWaiting,
+		CreatedAt: utils.Now(),
+	}
+}
+
+func NewReservation(
+	cid string, owner otypes.Account, image ICloudImage, cardsNum CloudSpecCardsNum,
+	startAt int64,
+) (PodRequest, error) {
+	now := utils.Now()
+	if startAt <= now || startAt > now+config.MaxReservationAhead {
+		return PodRequest{}, errors.New("invalid start time of reservation")
+	}
+
+	r := NewQueuedRequest(cid, owner, image, cardsNum)
+	r.StartAt = startAt
+
+	return r, nil
+}
+
+func (r *PodRequest) IsReservation() bool {
+	return r.StartAt > 0
+}
+
+// IsDue checks whether the request can be started now.
+func (r *PodRequest) IsDue() bool {
+	return r.StartAt <= utils.Now()
+}
+
+func (r *PodRequest) IsSingleCard() bool {
+	return r.CardsNum.CloudSpecCardsNum() == 1
+}
+
+func (r *PodRequest) IsOwner(owner otypes.Account) bool {
+	return r.Owner == owner
+}
+
+// PodQueue is the waiting requests of a cloud.
+type PodQueue struct {
+	requests []PodRequest
+}
+
+// NewPodQueue sorts the requests in the order that they will be started,
+// the due reservations go first and then the others in FIFO.
+func NewPodQueue(requests []PodRequest) PodQueue {
+	v := make([]PodRequest, 0, len(requests))
+
+	for i := range requests {
+		if requests[i].IsReservation() && requests[i].IsDue() {
+			v = append(v, requests[i])
+		}
+	}
+
+	for i := range requests {
+		if !requests[i].IsReservation() {
+			v = append(v, requests[i])
+		}
+	}
+
+	return PodQueue{requests: v}
+}
+
+func (q *PodQueue) Len() int {
+	return len(q.requests)
+}
+
+// IsFull checks whether the queue can't accept new requests any more.
+func (q *PodQueue) IsFull() bool {
+	return len(q.requests) >= config.MaxQueueLength
+}
+
+func (q *PodQueue) Requests() []PodRequest {
+	return q.requests
+}
+
+// Position returns the position of the request in the queue of the same kind
+// of cards. It starts from 1 and returns 0 if the request is not in the queue.
+func (q *PodQueue) Position(id string) int {
+	single := false
+	for i := range q.requests {
+		if q.requests[i].Id == id {
+			single = q.requests[i].IsSingleCard()
+		}
+	}
+
+	n := 0
+	for i := range q.requests {
+		r := &q.requests[i]
+
+		if r.IsSingleCard() != single {
+			continue
+		}
+
+		if n++; r.Id == id {
+			return n
+		}
+	}
+
+	return 0
+}
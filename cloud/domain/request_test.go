@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+func testRequest(id string, cards int, startAt int64) PodRequest {
+	return PodRequest{
+		Id:       id,
+		CardsNum: cloudSpecCardsNum(cards),
+		StartAt:  startAt,
+	}
+}
+
+func requestIds(v []PodRequest) []string {
+	r := make([]string, len(v))
+	for i := range v {
+		r[i] = v[i].Id
+	}
+
+	return r
+}
+
+func TestNewPodQueue(t *testing.T) {
+	now := utils.Now()
+
+	cases := []struct {
+		name     string
+		requests []PodRequest
+		want     []string
+	}{
+		{
+			"fifo",
+			[]PodRequest{
+				testRequest("a", 1, 0),
+				testRequest("b", 2, 0),
+				testRequest("c", 1, 0),
+			},
+			[]string{"a", "b", "c"},
+		},
+		{
+			"due reservations first",
+			[]PodRequest{
+				testRequest("a", 1, 0),
+				testRequest("b", 1, now-10),
+				testRequest("c", 1, 0),
+				testRequest("d", 1, now),
+			},
+			[]string{"b", "d", "a", "c"},
+		},
+		{
+			"future reservations excluded",
+			[]PodRequest{
+				testRequest("a", 1, now+3600),
+				testRequest("b", 1, 0),
+			},
+			[]string{"b"},
+		},
+	}
+
+	for i := range cases {
+		item := &cases[i]
+
+		q := NewPodQueue(item.requests)
+
+		if v := requestIds(q.Requests()); !reflect.DeepEqual(v, item.want) {
+			t.Errorf("%s: Requests() = %v, want %v", item.name, v, item.want)
+		}
+	}
+}
+
+func TestPodQueuePosition(t *testing.T) {
+	now := utils.Now()
+
+	q := NewPodQueue([]PodRequest{
+		testRequest("a", 2, 0),
+		testRequest("b", 1, 0),
+		testRequest("c", 1, now-10),
+		testRequest("d", 2, 0),
+		testRequest("e", 1, now+3600),
+	})
+
+	cases := []struct {
+		id   string
+		want int
+	}{
+		{"c", 1},
+		{"b", 2},
+		{"a", 1},
+		{"d", 2},
+		{"e", 0},
+		{"unknown", 0},
+	}
+
+	for _, item := range cases {
+		if v := q.Position(item.id); v != item.want {
+			t.Errorf("%s: Position() = %d, want %d", item.id, v, item.want)
+		}
+	}
+}
+
+func TestPodQueueIsFull(t *testing.T) {
+	old := config
+	defer func() { config = old }()
+
+	config.MaxQueueLength = 2
+
+	cases := []struct {
+		name string
+		num  int
+		want bool
+	}{
+		{"empty", 0, false},
+		{"not full", 1, false},
+		{"full", 2, true},
+	}
+
+	for i := range cases {
+		item := &cases[i]
+
+		v := make([]PodRequest, item.num)
+		q := NewPodQueue(v)
+
+		if b := q.IsFull(); b != item.want {
+			t.Errorf("%s: IsFull() = %v, want %v", item.name, b, item.want)
+		}
+	}
+}
+
+func TestNewReservation(t *testing.T) {
+	old := config
+	defer func() { config = old }()
+
+	config.MaxReservationAhead = 3600
+
+	now := utils.Now()
+
+	cases := []struct {
+		name    string
+		startAt int64
+		wantErr bool
+	}{
+		{"past", now - 10, true},
+		{"now", now, true},
+		{"in range", now + 60, false},
+		{"too far", now + 7200, true},
+	}
+
+	for i := range cases {
+		item := &cases[i]
+
+		r, err := NewReservation("cloud", nil, nil, cloudSpecCardsNum(1), item.startAt)
+		if (err != nil) != item.wantErr {
+			t.Errorf("%s: NewReservation() error = %v, want error %v", item.name, err, item.wantErr)
+
+			continue
+		}
+
+		if err == nil && (!r.IsReservation() || r.Status != PodRequestStatusWaiting) {
+			t.Errorf("%s: NewReservation() = %+v, want a waiting reservation", item.name, r)
+		}
+	}
+}
@@ -77,6 +77,13 @@ func (r *CloudService) SubscribeCloud(
 		return
 	}
 
+	return r.StartPod(c, u, image, cardsNum)
+}
+
+// StartPod saves the starting pod and asks to create the pod instance.
+func (r *CloudService) StartPod(
+	c *domain.CloudConf, u types.Account, image domain.ICloudImage, cardsNum domain.CloudSpecCardsNum,
+) (err error) {
 	// save into repo
 	p := new(domain.PodInfo)
 	if err := p.SetStartingPodInfo(c.Id, u, image, cardsNum); err != nil {
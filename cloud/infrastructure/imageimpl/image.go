@@ -0,0 +1,31 @@
+package imageimpl
+
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/image"
+	types "github.com/opensourceways/xihe-server/domain"
+	imageapp "github.com/opensourceways/xihe-server/image/app"
+)
+
+func NewImageImpl(s imageapp.ImageService) image.Image {
+	return &imageImpl{s}
+}
+
+type imageImpl struct {
+	srv imageapp.ImageService
+}
+
+func (impl *imageImpl) GetImage(user types.Account, id string, c *domain.CloudConf) (
+	domain.ICloudImage, error,
+) {
+	addr, _, err := impl.srv.GetUsableImage(&imageapp.ImageUsageCmd{
+		User:      user,
+		Id:        id,
+		Processor: c.ProcessorType(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.NewICloudImage(addr)
+}
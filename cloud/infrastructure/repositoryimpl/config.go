@@ -5,5 +5,6 @@ type Config struct {
 }
 
 type Table struct {
-	Pod string `json:"pod" required:"true"`
+	Pod        string `json:"pod"         required:"true"`
+	PodRequest string `json:"pod_request" required:"true"`
 }
@@ -7,10 +7,12 @@ import (
 )
 
 const (
-	fieldId      = "id"
-	fieldCloudId = "cloud_id"
-	fieldStatus  = "status"
-	fieldOwner   = "owner"
+	fieldId        = "id"
+	fieldCloudId   = "cloud_id"
+	fieldStatus    = "status"
+	fieldOwner     = "owner"
+	fieldStartAt   = "start_at"
+	fieldCreatedAt = "created_at"
 )
 
 func (doc *DCloudConf) toCloudConf(c *domain.CloudConf) (err error) {
@@ -105,13 +107,16 @@ func (table *TPod) toPodInfo(p *domain.PodInfo) (err error) {
 		return
 	}
 
+	p.Extended = table.Extended
+
 	return
 }
 
 func (table *TPod) toTPod(p *domain.PodInfo) {
 	*table = TPod{
-		CloudId: p.CloudId,
-		Image:   p.Image,
+		CloudId:  p.CloudId,
+		Image:    p.Image,
+		Extended: p.Extended,
 	}
 
 	if p.Id != "" {
@@ -146,3 +151,39 @@ func (table *TPod) toTPod(p *domain.PodInfo) {
 		table.CardsNum = p.CardsNum.CloudSpecCardsNum()
 	}
 }
+
+func (table *TPodRequest) toPodRequest(r *domain.PodRequest) (err error) {
+	r.Id = table.Id
+	r.CloudId = table.CloudId
+	r.StartAt = table.StartAt
+	r.CreatedAt = table.CreatedAt
+
+	if r.Owner, err = otypes.NewAccount(table.Owner); err != nil {
+		return
+	}
+
+	if r.Image, err = domain.NewICloudImage(table.Image); err != nil {
+		return
+	}
+
+	if r.CardsNum, err = domain.NewCloudSpecCardsNum(table.CardsNum); err != nil {
+		return
+	}
+
+	r.Status, err = domain.NewPodRequestStatus(table.Status)
+
+	return
+}
+
+func (table *TPodRequest) toTPodRequest(r *domain.PodRequest) {
+	*table = TPodRequest{
+		Id:        r.Id,
+		CloudId:   r.CloudId,
+		Owner:     r.Owner.Account(),
+		Image:     r.Image.Image(),
+		CardsNum:  r.CardsNum.CloudSpecCardsNum(),
+		StartAt:   r.StartAt,
+		Status:    r.Status.PodRequestStatus(),
+		CreatedAt: r.CreatedAt,
+	}
+}
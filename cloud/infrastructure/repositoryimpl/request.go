@@ -0,0 +1,147 @@
+package repositoryimpl
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
+	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+func NewPodRequestRepo(cfg *Config) (repository.PodRequest, error) {
+	db := pgsql.DB()
+
+	// the column of extension is added to the table of pod along with the queue
+	if err := db.Table(cfg.Table.Pod).AutoMigrate(&TPod{}); err != nil {
+		return nil, err
+	}
+
+	if err := db.Table(cfg.Table.PodRequest).AutoMigrate(&TPodRequest{}); err != nil {
+		return nil, err
+	}
+
+	return &podRequestRepoImpl{
+		cli: pgsql.NewDBTable(cfg.Table.PodRequest),
+	}, nil
+}
+
+type podRequestRepoImpl struct {
+	cli pgsqlClient
+}
+
+func (impl *podRequestRepoImpl) AddRequest(r *domain.PodRequest) (string, error) {
+	table := new(TPodRequest)
+	table.toTPodRequest(r)
+
+	if err := impl.cli.Create(table); err != nil {
+		return "", err
+	}
+
+	return table.Id, nil
+}
+
+func (impl *podRequestRepoImpl) GetRequest(rid string) (r domain.PodRequest, err error) {
+	filter := map[string]interface{}{
+		fieldId: rid,
+	}
+
+	var table TPodRequest
+	if err = impl.cli.GetRecord(filter, &table); err != nil {
+		return
+	}
+
+	err = table.toPodRequest(&r)
+
+	return
+}
+
+func (impl *podRequestRepoImpl) GetWaitingRequests(cid string) ([]domain.PodRequest, error) {
+	filter := map[string]interface{}{
+		fieldCloudId: cid,
+		fieldStatus:  domain.PodRequestStatusWaiting.PodRequestStatus(),
+	}
+
+	sort := []pgsql.SortByColumn{
+		{Column: fieldStartAt, Ascend: true},
+		{Column: fieldCreatedAt, Ascend: true},
+	}
+
+	var tables []TPodRequest
+	if err := impl.cli.GetRecords(filter, &tables, pgsql.Pagination{}, sort); err != nil {
+		return nil, err
+	}
+
+	v := make([]domain.PodRequest, len(tables))
+	for i := range tables {
+		if err := tables[i].toPodRequest(&v[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+func (impl *podRequestRepoImpl) GetUserWaitingRequest(user types.Account, cid string) (
+	r domain.PodRequest, err error,
+) {
+	filter := map[string]interface{}{
+		fieldOwner:   user.Account(),
+		fieldCloudId: cid,
+		fieldStatus:  domain.PodRequestStatusWaiting.PodRequestStatus(),
+	}
+
+	var table TPodRequest
+	if err = impl.cli.GetRecord(filter, &table); err != nil {
+		return
+	}
+
+	err = table.toPodRequest(&r)
+
+	return
+}
+
+func (impl *podRequestRepoImpl) UpdateRequestStatus(r *domain.PodRequest) error {
+	filter := map[string]interface{}{
+		fieldId:     r.Id,
+		fieldStatus: domain.PodRequestStatusWaiting.PodRequestStatus(),
+	}
+
+	update := map[string]interface{}{
+		fieldStatus: r.Status.PodRequestStatus(),
+	}
+
+	err := impl.cli.UpdateRecord(filter, update)
+	if err != nil && impl.cli.IsRowNotFound(err) {
+		err = commonrepo.NewErrorConcurrentUpdating(
+			errors.New("the request is not waiting"),
+		)
+	}
+
+	return err
+}
+
+func (impl *podRequestRepoImpl) ResumeRequest(r *domain.PodRequest) error {
+	filter := map[string]interface{}{
+		fieldId:     r.Id,
+		fieldStatus: domain.PodRequestStatusStarted.PodRequestStatus(),
+	}
+
+	update := map[string]interface{}{
+		fieldStatus: domain.PodRequestStatusWaiting.PodRequestStatus(),
+	}
+
+	err := impl.cli.UpdateRecord(filter, update)
+	if err != nil && impl.cli.IsRowNotFound(err) {
+		err = commonrepo.NewErrorConcurrentUpdating(
+			errors.New("the request is not started"),
+		)
+	}
+
+	if err == nil {
+		r.Status = domain.PodRequestStatusWaiting
+	}
+
+	return err
+}
@@ -11,8 +11,20 @@ type TPod struct {
 	CreatedAt int64  `gorm:"column:created_at;not null;default:extract(epoch from now())"`
 	CardsNum  int    `gorm:"column:cards_num;not null"`
 	Image     string `gorm:"column:image;not null"`
+	Extended  int64  `gorm:"column:extended;not null;default:0"`
 }
 
 func (TPod) TableName() string {
 	return "pod"
 }
+
+type TPodRequest struct {
+	Id        string `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	CloudId   string `gorm:"column:cloud_id;not null;index"`
+	Owner     string `gorm:"column:owner;not null"`
+	Image     string `gorm:"column:image;not null"`
+	CardsNum  int    `gorm:"column:cards_num;not null"`
+	StartAt   int64  `gorm:"column:start_at;not null;default:0"`
+	Status    string `gorm:"column:status;not null"`
+	CreatedAt int64  `gorm:"column:created_at;not null"`
+}
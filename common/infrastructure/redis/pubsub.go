@@ -0,0 +1,37 @@
+package redis
+
+import (
+	"context"
+)
+
+func Publish(ctx context.Context, channel string, msg interface{}) error {
+	return client.Publish(ctx, channel, msg).Err()
+}
+
+// Subscribe calls the handler with the payload of each message sent to
+// the channel until the ctx is done.
+func Subscribe(ctx context.Context, channel string, handler func([]byte)) error {
+	ps := client.Subscribe(ctx, channel)
+	defer ps.Close()
+
+	// wait for the confirmation of subscription
+	if _, err := ps.Receive(ctx); err != nil {
+		return err
+	}
+
+	ch := ps.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			handler([]byte(msg.Payload))
+		}
+	}
+}
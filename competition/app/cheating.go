@@ -0,0 +1,237 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	"github.com/opensourceways/xihe-server/competition/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type CheatingFlagListCmd = repository.CheatingFlagListOption
+
+type CheatingFlagReviewCmd struct {
+	CompetitionId string
+	FlagId        string
+	Reviewer      string
+	Disqualify    bool
+}
+
+type CheatingFlagDTO struct {
+	Id         string               `json:"id"`
+	Kind       string               `json:"kind"`
+	Players    []SuspectedPlayerDTO `json:"players"`
+	Evidence   string               `json:"evidence"`
+	Status     string               `json:"status"`
+	CreatedAt  string               `json:"created_at"`
+	Reviewer   string               `json:"reviewer,omitempty"`
+	ReviewedAt string               `json:"reviewed_at,omitempty"`
+}
+
+type SuspectedPlayerDTO struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func toCheatingFlagDTO(f *domain.CheatingFlag) CheatingFlagDTO {
+	dto := CheatingFlagDTO{
+		Id:        f.Id,
+		Kind:      f.Kind.CheatingKind(),
+		Evidence:  f.Evidence,
+		Status:    f.Status.CheatingFlagStatus(),
+		CreatedAt: utils.ToDate(f.CreatedAt),
+		Reviewer:  f.Reviewer,
+	}
+
+	if f.ReviewedAt > 0 {
+		dto.ReviewedAt = utils.ToDate(f.ReviewedAt)
+	}
+
+	dto.Players = make([]SuspectedPlayerDTO, len(f.Players))
+	for i := range f.Players {
+		dto.Players[i] = SuspectedPlayerDTO{
+			Id:   f.Players[i].Id,
+			Name: f.Players[i].Name,
+		}
+	}
+
+	return dto
+}
+
+// CompetitionCheatingService is used by the organiser to review the flags.
+type CompetitionCheatingService interface {
+	ListFlags(*CheatingFlagListCmd) ([]CheatingFlagDTO, error)
+	Review(*CheatingFlagReviewCmd) (string, error)
+}
+
+func NewCompetitionCheatingService(
+	repo repository.Competition,
+	workRepo repository.Work,
+	flagRepo repository.CheatingFlag,
+) CompetitionCheatingService {
+	return competitionCheatingService{
+		repo:     repo,
+		workRepo: workRepo,
+		flagRepo: flagRepo,
+	}
+}
+
+type competitionCheatingService struct {
+	repo     repository.Competition
+	workRepo repository.Work
+	flagRepo repository.CheatingFlag
+}
+
+func (s competitionCheatingService) ListFlags(cmd *CheatingFlagListCmd) (
+	[]CheatingFlagDTO, error,
+) {
+	v, err := s.flagRepo.FindFlags(cmd)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	dtos := make([]CheatingFlagDTO, len(v))
+	for i := range v {
+		dtos[i] = toCheatingFlagDTO(&v[i])
+	}
+
+	return dtos, nil
+}
+
+func (s competitionCheatingService) Review(cmd *CheatingFlagReviewCmd) (
+	code string, err error,
+) {
+	f, version, err := s.flagRepo.FindFlag(cmd.CompetitionId, cmd.FlagId)
+	if err != nil {
+		return
+	}
+
+	if !cmd.Disqualify {
+		if err = f.Accept(cmd.Reviewer); err != nil {
+			code = errorFlagReviewed
+
+			return
+		}
+
+		err = s.flagRepo.SaveFlag(&f, version)
+
+		return
+	}
+
+	if err = f.Disqualify(cmd.Reviewer); err != nil {
+		code = errorFlagReviewed
+
+		return
+	}
+
+	competition, err := s.repo.FindCompetition(&repository.CompetitionGetOption{
+		CompetitionId: cmd.CompetitionId,
+	})
+	if err != nil {
+		return
+	}
+
+	works, code, err := s.findWorks(&competition, f.Players)
+	if err != nil {
+		return
+	}
+
+	for i := range works {
+		item := &works[i]
+
+		if item.work.Disqualify() != nil {
+			continue
+		}
+
+		if err = s.workRepo.SaveDisqualification(&item.work, item.version); err != nil {
+			return
+		}
+	}
+
+	err = s.flagRepo.SaveFlag(&f, version)
+
+	return
+}
+
+type workVersion struct {
+	work    domain.Work
+	version int
+}
+
+// findWorks returns the works of players. The disqualification is kept on
+// the work, so the players can't be disqualified until all of them have
+// submitted. The flag stays pending in that case.
+func (s competitionCheatingService) findWorks(
+	c *domain.Competition, players []domain.SuspectedPlayer,
+) ([]workVersion, string, error) {
+	r := make([]workVersion, len(players))
+
+	for i := range players {
+		p := &players[i]
+
+		w, version, err := s.workRepo.FindWork(domain.NewWorkIndex(c.Id, p.Id), c.Phase)
+		if err != nil {
+			if repoerr.IsErrorResourceNotExists(err) {
+				return nil, errorFlagPlayerNoWork, fmt.Errorf(
+					"%s has not submitted and can't be disqualified", p.Name,
+				)
+			}
+
+			return nil, "", err
+		}
+
+		r[i] = workVersion{work: w, version: version}
+	}
+
+	return r, "", nil
+}
+
+// checkSubmission flags the players who submitted the same or similar file.
+// It will not fail the submission.
+func (s *competitionService) checkSubmission(w *domain.Work, ps *domain.PhaseSubmission) {
+	works, err := s.workRepo.FindSuspectedWorks(w, ps)
+	if err != nil {
+		logrus.Errorf("find suspected works of %s failed, err:%s", w.CompetitionId, err.Error())
+
+		return
+	}
+
+	s.addCheatingFlags(domain.CheckSubmission(w, ps, works))
+}
+
+// checkCompetitorContact flags the players who have the same phone or email
+// as any competitor of the player which a belongs to. It will not fail the
+// applying or joining.
+func (s *competitionService) checkCompetitorContact(cid string, a types.Account) {
+	p, _, err := s.playerRepo.FindPlayer(cid, a)
+	if err != nil {
+		logrus.Errorf("find player of %s failed, err:%s", cid, err.Error())
+
+		return
+	}
+
+	others, err := s.playerRepo.FindPlayersByContact(&p)
+	if err != nil {
+		logrus.Errorf("find players by contact failed, err:%s", err.Error())
+
+		return
+	}
+
+	s.addCheatingFlags(domain.CheckCompetitorContact(&p, others))
+}
+
+func (s *competitionService) addCheatingFlags(flags []domain.CheatingFlag) {
+	for i := range flags {
+		err := s.flagRepo.AddFlag(&flags[i])
+		if err != nil && !repoerr.IsErrorDuplicateCreating(err) {
+			logrus.Errorf(
+				"add cheating flag %s failed, err:%s",
+				flags[i].Key(), err.Error(),
+			)
+		}
+	}
+}
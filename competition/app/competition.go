@@ -44,6 +44,7 @@ func NewCompetitionService(
 	uploader uploader.SubmissionFileUploader,
 	userCli user.User,
 	user userrepo.User,
+	flagRepo repository.CheatingFlag,
 ) *competitionService {
 	return &competitionService{
 		repo:              repo,
@@ -53,6 +54,7 @@ func NewCompetitionService(
 		submissionService: domain.NewSubmissionService(uploader),
 		userCli:           userCli,
 		userRepo:          user,
+		flagRepo:          flagRepo,
 	}
 }
 
@@ -64,6 +66,7 @@ type competitionService struct {
 	submissionService domain.SubmissionService
 	userCli           user.User
 	userRepo          userrepo.User
+	flagRepo          repository.CheatingFlag
 }
 
 // show competition detail
@@ -2,10 +2,14 @@ package app
 
 const (
 	errorNotATeam            = "competition_not_a_team"
+	errorDisqualified        = "competition_disqualified"
+	errorFlagReviewed        = "competition_flag_reviewed"
+	errorFlagPlayerNoWork    = "competition_flag_player_no_work"
 	errorTeamExists          = "competition_team_exists"
 	errorNotFinalist         = "competition_not_finalist"
 	errorNoPermission        = "competition_no_permission"
 	errorSubmitTooMany       = "competition_submit_too_many_times"
+	errorSubmissionTooBig    = "competition_submission_too_big"
 	errorCompetitorExists    = "competition_competitor_exists"
 	errorTeamMembersEnough   = "competition_team_members_enough"
 	errorDoesnotOwnProject   = "competition_doesnot_own_project"
@@ -41,6 +41,8 @@ func (s *competitionService) Apply(cid string, cmd *CompetitorApplyCmd) (code st
 		return
 	}
 
+	s.checkCompetitorContact(cid, p.Leader.Account)
+
 	if err = s.userCli.AddUserRegInfo(&p.Leader); err != nil {
 		return
 	}
@@ -138,8 +140,12 @@ func (s *competitionService) JoinTeam(cid string, cmd *CompetitionTeamJoinCmd) (
 		utils.RetryThreeTimes(func() error {
 			return s.playerRepo.ResumePlayer(cid, me.Leader.Account)
 		})
+
+		return
 	}
 
+	s.checkCompetitorContact(cid, cmd.Leader)
+
 	return
 }
 
@@ -41,6 +41,10 @@ func (s *competitionService) getRankingList(
 ) []RankingDTO {
 	dtos := make([]RankingDTO, 0, len(ws))
 	for i := range ws {
+		if ws[i].Disqualified {
+			continue
+		}
+
 		if v := ws[i].BestOne(phase, order); v != nil {
 			dtos = append(dtos, RankingDTO{
 				Score:    v.Score,
@@ -217,6 +221,13 @@ func (s *competitionService) Submit(cmd *CompetitionSubmitCMD) (
 		}
 	}
 
+	if w.Disqualified {
+		code = errorDisqualified
+		err = errors.New("you are disqualified")
+
+		return
+	}
+
 	if w.HasSubmittedToday(phase) {
 		code = errorSubmitTooMany
 		err = errors.New("submit more than one time per day")
@@ -229,6 +240,10 @@ func (s *competitionService) Submit(cmd *CompetitionSubmitCMD) (
 		&w, phase, cmd.FileName, cmd.Data,
 	)
 	if err != nil {
+		if domain.IsErrorSubmissionTooBig(err) {
+			code = errorSubmissionTooBig
+		}
+
 		return
 	}
 
@@ -236,6 +251,8 @@ func (s *competitionService) Submit(cmd *CompetitionSubmitCMD) (
 		return
 	}
 
+	s.checkSubmission(&w, &ps)
+
 	// notify
 	info := w.NewSubmissionMessage(&ps)
 	if err = s.producer.SendWorkSubmittedEvent(&info); err != nil {
@@ -1,6 +1,7 @@
 package competition
 
 import (
+	"github.com/opensourceways/xihe-server/competition/domain"
 	competitionmsg "github.com/opensourceways/xihe-server/competition/infrastructure/messageadapter"
 	"github.com/opensourceways/xihe-server/infrastructure/competitionimpl"
 )
@@ -9,11 +10,13 @@ type Config struct {
 	competitionimpl.Config
 
 	Message competitionmsg.Config `json:"message" required:"true"`
+	Domain  domain.Config         `json:"domain"`
 }
 
 func (cfg *Config) ConfigItems() []interface{} {
 	return []interface{}{
 		&cfg.Config,
 		&cfg.Message,
+		&cfg.Domain,
 	}
 }
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+
 	"github.com/opensourceways/xihe-server/competition/app"
 	"github.com/opensourceways/xihe-server/competition/domain"
 	types "github.com/opensourceways/xihe-server/domain"
@@ -119,3 +121,39 @@ func (req *TransferLeaderRequest) ToCmd(leader types.Account) (
 }
 
 type DeleteMemberRequest = TransferLeaderRequest
+
+const (
+	cheatingFlagActionAccept     = "accept"
+	cheatingFlagActionDisqualify = "disqualify"
+)
+
+type ReviewCheatingFlagRequest struct {
+	Action   string `json:"action"`
+	Reviewer string `json:"reviewer"`
+}
+
+func (req *ReviewCheatingFlagRequest) ToCmd(cid, fid string) (
+	cmd app.CheatingFlagReviewCmd, err error,
+) {
+	if req.Reviewer == "" {
+		err = errors.New("missing reviewer")
+
+		return
+	}
+
+	switch req.Action {
+	case cheatingFlagActionAccept:
+	case cheatingFlagActionDisqualify:
+		cmd.Disqualify = true
+	default:
+		err = errors.New("invalid action")
+
+		return
+	}
+
+	cmd.CompetitionId = cid
+	cmd.FlagId = fid
+	cmd.Reviewer = req.Reviewer
+
+	return
+}
@@ -0,0 +1,210 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// SuspectedPlayer
+type SuspectedPlayer struct {
+	Id   string
+	Name string
+}
+
+// CheatingFlag
+// It is raised when the players of a competition are suspected of cheating,
+// and it will be reviewed by the organiser.
+type CheatingFlag struct {
+	Id            string
+	CompetitionId string
+	Kind          CheatingKind
+	Players       []SuspectedPlayer
+	Evidence      string
+	Status        CheatingFlagStatus
+	CreatedAt     int64
+	Reviewer      string
+	ReviewedAt    int64
+}
+
+func newCheatingFlag(
+	cid string, kind CheatingKind, evidence string, players ...SuspectedPlayer,
+) CheatingFlag {
+	return CheatingFlag{
+		CompetitionId: cid,
+		Kind:          kind,
+		Players:       players,
+		Evidence:      evidence,
+		Status:        CheatingFlagStatusPending,
+		CreatedAt:     utils.Now(),
+	}
+}
+
+// Key identifies the flags raised for the same players because of the same reason.
+func (f *CheatingFlag) Key() string {
+	ids := make([]string, len(f.Players))
+	for i := range f.Players {
+		ids[i] = f.Players[i].Id
+	}
+
+	sort.Strings(ids)
+
+	return f.Kind.CheatingKind() + ":" + strings.Join(ids, ",")
+}
+
+func (f *CheatingFlag) Accept(reviewer string) error {
+	return f.review(reviewer, CheatingFlagStatusAccepted)
+}
+
+func (f *CheatingFlag) Disqualify(reviewer string) error {
+	return f.review(reviewer, CheatingFlagStatusDisqualified)
+}
+
+func (f *CheatingFlag) review(reviewer string, status CheatingFlagStatus) error {
+	if !f.Status.IsPending() {
+		return errors.New("the flag has been reviewed")
+	}
+
+	f.Status = status
+	f.Reviewer = reviewer
+	f.ReviewedAt = utils.Now()
+
+	return nil
+}
+
+func (w *Work) suspectedPlayer() SuspectedPlayer {
+	return SuspectedPlayer{
+		Id:   w.PlayerId,
+		Name: w.PlayerName,
+	}
+}
+
+func (p *Player) suspectedPlayer() SuspectedPlayer {
+	return SuspectedPlayer{
+		Id:   p.Id,
+		Name: p.Name(),
+	}
+}
+
+// CheckSubmission finds the players who have submitted the same or
+// very similar file as the submission of w.
+func CheckSubmission(w *Work, s *PhaseSubmission, works []Work) []CheatingFlag {
+	var r []CheatingFlag
+
+	for i := range works {
+		other := &works[i]
+		if other.PlayerId == w.PlayerId {
+			continue
+		}
+
+		if f, ok := checkSubmissionOfWork(w, s, other); ok {
+			r = append(r, f)
+		}
+	}
+
+	return r
+}
+
+func checkSubmissionOfWork(w *Work, s *PhaseSubmission, other *Work) (CheatingFlag, bool) {
+	best := 0.0
+	submissions := other.Submissions(s.Phase)
+
+	for i := range submissions {
+		item := &submissions[i].SubmissionFingerprint
+
+		if s.IsSameAs(item) {
+			return newCheatingFlag(
+				w.CompetitionId, CheatingKindSameSubmission,
+				fmt.Sprintf("same file hash: %s", item.Hash),
+				w.suspectedPlayer(), other.suspectedPlayer(),
+			), true
+		}
+
+		if s.IsSimilarTo(item) {
+			if v := s.Similarity(item); v > best {
+				best = v
+			}
+		}
+	}
+
+	if best == 0 {
+		return CheatingFlag{}, false
+	}
+
+	return newCheatingFlag(
+		w.CompetitionId, CheatingKindSimilarSubmission,
+		fmt.Sprintf("prediction overlap: %.2f", best),
+		w.suspectedPlayer(), other.suspectedPlayer(),
+	), true
+}
+
+// CheckCompetitorContact finds the players who have a competitor registered
+// with the same phone or email as the competitors of p.
+func CheckCompetitorContact(p *Player, others []Player) []CheatingFlag {
+	var r []CheatingFlag
+
+	for i := range others {
+		other := &others[i]
+		if other.Id == p.Id {
+			continue
+		}
+
+		if v := other.competitorWithSameEmail(p); v != "" {
+			r = append(r, newCheatingFlag(
+				p.CompetitionId, CheatingKindSameEmail,
+				fmt.Sprintf("same email: %s", v),
+				p.suspectedPlayer(), other.suspectedPlayer(),
+			))
+		}
+
+		if v := other.competitorWithSamePhone(p); v != "" {
+			r = append(r, newCheatingFlag(
+				p.CompetitionId, CheatingKindSamePhone,
+				fmt.Sprintf("same phone: %s", v),
+				p.suspectedPlayer(), other.suspectedPlayer(),
+			))
+		}
+	}
+
+	return r
+}
+
+// Competitors returns the leader and all the members of the player.
+func (p *Player) Competitors() []Competitor {
+	return append([]Competitor{p.Leader}, p.Team.Members...)
+}
+
+func (p *Player) competitorWithSameEmail(p1 *Player) string {
+	for _, c := range p.Competitors() {
+		for _, c1 := range p1.Competitors() {
+			if c.Email == nil || c1.Email == nil || c.Email.Email() == "" {
+				continue
+			}
+
+			if c.Email.Email() == c1.Email.Email() {
+				return c.Email.Email()
+			}
+		}
+	}
+
+	return ""
+}
+
+func (p *Player) competitorWithSamePhone(p1 *Player) string {
+	for _, c := range p.Competitors() {
+		for _, c1 := range p1.Competitors() {
+			if c.Phone == nil || c1.Phone == nil || c.Phone.Phone() == "" {
+				continue
+			}
+
+			if c.Phone.Phone() == c1.Phone.Phone() {
+				return c.Phone.Phone()
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,225 @@
+package domain
+
+import (
+	"testing"
+
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+func testCompetitor(account, email, tel string) Competitor {
+	c := Competitor{
+		Account: types.CreateAccount(account),
+		Phone:   phone(tel),
+	}
+
+	c.Email, _ = types.NewEmail(email)
+
+	return c
+}
+
+func testPlayer(id string, leader Competitor, members ...Competitor) Player {
+	return Player{
+		PlayerIndex: NewPlayerIndex("cid", id),
+		Leader:      leader,
+		Team:        Team{Members: members},
+	}
+}
+
+func TestCheckCompetitorContact(t *testing.T) {
+	p := testPlayer(
+		"p", testCompetitor("alice", "alice@example.com", "13800000001"),
+		testCompetitor("bob", "", ""),
+	)
+
+	cases := []struct {
+		name  string
+		other Player
+		want  []CheatingKind
+	}{
+		{
+			"same player",
+			p,
+			nil,
+		},
+		{
+			"different contact",
+			testPlayer("o", testCompetitor("carol", "carol@example.com", "13800000002")),
+			nil,
+		},
+		{
+			"both empty contact",
+			testPlayer("o", testCompetitor("carol", "", "")),
+			nil,
+		},
+		{
+			"same email",
+			testPlayer("o", testCompetitor("carol", "alice@example.com", "13800000002")),
+			[]CheatingKind{CheatingKindSameEmail},
+		},
+		{
+			"same phone of member",
+			testPlayer(
+				"o", testCompetitor("carol", "carol@example.com", ""),
+				testCompetitor("dave", "dave@example.com", "13800000001"),
+			),
+			[]CheatingKind{CheatingKindSamePhone},
+		},
+		{
+			"same email and phone",
+			testPlayer("o", testCompetitor("carol", "alice@example.com", "13800000001")),
+			[]CheatingKind{CheatingKindSameEmail, CheatingKindSamePhone},
+		},
+	}
+
+	for _, c := range cases {
+		flags := CheckCompetitorContact(&p, []Player{c.other})
+
+		if len(flags) != len(c.want) {
+			t.Errorf("%s: got %d flags, want %d", c.name, len(flags), len(c.want))
+
+			continue
+		}
+
+		for i := range flags {
+			if k := flags[i].Kind.CheatingKind(); k != c.want[i].CheatingKind() {
+				t.Errorf("%s: kind = %s, want %s", c.name, k, c.want[i].CheatingKind())
+			}
+
+			if !flags[i].Status.IsPending() {
+				t.Errorf("%s: the flag is not pending", c.name)
+			}
+		}
+	}
+}
+
+func TestCheckSubmission(t *testing.T) {
+	old := config
+	defer func() { config = old }()
+
+	cfg := Config{}
+	cfg.SetDefault()
+	Init(&cfg)
+
+	predictions := []byte("1\n0\n1\n1\n0\n1\n0\n0\n1\n1\n")
+
+	same := NewSubmissionFingerprint(predictions)
+	other := NewSubmissionFingerprint([]byte("0\n1\n0\n0\n1\n0\n1\n1\n0\n0\n"))
+
+	// the same predictions with different whitespaces have different hashes
+	similar := NewSubmissionFingerprint([]byte("1 \n0\n1\n1\n0\n1\n0\n0\n1\n1\n"))
+
+	w := Work{WorkIndex: NewWorkIndex("cid", "p")}
+	s := PhaseSubmission{
+		Phase:      CompetitionPhasePreliminary,
+		Submission: Submission{SubmissionFingerprint: same},
+	}
+
+	work := func(pid string, phase CompetitionPhase, f SubmissionFingerprint) Work {
+		v := Work{WorkIndex: NewWorkIndex("cid", pid)}
+		item := []Submission{{SubmissionFingerprint: f}}
+
+		if phase.IsFinal() {
+			v.Final = item
+		} else {
+			v.Preliminary = item
+		}
+
+		return v
+	}
+
+	cases := []struct {
+		name string
+		work Work
+		want []CheatingKind
+	}{
+		{
+			"own work",
+			work("p", CompetitionPhasePreliminary, same),
+			nil,
+		},
+		{
+			"different file",
+			work("o", CompetitionPhasePreliminary, other),
+			nil,
+		},
+		{
+			"same file of other phase",
+			work("o", CompetitionPhaseFinal, same),
+			nil,
+		},
+		{
+			"same file",
+			work("o", CompetitionPhasePreliminary, same),
+			[]CheatingKind{CheatingKindSameSubmission},
+		},
+		{
+			"similar file",
+			work("o", CompetitionPhasePreliminary, similar),
+			[]CheatingKind{CheatingKindSimilarSubmission},
+		},
+	}
+
+	for _, c := range cases {
+		flags := CheckSubmission(&w, &s, []Work{c.work})
+
+		if len(flags) != len(c.want) {
+			t.Errorf("%s: got %d flags, want %d", c.name, len(flags), len(c.want))
+
+			continue
+		}
+
+		for i := range flags {
+			if k := flags[i].Kind.CheatingKind(); k != c.want[i].CheatingKind() {
+				t.Errorf("%s: kind = %s, want %s", c.name, k, c.want[i].CheatingKind())
+			}
+		}
+	}
+}
+
+func TestSubmissionFingerprintBands(t *testing.T) {
+	old := config
+	defer func() { config = old }()
+
+	cfg := Config{}
+	cfg.SetDefault()
+	Init(&cfg)
+
+	f := NewSubmissionFingerprint([]byte("1\n0\n1\n1\n0\n1\n0\n0\n1\n1\n"))
+
+	shared := func(f1 SubmissionFingerprint) bool {
+		m := map[string]bool{}
+		for _, v := range f.Bands() {
+			m[v] = true
+		}
+
+		for _, v := range f1.Bands() {
+			if m[v] {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	cases := []struct {
+		name   string
+		data   string
+		shared bool
+	}{
+		{"same file", "1\n0\n1\n1\n0\n1\n0\n0\n1\n1\n", true},
+		{"similar file", "1 \n0\n1\n1\n0\n1\n0\n0\n1\n1\n", true},
+		{"one prediction changed", "1\n0\n1\n1\n0\n1\n0\n0\n1\n0\n", true},
+		{"different file", "0\n1\n0\n0\n1\n0\n1\n1\n0\n0\n", false},
+		{"empty file", "", false},
+	}
+
+	for _, c := range cases {
+		if v := shared(NewSubmissionFingerprint([]byte(c.data))); v != c.shared {
+			t.Errorf("%s: shared band = %v, want %v", c.name, v, c.shared)
+		}
+	}
+
+	if n, want := len(f.Bands()), cfg.SignatureSize/cfg.SignatureBandSize; n != want {
+		t.Errorf("got %d bands, want %d", n, want)
+	}
+}
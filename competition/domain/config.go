@@ -0,0 +1,49 @@
+package domain
+
+import "errors"
+
+var config Config
+
+func Init(cfg *Config) {
+	config = *cfg
+}
+
+type Config struct {
+	// SimilarityThreshold is the ratio of the same predictions above which
+	// two submissions of different players will be flagged.
+	SimilarityThreshold float64 `json:"similarity_threshold"`
+	SignatureSize       int     `json:"signature_size"`
+
+	// SignatureBandSize is the num of rows of signature in a band. The
+	// smaller it is, the more candidates are found to be checked.
+	SignatureBandSize int `json:"signature_band_size"`
+
+	// MaxSubmissionSize is the max size of the submitted file. The unit is byte.
+	MaxSubmissionSize int64 `json:"max_submission_size"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.SimilarityThreshold <= 0 || cfg.SimilarityThreshold > 1 {
+		cfg.SimilarityThreshold = 0.95
+	}
+
+	if cfg.SignatureSize <= 0 {
+		cfg.SignatureSize = 128
+	}
+
+	if cfg.SignatureBandSize <= 0 {
+		cfg.SignatureBandSize = 4
+	}
+
+	if cfg.MaxSubmissionSize <= 0 {
+		cfg.MaxSubmissionSize = 10 * 1024 * 1024
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.SignatureBandSize > cfg.SignatureSize {
+		return errors.New("the band size of signature should not exceed the signature size")
+	}
+
+	return nil
+}
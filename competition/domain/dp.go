@@ -480,3 +480,77 @@ type dpLanguage string
 func (r dpLanguage) Language() string {
 	return string(r)
 }
+
+const (
+	cheatingKindSameSubmission    = "same_submission"
+	cheatingKindSimilarSubmission = "similar_submission"
+	cheatingKindSamePhone         = "same_phone"
+	cheatingKindSameEmail         = "same_email"
+
+	cheatingFlagStatusPending      = "pending"
+	cheatingFlagStatusAccepted     = "accepted"
+	cheatingFlagStatusDisqualified = "disqualified"
+)
+
+var (
+	CheatingKindSameSubmission    = cheatingKind(cheatingKindSameSubmission)
+	CheatingKindSimilarSubmission = cheatingKind(cheatingKindSimilarSubmission)
+	CheatingKindSamePhone         = cheatingKind(cheatingKindSamePhone)
+	CheatingKindSameEmail         = cheatingKind(cheatingKindSameEmail)
+
+	CheatingFlagStatusPending      = cheatingFlagStatus(cheatingFlagStatusPending)
+	CheatingFlagStatusAccepted     = cheatingFlagStatus(cheatingFlagStatusAccepted)
+	CheatingFlagStatusDisqualified = cheatingFlagStatus(cheatingFlagStatusDisqualified)
+)
+
+// CheatingKind
+type CheatingKind interface {
+	CheatingKind() string
+}
+
+func NewCheatingKind(v string) (CheatingKind, error) {
+	b := v == cheatingKindSameSubmission ||
+		v == cheatingKindSimilarSubmission ||
+		v == cheatingKindSamePhone ||
+		v == cheatingKindSameEmail
+
+	if b {
+		return cheatingKind(v), nil
+	}
+
+	return nil, errors.New("invalid cheating kind")
+}
+
+type cheatingKind string
+
+func (r cheatingKind) CheatingKind() string {
+	return string(r)
+}
+
+// CheatingFlagStatus
+type CheatingFlagStatus interface {
+	CheatingFlagStatus() string
+	IsPending() bool
+}
+
+func NewCheatingFlagStatus(v string) (CheatingFlagStatus, error) {
+	b := v == cheatingFlagStatusPending ||
+		v == cheatingFlagStatusAccepted ||
+		v == cheatingFlagStatusDisqualified
+
+	if b {
+		return cheatingFlagStatus(v), nil
+	}
+
+	return nil, errors.New("invalid cheating flag status")
+}
+
+type cheatingFlagStatus string
+
+func (r cheatingFlagStatus) CheatingFlagStatus() string {
+	return string(r)
+}
+
+func (r cheatingFlagStatus) IsPending() bool {
+	return string(r) == cheatingFlagStatusPending
+}
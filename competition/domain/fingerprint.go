@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+)
+
+// SubmissionFingerprint
+// Hash is the sha256 of the whole file.
+// Signature is the minhash of the predictions which are the lines with their
+// positions, it is used to estimate the overlap of predictions of two files.
+type SubmissionFingerprint struct {
+	Hash      string
+	Signature []uint32
+}
+
+func NewSubmissionFingerprint(data []byte) SubmissionFingerprint {
+	sum := sha256.Sum256(data)
+
+	return SubmissionFingerprint{
+		Hash:      hex.EncodeToString(sum[:]),
+		Signature: genSignature(data, config.SignatureSize),
+	}
+}
+
+func (f *SubmissionFingerprint) IsSameAs(f1 *SubmissionFingerprint) bool {
+	return f.Hash != "" && f.Hash == f1.Hash
+}
+
+// Similarity returns the estimated ratio of the same predictions.
+func (f *SubmissionFingerprint) Similarity(f1 *SubmissionFingerprint) float64 {
+	n := len(f.Signature)
+	if n == 0 || n != len(f1.Signature) {
+		return 0
+	}
+
+	same := 0
+	for i := range f.Signature {
+		if f.Signature[i] == f1.Signature[i] {
+			same++
+		}
+	}
+
+	return float64(same) / float64(n)
+}
+
+// Bands splits the signature into the bands and returns the key of each
+// band. The similar submissions share a band at least with a high
+// probability, so that they can be found by the keys without comparing
+// with all the submissions.
+func (f *SubmissionFingerprint) Bands() []string {
+	size := config.SignatureBandSize
+	if size <= 0 || len(f.Signature) == 0 {
+		return nil
+	}
+
+	r := make([]string, 0, (len(f.Signature)+size-1)/size)
+	b := make([]byte, 4)
+
+	for i := 0; i < len(f.Signature); i += size {
+		end := i + size
+		if end > len(f.Signature) {
+			end = len(f.Signature)
+		}
+
+		h := fnv.New64a()
+		for _, v := range f.Signature[i:end] {
+			binary.BigEndian.PutUint32(b, v)
+			_, _ = h.Write(b)
+		}
+
+		r = append(r, fmt.Sprintf("%d:%x", i/size, h.Sum64()))
+	}
+
+	return r
+}
+
+func (f *SubmissionFingerprint) IsSimilarTo(f1 *SubmissionFingerprint) bool {
+	return f.Similarity(f1) >= config.SimilarityThreshold
+}
+
+func genSignature(data []byte, size int) []uint32 {
+	if size <= 0 {
+		return nil
+	}
+
+	sig := make([]uint32, size)
+	for i := range sig {
+		sig[i] = math.MaxUint32
+	}
+
+	empty := true
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 0; scanner.Scan(); line++ {
+		v := bytes.TrimSpace(scanner.Bytes())
+		if len(v) == 0 {
+			continue
+		}
+
+		empty = false
+
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(strconv.Itoa(line)))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(v)
+		base := h.Sum64()
+
+		for i := range sig {
+			if v := uint32(mix64(base+uint64(i)*0x9e3779b97f4a7c15) >> 32); v < sig[i] {
+				sig[i] = v
+			}
+		}
+	}
+
+	if empty {
+		return nil
+	}
+
+	return sig
+}
+
+// mix64 is the finalizer of splitmix64.
+func mix64(v uint64) uint64 {
+	v = (v ^ (v >> 30)) * 0xbf58476d1ce4e5b9
+	v = (v ^ (v >> 27)) * 0x94d049bb133111eb
+
+	return v ^ (v >> 31)
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/competition/domain"
+)
+
+type CheatingFlagListOption struct {
+	CompetitionId string
+	Status        domain.CheatingFlagStatus
+}
+
+type CheatingFlag interface {
+	AddFlag(*domain.CheatingFlag) error
+	SaveFlag(*domain.CheatingFlag, int) error
+
+	FindFlag(cid, id string) (domain.CheatingFlag, int, error)
+	FindFlags(*CheatingFlagListOption) ([]domain.CheatingFlag, error)
+}
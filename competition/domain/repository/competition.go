@@ -40,6 +40,8 @@ type Player interface {
 
 	FindPlayer(cid string, a types.Account) (domain.Player, int, error)
 
+	FindPlayersByContact(*domain.Player) ([]domain.Player, error)
+
 	FindCompetitionsUserApplied(types.Account) ([]string, error)
 
 	SavePlayer(p *domain.Player, version int) error
@@ -54,7 +56,12 @@ type Work interface {
 	SaveRepo(*domain.Work, int) error
 	AddSubmission(*domain.Work, *domain.PhaseSubmission, int) error
 	SaveSubmission(*domain.Work, *domain.PhaseSubmission) error
+	SaveDisqualification(*domain.Work, int) error
 
 	FindWork(domain.WorkIndex, domain.CompetitionPhase) (domain.Work, int, error)
 	FindWorks(cid string) ([]domain.Work, error)
+
+	// FindSuspectedWorks returns the works of the other players which have
+	// a submission of the same hash as s or sharing a band with it.
+	FindSuspectedWorks(w *domain.Work, s *domain.PhaseSubmission) ([]domain.Work, error)
 }
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
@@ -10,6 +12,12 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var errorSubmissionTooBig = errors.New("the submission file is too big")
+
+func IsErrorSubmissionTooBig(err error) bool {
+	return errors.Is(err, errorSubmissionTooBig)
+}
+
 // SubmissionUpdatingInfo
 type SubmissionUpdatingInfo struct {
 	Index  WorkIndex
@@ -26,6 +34,8 @@ type Submission struct {
 	OBSPath  string
 	SubmitAt int64
 	Score    float32
+
+	SubmissionFingerprint
 }
 
 func (info *Submission) isSuccess() bool {
@@ -51,6 +61,16 @@ func NewSubmissionService(v uploader.SubmissionFileUploader) SubmissionService {
 func (s *SubmissionService) Submit(
 	w *Work, phase CompetitionPhase, fileName string, data io.Reader,
 ) (PhaseSubmission, error) {
+	// read one more byte to tell whether the file exceeds the max size
+	v, err := io.ReadAll(io.LimitReader(data, config.MaxSubmissionSize+1))
+	if err != nil {
+		return PhaseSubmission{}, err
+	}
+
+	if int64(len(v)) > config.MaxSubmissionSize {
+		return PhaseSubmission{}, errorSubmissionTooBig
+	}
+
 	now := utils.Now()
 
 	obspath := fmt.Sprintf(
@@ -58,7 +78,7 @@ func (s *SubmissionService) Submit(
 		w.submissionOBSPathPrefix(phase),
 		strconv.FormatInt(now, 10), fileName,
 	)
-	if err := s.uploader.Upload(data, obspath); err != nil {
+	if err := s.uploader.Upload(bytes.NewReader(v), obspath); err != nil {
 		return PhaseSubmission{}, err
 	}
 
@@ -68,6 +88,8 @@ func (s *SubmissionService) Submit(
 			SubmitAt: now,
 			OBSPath:  obspath,
 			Status:   "calculating",
+
+			SubmissionFingerprint: NewSubmissionFingerprint(v),
 		},
 		Phase: phase,
 	}, nil
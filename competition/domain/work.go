@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/opensourceways/xihe-server/utils"
@@ -24,9 +25,10 @@ type Work struct {
 
 	PlayerName string
 
-	Repo        string
-	Final       []Submission
-	Preliminary []Submission
+	Repo         string
+	Final        []Submission
+	Preliminary  []Submission
+	Disqualified bool
 }
 
 func NewWork(cid string, p *Player) Work {
@@ -67,6 +69,16 @@ func (w *Work) BestOne(phase CompetitionPhase, order CompetitionScoreOrder) (
 	return
 }
 
+func (w *Work) Disqualify() error {
+	if w.Disqualified {
+		return errors.New("already disqualified")
+	}
+
+	w.Disqualified = true
+
+	return nil
+}
+
 func (w *Work) submissionOBSPathPrefix(phase CompetitionPhase) string {
 	return fmt.Sprintf(
 		"%s/%s/%s",
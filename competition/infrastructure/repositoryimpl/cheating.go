@@ -0,0 +1,147 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	"github.com/opensourceways/xihe-server/competition/domain/repository"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+func NewCheatingFlagRepo(m mongodbClient) repository.CheatingFlag {
+	return cheatingFlagRepoImpl{m}
+}
+
+type cheatingFlagRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl cheatingFlagRepoImpl) docFilter(cid, id string) (bson.M, error) {
+	filter, err := impl.cli.ObjectIdFilter(id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter[fieldCid] = cid
+
+	return filter, nil
+}
+
+func (impl cheatingFlagRepoImpl) AddFlag(f *domain.CheatingFlag) error {
+	obj := toCheatingFlagDoc(f)
+
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return err
+	}
+	doc[fieldVersion] = 0
+
+	g := func(ctx context.Context) error {
+		// only one flag for the same players with the same reason, so the
+		// one which has been reviewed will not be raised again.
+		filter := bson.M{
+			fieldCid: f.CompetitionId,
+			fieldKey: obj.Key,
+		}
+
+		_, err := impl.cli.NewDocIfNotExist(ctx, filter, doc)
+
+		return err
+	}
+
+	if err = withContext(g); err != nil {
+		if impl.cli.IsDocExists(err) {
+			err = repoerr.NewErrorDuplicateCreating(err)
+		}
+	}
+
+	return err
+}
+
+func (impl cheatingFlagRepoImpl) SaveFlag(f *domain.CheatingFlag, version int) error {
+	filter, err := impl.docFilter(f.CompetitionId, f.Id)
+	if err != nil {
+		return err
+	}
+
+	doc := bson.M{
+		fieldStatus:     f.Status.CheatingFlagStatus(),
+		fieldReviewer:   f.Reviewer,
+		fieldReviewedAt: f.ReviewedAt,
+	}
+
+	g := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(ctx, filter, doc, mongoCmdSet, version)
+	}
+
+	if err = withContext(g); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorConcurrentUpdating(err)
+		}
+	}
+
+	return err
+}
+
+func (impl cheatingFlagRepoImpl) FindFlag(cid, id string) (
+	f domain.CheatingFlag, version int, err error,
+) {
+	filter, err := impl.docFilter(cid, id)
+	if err != nil {
+		return
+	}
+
+	var v dCheatingFlag
+
+	g := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, filter, nil, &v)
+	}
+
+	if err = withContext(g); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	if err = v.toCheatingFlag(&f); err == nil {
+		version = v.Version
+	}
+
+	return
+}
+
+func (impl cheatingFlagRepoImpl) FindFlags(opt *repository.CheatingFlagListOption) (
+	[]domain.CheatingFlag, error,
+) {
+	var v []dCheatingFlag
+
+	g := func(ctx context.Context) error {
+		filter := bson.M{fieldCid: opt.CompetitionId}
+		if opt.Status != nil {
+			filter[fieldStatus] = opt.Status.CheatingFlagStatus()
+		}
+
+		opts := options.FindOptions{}
+		opts.SetSort(bson.M{fieldCreatedAt: -1})
+
+		return impl.cli.GetDocs(ctx, filter, &opts, &v)
+	}
+
+	if err := withContext(g); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.CheatingFlag, len(v))
+	for i := range v {
+		if err := v[i].toCheatingFlag(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
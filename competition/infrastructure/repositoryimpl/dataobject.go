@@ -92,6 +92,7 @@ func (doc *dWork) toWork(w *domain.Work) {
 	w.PlayerName = doc.PlayerName
 	w.PlayerId = doc.PlayerId
 	w.Repo = doc.Repo
+	w.Disqualified = doc.Disqualified
 
 	if r := doc.toSubmissions(doc.Preliminary); len(r) != 0 {
 		w.Preliminary = r
@@ -122,6 +123,11 @@ func (doc *dSubmission) toSubmission(s *domain.Submission) {
 		OBSPath:  doc.OBSPath,
 		SubmitAt: doc.SubmitAt,
 		Score:    float32(doc.Score),
+
+		SubmissionFingerprint: domain.SubmissionFingerprint{
+			Hash:      doc.Hash,
+			Signature: doc.Signature,
+		},
 	}
 }
 
@@ -239,3 +245,52 @@ func toCompetitorDoc(c *domain.Competitor) dCompetitor {
 
 	return doc
 }
+
+func (doc *dCheatingFlag) toCheatingFlag(f *domain.CheatingFlag) (err error) {
+	if f.Kind, err = domain.NewCheatingKind(doc.Kind); err != nil {
+		return
+	}
+
+	if f.Status, err = domain.NewCheatingFlagStatus(doc.Status); err != nil {
+		return
+	}
+
+	f.Id = doc.Id.Hex()
+	f.CompetitionId = doc.CompetitionId
+	f.Evidence = doc.Evidence
+	f.CreatedAt = doc.CreatedAt
+	f.Reviewer = doc.Reviewer
+	f.ReviewedAt = doc.ReviewedAt
+
+	f.Players = make([]domain.SuspectedPlayer, len(doc.Players))
+	for i := range doc.Players {
+		f.Players[i] = domain.SuspectedPlayer{
+			Id:   doc.Players[i].Id,
+			Name: doc.Players[i].Name,
+		}
+	}
+
+	return
+}
+
+func toCheatingFlagDoc(f *domain.CheatingFlag) dCheatingFlag {
+	players := make([]dSuspectedPlayer, len(f.Players))
+	for i := range f.Players {
+		players[i] = dSuspectedPlayer{
+			Id:   f.Players[i].Id,
+			Name: f.Players[i].Name,
+		}
+	}
+
+	return dCheatingFlag{
+		CompetitionId: f.CompetitionId,
+		Key:           f.Key(),
+		Kind:          f.Kind.CheatingKind(),
+		Players:       players,
+		Evidence:      f.Evidence,
+		Status:        f.Status.CheatingFlagStatus(),
+		CreatedAt:     f.CreatedAt,
+		Reviewer:      f.Reviewer,
+		ReviewedAt:    f.ReviewedAt,
+	}
+}
@@ -19,6 +19,16 @@ const (
 	fieldStatus      = "status"
 	fieldTags        = "tags"
 	fieldLanguage    = "language"
+	fieldEmail       = "email"
+	fieldPhone       = "phone"
+	fieldKey         = "key"
+	fieldReviewer    = "reviewer"
+	fieldReviewedAt  = "reviewed_at"
+	fieldCreatedAt   = "created_at"
+	fieldHash        = "hash"
+	fieldBands       = "bands"
+
+	fieldDisqualified = "disqualified"
 )
 
 type dCompetition struct {
@@ -49,6 +59,7 @@ type dWork struct {
 	Repo          string        `bson:"repo"           json:"repo"`
 	Final         []dSubmission `bson:"final"          json:"final"`
 	Preliminary   []dSubmission `bson:"preliminary"    json:"preliminary"`
+	Disqualified  bool          `bson:"disqualified"   json:"disqualified"`
 	Version       int           `bson:"version"        json:"-"`
 }
 
@@ -58,6 +69,10 @@ type dSubmission struct {
 	OBSPath  string  `bson:"path"        json:"path"`
 	SubmitAt int64   `bson:"submit_at"   json:"submit_at"`
 	Score    float64 `bson:"score"       json:"score"`
+
+	Hash      string   `bson:"hash"        json:"hash,omitempty"`
+	Signature []uint32 `bson:"signature"   json:"signature,omitempty"`
+	Bands     []string `bson:"bands"       json:"bands,omitempty"`
 }
 
 // dPlayer
@@ -85,3 +100,22 @@ type dCompetitor struct {
 	Province string            `bson:"province"  json:"province,omitempty"`
 	Detail   map[string]string `bson:"detail"    json:"detail,omitempty"`
 }
+
+type dCheatingFlag struct {
+	Id            primitive.ObjectID `bson:"_id"            json:"-"`
+	CompetitionId string             `bson:"cid"            json:"cid"`
+	Key           string             `bson:"key"            json:"key"`
+	Kind          string             `bson:"kind"           json:"kind"`
+	Players       []dSuspectedPlayer `bson:"players"        json:"players"`
+	Evidence      string             `bson:"evidence"       json:"evidence"`
+	Status        string             `bson:"status"         json:"status"`
+	CreatedAt     int64              `bson:"created_at"     json:"created_at"`
+	Reviewer      string             `bson:"reviewer"       json:"reviewer"`
+	ReviewedAt    int64              `bson:"reviewed_at"    json:"reviewed_at"`
+	Version       int                `bson:"version"        json:"-"`
+}
+
+type dSuspectedPlayer struct {
+	Id   string `bson:"id"      json:"id"`
+	Name string `bson:"name"    json:"name"`
+}
@@ -177,6 +177,51 @@ func (impl playerRepoImpl) FindPlayer(cid string, a types.Account) (
 	return
 }
 
+// FindPlayersByContact finds the players who have a competitor registered
+// with the same phone or email as any competitor of p.
+func (impl playerRepoImpl) FindPlayersByContact(p *domain.Player) (
+	[]domain.Player, error,
+) {
+	conds := bson.A{}
+	for _, c := range p.Competitors() {
+		if c.Email != nil && c.Email.Email() != "" {
+			conds = append(conds, bson.M{fieldEmail: c.Email.Email()})
+		}
+
+		if c.Phone != nil && c.Phone.Phone() != "" {
+			conds = append(conds, bson.M{fieldPhone: c.Phone.Phone()})
+		}
+	}
+
+	if len(conds) == 0 {
+		return nil, nil
+	}
+
+	var v []dPlayer
+
+	f := func(ctx context.Context) error {
+		filter := impl.docFilter(p.CompetitionId)
+		impl.cli.AppendElemMatchToFilter(
+			fieldCompetitors, true, bson.M{"$or": conds}, filter,
+		)
+
+		return impl.cli.GetDocs(ctx, filter, nil, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.Player, len(v))
+	for i := range v {
+		if err := v[i].toPlayer(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
 // FindCompetitionsUserApplied
 func (impl playerRepoImpl) FindCompetitionsUserApplied(a types.Account) (
 	r []string, err error,
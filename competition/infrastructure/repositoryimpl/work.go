@@ -4,20 +4,45 @@ import (
 	"context"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/opensourceways/xihe-server/competition/domain"
 	"github.com/opensourceways/xihe-server/competition/domain/repository"
 	repoerr "github.com/opensourceways/xihe-server/domain/repository"
 )
 
-func NewWorkRepo(m mongodbClient) repository.Work {
-	return workRepoImpl{m}
+// NewWorkRepo creates the indexes which are used to find the suspected works.
+func NewWorkRepo(m mongodbClient) (repository.Work, error) {
+	impl := workRepoImpl{m}
+
+	if err := withContext(impl.createIndexes); err != nil {
+		return nil, err
+	}
+
+	return impl, nil
 }
 
 type workRepoImpl struct {
 	cli mongodbClient
 }
 
+func (impl workRepoImpl) createIndexes(ctx context.Context) error {
+	var v []mongo.IndexModel
+
+	for _, phase := range []string{fieldPreliminary, fieldFinal} {
+		for _, field := range []string{fieldHash, fieldBands} {
+			v = append(v, mongo.IndexModel{
+				Keys: bson.D{{Key: fieldCid, Value: 1}, {Key: phase + "." + field, Value: 1}},
+			})
+		}
+	}
+
+	_, err := impl.cli.Collection().Indexes().CreateMany(ctx, v)
+
+	return err
+}
+
 func (impl workRepoImpl) docFilter(index *domain.WorkIndex) bson.M {
 	return bson.M{
 		fieldCid: index.CompetitionId,
@@ -74,6 +99,24 @@ func (impl workRepoImpl) SaveRepo(w *domain.Work, version int) error {
 
 }
 
+func (impl workRepoImpl) SaveDisqualification(w *domain.Work, version int) error {
+	f := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(
+			ctx, impl.docFilter(&w.WorkIndex),
+			bson.M{fieldDisqualified: w.Disqualified}, mongoCmdSet, version,
+		)
+	}
+
+	err := withContext(f)
+	if err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorConcurrentUpdating(err)
+		}
+	}
+
+	return err
+}
+
 func (impl workRepoImpl) AddSubmission(
 	w *domain.Work, cs *domain.PhaseSubmission, version int,
 ) error {
@@ -82,6 +125,10 @@ func (impl workRepoImpl) AddSubmission(
 		Status:   cs.Status,
 		OBSPath:  cs.OBSPath,
 		SubmitAt: cs.SubmitAt,
+
+		Hash:      cs.Hash,
+		Signature: cs.Signature,
+		Bands:     cs.Bands(),
 	})
 	if err != nil {
 		return err
@@ -117,6 +164,10 @@ func (impl workRepoImpl) SaveSubmission(
 		SubmitAt: submission.SubmitAt,
 		Id:       submission.Id,
 		OBSPath:  submission.OBSPath,
+
+		Hash:      submission.Hash,
+		Signature: submission.Signature,
+		Bands:     submission.Bands(),
 	})
 	if err != nil {
 		return err
@@ -187,3 +238,42 @@ func (impl workRepoImpl) FindWorks(cid string) (ws []domain.Work, err error) {
 
 	return
 }
+
+func (impl workRepoImpl) FindSuspectedWorks(w *domain.Work, s *domain.PhaseSubmission) (
+	ws []domain.Work, err error,
+) {
+	field, other := fieldPreliminary, fieldFinal
+	if s.Phase.IsFinal() {
+		field, other = fieldFinal, fieldPreliminary
+	}
+
+	cond := bson.A{bson.M{field + "." + fieldHash: s.Hash}}
+	if bands := s.Bands(); len(bands) > 0 {
+		cond = append(cond, bson.M{field + "." + fieldBands: bson.M{"$in": bands}})
+	}
+
+	filter := bson.M{
+		fieldCid: w.CompetitionId,
+		fieldPid: bson.M{"$ne": w.PlayerId},
+		"$or":    cond,
+	}
+
+	var v []dWork
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDocs(
+			ctx, filter, options.Find().SetProjection(bson.M{other: 0}), &v,
+		)
+	}
+
+	if err = withContext(f); err != nil || len(v) == 0 {
+		return
+	}
+
+	ws = make([]domain.Work, len(v))
+	for i := range v {
+		v[i].toWork(&ws[i])
+	}
+
+	return
+}
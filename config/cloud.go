@@ -0,0 +1,19 @@
+package config
+
+import (
+	clouddomain "github.com/opensourceways/xihe-server/cloud/domain"
+	cloudmsg "github.com/opensourceways/xihe-server/cloud/infrastructure/messageadapter"
+)
+
+type cloudConfig struct {
+	cloudmsg.Config
+
+	Domain clouddomain.Config `json:"domain"`
+}
+
+func (cfg *cloudConfig) ConfigItems() []interface{} {
+	return []interface{}{
+		&cfg.Config,
+		&cfg.Domain,
+	}
+}
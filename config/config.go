@@ -9,7 +9,7 @@ import (
 	aiccconfig "github.com/opensourceways/xihe-server/aiccfinetune/config"
 	"github.com/opensourceways/xihe-server/app"
 	bigmodel "github.com/opensourceways/xihe-server/bigmodel/config"
-	cloudmsg "github.com/opensourceways/xihe-server/cloud/infrastructure/messageadapter"
+	clouddomain "github.com/opensourceways/xihe-server/cloud/domain"
 	cloudrepoimpl "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
 	common "github.com/opensourceways/xihe-server/common/config"
 	"github.com/opensourceways/xihe-server/common/infrastructure/kafka"
@@ -17,16 +17,21 @@ import (
 	"github.com/opensourceways/xihe-server/common/infrastructure/redis"
 	"github.com/opensourceways/xihe-server/common/infrastructure/sdk"
 	"github.com/opensourceways/xihe-server/competition"
+	competitiondomain "github.com/opensourceways/xihe-server/competition/domain"
 	"github.com/opensourceways/xihe-server/computility"
 	"github.com/opensourceways/xihe-server/controller"
 	"github.com/opensourceways/xihe-server/course"
+	coursedomain "github.com/opensourceways/xihe-server/course/domain"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/filescan/infrastructure"
+	"github.com/opensourceways/xihe-server/image"
 	"github.com/opensourceways/xihe-server/infrastructure/authingimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/challengeimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/finetuneimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/gitlab"
 	"github.com/opensourceways/xihe-server/infrastructure/messages"
+	"github.com/opensourceways/xihe-server/job/infrastructure/eventbusimpl"
+	"github.com/opensourceways/xihe-server/moderation"
 	pointsdomain "github.com/opensourceways/xihe-server/points/domain"
 	"github.com/opensourceways/xihe-server/space"
 	"github.com/opensourceways/xihe-server/spaceapp"
@@ -70,7 +75,8 @@ type Config struct {
 	Resource     messages.ResourceConfig         `json:"resource"     required:"true"`
 	Download     messages.DownloadProducerConfig `json:"download"     required:"true"`
 	Inference    inferenceimpl.Config            `json:"inference"    required:"true"`
-	Cloud        cloudmsg.Config                 `json:"cloud"        required:"true"`
+	Cloud        cloudConfig                     `json:"cloud"        required:"true"`
+	Image        image.Config                    `json:"image"`
 	User         userConfig                      `json:"user"`
 	Like         messages.LikeConfig             `json:"like"`
 	Agreement    agreement.Config                `json:"agreement"`
@@ -80,6 +86,9 @@ type Config struct {
 	Space        space.Config                    `json:"space"`
 	Filescan     infrastructure.FileScanConfig   `json:"file_scan"`
 	AuditSyncSdk sdk.Config                      `json:"audit_sync_sdk"`
+	JobEvent     eventbusimpl.Config             `json:"job_event"    required:"true"`
+	JobModel     jobModelConfig                  `json:"job_model"`
+	Moderation   moderation.Config               `json:"moderation"`
 }
 
 func (cfg *Config) GetRedisConfig() redislib.Config {
@@ -96,6 +105,7 @@ func (cfg *Config) ConfigItems() []interface{} {
 	return []interface{}{
 		&cfg.Competition.Config,
 		&cfg.Competition.Message,
+		&cfg.Competition.Domain,
 		&cfg.Challenge,
 		&cfg.Training,
 		&cfg.Finetune,
@@ -114,13 +124,16 @@ func (cfg *Config) ConfigItems() []interface{} {
 		&cfg.SignIn,
 		&cfg.Points,
 		&cfg.Cloud,
+		&cfg.Image,
 		&cfg.Download,
 		&cfg.Course,
 		&cfg.Resource,
 		&cfg.User,
 		&cfg.Like,
 		&cfg.AICCFinetune,
+		&cfg.JobModel,
 		&cfg.Agreement,
+		&cfg.Moderation,
 	}
 }
 
@@ -179,21 +192,36 @@ type MongodbCollections struct {
 	Project           string `json:"project"                required:"true"`
 	Activity          string `json:"activity"               required:"true"`
 	Training          string `json:"training"               required:"true"`
+	TrainingSweep     string `json:"training_sweep"         required:"true"`
+	TrainingMetric    string `json:"training_metric"        required:"true"`
+	TrainingPipeline  string `json:"training_pipeline"      required:"true"`
+	TrainingSchedule  string `json:"training_schedule"      required:"true"`
 	Finetune          string `json:"finetune"               required:"true"`
 	Inference         string `json:"inference"              required:"true"`
 	AIQuestion        string `json:"aiquestion"             required:"true"`
 	Competition       string `json:"competition"            required:"true"`
 	QuestionPool      string `json:"question_pool"          required:"true"`
 	WuKongPicture     string `json:"wukong_picture"         required:"true"`
+	WuKongGallery     string `json:"wukong_gallery"         required:"true"`
+	WuKongCollection  string `json:"wukong_collection"      required:"true"`
 	CompetitionWork   string `json:"competition_work"       required:"true"`
 	CompetitionPlayer string `json:"competition_player"     required:"true"`
+	CompetitionFlag   string `json:"competition_flag"       required:"true"`
 	Course            string `json:"course"                 required:"true"`
 	CoursePlayer      string `json:"course_player"          required:"true"`
 	CourseWork        string `json:"course_work"            required:"true"`
 	CourseRecord      string `json:"course_record"          required:"true"`
+	CourseCert        string `json:"course_cert"            required:"true"`
+	CourseSubmission  string `json:"course_submission"      required:"true"`
+	CoursePath        string `json:"course_path"            required:"true"`
 	CloudConf         string `json:"cloud_conf"             required:"true"`
+	CustomImage       string `json:"custom_image"           required:"true"`
 	ApiApply          string `json:"api_apply"              required:"true"`
 	ApiInfo           string `json:"api_info"               required:"true"`
+	ApiKey            string `json:"api_key"                required:"true"`
+	ApiUsage          string `json:"api_usage"              required:"true"`
+	Conversation      string `json:"conversation"           required:"true"`
+	ModerationRecord  string `json:"moderation_record"      required:"true"`
 	PointsTask        string `json:"points_task"            required:"true"`
 	UserPoints        string `json:"user_points"            required:"true"`
 	Promotion         string `json:"promotion"              required:"true"`
@@ -201,6 +229,7 @@ type MongodbCollections struct {
 	PromotionTask     string `json:"promotion_task"         required:"true"`
 	AICCFinetune      string `json:"aicc_finetune"          required:"true"`
 	UserWhiteList     string `json:"user_whitelist"         required:"true"`
+	JobEvaluation     string `json:"job_evaluation"         required:"true"`
 }
 
 func (cfg *Config) InitDomainConfig() error {
@@ -210,6 +239,12 @@ func (cfg *Config) InitDomainConfig() error {
 
 	pointsdomain.Init(&cfg.Points.Domain)
 
+	competitiondomain.Init(&cfg.Competition.Domain)
+
+	coursedomain.Init(&cfg.Course.Domain)
+
+	clouddomain.Init(&cfg.Cloud.Domain)
+
 	return nil
 }
 
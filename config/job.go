@@ -0,0 +1,18 @@
+package config
+
+import (
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	"github.com/opensourceways/xihe-server/job/infrastructure/modelserviceimpl"
+)
+
+type jobModelConfig struct {
+	App     jobapp.ModelConfig      `json:"app"`
+	Service modelserviceimpl.Config `json:"service"`
+}
+
+func (cfg *jobModelConfig) ConfigItems() []interface{} {
+	return []interface{}{
+		&cfg.App,
+		&cfg.Service,
+	}
+}
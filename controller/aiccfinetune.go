@@ -3,15 +3,13 @@ package controller
 import (
 	"fmt"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/gorilla/websocket"
 
 	"github.com/opensourceways/xihe-server/aiccfinetune/app"
 	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
 	appout "github.com/opensourceways/xihe-server/app"
-	types "github.com/opensourceways/xihe-server/domain"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
 	papp "github.com/opensourceways/xihe-server/promotion/app"
 
 	"github.com/opensourceways/xihe-server/utils"
@@ -21,23 +19,23 @@ func AddRouterForAICCFinetuneController(
 	rg *gin.RouterGroup,
 	as app.AICCFinetuneService,
 	pros papp.PromotionService,
+	hub *JobHub,
 ) {
 	ctl := AICCFinetuneController{
 		as:   as,
 		pros: pros,
+		hub:  hub,
 	}
 
 	rg.POST("/v1/aiccfinetune/:model", checkUserEmailMiddleware(&ctl.baseController), ctl.Create)
 	rg.GET("/v1/aiccfinetune/:model", ctl.List)
 	rg.GET("/v1/aiccfinetune/:model/ws", ctl.ListByWS)
-	rg.GET(
-		"/v1/aiccfinetune/:model/:id/result/:type", checkUserEmailMiddleware(&ctl.baseController),
-		ctl.GetResultDownloadURL,
-	)
-	rg.PUT("/v1/aiccfinetune/:model/:id", ctl.Terminate)
 	rg.GET("/v1/aiccfinetune/:model/:id", ctl.Get)
 	rg.DELETE("/v1/aiccfinetune/:model/:id", ctl.Delete)
 	rg.POST("/v1/aiccfinetune/:model/:task/data", ctl.UploadData)
+	rg.GET("/v1/aiccfinetune/:model/dataset", ctl.ListDatasets)
+	rg.GET("/v1/aiccfinetune/:model/dataset/:id", ctl.GetDataset)
+	rg.DELETE("/v1/aiccfinetune/:model/dataset/:id", ctl.DeleteDataset)
 }
 
 type AICCFinetuneController struct {
@@ -45,6 +43,7 @@ type AICCFinetuneController struct {
 
 	as   app.AICCFinetuneService
 	pros papp.PromotionService
+	hub  *JobHub
 }
 
 // @Summary		Create
@@ -106,9 +105,9 @@ func (ctl *AICCFinetuneController) Create(ctx *gin.Context) {
 		return
 	}
 
-	v, err := ctl.as.Create(cmd)
+	v, code, err := ctl.as.Create(cmd)
 	if err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+		ctl.sendCodeMessage(ctx, code, err)
 
 		return
 	}
@@ -146,36 +145,6 @@ func (ctl *AICCFinetuneController) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, newResponseData("success"))
 }
 
-// @Summary		Terminate
-// @Description	terminate AICC Finetune
-// @Tags			AICC Finetune
-// @Param			model	path	string	true	"model name"
-// @Param			id		path	string	true	"finetune id"
-// @Accept			json
-// @Success		202
-// @Failure		500	system_error	system	error
-// @Router			/v1/aiccfinetune/{model}/{id} [put]
-func (ctl *AICCFinetuneController) Terminate(ctx *gin.Context) {
-	info, ok := ctl.getAICCFinetuneInfo(ctx)
-	if !ok {
-		return
-	}
-
-	pl, _, _ := ctl.checkUserApiToken(ctx, false)
-	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "terminate aicc finetune")
-
-	if err := ctl.as.Terminate(&info); err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
-
-		return
-	}
-
-	utils.DoLog("", info.User.Account(), "terminate finetune",
-		fmt.Sprintf("finetune id: %s", info.FinetuneId), "success")
-
-	ctx.JSON(http.StatusAccepted, newResponseData("success"))
-}
-
 // @Summary		Get
 // @Description	get AICC finetune info
 // @Tags			AICC Finetune
@@ -188,107 +157,45 @@ func (ctl *AICCFinetuneController) Terminate(ctx *gin.Context) {
 // @Failure		500	system_error	system	error
 // @Router			/v1/aiccfinetune/{model}/{id} [get]
 func (ctl *AICCFinetuneController) Get(ctx *gin.Context) {
-	pl, csrftoken, _, ok := ctl.checkTokenForWebsocket(ctx, false)
+	index, ok := ctl.jobIndex(ctx)
 	if !ok {
 		return
 	}
 
-	model, err := domain.NewModelName(ctx.Param("model"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
-			errorBadRequestParam, err,
-		))
-
-		return
-	}
-
-	index := domain.AICCFinetuneIndex{
-		User:       pl.DomainAccount(),
-		FinetuneId: ctx.Param("id"),
-		Model:      model,
-	}
+	index.Id = ctx.Param("id")
 
-	//setup websocket
-	upgrader := websocket.Upgrader{
-		Subprotocols: []string{csrftoken},
-		CheckOrigin: func(r *http.Request) bool {
-			return r.Header.Get(headerSecWebsocket) == csrftoken
-		},
-	}
-
-	ws, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
-	if err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
-
-		return
-	}
-
-	defer ws.Close()
-
-	ctl.watchFinetune(ws, &index)
+	ctl.watchJobsByWS(ctx, ctl.hub, "aiccfinetune", index, jobLogRefreshInterval, ctl.finetuneView)
 }
 
-func (ctl *AICCFinetuneController) watchFinetune(ws *websocket.Conn, index *domain.AICCFinetuneIndex) {
-	duration := 0
-	sleep := func() {
-		time.Sleep(time.Second)
-
-		if duration > 0 {
-			duration++
-		}
+func (ctl *AICCFinetuneController) finetuneView(index *jobdomain.JobIndex) jobView {
+	info := domain.AICCFinetuneIndex{
+		User:       index.Owner,
+		FinetuneId: index.Id,
 	}
 
-	data := &aiccFinetuneDetail{}
-
-	start, end := 4, 5
-	i := start
-	for {
-		if i++; i == end {
-			v, code, err := ctl.as.Get(index)
-			if err != nil {
-				if code == appout.ErrorAICCFinetuneNotFound {
-					break
-				}
-
-				i = start
-				sleep()
-
-				continue
-			}
-
-			data.AICCFinetuneDTO = v
-
-			if duration == 0 {
-				duration = v.Duration
-			} else {
-				data.Duration = duration
-			}
+	// the scope has been checked by jobIndex
+	info.Model, _ = domain.NewModelName(index.Scope)
 
-			log, err := downloadLog(v.LogPreviewURL)
-			if err == nil && len(log) > 0 {
-				data.Log = string(log)
+	return func() (*responseData, bool, error) {
+		v, code, err := ctl.as.Get(&info)
+		if err != nil {
+			if code == appout.ErrorAICCFinetuneNotFound {
+				return nil, true, nil
 			}
 
-			if err = ws.WriteJSON(newResponseData(data)); err != nil {
-				break
-			}
-
-			if v.IsDone {
-				break
-			}
+			return nil, false, err
+		}
 
-			i = 0
-		} else {
-			if data.Duration > 0 {
-				data.Duration++
+		data := aiccFinetuneDetail{AICCFinetuneDTO: v}
 
-				if err := ws.WriteJSON(newResponseData(data)); err != nil {
-					break
-				}
-			}
+		log, err := downloadLog(v.LogPreviewURL)
+		if err == nil && len(log) > 0 {
+			data.Log = string(log)
 		}
 
-		sleep()
+		resp := newResponseData(data)
+
+		return &resp, v.IsDone, nil
 	}
 }
 
@@ -333,172 +240,53 @@ func (ctl *AICCFinetuneController) List(ctx *gin.Context) {
 // @Failure		500	system_error	system	error
 // @Router			/v1/aiccfinetune/{model}/ws [get]
 func (ctl *AICCFinetuneController) ListByWS(ctx *gin.Context) {
-	pl, csrftoken, _, ok := ctl.checkTokenForWebsocket(ctx, false)
-	if !ok {
-		return
+	if index, ok := ctl.jobIndex(ctx); ok {
+		ctl.watchJobsByWS(ctx, ctl.hub, "aiccfinetunes", index, jobListRefreshInterval, ctl.finetunesView)
 	}
-
-	model, err := domain.NewModelName(ctx.Param("model"))
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
-			errorBadRequestParam, err,
-		))
-
-		return
-	}
-
-	// setup websocket
-	upgrader := websocket.Upgrader{
-		Subprotocols: []string{csrftoken},
-		CheckOrigin: func(r *http.Request) bool {
-			return r.Header.Get(headerSecWebsocket) == csrftoken
-		},
-	}
-
-	ws, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
-	if err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
-
-		return
-	}
-
-	defer ws.Close()
-
-	ctl.watchFinetunes(ws, pl.DomainAccount(), model)
 }
 
-func (ctl *AICCFinetuneController) watchFinetunes(ws *websocket.Conn, user types.Account, model domain.ModelName) {
-	finished := func(v []app.AICCFinetuneSummaryDTO) (b bool, i int) {
-		for i = range v {
-			if !v[i].IsDone {
-				return
-			}
-		}
-
-		b = true
+func (ctl *AICCFinetuneController) finetunesView(index *jobdomain.JobIndex) jobView {
+	// the scope has been checked by jobIndex
+	model, _ := domain.NewModelName(index.Scope)
 
-		return
-	}
-
-	duration := 0
-	sleep := func() {
-		time.Sleep(time.Second)
-
-		if duration > 0 {
-			duration++
+	return func() (*responseData, bool, error) {
+		v, err := ctl.as.List(index.Owner, model)
+		if err != nil {
+			return nil, false, err
 		}
-	}
-
-	// start loop
-	var err error
-	var v []app.AICCFinetuneSummaryDTO
-	var running *app.AICCFinetuneSummaryDTO
-
-	start, end := 4, 5
-	i := start
-	for {
-		if i++; i == end {
-			v, err = ctl.as.List(user, model)
-			if err != nil {
-				i = start
-				sleep()
-
-				continue
-			}
-
-			if len(v) == 0 {
-				break
-			}
-
-			done, index := finished(v)
-			if done {
-				if err = ws.WriteJSON(newResponseData(v)); err != nil {
-					log.Error("create pod timeout | web socket write error:", err)
-				}
 
-				break
-			}
-
-			running = &v[index]
+		if len(v) == 0 {
+			return nil, true, nil
+		}
 
-			if duration == 0 {
-				duration = running.Duration
-			} else {
-				running.Duration = duration
-			}
+		done := true
+		for i := range v {
+			if !v[i].IsDone {
+				done = false
 
-			if err = ws.WriteJSON(newResponseData(v)); err != nil {
 				break
 			}
-
-			i = 0
-		} else {
-			if running.Duration > 0 {
-				running.Duration++
-
-				if err = ws.WriteJSON(newResponseData(v)); err != nil {
-					break
-				}
-			}
 		}
 
-		sleep()
-	}
-}
+		data := newResponseData(v)
 
-// @Summary		GetLog
-// @Description	get log url of AICC Finetune for downloading
-// @Tags			AICC Finetune
-// @Param			pid		path	string	true	"project id"
-// @Param			id		path	string	true	"finetune id"
-// @Param			type	path	string	true	"aicc finetune result: log, output"
-// @Accept			json
-// @Success		200	{object}		aiccFinetuneLogResp
-// @Failure		500	system_error	system	error
-// @Router			/v1/aiccfinetune/{model}/{id}/result/{type} [get]
-func (ctl *AICCFinetuneController) GetResultDownloadURL(ctx *gin.Context) {
-	pl, _, ok := ctl.checkUserApiToken(ctx, false)
-	if !ok {
-		return
+		return &data, done, nil
 	}
+}
 
-	var err error
+// jobIndex checks the model and returns the index of aicc finetunes of it.
+func (ctl *AICCFinetuneController) jobIndex(ctx *gin.Context) (jobdomain.JobIndex, bool) {
 	model, err := domain.NewModelName(ctx.Param("model"))
 	if err != nil {
-		ctl.sendBadRequest(ctx, newResponseCodeMsg(
-			errorBadRequestParam, "unknown model name",
-		))
-
-	}
+		ctl.sendBadRequestParam(ctx, err)
 
-	info := domain.AICCFinetuneIndex{
-		User:       pl.DomainAccount(),
-		Model:      model,
-		FinetuneId: ctx.Param("id"),
+		return jobdomain.JobIndex{}, false
 	}
 
-	v, code := "", ""
-
-	switch ctx.Param("type") {
-	case "log":
-		v, code, err = ctl.as.GetLogDownloadURL(&info)
-
-	case "output":
-		v, code, err = ctl.as.GetOutputDownloadURL(&info)
-
-	default:
-		ctl.sendBadRequest(ctx, newResponseCodeMsg(
-			errorBadRequestParam, "unknown result type",
-		))
-
-		return
-	}
-
-	if err != nil {
-		ctl.sendCodeMessage(ctx, code, err)
-	} else {
-		ctl.sendRespOfGet(ctx, aiccFinetuneLogResp{v})
-	}
+	return jobdomain.JobIndex{
+		Type:  jobdomain.JobTypeAICCFinetune,
+		Scope: model.ModelName(),
+	}, true
 }
 
 func (ctl *AICCFinetuneController) getAICCFinetuneInfo(ctx *gin.Context) (domain.AICCFinetuneIndex, bool) {
@@ -520,7 +308,7 @@ func (ctl *AICCFinetuneController) getAICCFinetuneInfo(ctx *gin.Context) (domain
 }
 
 // @Summary		UploadData
-// @Description	Upload Data
+// @Description	upload data, the JSONL dataset is validated and kept for the later finetunes
 // @Tags			AICC Finetune
 // @Param			model	path		string	true	"model name"
 // @Param			file	formData	file	true	"result file"
@@ -599,10 +387,8 @@ func (ctl *AICCFinetuneController) UploadData(ctx *gin.Context) {
 		Task:     task,
 	}
 
-	if v, err := ctl.as.UploadData(cmd); err != nil {
-		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
-			errorBadRequestParam, err,
-		))
+	if v, code, err := ctl.as.UploadData(cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
 
 		return
 	} else {
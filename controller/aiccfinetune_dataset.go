@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/aiccfinetune/app"
+	"github.com/opensourceways/xihe-server/aiccfinetune/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// @Summary		ListDatasets
+// @Description	list the validated datasets which can be used by the finetunes
+// @Tags			AICC Finetune
+// @Param			model	path	string	true	"model name"
+// @Accept			json
+// @Success		200	{object}		[]app.DatasetDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/aiccfinetune/{model}/dataset [get]
+func (ctl *AICCFinetuneController) ListDatasets(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	model, err := domain.NewModelName(ctx.Param("model"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.as.ListDatasets(pl.DomainAccount(), model); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		GetDataset
+// @Description	get the dataset with its report and sample rows
+// @Tags			AICC Finetune
+// @Param			model	path	string	true	"model name"
+// @Param			id		path	string	true	"dataset id"
+// @Accept			json
+// @Success		200	{object}		app.DatasetDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/aiccfinetune/{model}/dataset/{id} [get]
+func (ctl *AICCFinetuneController) GetDataset(ctx *gin.Context) {
+	index, ok := ctl.getDatasetIndex(ctx)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.as.GetDataset(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		DeleteDataset
+// @Description	delete the dataset
+// @Tags			AICC Finetune
+// @Param			model	path	string	true	"model name"
+// @Param			id		path	string	true	"dataset id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/aiccfinetune/{model}/dataset/{id} [delete]
+func (ctl *AICCFinetuneController) DeleteDataset(ctx *gin.Context) {
+	index, ok := ctl.getDatasetIndex(ctx)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, index.User.Account(), OPERATE_TYPE_USER, "delete aicc dataset")
+
+	if err := ctl.as.DeleteDataset(&index); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	utils.DoLog("", index.User.Account(), "delete aicc dataset",
+		fmt.Sprintf("dataset id: %s", index.DatasetId), "success")
+
+	ctl.sendRespOfDelete(ctx)
+}
+
+func (ctl *AICCFinetuneController) getDatasetIndex(ctx *gin.Context) (app.DatasetIndex, bool) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return app.DatasetIndex{}, false
+	}
+
+	model, err := domain.NewModelName(ctx.Param("model"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return app.DatasetIndex{}, false
+	}
+
+	return app.DatasetIndex{
+		User:      pl.DomainAccount(),
+		Model:     model,
+		DatasetId: ctx.Param("id"),
+	}, true
+}
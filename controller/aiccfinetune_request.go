@@ -11,10 +11,6 @@ type aiccFinetuneCreateResp struct {
 	Id string `json:"id"`
 }
 
-type aiccFinetuneLogResp struct {
-	LogURL string `json:"log_url"`
-}
-
 type aiccFinetuneDetail struct {
 	app.AICCFinetuneDTO
 	Log string `json:"log"`
@@ -27,6 +23,9 @@ type aiccFinetuneCreateRequest struct {
 
 	Hyperparameters []AICCKeyValue `json:"hyperparameter"`
 	Env             []AICCKeyValue `json:"env"`
+
+	// Dataset is the id of dataset uploaded before, optional
+	Dataset string `json:"dataset"`
 }
 
 func (req *aiccFinetuneCreateRequest) toCmd(cmd *app.AICCFinetuneCreateCmd, model string) (err error) {
@@ -54,6 +53,8 @@ func (req *aiccFinetuneCreateRequest) toCmd(cmd *app.AICCFinetuneCreateCmd, mode
 		return
 	}
 
+	cmd.Dataset = req.Dataset
+
 	return
 }
 
@@ -702,14 +702,13 @@ func (ctl baseController) newRepo() repositories.Access {
 	return repositories.NewAccessRepo(int(apiConfig.TokenExpiry - 10))
 }
 
-func (ctl baseController) checkBigmodelApiToken(ctx *gin.Context) (user string, ok bool) {
-	v := ctx.GetHeader(Token)
+// parseBigmodelApiToken returns the user who applied the api token. The user
+// is returned even if the token is expired.
+func (ctl baseController) parseBigmodelApiToken(v string) (user string, err error) {
 	deToken, err := ctl.decryptData(v)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
-			errorBadRequestParam, "invalid token",
-		))
-		prepareOperateLog(ctx, "anonymous", OPERATE_TYPE_SYSTEM, "check bigmodel api token")
+		err = errors.New("invalid token")
+
 		return
 	}
 	defer utils.ClearByteArrayMemory(deToken)
@@ -717,36 +716,24 @@ func (ctl baseController) checkBigmodelApiToken(ctx *gin.Context) (user string,
 	strs := strings.Split(string(deToken), "+")
 	user = strs[0]
 
-	time, err := strconv.ParseInt(strs[1], 10, 64)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
-			errorBadRequestParam, "invalid token",
-		))
-
-		if user == "" {
-			prepareOperateLog(ctx, "anonymous", OPERATE_TYPE_SYSTEM, "check bigmodel api token")
-		} else {
-			prepareOperateLog(ctx, user, OPERATE_TYPE_SYSTEM, "check bigmodel api token")
-		}
+	if len(strs) != 2 {
+		err = errors.New("invalid token")
 
 		return
 	}
 
-	if utils.Now()-time > 5184000 {
-		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
-			errorBadRequestParam, "token expire",
-		))
-
-		if user == "" {
-			prepareOperateLog(ctx, "anonymous", OPERATE_TYPE_SYSTEM, "check bigmodel api token")
-		} else {
-			prepareOperateLog(ctx, user, OPERATE_TYPE_SYSTEM, "check bigmodel api token")
-		}
+	time, err := strconv.ParseInt(strs[1], 10, 64)
+	if err != nil {
+		err = errors.New("invalid token")
 
 		return
 	}
 
-	return user, true
+	if utils.Now()-time > apiConfig.BigModelLegacyToken.Expiry {
+		err = errors.New("token expire")
+	}
+
+	return
 }
 
 func (ctl baseController) ClearCookieAfterRevokePrivacy(ctx *gin.Context) {
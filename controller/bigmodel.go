@@ -14,7 +14,6 @@ import (
 
 	"github.com/opensourceways/xihe-server/bigmodel/app"
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
-	types "github.com/opensourceways/xihe-server/domain"
 	userapp "github.com/opensourceways/xihe-server/user/app"
 	"github.com/opensourceways/xihe-server/utils"
 )
@@ -26,11 +25,19 @@ const (
 func AddRouterForBigModelController(
 	rg *gin.RouterGroup,
 	s app.BigModelService,
+	ks app.ApiKeyService,
+	usage app.ApiUsageService,
+	cs app.ConversationService,
+	wcs app.WuKongCollectionService,
 	us userapp.RegService,
 ) {
 	ctl := BigModelController{
-		s:  s,
-		us: us,
+		s:     s,
+		ks:    ks,
+		cs:    cs,
+		us:    us,
+		wcs:   wcs,
+		usage: usage,
 	}
 
 	// luojia
@@ -54,6 +61,10 @@ func AddRouterForBigModelController(
 	rg.GET("/v1/bigmodel/wukong", ctl.ListLike)
 	rg.POST("/v1/bigmodel/wukong/digg", ctl.AddDigg)
 	rg.DELETE("/v1/bigmodel/wukong/digg", ctl.CancelDigg)
+	rg.PUT("/v1/bigmodel/wukong/public/:id/tags", ctl.UpdatePublicTags)
+	rg.GET("/v1/bigmodel/wukong/public/:owner/:id/similar", ctl.ListSimilarPublics)
+	rg.GET("/v1/bigmodel/wukong/collection", ctl.ListWuKongCollections)
+	rg.GET("/v1/bigmodel/wukong/collection/:id", ctl.GetWuKongCollection)
 
 	// others
 	// rg.POST("/v1/bigmodel/ai_detector", ctl.AIDetector)
@@ -62,6 +73,7 @@ func AddRouterForBigModelController(
 	// rg.POST("/v1/bigmodel/llama2_7b", ctl.LLAMA2)
 	// rg.POST("/v1/bigmodel/skywork_13b", ctl.SkyWork)
 	// rg.POST("/v1/bigmodel/iflytekspark", ctl.IFlytekSpark)
+	rg.POST("/v1/chat/completions", ctl.ChatCompletions)
 
 	// api apply
 	rg.POST("/v1/bigmodel/api/apply/:model", ctl.ApplyApi)
@@ -70,13 +82,33 @@ func AddRouterForBigModelController(
 	// rg.POST("/v1/bigmodel/api/wukong", ctl.WukongAPI)
 	rg.GET("/v1/bigmodel/apiinfo/get/:model", ctl.GetApiInfo)
 	rg.GET("/v1/bigmodel/api/refresh/:model", ctl.RefreshApiToken)
+
+	// api key
+	rg.POST("/v1/bigmodel/api/key", ctl.CreateApiKey)
+	rg.GET("/v1/bigmodel/api/key", ctl.ListApiKeys)
+	rg.PUT("/v1/bigmodel/api/key/:id", ctl.RotateApiKey)
+	rg.DELETE("/v1/bigmodel/api/key/:id", ctl.RevokeApiKey)
+	rg.GET("/v1/bigmodel/api/usage", ctl.GetApiUsage)
+
+	// conversation
+	rg.POST("/v1/bigmodel/conversation", ctl.CreateConversation)
+	rg.GET("/v1/bigmodel/conversation", ctl.ListConversations)
+	rg.GET("/v1/bigmodel/conversation/:id", ctl.GetConversation)
+	rg.PUT("/v1/bigmodel/conversation/:id", ctl.RenameConversation)
+	rg.DELETE("/v1/bigmodel/conversation/:id", ctl.DeleteConversation)
+	rg.GET("/v1/bigmodel/conversation/:id/export", ctl.ExportConversation)
+	rg.POST("/v1/bigmodel/conversation/:id/chat", ctl.ChatInConversation)
 }
 
 type BigModelController struct {
 	baseController
 
-	s  app.BigModelService
-	us userapp.RegService
+	s     app.BigModelService
+	ks    app.ApiKeyService
+	cs    app.ConversationService
+	us    userapp.RegService
+	wcs   app.WuKongCollectionService
+	usage app.ApiUsageService
 }
 
 //	@Title			LuoJia
@@ -645,6 +677,12 @@ func (ctl *BigModelController) ListPublic(ctx *gin.Context) {
 //	@Title			GetPublicGlobal
 //	@Description	list all wukong pictures publiced
 //	@Tags			BigModel
+//	@Param			keyword			query	string	false	"search in the desc and style"
+//	@Param			style			query	string	false	"style of picture"
+//	@Param			tag				query	string	false	"tag of picture"
+//	@Param			level			query	string	false	"official or hot"
+//	@Param			count_per_page	query	int		false	"count per page, 10 by default and 100 at most"
+//	@Param			page_num		query	int		false	"page num which starts from 1, 1 by default"
 //	@Accept			json
 //	@Success		200	{object}		app.WuKongPublicDTO
 //	@Failure		500	system_error	system	error
@@ -655,14 +693,20 @@ func (ctl *BigModelController) GetPublicsGlobal(ctx *gin.Context) {
 		return
 	}
 
-	cmd := app.WuKongListPublicGlobalCmd{}
+	// the gallery is listed without limit if count_per_page is 0
+	cmd := app.WuKongListPublicGlobalCmd{
+		WuKongPictureListOption: app.WuKongPictureListOption{
+			CountPerPage: wukongPublicsDefaultCountPerPage,
+			PageNum:      1,
+		},
+	}
 
 	f := func() (err error) {
 		if v := ctl.getQueryParameter(ctx, "count_per_page"); v != "" {
 			if cmd.CountPerPage, err = strconv.Atoi(v); err != nil {
 				return
 			}
-			if cmd.CountPerPage > 100 || cmd.CountPerPage <= 0 {
+			if cmd.CountPerPage > wukongPublicsMaxCountPerPage || cmd.CountPerPage <= 0 {
 				err = errors.New("bad count_per_page")
 				return
 			}
@@ -678,6 +722,14 @@ func (ctl *BigModelController) GetPublicsGlobal(ctx *gin.Context) {
 			cmd.Level = domain.NewWuKongPictureLevel(v)
 		}
 
+		if v := ctl.getQueryParameter(ctx, "tag"); v != "" {
+			if cmd.Tag, err = domain.NewWuKongPictureTag(v); err != nil {
+				return
+			}
+		}
+
+		cmd.Keyword = ctl.getQueryParameter(ctx, "keyword")
+		cmd.Style = ctl.getQueryParameter(ctx, "style")
 		cmd.User = pl.DomainAccount()
 
 		return
@@ -1165,43 +1217,24 @@ func (ctl *BigModelController) ApplyApi(ctx *gin.Context) {
 //	@Failure		500	system_error	system	error
 //	@Router			/v1/bigmodel/api/{model} [post]
 func (ctl *BigModelController) WukongAPI(ctx *gin.Context) {
-	user, ok := ctl.checkBigmodelApiToken(ctx)
-	if !ok {
-		return
-	}
-	ac, err := types.NewAccount(user)
-	if err != nil {
-		ctl.sendBadRequestParam(ctx, err)
-		return
-	}
-
-	desc := "launch wukong bigmodel by api"
-	prepareOperateLog(ctx, user, OPERATE_TYPE_USER, desc)
-
 	model, err := domain.NewModelName("wukong")
 	if err != nil {
 		ctl.sendBadRequestParam(ctx, err)
 		return
 	}
 
-	r, err := ctl.s.GetApplyRecordByModel(ac, model)
+	caller, code, err := ctl.authApiCaller(apiTokenOf(ctx), model)
 	if err != nil {
-		ctl.sendBadRequestParam(ctx, err)
+		prepareOperateLog(ctx, "anonymous", OPERATE_TYPE_SYSTEM, "check bigmodel api token")
+		ctl.sendCodeMessage(ctx, code, err)
+
 		return
 	}
 
-	deToken, err := ctl.decryptDataForToken(r)
-	if err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	ac := caller.User
 
-		return
-	}
-	if ctx.GetHeader(Token) != string(deToken) {
-		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
-			errorBadRequestParam, "invalid token",
-		))
-		return
-	}
+	desc := "launch wukong bigmodel by api"
+	prepareOperateLog(ctx, ac.Account(), OPERATE_TYPE_USER, desc)
 
 	req := wukongApiRequest{}
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -1216,11 +1249,26 @@ func (ctl *BigModelController) WukongAPI(ctx *gin.Context) {
 		return
 	}
 
-	if v, code, err := ctl.s.WukongApi(ac, model, &cmd); err != nil {
+	if code, err := ctl.checkApiLimit(ctx, &caller, model); err != nil {
+		if code == app.ErrorApiRateLimited || code == app.ErrorApiExceedTokenQuota {
+			ctx.JSON(http.StatusTooManyRequests, newResponseCodeError(code, err))
+		} else {
+			ctl.sendCodeMessage(ctx, code, err)
+		}
+
+		return
+	}
+
+	v, code, err := ctl.s.WukongApi(ac, model, &cmd)
+	if err != nil {
 		ctl.sendCodeMessage(ctx, code, err)
-	} else {
-		ctl.sendRespOfPost(ctx, wukongPicturesGenerateResp{v})
+
+		return
 	}
+
+	ctl.recordApiCall(&caller, model, utils.StrLen(req.Desc), 0)
+
+	ctl.sendRespOfPost(ctx, wukongPicturesGenerateResp{v})
 }
 
 //	@Title			GetUserApplyRecord
@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/bigmodel/app"
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	headerAuthorization = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+// @Summary		CreateApiKey
+// @Description	create api key which can call the apis of models in the scopes
+// @Tags			BigModel
+// @Param			body	body	apiKeyCreateRequest	true	"body of api key"
+// @Accept			json
+// @Success		201	{object}			app.ApiKeyCreatedDTO
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/api/key [post]
+func (ctl *BigModelController) CreateApiKey(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	req := apiKeyCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(pl.DomainAccount())
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "create bigmodel api key")
+
+	v, code, err := ctl.ks.Create(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "create bigmodel api key",
+		fmt.Sprintf("api key id: %s", v.Id), "success")
+
+	ctl.sendRespOfPost(ctx, v)
+}
+
+// @Summary		ListApiKeys
+// @Description	list the api keys of user, the plain keys are not returned
+// @Tags			BigModel
+// @Accept			json
+// @Success		200	{object}		[]app.ApiKeyDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/api/key [get]
+func (ctl *BigModelController) ListApiKeys(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, err := ctl.ks.List(pl.DomainAccount()); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		RotateApiKey
+// @Description	replace the api key with a new one, the old one is invalid immediately
+// @Tags			BigModel
+// @Param			id	path	string	true	"api key id"
+// @Accept			json
+// @Success		202	{object}		app.ApiKeyCreatedDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/api/key/{id} [put]
+func (ctl *BigModelController) RotateApiKey(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	id := ctx.Param("id")
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "rotate bigmodel api key")
+
+	v, code, err := ctl.ks.Rotate(pl.DomainAccount(), id)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "rotate bigmodel api key",
+		fmt.Sprintf("api key id: %s", id), "success")
+
+	ctl.sendRespOfPut(ctx, v)
+}
+
+// @Summary		RevokeApiKey
+// @Description	revoke the api key, it is invalid immediately
+// @Tags			BigModel
+// @Param			id	path	string	true	"api key id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/api/key/{id} [delete]
+func (ctl *BigModelController) RevokeApiKey(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	id := ctx.Param("id")
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "revoke bigmodel api key")
+
+	if err := ctl.ks.Revoke(pl.DomainAccount(), id); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "revoke bigmodel api key",
+		fmt.Sprintf("api key id: %s", id), "success")
+
+	ctl.sendRespOfDelete(ctx)
+}
+
+// authApiCaller returns the user who calls the api of model. The caller is
+// authenticated by the api key, or by the token applied for the model which
+// is deprecated and only accepted until the end date in the config.
+func (ctl *BigModelController) authApiCaller(token string, model domain.ModelName) (
+	caller app.ApiCaller, code string, err error,
+) {
+	if domain.IsApiKey(token) {
+		return ctl.ks.Authenticate(token, model)
+	}
+
+	code = errorInvalidToken
+
+	if !apiConfig.BigModelLegacyToken.isAccepted() {
+		err = errors.New("the token is not accepted any more, please use the api key")
+
+		return
+	}
+
+	name, err := ctl.parseBigmodelApiToken(token)
+	if err != nil {
+		return
+	}
+
+	user, err := types.NewAccount(name)
+	if err != nil {
+		err = errors.New("invalid token")
+
+		return
+	}
+
+	r, err := ctl.s.GetApplyRecordByModel(user, model)
+	if err != nil {
+		err = errors.New("the api of model is not applied")
+
+		return
+	}
+
+	deToken, err := ctl.decryptDataForToken(r)
+	if err != nil {
+		code = ""
+
+		return
+	}
+	defer utils.ClearByteArrayMemory(deToken)
+
+	if token != string(deToken) {
+		err = errors.New("invalid token")
+
+		return
+	}
+
+	caller.User = user
+	code = ""
+
+	return
+}
+
+// apiTokenOf returns the bearer token, or the token in the header as before.
+func apiTokenOf(ctx *gin.Context) string {
+	if v := ctx.GetHeader(headerAuthorization); strings.HasPrefix(v, bearerPrefix) {
+		return strings.TrimPrefix(v, bearerPrefix)
+	}
+
+	return ctx.GetHeader(Token)
+}
@@ -0,0 +1,71 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/bigmodel/app"
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+)
+
+const (
+	headerRetryAfter    = "Retry-After"
+	defaultApiUsageDays = 30
+)
+
+// @Summary		GetApiUsage
+// @Description	get the daily usage of apis of models
+// @Tags			BigModel
+// @Param			days	query	int	false	"the recent days, 30 by default"
+// @Accept			json
+// @Success		200	{object}		[]app.ApiUsageDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/api/usage [get]
+func (ctl *BigModelController) GetApiUsage(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	days := defaultApiUsageDays
+	if v := ctl.getQueryParameter(ctx, "days"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		days = n
+	}
+
+	if v, err := ctl.usage.ListUsage(pl.DomainAccount(), days); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// checkApiLimit sets the Retry-After header if the limit is reached.
+func (ctl *BigModelController) checkApiLimit(
+	ctx *gin.Context, caller *app.ApiCaller, model domain.ModelName,
+) (string, error) {
+	wait, code, err := ctl.usage.Allow(caller, model)
+	if err != nil && wait > 0 {
+		ctx.Header(headerRetryAfter, strconv.Itoa(wait))
+	}
+
+	return code, err
+}
+
+func (ctl *BigModelController) recordApiCall(
+	caller *app.ApiCaller, model domain.ModelName, prompt, completion int,
+) {
+	ctl.usage.Record(&app.ApiCallCmd{
+		ApiCaller:        *caller,
+		Model:            model,
+		PromptTokens:     prompt,
+		CompletionTokens: completion,
+	})
+}
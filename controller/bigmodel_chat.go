@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/opensourceways/xihe-server/bigmodel/app"
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	chatFinishReasonStop = "stop"
+	chatStreamDone       = "[DONE]"
+
+	chatErrorTypeInvalidRequest = "invalid_request_error"
+	chatErrorTypeAuthentication = "authentication_error"
+	chatErrorTypeServer         = "server_error"
+	chatErrorTypeRateLimit      = "rate_limit_error"
+)
+
+// @Summary		ChatCompletions
+// @Description	the OpenAI compatible api of the hosted chat models
+// @Tags			BigModel
+// @Param			Authorization	header	string					true	"Bearer api key"
+// @Param			body			body	chatCompletionRequest	true	"body of chat completion"
+// @Accept			json
+// @Success		200	{object}			chatCompletionResp
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		401	invalid_token		invalid	token
+// @Failure		429	rate_limited		too		many	requests
+// @Failure		500	system_error		system	error
+// @Router			/v1/chat/completions [post]
+func (ctl *BigModelController) ChatCompletions(ctx *gin.Context) {
+	req := chatCompletionRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendChatError(ctx, http.StatusBadRequest, chatErrorTypeInvalidRequest,
+			errorBadRequestBody, "can't parse request body")
+
+		return
+	}
+
+	model, err := domain.NewChatModelName(req.Model)
+	if err != nil {
+		ctl.sendChatError(ctx, http.StatusBadRequest, chatErrorTypeInvalidRequest,
+			errorBadRequestParam, err.Error())
+
+		return
+	}
+
+	caller, ok := ctl.checkChatApiToken(ctx, model)
+	if !ok {
+		return
+	}
+
+	user := caller.User
+
+	prepareOperateLog(ctx, user.Account(), OPERATE_TYPE_USER, "launch chat completion by api")
+
+	ch := make(chan string, chBufferSize)
+	cmd, err := req.toCmd(ch, user, model)
+	if err != nil {
+		ctl.sendChatError(ctx, http.StatusBadRequest, chatErrorTypeInvalidRequest,
+			errorBadRequestParam, err.Error())
+
+		return
+	}
+
+	if code, err := ctl.checkApiLimit(ctx, &caller, model); err != nil {
+		ctl.sendChatCodeError(ctx, code, err)
+
+		return
+	}
+
+	if code, err := ctl.s.ChatCompletion(&cmd); err != nil {
+		ctl.sendChatCodeError(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", user.Account(), "launch chat completion by api",
+		fmt.Sprintf("model: %s", model.ModelName()), "success")
+
+	// don't block the model if the client leaves early
+	defer func() {
+		go func() {
+			for range ch {
+			}
+		}()
+	}()
+
+	id := "chatcmpl-" + primitive.NewObjectID().Hex()
+	created := utils.Now()
+
+	completion := 0
+	defer func() {
+		ctl.recordApiCall(&caller, model, cmd.Size(), completion)
+	}()
+
+	if !req.Stream {
+		b := strings.Builder{}
+		for msg := range ch {
+			if msg == "done" {
+				break
+			}
+
+			b.WriteString(msg)
+		}
+
+		completion = utils.StrLen(b.String())
+
+		ctx.JSON(http.StatusOK, newChatCompletionResp(id, created, req.Model, b.String()))
+
+		return
+	}
+
+	ctx.Header("Content-Type", "text/event-stream; charset=utf-8")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+
+	first := true
+	ctx.Stream(func(w io.Writer) bool {
+		msg, ok := <-ch
+		if !ok || msg == "done" {
+			chunk := newChatCompletionChunk(id, created, req.Model)
+			chunk.Choices[0].FinishReason = chatFinishReasonStop
+
+			writeChatChunk(w, &chunk)
+			fmt.Fprintf(w, "data: %s\n\n", chatStreamDone)
+
+			return false
+		}
+
+		chunk := newChatCompletionChunk(id, created, req.Model)
+		if first {
+			first = false
+			chunk.Choices[0].Delta.Role = domain.ChatRoleAssistant
+		}
+		chunk.Choices[0].Delta.Content = msg
+		completion += utils.StrLen(msg)
+
+		writeChatChunk(w, &chunk)
+
+		return true
+	})
+}
+
+// checkChatApiToken authenticates the caller by the api key, which is passed
+// as the bearer token like the OpenAI api.
+func (ctl *BigModelController) checkChatApiToken(ctx *gin.Context, model domain.ModelName) (
+	app.ApiCaller, bool,
+) {
+	caller, code, err := ctl.authApiCaller(apiTokenOf(ctx), model)
+	if err == nil {
+		return caller, true
+	}
+
+	if code == "" {
+		ctl.sendChatError(ctx, http.StatusInternalServerError, chatErrorTypeServer,
+			errorSystemError, err.Error())
+	} else {
+		ctl.sendChatError(ctx, http.StatusUnauthorized, chatErrorTypeAuthentication,
+			code, err.Error())
+	}
+
+	prepareOperateLog(ctx, "anonymous", OPERATE_TYPE_SYSTEM, "check chat api token")
+
+	return caller, false
+}
+
+func (ctl *BigModelController) sendChatCodeError(ctx *gin.Context, code string, err error) {
+	switch code {
+	case "":
+		ctl.sendChatError(ctx, http.StatusInternalServerError, chatErrorTypeServer,
+			errorSystemError, err.Error())
+
+	case app.ErrorApiRateLimited, app.ErrorApiExceedTokenQuota:
+		ctl.sendChatError(ctx, http.StatusTooManyRequests, chatErrorTypeRateLimit,
+			code, err.Error())
+
+	case app.ErrorBigModelRecourseBusy:
+		ctl.sendChatError(ctx, http.StatusTooManyRequests, chatErrorTypeServer,
+			code, "access overload, please try again later")
+
+	case app.ErrorBigModelSensitiveInfo:
+		ctl.sendChatError(ctx, http.StatusBadRequest, chatErrorTypeInvalidRequest,
+			code, "I cannot answer such questions")
+
+	default:
+		ctl.sendChatError(ctx, http.StatusBadRequest, chatErrorTypeInvalidRequest,
+			code, err.Error())
+	}
+}
+
+// sendChatError responds the error in the format of OpenAI api, so that the
+// client of OpenAI can parse it.
+func (ctl *BigModelController) sendChatError(ctx *gin.Context, status int, t, code, msg string) {
+	ctx.JSON(status, chatErrorResp{
+		Error: chatError{
+			Message: msg,
+			Type:    t,
+			Code:    code,
+		},
+	})
+}
+
+func writeChatChunk(w io.Writer, chunk *chatCompletionChunk) {
+	v, err := json.Marshal(chunk)
+	if err != nil {
+		logrus.Errorf("marshal chat chunk failed, err:%s", err.Error())
+
+		return
+	}
+
+	fmt.Fprintf(w, "data: %s\n\n", v)
+}
@@ -0,0 +1,270 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/bigmodel/app"
+	"github.com/opensourceways/xihe-server/bigmodel/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// @Summary		CreateConversation
+// @Description	create conversation with the chat model
+// @Tags			BigModel
+// @Param			body	body	conversationCreateRequest	true	"body of conversation"
+// @Accept			json
+// @Success		201	{object}			app.ConversationDTO
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/conversation [post]
+func (ctl *BigModelController) CreateConversation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	req := conversationCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(pl.DomainAccount())
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, code, err := ctl.cs.Create(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary		ListConversations
+// @Description	list the conversations of user, the recent first
+// @Tags			BigModel
+// @Accept			json
+// @Success		200	{object}		[]app.ConversationDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/conversation [get]
+func (ctl *BigModelController) ListConversations(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, err := ctl.cs.List(pl.DomainAccount()); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		GetConversation
+// @Description	get the conversation with its messages
+// @Tags			BigModel
+// @Param			id	path	string	true	"conversation id"
+// @Accept			json
+// @Success		200	{object}		app.ConversationDetailDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/conversation/{id} [get]
+func (ctl *BigModelController) GetConversation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.cs.Get(pl.DomainAccount(), ctx.Param("id")); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		RenameConversation
+// @Description	rename the conversation
+// @Tags			BigModel
+// @Param			id		path	string						true	"conversation id"
+// @Param			body	body	conversationRenameRequest	true	"body of title"
+// @Accept			json
+// @Success		202
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/conversation/{id} [put]
+func (ctl *BigModelController) RenameConversation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	req := conversationRenameRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	title, err := domain.NewConversationTitle(req.Title)
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.cs.Rename(pl.DomainAccount(), ctx.Param("id"), title); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+// @Summary		DeleteConversation
+// @Description	delete the conversation
+// @Tags			BigModel
+// @Param			id	path	string	true	"conversation id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/conversation/{id} [delete]
+func (ctl *BigModelController) DeleteConversation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	id := ctx.Param("id")
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "delete bigmodel conversation")
+
+	if err := ctl.cs.Delete(pl.DomainAccount(), id); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "delete bigmodel conversation",
+		fmt.Sprintf("conversation id: %s", id), "success")
+
+	ctl.sendRespOfDelete(ctx)
+}
+
+// @Summary		ExportConversation
+// @Description	export the conversation as a file
+// @Tags			BigModel
+// @Param			id		path	string	true	"conversation id"
+// @Param			format	query	string	false	"json or markdown, json by default"
+// @Accept			json
+// @Success		200
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/conversation/{id}/export [get]
+func (ctl *BigModelController) ExportConversation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	format := ctl.getQueryParameter(ctx, "format")
+	if format == "" {
+		format = app.ConversationExportJSON
+	}
+
+	v, code, err := ctl.cs.Export(pl.DomainAccount(), ctx.Param("id"), format)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctx.Header(
+		"Content-Disposition",
+		fmt.Sprintf("attachment; filename*=UTF-8''%s", url.PathEscape(v.FileName)),
+	)
+	ctx.Data(http.StatusOK, v.ContentType, v.Content)
+}
+
+// @Summary		ChatInConversation
+// @Description	chat with the model in the conversation, the reply is streamed
+// @Tags			BigModel
+// @Param			id		path	string					true	"conversation id"
+// @Param			body	body	conversationChatRequest	true	"body of chat"
+// @Accept			json
+// @Success		202	{object}			string
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/conversation/{id}/chat [post]
+func (ctl *BigModelController) ChatInConversation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	desc := "chat in bigmodel conversation"
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, desc)
+
+	req := conversationChatRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	ch := make(chan string, chBufferSize)
+	cmd, err := req.toCmd(ch, pl.DomainAccount(), ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.cs.Chat(&cmd); err != nil {
+		switch code {
+		case app.ErrorBigModelRecourseBusy:
+			ctl.sendCodeMessage(ctx, code, errors.New("access overload, please try again later"))
+
+		case app.ErrorBigModelSensitiveInfo:
+			ctl.sendCodeMessage(ctx, code, errors.New("I cannot answer such questions"))
+
+		default:
+			ctl.sendCodeMessage(ctx, code, err)
+		}
+
+		return
+	}
+
+	// don't block the relay if the client leaves early, otherwise the reply
+	// will not be saved.
+	defer func() {
+		go func() {
+			for range ch {
+			}
+		}()
+	}()
+
+	ctx.Header("Content-Type", "text/event-stream; charset=utf-8")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+
+	ctx.Stream(func(w io.Writer) bool {
+		if msg, ok := <-ch; ok {
+			if msg == "done" {
+				ctx.SSEvent("status", "done")
+			} else {
+				ctx.SSEvent("message", msg)
+			}
+
+			return true
+		}
+
+		return false
+	})
+}
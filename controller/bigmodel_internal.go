@@ -0,0 +1,327 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/bigmodel/app"
+)
+
+func AddRouterForBigModelInternalController(
+	rg *gin.RouterGroup,
+	s app.EndpointService,
+	bm app.BigModelService,
+	wcs app.WuKongCollectionService,
+) {
+	ctl := BigModelInternalController{
+		s:   s,
+		bm:  bm,
+		wcs: wcs,
+	}
+
+	rg.GET("/v1/bigmodel/endpoint", internalApiCheckMiddleware(&ctl.baseController), ctl.ListEndpoints)
+	rg.POST("/v1/bigmodel/endpoint/:pool", internalApiCheckMiddleware(&ctl.baseController), ctl.AddEndpoint)
+	rg.PUT("/v1/bigmodel/endpoint/:pool", internalApiCheckMiddleware(&ctl.baseController), ctl.DrainEndpoint)
+	rg.DELETE("/v1/bigmodel/endpoint/:pool", internalApiCheckMiddleware(&ctl.baseController), ctl.RemoveEndpoint)
+
+	rg.POST("/v1/bigmodel/wukong/gallery/sync", internalApiCheckMiddleware(&ctl.baseController), ctl.SyncWuKongGallery)
+	rg.GET("/v1/bigmodel/wukong/collection", internalApiCheckMiddleware(&ctl.baseController), ctl.ListWuKongCollections)
+	rg.POST("/v1/bigmodel/wukong/collection", internalApiCheckMiddleware(&ctl.baseController), ctl.CreateWuKongCollection)
+	rg.GET("/v1/bigmodel/wukong/collection/:id", internalApiCheckMiddleware(&ctl.baseController), ctl.GetWuKongCollection)
+	rg.PUT("/v1/bigmodel/wukong/collection/:id", internalApiCheckMiddleware(&ctl.baseController), ctl.UpdateWuKongCollection)
+	rg.DELETE("/v1/bigmodel/wukong/collection/:id", internalApiCheckMiddleware(&ctl.baseController), ctl.DeleteWuKongCollection)
+	rg.POST("/v1/bigmodel/wukong/collection/:id/picture", internalApiCheckMiddleware(&ctl.baseController), ctl.AddWuKongCollectionPicture)
+	rg.DELETE(
+		"/v1/bigmodel/wukong/collection/:id/picture/:owner/:pid",
+		internalApiCheckMiddleware(&ctl.baseController), ctl.RemoveWuKongCollectionPicture,
+	)
+}
+
+type BigModelInternalController struct {
+	baseController
+
+	s   app.EndpointService
+	bm  app.BigModelService
+	wcs app.WuKongCollectionService
+}
+
+// @Summary		ListEndpoints
+// @Description	list the endpoints of models with their states and stats
+// @Tags			BigModelInternal
+// @Accept			json
+// @Success		200	{object}	[]app.EndpointPoolDTO
+// @Router			/v1/bigmodel/endpoint [get]
+func (ctl *BigModelInternalController) ListEndpoints(ctx *gin.Context) {
+	ctl.sendRespOfGet(ctx, ctl.s.List())
+}
+
+// @Summary		AddEndpoint
+// @Description	add endpoint to the pool of model, it only takes effect on the current instance
+// @Tags			BigModelInternal
+// @Param			pool	path	string				true	"pool name, such as glm, llama, wukong"
+// @Param			body	body	endpointAddRequest	true	"body of endpoint"
+// @Accept			json
+// @Success		201
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/endpoint/{pool} [post]
+func (ctl *BigModelInternalController) AddEndpoint(ctx *gin.Context) {
+	req := endpointAddRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("pool"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.s.Add(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, "success")
+	}
+}
+
+// @Summary		DrainEndpoint
+// @Description	stop or resume dispatching requests to the endpoint, the requests in flight are not affected
+// @Tags			BigModelInternal
+// @Param			pool	path	string					true	"pool name"
+// @Param			body	body	endpointDrainRequest	true	"body of drain"
+// @Accept			json
+// @Success		202
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/endpoint/{pool} [put]
+func (ctl *BigModelInternalController) DrainEndpoint(ctx *gin.Context) {
+	req := endpointDrainRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("pool"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.s.Drain(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+// @Summary		RemoveEndpoint
+// @Description	remove the endpoint from the pool of model, drain it first to avoid new requests
+// @Tags			BigModelInternal
+// @Param			pool	path	string	true	"pool name"
+// @Param			url		query	string	true	"url of endpoint"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/endpoint/{pool} [delete]
+func (ctl *BigModelInternalController) RemoveEndpoint(ctx *gin.Context) {
+	url := ctl.getQueryParameter(ctx, "url")
+	if url == "" {
+		ctl.sendBadRequestParam(ctx, errors.New("missing url"))
+
+		return
+	}
+
+	if code, err := ctl.s.Remove(ctx.Param("pool"), url); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
+
+// @Summary		SyncWuKongGallery
+// @Description	save all the public wukong pictures to the gallery
+// @Tags			BigModelInternal
+// @Accept			json
+// @Success		201	{object}		wukongGallerySyncResp
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/wukong/gallery/sync [post]
+func (ctl *BigModelInternalController) SyncWuKongGallery(ctx *gin.Context) {
+	if n, err := ctl.bm.SyncWuKongGallery(); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfPost(ctx, wukongGallerySyncResp{n})
+	}
+}
+
+// @Summary		ListWuKongCollections
+// @Description	list all the collections of wukong pictures
+// @Tags			BigModelInternal
+// @Accept			json
+// @Success		200	{object}		[]app.WuKongCollectionDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/wukong/collection [get]
+func (ctl *BigModelInternalController) ListWuKongCollections(ctx *gin.Context) {
+	if v, err := ctl.wcs.List(false); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		CreateWuKongCollection
+// @Description	create collection of wukong pictures
+// @Tags			BigModelInternal
+// @Param			body	body	wukongCollectionCreateRequest	true	"body of collection"
+// @Accept			json
+// @Success		201	{object}			app.WuKongCollectionDTO
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/wukong/collection [post]
+func (ctl *BigModelInternalController) CreateWuKongCollection(ctx *gin.Context) {
+	req := wukongCollectionCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.wcs.Create(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfPost(ctx, v)
+	}
+}
+
+// @Summary		GetWuKongCollection
+// @Description	get the collection with its pictures, whether it is featured or not
+// @Tags			BigModelInternal
+// @Param			id	path	string	true	"collection id"
+// @Accept			json
+// @Success		200	{object}		app.WuKongCollectionDetailDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/wukong/collection/{id} [get]
+func (ctl *BigModelInternalController) GetWuKongCollection(ctx *gin.Context) {
+	if v, code, err := ctl.wcs.Get(nil, ctx.Param("id"), false); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		UpdateWuKongCollection
+// @Description	update the name, desc or featured of collection
+// @Tags			BigModelInternal
+// @Param			id		path	string							true	"collection id"
+// @Param			body	body	wukongCollectionUpdateRequest	true	"body of collection"
+// @Accept			json
+// @Success		202
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/wukong/collection/{id} [put]
+func (ctl *BigModelInternalController) UpdateWuKongCollection(ctx *gin.Context) {
+	req := wukongCollectionUpdateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.wcs.Update(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+// @Summary		DeleteWuKongCollection
+// @Description	delete the collection, the pictures in it are not affected
+// @Tags			BigModelInternal
+// @Param			id	path	string	true	"collection id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/wukong/collection/{id} [delete]
+func (ctl *BigModelInternalController) DeleteWuKongCollection(ctx *gin.Context) {
+	if err := ctl.wcs.Delete(ctx.Param("id")); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
+
+// @Summary		AddWuKongCollectionPicture
+// @Description	add public wukong picture to the collection
+// @Tags			BigModelInternal
+// @Param			id		path	string							true	"collection id"
+// @Param			body	body	wukongCollectionPictureRequest	true	"body of picture"
+// @Accept			json
+// @Success		201
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/wukong/collection/{id}/picture [post]
+func (ctl *BigModelInternalController) AddWuKongCollectionPicture(ctx *gin.Context) {
+	req := wukongCollectionPictureRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.wcs.AddPicture(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, "success")
+	}
+}
+
+// @Summary		RemoveWuKongCollectionPicture
+// @Description	remove wukong picture from the collection
+// @Tags			BigModelInternal
+// @Param			id		path	string	true	"collection id"
+// @Param			owner	path	string	true	"owner of picture"
+// @Param			pid		path	string	true	"picture id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/wukong/collection/{id}/picture/{owner}/{pid} [delete]
+func (ctl *BigModelInternalController) RemoveWuKongCollectionPicture(ctx *gin.Context) {
+	cmd, err := toWuKongCollectionPictureCmd(ctx.Param("id"), ctx.Param("owner"), ctx.Param("pid"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.wcs.RemovePicture(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
@@ -1,6 +1,10 @@
 package controller
 
 import (
+	"errors"
+	"net/url"
+	"strings"
+
 	"github.com/opensourceways/xihe-server/bigmodel/app"
 	"github.com/opensourceways/xihe-server/bigmodel/domain"
 	types "github.com/opensourceways/xihe-server/domain"
@@ -477,3 +481,361 @@ func (req *iflyteksparkRequest) toCmd(ch chan string, user types.Account) (cmd a
 
 	return
 }
+
+// chat completion
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Stream      bool          `json:"stream"`
+	Temperature *float64      `json:"temperature"`
+	TopP        *float64      `json:"top_p"`
+}
+
+func (req *chatCompletionRequest) toCmd(
+	ch chan string, user types.Account, model domain.ModelName,
+) (cmd app.ChatCompletionCmd, err error) {
+	msgs := make([]domain.ChatMessage, len(req.Messages))
+	for i := range req.Messages {
+		msgs[i] = domain.ChatMessage{
+			Role:    req.Messages[i].Role,
+			Content: req.Messages[i].Content,
+		}
+	}
+
+	if cmd.ChatConversation, err = domain.NewChatConversation(msgs); err != nil {
+		return
+	}
+
+	if req.Temperature != nil {
+		if cmd.Temperature, err = domain.NewTemperature(*req.Temperature); err != nil {
+			return
+		}
+	}
+
+	if req.TopP != nil {
+		if cmd.TopP, err = domain.NewTopP(*req.TopP); err != nil {
+			return
+		}
+	}
+
+	cmd.CH = ch
+	cmd.User = user
+	cmd.Model = model
+
+	return
+}
+
+type chatCompletionMessage struct {
+	Role    string `json:"role,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+type chatCompletionChoice struct {
+	Index        int                   `json:"index"`
+	Message      chatCompletionMessage `json:"message"`
+	FinishReason string                `json:"finish_reason"`
+}
+
+type chatCompletionResp struct {
+	Id      string                 `json:"id"`
+	Object  string                 `json:"object"`
+	Created int64                  `json:"created"`
+	Model   string                 `json:"model"`
+	Choices []chatCompletionChoice `json:"choices"`
+}
+
+func newChatCompletionResp(id string, created int64, model, content string) chatCompletionResp {
+	return chatCompletionResp{
+		Id:      id,
+		Object:  "chat.completion",
+		Created: created,
+		Model:   model,
+		Choices: []chatCompletionChoice{{
+			Message: chatCompletionMessage{
+				Role:    domain.ChatRoleAssistant,
+				Content: content,
+			},
+			FinishReason: chatFinishReasonStop,
+		}},
+	}
+}
+
+type chatCompletionChunkChoice struct {
+	Index        int                   `json:"index"`
+	Delta        chatCompletionMessage `json:"delta"`
+	FinishReason string                `json:"finish_reason,omitempty"`
+}
+
+type chatCompletionChunk struct {
+	Id      string                      `json:"id"`
+	Object  string                      `json:"object"`
+	Created int64                       `json:"created"`
+	Model   string                      `json:"model"`
+	Choices []chatCompletionChunkChoice `json:"choices"`
+}
+
+func newChatCompletionChunk(id string, created int64, model string) chatCompletionChunk {
+	return chatCompletionChunk{
+		Id:      id,
+		Object:  "chat.completion.chunk",
+		Created: created,
+		Model:   model,
+		Choices: make([]chatCompletionChunkChoice, 1),
+	}
+}
+
+type chatError struct {
+	Message string `json:"message"`
+	Type    string `json:"type"`
+	Code    string `json:"code"`
+}
+
+type chatErrorResp struct {
+	Error chatError `json:"error"`
+}
+
+// api key
+type apiKeyCreateRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+func (req *apiKeyCreateRequest) toCmd(user types.Account) (cmd app.ApiKeyCreateCmd, err error) {
+	if cmd.Name, err = domain.NewApiKeyName(req.Name); err != nil {
+		return
+	}
+
+	cmd.Scopes = make([]domain.ModelName, len(req.Scopes))
+	for i := range req.Scopes {
+		if cmd.Scopes[i], err = domain.NewModelName(req.Scopes[i]); err != nil {
+			return
+		}
+	}
+
+	cmd.User = user
+
+	return
+}
+
+// endpoint
+type endpointAddRequest struct {
+	URL      string `json:"url"`
+	Weight   int    `json:"weight"`
+	Capacity int    `json:"capacity"`
+}
+
+func (req *endpointAddRequest) toCmd(pool string) (cmd app.EndpointAddCmd, err error) {
+	if err = checkEndpointURL(req.URL); err != nil {
+		return
+	}
+
+	if req.Weight < 0 || req.Capacity < 0 {
+		err = errors.New("invalid weight or capacity")
+
+		return
+	}
+
+	cmd.Pool = pool
+	cmd.URL = req.URL
+	cmd.Weight = req.Weight
+	cmd.Capacity = req.Capacity
+
+	return
+}
+
+type endpointDrainRequest struct {
+	URL   string `json:"url"`
+	Drain bool   `json:"drain"`
+}
+
+func (req *endpointDrainRequest) toCmd(pool string) (cmd app.EndpointDrainCmd, err error) {
+	if req.URL == "" {
+		err = errors.New("missing url")
+
+		return
+	}
+
+	cmd.Pool = pool
+	cmd.URL = req.URL
+	cmd.Drain = req.Drain
+
+	return
+}
+
+func checkEndpointURL(v string) error {
+	u, err := url.Parse(v)
+	if err != nil {
+		return err
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("invalid url")
+	}
+
+	return nil
+}
+
+// conversation
+type conversationCreateRequest struct {
+	Title       string   `json:"title"`
+	Model       string   `json:"model"`
+	Temperature *float64 `json:"temperature"`
+	TopP        *float64 `json:"top_p"`
+}
+
+func (req *conversationCreateRequest) toCmd(user types.Account) (cmd app.ConversationCreateCmd, err error) {
+	if cmd.Title, err = domain.NewConversationTitle(req.Title); err != nil {
+		return
+	}
+
+	if cmd.Model, err = domain.NewChatModelName(req.Model); err != nil {
+		return
+	}
+
+	if req.Temperature != nil {
+		if cmd.Temperature, err = domain.NewTemperature(*req.Temperature); err != nil {
+			return
+		}
+	}
+
+	if req.TopP != nil {
+		if cmd.TopP, err = domain.NewTopP(*req.TopP); err != nil {
+			return
+		}
+	}
+
+	cmd.User = user
+
+	return
+}
+
+type conversationRenameRequest struct {
+	Title string `json:"title"`
+}
+
+type conversationChatRequest struct {
+	Content string `json:"content"`
+}
+
+func (req *conversationChatRequest) toCmd(
+	ch chan string, user types.Account, id string,
+) (cmd app.ConversationChatCmd, err error) {
+	if strings.TrimSpace(req.Content) == "" {
+		err = errors.New("empty content")
+
+		return
+	}
+
+	cmd = app.ConversationChatCmd{
+		CH:       ch,
+		User:     user,
+		Id:       id,
+		Question: req.Content,
+	}
+
+	return
+}
+
+// wukong gallery
+type wukongTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+func (req *wukongTagsRequest) toCmd(user types.Account, id string) (cmd app.WuKongUpdateTagsCmd, err error) {
+	if cmd.Tags, err = domain.NewWuKongPictureTags(req.Tags); err != nil {
+		return
+	}
+
+	cmd.User = user
+	cmd.Id = id
+
+	return
+}
+
+type wukongCollectionCreateRequest struct {
+	Name      string `json:"name"`
+	Desc      string `json:"desc"`
+	Featured  bool   `json:"featured"`
+	CreatedBy string `json:"created_by"`
+}
+
+func (req *wukongCollectionCreateRequest) toCmd() (cmd app.WuKongCollectionCreateCmd, err error) {
+	if cmd.Name, err = domain.NewWuKongCollectionName(req.Name); err != nil {
+		return
+	}
+
+	if cmd.Desc, err = domain.NewWuKongCollectionDesc(req.Desc); err != nil {
+		return
+	}
+
+	cmd.Featured = req.Featured
+	cmd.CreatedBy = req.CreatedBy
+
+	return
+}
+
+type wukongCollectionUpdateRequest struct {
+	Name     *string `json:"name"`
+	Desc     *string `json:"desc"`
+	Featured *bool   `json:"featured"`
+}
+
+func (req *wukongCollectionUpdateRequest) toCmd(id string) (cmd app.WuKongCollectionUpdateCmd, err error) {
+	if req.Name == nil && req.Desc == nil && req.Featured == nil {
+		err = errors.New("nothing to update")
+
+		return
+	}
+
+	if req.Name != nil {
+		if cmd.Name, err = domain.NewWuKongCollectionName(*req.Name); err != nil {
+			return
+		}
+	}
+
+	if req.Desc != nil {
+		if cmd.Desc, err = domain.NewWuKongCollectionDesc(*req.Desc); err != nil {
+			return
+		}
+	}
+
+	cmd.Id = id
+	cmd.Featured = req.Featured
+
+	return
+}
+
+type wukongCollectionPictureRequest struct {
+	Owner string `json:"owner"`
+	Id    string `json:"id"`
+}
+
+func (req *wukongCollectionPictureRequest) toCmd(id string) (cmd app.WuKongCollectionPictureCmd, err error) {
+	return toWuKongCollectionPictureCmd(id, req.Owner, req.Id)
+}
+
+func toWuKongCollectionPictureCmd(id, owner, pid string) (cmd app.WuKongCollectionPictureCmd, err error) {
+	if cmd.Owner, err = types.NewAccount(owner); err != nil {
+		return
+	}
+
+	if pid == "" {
+		err = errors.New("missing picture id")
+
+		return
+	}
+
+	cmd.CollectionId = id
+	cmd.Id = pid
+
+	return
+}
+
+type wukongGallerySyncResp struct {
+	Num int `json:"num"`
+}
@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/bigmodel/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	wukongSimilarMaxNum = 50
+
+	wukongPublicsDefaultCountPerPage = 10
+	wukongPublicsMaxCountPerPage     = 100
+)
+
+// @Summary		UpdatePublicTags
+// @Description	update the tags of public wukong picture
+// @Tags			BigModel
+// @Param			id		path	string				true	"picture id"
+// @Param			body	body	wukongTagsRequest	true	"body of tags"
+// @Accept			json
+// @Success		202
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/wukong/public/{id}/tags [put]
+func (ctl *BigModelController) UpdatePublicTags(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	desc := "update tags of wukong public picture"
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, desc)
+
+	req := wukongTagsRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(pl.DomainAccount(), ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.s.UpdatePublicTags(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, desc, fmt.Sprintf("picture id: %s", cmd.Id), "success")
+
+	ctl.sendRespOfPut(ctx, "success")
+}
+
+// @Summary		ListSimilarPublics
+// @Description	list the public wukong pictures of the same style
+// @Tags			BigModel
+// @Param			owner	path	string	true	"owner of picture"
+// @Param			id		path	string	true	"picture id"
+// @Param			num		query	int		false	"max num of pictures, 10 by default"
+// @Accept			json
+// @Success		200	{object}			[]app.WuKongPublicDTO
+// @Failure		400	bad_request_param	some	parameter	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/bigmodel/wukong/public/{owner}/{id}/similar [get]
+func (ctl *BigModelController) ListSimilarPublics(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, true)
+	if !ok {
+		return
+	}
+
+	owner, err := domain.NewAccount(ctx.Param("owner"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := app.WuKongListSimilarCmd{
+		User: pl.DomainAccount(),
+		Num:  10,
+	}
+	cmd.Owner = owner
+	cmd.Id = ctx.Param("id")
+
+	if v := ctl.getQueryParameter(ctx, "num"); v != "" {
+		if cmd.Num, err = strconv.Atoi(v); err != nil || cmd.Num <= 0 || cmd.Num > wukongSimilarMaxNum {
+			ctl.sendBadRequestParam(ctx, errors.New("bad num"))
+
+			return
+		}
+	}
+
+	if v, code, err := ctl.s.ListSimilarPublics(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		ListWuKongCollections
+// @Description	list the featured collections of wukong pictures
+// @Tags			BigModel
+// @Accept			json
+// @Success		200	{object}		[]app.WuKongCollectionDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/wukong/collection [get]
+func (ctl *BigModelController) ListWuKongCollections(ctx *gin.Context) {
+	if _, _, ok := ctl.checkUserApiToken(ctx, true); !ok {
+		return
+	}
+
+	if v, err := ctl.wcs.List(true); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		GetWuKongCollection
+// @Description	get the featured collection with its pictures
+// @Tags			BigModel
+// @Param			id	path	string	true	"collection id"
+// @Accept			json
+// @Success		200	{object}		app.WuKongCollectionDetailDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/bigmodel/wukong/collection/{id} [get]
+func (ctl *BigModelController) GetWuKongCollection(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, true)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.wcs.Get(pl.DomainAccount(), ctx.Param("id"), true); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
@@ -30,6 +30,9 @@ func AddRouterForCloudController(
 	rg.GET("/v1/cloud/pod/:cid", ctl.GetHttp)
 	rg.GET("/v1/cloud/read/:owner", ctl.CanRead)
 	rg.DELETE("/v1/cloud/pod/:id", ctl.ReleasePod)
+	rg.PUT("/v1/cloud/pod/:id/extension", ctl.ExtendPod)
+	rg.POST("/v1/cloud/reservation", checkUserEmailMiddleware(&ctl.baseController), ctl.Reserve)
+	rg.DELETE("/v1/cloud/request/:id", ctl.CancelRequest)
 	rg.GET("/v1/ws/cloud/pod/:id", ctl.WsSendReleasedPod)
 }
 
@@ -156,6 +159,10 @@ func (ctl *CloudController) Get(ctx *gin.Context) {
 		return
 	}
 
+	if !ctl.waitInQueue(ws, &cmd) {
+		return
+	}
+
 	for i := 0; i < apiConfig.PodTimeout; i++ {
 		dto, err := ctl.s.Get(&cmd)
 		if err != nil {
@@ -190,6 +197,49 @@ func (ctl *CloudController) Get(ctx *gin.Context) {
 	}
 }
 
+// waitInQueue sends the queue position whenever it changes until the request
+// is started. It returns false if the websocket should be closed.
+func (ctl *CloudController) waitInQueue(ws *websocket.Conn, cmd *app.PodInfoCmd) bool {
+	position := -1
+
+	for {
+		dto, err := ctl.s.Get(cmd)
+		if err != nil {
+			if wsErr := ws.WriteJSON(newResponseError(err)); wsErr != nil {
+				log.Errorf("wait in queue failed: web socket write err:%s", wsErr.Error())
+			}
+
+			log.Errorf("wait in queue failed: get status, err:%s", err.Error())
+
+			return false
+		}
+
+		if !dto.IsWaiting() {
+			return true
+		}
+
+		if dto.QueuePosition != position {
+			position = dto.QueuePosition
+
+			if wsErr := ws.WriteJSON(newResponseData(dto)); wsErr != nil {
+				log.Errorf("wait in queue failed: web socket write err:%s", wsErr.Error())
+
+				return false
+			}
+		} else {
+			// check whether the client is still there
+			deadline := time.Now().Add(time.Second)
+			if wsErr := ws.WriteControl(websocket.PingMessage, nil, deadline); wsErr != nil {
+				log.Debugf("wait in queue: client is gone, err:%s", wsErr.Error())
+
+				return false
+			}
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
 //	@Summary		GetHttp
 //	@Description	get cloud pod
 //	@Tags			Cloud
@@ -381,3 +431,129 @@ func (ctl *CloudController) WsSendReleasedPod(ctx *gin.Context) {
 		log.Errorf("[RELEASE] fail to get pod | web socket write error:%s", wsErr.Error())
 	}
 }
+
+//	@Summary		ExtendPod
+//	@Description	extend the expiry of running pod
+//	@Tags			Cloud
+//	@Param			id		path	string				true	"pod id"
+//	@Param			body	body	podExtendRequest	true	"body of extending pod"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		403	{object}			responseData	"not allowed"
+//	@Failure		500	{object}			responseData	"system error"
+//	@Router			/v1/cloud/pod/{id}/extension [put]
+func (ctl *CloudController) ExtendPod(ctx *gin.Context) {
+	req := podExtendRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "extend cloud")
+
+	cmd := &app.ExtendPodCmd{
+		PodId:    ctx.Param("id"),
+		User:     pl.DomainAccount(),
+		Duration: req.Duration,
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	code, err := ctl.s.ExtendPod(cmd)
+	if err == nil {
+		ctl.sendRespOfPut(ctx, "success")
+
+		return
+	}
+
+	if errors.Is(err, app.ErrCloudNotAllowed) {
+		ctx.JSON(http.StatusForbidden, newResponseError(err))
+	} else {
+		ctl.sendCodeMessage(ctx, code, err)
+	}
+}
+
+//	@Summary		Reserve
+//	@Description	reserve cloud at a future time
+//	@Tags			Cloud
+//	@Param			body	body	cloudReserveRequest	true	"body of reserving cloud"
+//	@Accept			json
+//	@Success		201
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/cloud/reservation [post]
+func (ctl *CloudController) Reserve(ctx *gin.Context) {
+	req := cloudReserveRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "reserve cloud")
+
+	cmd := req.toCmd(pl.DomainAccount())
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	if code, err := ctl.s.ReserveCloud(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, "success")
+	}
+}
+
+//	@Summary		CancelRequest
+//	@Description	cancel the queued request or reservation
+//	@Tags			Cloud
+//	@Param			id	path	string	true	"request id"
+//	@Accept			json
+//	@Success		204
+//	@Failure		403	{object}	responseData	"not allowed"
+//	@Failure		500	{object}	responseData	"system error"
+//	@Router			/v1/cloud/request/{id} [delete]
+func (ctl *CloudController) CancelRequest(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "cancel cloud request")
+
+	cmd := &app.CancelRequestCmd{
+		RequestId: ctx.Param("id"),
+		User:      pl.DomainAccount(),
+	}
+
+	if err := ctl.s.CancelRequest(cmd); err != nil {
+		if errors.Is(err, app.ErrCloudNotAllowed) {
+			ctx.JSON(http.StatusForbidden, newResponseError(err))
+		} else if errors.Is(err, app.ErrRequestNotWaiting) {
+			ctl.sendBadRequestParam(ctx, err)
+		} else {
+			ctl.sendRespWithInternalError(ctx, newResponseError(err))
+		}
+
+		return
+	}
+
+	ctl.sendRespOfDelete(ctx)
+}
@@ -8,14 +8,45 @@ import (
 
 type cloudSubscribeRequest struct {
 	CloudId  string `json:"cloud_id"`
-	Image    string `json:"image" binding:"required"`
+	Image    string `json:"image"`
 	CardsNum int    `json:"cards_num" binding:"required,min=1"`
+	Queue    bool   `json:"queue"`
+
+	// CustomImage is the id of custom image which is used instead of Image.
+	CustomImage string `json:"custom_image"`
 }
 
 func (req *cloudSubscribeRequest) toCmd(user domain.Account) cloudapp.SubscribeCloudCmd {
 	cmd := cloudapp.SubscribeCloudCmd{
-		User:    user,
-		CloudId: req.CloudId,
+		User:        user,
+		CloudId:     req.CloudId,
+		Queue:       req.Queue,
+		CustomImage: req.CustomImage,
+	}
+
+	cmd.ImageAlias, _ = cloudtypes.NewCloudImageAlias(req.Image)
+	cmd.CardsNum, _ = cloudtypes.NewCloudSpecCardsNum(req.CardsNum)
+
+	return cmd
+}
+
+type cloudReserveRequest struct {
+	CloudId  string `json:"cloud_id"`
+	Image    string `json:"image"`
+	CardsNum int    `json:"cards_num" binding:"required,min=1"`
+	StartAt  int64  `json:"start_at" binding:"required"`
+
+	CustomImage string `json:"custom_image"`
+}
+
+func (req *cloudReserveRequest) toCmd(user domain.Account) cloudapp.ReserveCloudCmd {
+	cmd := cloudapp.ReserveCloudCmd{
+		SubscribeCloudCmd: cloudapp.SubscribeCloudCmd{
+			User:        user,
+			CloudId:     req.CloudId,
+			CustomImage: req.CustomImage,
+		},
+		StartAt: req.StartAt,
 	}
 
 	cmd.ImageAlias, _ = cloudtypes.NewCloudImageAlias(req.Image)
@@ -23,3 +54,7 @@ func (req *cloudSubscribeRequest) toCmd(user domain.Account) cloudapp.SubscribeC
 
 	return cmd
 }
+
+type podExtendRequest struct {
+	Duration int64 `json:"duration" binding:"required,min=1"`
+}
@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/competition/app"
+	cc "github.com/opensourceways/xihe-server/competition/controller"
+	"github.com/opensourceways/xihe-server/competition/domain"
+)
+
+func AddRouterForCompetitionInternalController(
+	rg *gin.RouterGroup,
+	s app.CompetitionCheatingService,
+) {
+	ctl := CompetitionInternalController{
+		s: s,
+	}
+
+	rg.GET("/v1/competition/:id/cheating_flags", internalApiCheckMiddleware(&ctl.baseController), ctl.ListCheatingFlags)
+	rg.PUT("/v1/competition/:id/cheating_flags/:fid", internalApiCheckMiddleware(&ctl.baseController), ctl.ReviewCheatingFlag)
+}
+
+type CompetitionInternalController struct {
+	baseController
+
+	s app.CompetitionCheatingService
+}
+
+// @Summary		ListCheatingFlags
+// @Description	list the cheating flags of competition for the organiser to review
+// @Tags			CompetitionInternal
+// @Param			id		path	string	true	"competition id"
+// @Param			status	query	string	false	"status of flag, such as pending, accepted, disqualified"
+// @Accept			json
+// @Success		200	{object}		app.CheatingFlagDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/competition/{id}/cheating_flags [get]
+func (ctl *CompetitionInternalController) ListCheatingFlags(ctx *gin.Context) {
+	cmd := app.CheatingFlagListCmd{
+		CompetitionId: ctx.Param("id"),
+	}
+
+	if str := ctl.getQueryParameter(ctx, "status"); str != "" {
+		status, err := domain.NewCheatingFlagStatus(str)
+		if err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		cmd.Status = status
+	}
+
+	if data, err := ctl.s.ListFlags(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		ReviewCheatingFlag
+// @Description	accept or disqualify the players of a cheating flag, the players who have not submitted can't be disqualified
+// @Tags			CompetitionInternal
+// @Param			id		path	string							true	"competition id"
+// @Param			fid		path	string							true	"flag id"
+// @Param			body	body	cc.ReviewCheatingFlagRequest	true	"body of review"
+// @Accept			json
+// @Success		202
+// @Failure		500	system_error	system	error
+// @Router			/v1/competition/{id}/cheating_flags/{fid} [put]
+func (ctl *CompetitionInternalController) ReviewCheatingFlag(ctx *gin.Context) {
+	req := cc.ReviewCheatingFlagRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.ToCmd(ctx.Param("id"), ctx.Param("fid"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.s.Review(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
@@ -1,6 +1,8 @@
 package controller
 
 import (
+	"errors"
+
 	"github.com/sirupsen/logrus"
 
 	"github.com/opensourceways/xihe-server/domain"
@@ -75,8 +77,13 @@ type APIConfig struct {
 	MaxTagsNumToSearchResource     int    `json:"max_tags_num_to_search_resource"`
 	MaxTagKindsNumToSearchResource int    `json:"max_tag_kinds_num_to_search_resource"`
 	MaxFinetuneSubmmitFileSize     int64  `json:"max_finetune_submmit_file_size"`
+	MaxTrainingPublishFileSize     int64  `json:"max_training_publish_file_size"`
 	LocalDomainCookie              bool   `json:"local_domain_cookie"`
 
+	// BigModelLegacyToken is for the tokens applied for the models before
+	// the api keys. They are rejected if it is disabled or after the end date.
+	BigModelLegacyToken BigModelLegacyTokenConfig `json:"bigmodel_legacy_token"`
+
 	InternalTokenHash string `json:"internal_token_hash" required:"true"`
 	InternalTokeSalt  string `json:"internal_token_salt" required:"true"`
 	InternalHeader    string `json:"internal_token_header" required:"true"`
@@ -118,6 +125,10 @@ func (cfg *APIConfig) SetDefault() {
 	if cfg.MaxFinetuneSubmmitFileSize <= 0 {
 		cfg.MaxFinetuneSubmmitFileSize = 50 * 1024 * 1024
 	}
+
+	if cfg.MaxTrainingPublishFileSize <= 0 {
+		cfg.MaxTrainingPublishFileSize = 100 * 1024 * 1024
+	}
 }
 
 func (cfg *APIConfig) Validate() (err error) {
@@ -129,9 +140,46 @@ func (cfg *APIConfig) Validate() (err error) {
 		return
 	}
 
-	_, err = domain.NewFilePath(cfg.InferenceBootFile)
+	if _, err = domain.NewFilePath(cfg.InferenceBootFile); err != nil {
+		return
+	}
+
+	return cfg.BigModelLegacyToken.validate()
+}
+
+type BigModelLegacyTokenConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// EndDate is the last day to accept the tokens, such as 2006-01-02.
+	EndDate string `json:"end_date"`
+
+	// Expiry is the seconds for which the token is valid since it is applied.
+	Expiry int64 `json:"expiry"`
+
+	end int64
+}
+
+func (cfg *BigModelLegacyTokenConfig) validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	t, err := utils.ToUnixTime(cfg.EndDate)
+	if err != nil {
+		return errors.New("invalid end date of bigmodel legacy token")
+	}
+
+	if cfg.Expiry <= 0 {
+		return errors.New("invalid expiry of bigmodel legacy token")
+	}
+
+	cfg.end = t.AddDate(0, 0, 1).Unix()
+
+	return nil
+}
 
-	return
+func (cfg *BigModelLegacyTokenConfig) isAccepted() bool {
+	return cfg.Enabled && utils.Now() < cfg.end
 }
 
 type Tags struct {
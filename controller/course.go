@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -36,6 +37,14 @@ func AddRouterForCourseController(
 	rg.GET("/v1/course/reginfo", ctl.GetRegisterInfo)
 	rg.GET("/v1/course/:id/asg/:asgid", ctl.GetAssignment)
 	rg.PUT("/v1/course/:id/record", ctl.AddPlayRecord)
+	rg.GET("/v1/course/:id/progress", ctl.GetProgress)
+	rg.GET("/v1/cert/verify/:serial", ctl.VerifyCertificate)
+	rg.POST("/v1/course/:id/asg/:asgid/submission", ctl.Submit)
+	rg.GET("/v1/course/:id/asg/:asgid/submission", ctl.ListSubmissions)
+	rg.GET("/v1/learning_path", ctl.ListPaths)
+	rg.GET("/v1/learning_path/:id", ctl.GetPath)
+	rg.GET("/v1/learning_path/:id/progress", ctl.GetPathProgress)
+	rg.GET("/v1/learning_path/:id/cert", ctl.GetPathCertification)
 }
 
 type CourseController struct {
@@ -432,3 +441,209 @@ func (ctl *CourseController) AddPlayRecord(ctx *gin.Context) {
 		ctl.sendRespOfPut(ctx, "success")
 	}
 }
+
+// @Summary		GetProgress
+// @Description	get learning progress of the course
+// @Tags			Course
+// @Param			id	path	string	true	"course id"
+// @Accept			json
+// @Success		200	{object}		app.CourseProgressDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/course/{id}/progress [get]
+func (ctl *CourseController) GetProgress(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := toGetCmd(ctx.Param("id"), pl.DomainAccount())
+
+	if data, code, err := ctl.s.GetProgress(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		VerifyCertificate
+// @Description	verify the certificate by its serial and the signature printed on it
+// @Tags			Course
+// @Param			serial		path	string	true	"serial of certificate"
+// @Param			signature	query	string	true	"signature of certificate"
+// @Accept			json
+// @Success		200	{object}			app.CertVerifyDTO
+// @Failure		400	bad_request_param	some	parameter	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/cert/verify/{serial} [get]
+func (ctl *CourseController) VerifyCertificate(ctx *gin.Context) {
+	cmd := app.CertVerifyCmd{
+		Serial:    ctx.Param("serial"),
+		Signature: ctl.getQueryParameter(ctx, "signature"),
+	}
+
+	if cmd.Signature == "" {
+		ctl.sendBadRequestParam(ctx, errors.New("missing signature"))
+
+		return
+	}
+
+	if data, code, err := ctl.s.VerifyCertificate(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		Submit
+// @Description	submit a commit of the related project for the assignment
+// @Tags			Course
+// @Param			id		path	string			true	"course id"
+// @Param			asgid	path	string			true	"assignment id"
+// @Param			body	body	SubmitRequest	true	"body of submission"
+// @Accept			json
+// @Success		201	{object}		app.SubmissionDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/course/{id}/asg/{asgid}/submission [post]
+func (ctl *CourseController) Submit(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "submit assignment")
+
+	req := SubmitRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
+			errorBadRequestBody,
+			"can't fetch request body",
+		))
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("id"), ctx.Param("asgid"), pl.DomainAccount())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	if data, code, err := ctl.s.Submit(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, data)
+	}
+}
+
+// @Summary		ListSubmissions
+// @Description	list grading history of the assignment
+// @Tags			Course
+// @Param			id		path	string	true	"course id"
+// @Param			asgid	path	string	true	"assignment id"
+// @Accept			json
+// @Success		200	{object}		app.SubmissionDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/course/{id}/asg/{asgid}/submission [get]
+func (ctl *CourseController) ListSubmissions(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	var cmd app.AsgGetCmd
+	cmd.User = pl.DomainAccount()
+	cmd.Cid = ctx.Param("id")
+	cmd.AsgId = ctx.Param("asgid")
+
+	if data, code, err := ctl.s.ListSubmissions(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		ListPaths
+// @Description	list learning paths
+// @Tags			Course
+// @Accept			json
+// @Success		200	{object}		app.LearningPathDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/learning_path [get]
+func (ctl *CourseController) ListPaths(ctx *gin.Context) {
+	if data, err := ctl.s.ListPaths(); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		GetPath
+// @Description	get learning path
+// @Tags			Course
+// @Param			id	path	string	true	"learning path id"
+// @Accept			json
+// @Success		200	{object}		app.LearningPathDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/learning_path/{id} [get]
+func (ctl *CourseController) GetPath(ctx *gin.Context) {
+	if data, err := ctl.s.GetPath(ctx.Param("id")); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		GetPathProgress
+// @Description	get progress of learning path
+// @Tags			Course
+// @Param			id	path	string	true	"learning path id"
+// @Accept			json
+// @Success		200	{object}		app.PathProgressDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/learning_path/{id}/progress [get]
+func (ctl *CourseController) GetPathProgress(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.PathGetCmd{
+		Id:   ctx.Param("id"),
+		User: pl.DomainAccount(),
+	}
+
+	if data, err := ctl.s.GetPathProgress(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		GetPathCertification
+// @Description	get certification of learning path
+// @Tags			Course
+// @Param			id	path	string	true	"learning path id"
+// @Accept			json
+// @Success		200	{object}		app.CertInfoDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/learning_path/{id}/cert [get]
+func (ctl *CourseController) GetPathCertification(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.PathGetCmd{
+		Id:   ctx.Param("id"),
+		User: pl.DomainAccount(),
+	}
+
+	if data, err := ctl.s.GetPathCertification(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
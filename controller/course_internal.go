@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/course/app"
+)
+
+func AddRouterForCourseInternalController(
+	rg *gin.RouterGroup,
+	s app.CourseService,
+) {
+	ctl := CourseInternalController{
+		s: s,
+	}
+
+	rg.GET("/v1/course/:id/analytics", internalApiCheckMiddleware(&ctl.baseController), ctl.GetAnalytics)
+	rg.POST("/v1/learning_path", internalApiCheckMiddleware(&ctl.baseController), ctl.AddPath)
+	rg.PUT("/v1/learning_path/:id", internalApiCheckMiddleware(&ctl.baseController), ctl.UpdatePath)
+	rg.DELETE("/v1/learning_path/:id", internalApiCheckMiddleware(&ctl.baseController), ctl.DeletePath)
+}
+
+type CourseInternalController struct {
+	baseController
+
+	s app.CourseService
+}
+
+// @Summary		GetAnalytics
+// @Description	get drop-off of lessons and score distribution of assignments for the instructor
+// @Tags			CourseInternal
+// @Param			id	path	string	true	"course id"
+// @Accept			json
+// @Success		200	{object}		app.CourseAnalyticsDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/course/{id}/analytics [get]
+func (ctl *CourseInternalController) GetAnalytics(ctx *gin.Context) {
+	if data, err := ctl.s.GetAnalytics(ctx.Param("id")); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		AddPath
+// @Description	add learning path
+// @Tags			CourseInternal
+// @Param			body	body	LearningPathRequest	true	"body of learning path"
+// @Accept			json
+// @Success		201	{object}		app.LearningPathDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/learning_path [post]
+func (ctl *CourseInternalController) AddPath(ctx *gin.Context) {
+	req := LearningPathRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd()
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if data, code, err := ctl.s.AddPath(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, data)
+	}
+}
+
+// @Summary		UpdatePath
+// @Description	update learning path
+// @Tags			CourseInternal
+// @Param			id		path	string				true	"learning path id"
+// @Param			body	body	LearningPathRequest	true	"body of learning path"
+// @Accept			json
+// @Success		202	{object}		app.LearningPathDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/learning_path/{id} [put]
+func (ctl *CourseInternalController) UpdatePath(ctx *gin.Context) {
+	req := LearningPathRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	v, err := req.toCmd()
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := app.LearningPathUpdateCmd{
+		LearningPathCmd: v,
+		Id:              ctx.Param("id"),
+	}
+
+	if data, code, err := ctl.s.UpdatePath(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, data)
+	}
+}
+
+// @Summary		DeletePath
+// @Description	delete learning path
+// @Tags			CourseInternal
+// @Param			id	path	string	true	"learning path id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/learning_path/{id} [delete]
+func (ctl *CourseInternalController) DeletePath(ctx *gin.Context) {
+	if err := ctl.s.DeletePath(ctx.Param("id")); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
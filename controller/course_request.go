@@ -28,6 +28,8 @@ type PlayRecordRequest struct {
 	PointId     string `bson:"point_id"      json:"point_id"`
 	PlayCount   int    `bson:"play_count"    json:"play_count"`
 	FinishCount int    `bson:"finish_count"  json:"finish_count"`
+	Position    int    `bson:"position"      json:"position"`
+	Duration    int    `bson:"duration"      json:"duration"`
 }
 
 func (req *AddCourseRelatedProjectRequest) ToInfo() (
@@ -100,6 +102,8 @@ func (req *PlayRecordRequest) toRecordCmd(cid string, user types.Account) (
 	cmd.User = user
 	cmd.PlayCount = req.PlayCount
 	cmd.FinishCount = req.FinishCount
+	cmd.Position = req.Position
+	cmd.TimeSpent = req.Duration
 
 	if err = cmd.Validate(); err != nil {
 		return
@@ -113,3 +117,57 @@ type submissionDetail struct {
 
 	*app.RelateProjectDTO
 }
+
+type SubmitRequest struct {
+	Commit string `json:"commit"`
+}
+
+func (req *SubmitRequest) toCmd(cid, asgId string, user types.Account) (
+	cmd app.SubmitCmd, err error,
+) {
+	if cmd.Commit, err = domain.NewCommitId(req.Commit); err != nil {
+		return
+	}
+
+	cmd.Cid = cid
+	cmd.AsgId = asgId
+	cmd.User = user
+
+	return
+}
+
+type PathCourseRequest struct {
+	Id            string   `json:"id"`
+	Prerequisites []string `json:"prerequisites"`
+}
+
+type LearningPathRequest struct {
+	Name    string              `json:"name"`
+	Desc    string              `json:"desc"`
+	Poster  string              `json:"poster"`
+	Courses []PathCourseRequest `json:"courses"`
+}
+
+func (req *LearningPathRequest) toCmd() (cmd app.LearningPathCmd, err error) {
+	if cmd.Name, err = domain.NewPathName(req.Name); err != nil {
+		return
+	}
+
+	if cmd.Desc, err = domain.NewPathDesc(req.Desc); err != nil {
+		return
+	}
+
+	if cmd.Poster, err = domain.NewURL(req.Poster); err != nil {
+		return
+	}
+
+	cmd.Courses = make([]domain.PathCourse, len(req.Courses))
+	for i := range req.Courses {
+		cmd.Courses[i] = domain.PathCourse{
+			CourseId:      req.Courses[i].Id,
+			Prerequisites: req.Courses[i].Prerequisites,
+		}
+	}
+
+	return
+}
@@ -7,10 +7,6 @@ import (
 
 type finetuneCreateResp = trainingCreateResp
 
-type finetuneLog struct {
-	Log string `json:"log"`
-}
-
 type FinetuneCreateRequest struct {
 	Name            string     `json:"name"`
 	Model           string     `json:"model"`
@@ -62,17 +62,29 @@ func (ctl *HomeController) ListAll(ctx *gin.Context) {
 	compRes, err := ctl.comp.List(&compCmd)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	courseCmd := courseapp.CourseListCmd{}
 	courseRes, err := ctl.course.List(&courseCmd)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	pathRes, err := ctl.course.ListPaths()
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	info := homeInfo{
 		Comp:   compRes,
 		Course: courseRes,
+		Paths:  pathRes,
 	}
 
 	ctl.sendRespOfGet(ctx, info)
@@ -99,6 +111,8 @@ func (ctl *HomeController) Get(ctx *gin.Context) {
 	compRes, err := ctl.comp.List(&compCmd)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	ct, _ := coursedomain.NewCourseType(industry)
@@ -106,6 +120,8 @@ func (ctl *HomeController) Get(ctx *gin.Context) {
 	courseRes, err := ctl.course.List(&courseCmd)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	promotionsDTO, err := ctl.promotion.List(
@@ -117,11 +133,15 @@ func (ctl *HomeController) Get(ctx *gin.Context) {
 	)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	cmd, err := ctl.getListGlobalResourceParameter(ctx)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	cmd.Tags = append(cmd.Tags, industry)
@@ -129,16 +149,22 @@ func (ctl *HomeController) Get(ctx *gin.Context) {
 	p, err := ctl.project.ListGlobal(&cmd)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	m, err := ctl.model.ListGlobal(&cmd)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	d, err := ctl.dataset.ListGlobal(&cmd)
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
 	}
 
 	dto := IndustryDTO{
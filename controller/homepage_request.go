@@ -11,6 +11,7 @@ import (
 type homeInfo struct {
 	Comp   []compapp.CompetitionSummaryDTO `json:"comp"`
 	Course []courseapp.CourseSummaryDTO    `json:"course"`
+	Paths  []courseapp.LearningPathDTO     `json:"paths"`
 }
 
 type IndustryDTO struct {
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/image/app"
+	spacerepo "github.com/opensourceways/xihe-server/space/domain/repository"
+)
+
+func AddRouterForImageController(
+	rg *gin.RouterGroup,
+	s app.ImageService,
+	project spacerepo.Project,
+) {
+	ctl := ImageController{
+		s:       s,
+		project: project,
+	}
+
+	rg.GET("/v1/image", ctl.List)
+	rg.POST("/v1/image", checkUserEmailMiddleware(&ctl.baseController), ctl.Register)
+	rg.DELETE("/v1/image/:id", ctl.Delete)
+}
+
+type ImageController struct {
+	baseController
+
+	s       app.ImageService
+	project spacerepo.Project
+}
+
+// @Summary		Register
+// @Description	register a custom image built from Dockerfile of project or referenced by digest
+// @Tags			Image
+// @Param			body	body	imageRegisterRequest	true	"body of registering image"
+// @Accept			json
+// @Success		201	{object}			app.ImageDTO
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/image [post]
+func (ctl *ImageController) Register(ctx *gin.Context) {
+	req := imageRegisterRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "register custom image")
+
+	cmd, err := req.toCmd(pl.DomainAccount())
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if req.isDockerfile() {
+		name, err := types.NewResourceName(req.Project)
+		if err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		if cmd.Project, err = ctl.project.GetSummaryByName(cmd.Owner, name); err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if data, code, err := ctl.s.Register(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, data)
+	}
+}
+
+// @Summary		List
+// @Description	list custom images of user or the organization which user belongs to
+// @Tags			Image
+// @Param			org	query	string	false	"organization"
+// @Accept			json
+// @Success		200	{object}			[]app.ImageDTO
+// @Failure		400	bad_request_param	some	parameter	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/image [get]
+func (ctl *ImageController) List(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.ImageListCmd{
+		User: pl.DomainAccount(),
+	}
+
+	if v := ctl.getQueryParameter(ctx, "org"); v != "" {
+		org, err := types.NewAccount(v)
+		if err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		cmd.Org = org
+	}
+
+	if data, code, err := ctl.s.List(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		Delete
+// @Description	delete custom image
+// @Tags			Image
+// @Param			id	path	string	true	"image id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/image/{id} [delete]
+func (ctl *ImageController) Delete(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "delete custom image")
+
+	cmd := app.ImageDeleteCmd{
+		User: pl.DomainAccount(),
+		Id:   ctx.Param("id"),
+	}
+
+	if code, err := ctl.s.Delete(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/image/app"
+)
+
+func AddRouterForImageInternalController(
+	rg *gin.RouterGroup,
+	s app.ImageInternalService,
+) {
+	ctl := ImageInternalController{
+		s: s,
+	}
+
+	rg.GET("/v1/image/reviewing", internalApiCheckMiddleware(&ctl.baseController), ctl.ListReviewing)
+	rg.PUT("/v1/image/:id/scan", internalApiCheckMiddleware(&ctl.baseController), ctl.SetScanResult)
+	rg.PUT("/v1/image/:id/review", internalApiCheckMiddleware(&ctl.baseController), ctl.Review)
+}
+
+type ImageInternalController struct {
+	baseController
+
+	s app.ImageInternalService
+}
+
+// @Summary		ListReviewing
+// @Description	list images which are waiting for review
+// @Tags			ImageInternal
+// @Accept			json
+// @Success		200	{object}		[]app.ImageDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/image/reviewing [get]
+func (ctl *ImageInternalController) ListReviewing(ctx *gin.Context) {
+	if data, err := ctl.s.ListReviewing(); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, data)
+	}
+}
+
+// @Summary		SetScanResult
+// @Description	set the result of building and scanning image
+// @Tags			ImageInternal
+// @Param			id		path	string				true	"image id"
+// @Param			body	body	imageScanRequest	true	"body of scan result"
+// @Accept			json
+// @Success		202
+// @Failure		500	system_error	system	error
+// @Router			/v1/image/{id}/scan [put]
+func (ctl *ImageInternalController) SetScanResult(ctx *gin.Context) {
+	req := imageScanRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd := req.toCmd(ctx.Param("id"))
+
+	if code, err := ctl.s.SetScanResult(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+// @Summary		Review
+// @Description	approve or reject the image
+// @Tags			ImageInternal
+// @Param			id		path	string				true	"image id"
+// @Param			body	body	imageReviewRequest	true	"body of review"
+// @Accept			json
+// @Success		202
+// @Failure		500	system_error	system	error
+// @Router			/v1/image/{id}/review [put]
+func (ctl *ImageInternalController) Review(ctx *gin.Context) {
+	req := imageReviewRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd := req.toCmd(ctx.Param("id"))
+
+	if code, err := ctl.s.Review(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
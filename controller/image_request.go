@@ -0,0 +1,95 @@
+package controller
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/image/app"
+	"github.com/opensourceways/xihe-server/image/domain"
+)
+
+type imageRegisterRequest struct {
+	Name      string `json:"name"`
+	Tag       string `json:"tag"`
+	Processor string `json:"processor"`
+
+	// Org is the organization which owns the image, the image is owned
+	// by the user if it is empty.
+	Org string `json:"org"`
+
+	// Project is the name of project which contains the Dockerfile
+	Project    string `json:"project"`
+	Dockerfile string `json:"dockerfile"`
+
+	Reference string `json:"reference"`
+	Digest    string `json:"digest"`
+}
+
+func (req *imageRegisterRequest) isDockerfile() bool {
+	return req.Dockerfile != ""
+}
+
+func (req *imageRegisterRequest) toCmd(user types.Account) (cmd app.ImageRegisterCmd, err error) {
+	if cmd.Name, err = domain.NewImageName(req.Name); err != nil {
+		return
+	}
+
+	if cmd.Tag, err = domain.NewImageTag(req.Tag); err != nil {
+		return
+	}
+
+	if cmd.Processor, err = domain.NewImageProcessor(req.Processor); err != nil {
+		return
+	}
+
+	cmd.User = user
+	cmd.Owner = user
+
+	if req.Org != "" {
+		if cmd.Owner, err = types.NewAccount(req.Org); err != nil {
+			return
+		}
+
+		cmd.OrgOwned = true
+	}
+
+	if req.isDockerfile() {
+		cmd.Dockerfile, err = types.NewFilePath(req.Dockerfile)
+
+		return
+	}
+
+	if cmd.Reference, err = domain.NewImageReference(req.Reference); err != nil {
+		return
+	}
+
+	cmd.Digest, err = domain.NewImageDigest(req.Digest)
+
+	return
+}
+
+type imageScanRequest struct {
+	Address string `json:"address"`
+	Passed  bool   `json:"passed"`
+	Reason  string `json:"reason"`
+}
+
+func (req *imageScanRequest) toCmd(id string) app.ImageScanCmd {
+	return app.ImageScanCmd{
+		Id:      id,
+		Address: req.Address,
+		Passed:  req.Passed,
+		Reason:  req.Reason,
+	}
+}
+
+type imageReviewRequest struct {
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason"`
+}
+
+func (req *imageReviewRequest) toCmd(id string) app.ImageReviewCmd {
+	return app.ImageReviewCmd{
+		Id:       id,
+		Approved: req.Approved,
+		Reason:   req.Reason,
+	}
+}
@@ -0,0 +1,426 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	libutils "github.com/opensourceways/community-robot-lib/utils"
+
+	"github.com/opensourceways/xihe-server/job/app"
+	"github.com/opensourceways/xihe-server/job/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+func AddRouterForJobController(
+	rg *gin.RouterGroup,
+	js app.JobService,
+	ms app.JobModelService,
+	hub *JobHub,
+) {
+	ctl := JobController{
+		js:  js,
+		ms:  ms,
+		hub: hub,
+	}
+
+	rg.GET("/v1/job/:type", ctl.List)
+	rg.GET("/v1/job/:type/ws", ctl.WatchJobs)
+	rg.GET("/v1/job/:type/:id", ctl.Get)
+	rg.GET("/v1/job/:type/:id/ws", ctl.WatchJob)
+	rg.PUT("/v1/job/:type/:id", ctl.Terminate)
+	rg.GET(
+		"/v1/job/:type/:id/result/:result", checkUserEmailMiddleware(&ctl.baseController),
+		ctl.GetResultDownloadURL,
+	)
+	rg.GET("/v1/job/:type/:id/log", ctl.GetLog)
+	rg.POST("/v1/job/:type/:id/evaluation", ctl.Evaluate)
+	rg.GET("/v1/job/:type/:id/evaluation", ctl.ListEvaluations)
+	rg.POST("/v1/job/:type/:id/playground", ctl.Chat)
+
+	// the routes of each type before the job subsystem
+	training := jobTypeMiddleware(domain.JobTypeTraining, "pid")
+	rg.PUT("/v1/train/project/:pid/training/:id", training, ctl.Terminate)
+	rg.GET(
+		"/v1/train/project/:pid/training/:id/result/:result", checkUserEmailMiddleware(&ctl.baseController),
+		training, ctl.GetResultDownloadURL,
+	)
+
+	finetune := jobTypeMiddleware(domain.JobTypeFinetune, "")
+	rg.PUT("/v1/finetune/:id", finetune, ctl.Terminate)
+	rg.GET("/v1/finetune/:id/log", finetune, ctl.GetLog)
+	rg.GET("/v1/finetune/:id/log/ws", finetune, ctl.WatchJob)
+
+	aiccFinetune := jobTypeMiddleware(domain.JobTypeAICCFinetune, "model")
+	rg.PUT("/v1/aiccfinetune/:model/:id", aiccFinetune, ctl.Terminate)
+	rg.GET(
+		"/v1/aiccfinetune/:model/:id/result/:result", checkUserEmailMiddleware(&ctl.baseController),
+		aiccFinetune, ctl.GetResultDownloadURL,
+	)
+}
+
+// jobTypeMiddleware serves the route of a type of job by the handlers of
+// job controller. The scope of job is the path parameter of route if it
+// is not empty.
+func jobTypeMiddleware(t domain.JobType, scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Params = append(ctx.Params, gin.Param{Key: "type", Value: t.JobType()})
+
+		if scope != "" {
+			ctx.Params = append(ctx.Params, gin.Param{Key: "scope", Value: ctx.Param(scope)})
+		}
+	}
+}
+
+type JobController struct {
+	baseController
+
+	js  app.JobService
+	ms  app.JobModelService
+	hub *JobHub
+}
+
+// @Summary		List
+// @Description	list jobs of the type
+// @Tags			Job
+// @Param			type	path	string	true	"job type: training, finetune, aiccfinetune"
+// @Param			scope	query	string	false	"project id of training or model name of aicc finetune"
+// @Accept			json
+// @Success		200	{object}		[]app.JobDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type} [get]
+func (ctl *JobController) List(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	t, err := domain.NewJobType(ctx.Param("type"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, code, err := ctl.js.List(t, pl.DomainAccount(), ctl.getQueryParameter(ctx, "scope"))
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		Get
+// @Description	get job
+// @Tags			Job
+// @Param			type	path	string	true	"job type: training, finetune, aiccfinetune"
+// @Param			id		path	string	true	"job id"
+// @Param			scope	query	string	false	"project id of training or model name of aicc finetune"
+// @Accept			json
+// @Success		200	{object}		app.JobDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/{id} [get]
+func (ctl *JobController) Get(ctx *gin.Context) {
+	index, ok := ctl.jobIndex(ctx)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.js.Get(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		Terminate
+// @Description	terminate job
+// @Tags			Job
+// @Param			type	path	string	true	"job type: training, finetune, aiccfinetune"
+// @Param			id		path	string	true	"job id"
+// @Param			scope	query	string	false	"project id of training or model name of aicc finetune"
+// @Accept			json
+// @Success		202
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/{id} [put]
+func (ctl *JobController) Terminate(ctx *gin.Context) {
+	index, ok := ctl.jobIndex(ctx)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, index.Owner.Account(), OPERATE_TYPE_USER, "terminate job")
+
+	if code, err := ctl.js.Terminate(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", index.Owner.Account(), "terminate job",
+		fmt.Sprintf("type: %s, jobid: %s", index.Type.JobType(), index.Id), "success")
+
+	ctl.sendRespOfPut(ctx, "success")
+}
+
+// @Summary		GetResultDownloadURL
+// @Description	get the url of job result for downloading
+// @Tags			Job
+// @Param			type	path	string	true	"job type: training, finetune, aiccfinetune"
+// @Param			id		path	string	true	"job id"
+// @Param			result	path	string	true	"job result: log, output"
+// @Param			scope	query	string	false	"project id of training or model name of aicc finetune"
+// @Accept			json
+// @Success		200	{object}		jobResultResp
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/{id}/result/{result} [get]
+func (ctl *JobController) GetResultDownloadURL(ctx *gin.Context) {
+	index, ok := ctl.jobIndex(ctx)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.js.GetResultDownloadURL(&index, ctx.Param("result"))
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, jobResultResp{v})
+	}
+}
+
+// @Summary		GetLog
+// @Description	get the log of job
+// @Tags			Job
+// @Param			type	path	string	true	"job type: training, finetune, aiccfinetune"
+// @Param			id		path	string	true	"job id"
+// @Param			scope	query	string	false	"project id of training or model name of aicc finetune"
+// @Accept			json
+// @Success		200	{object}		jobLog
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/{id}/log [get]
+func (ctl *JobController) GetLog(ctx *gin.Context) {
+	index, ok := ctl.jobIndex(ctx)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.js.Get(&index)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	if log, err := downloadLog(v.LogPreviewURL); err != nil {
+		ctl.sendCodeMessage(ctx, "", err)
+	} else {
+		ctl.sendRespOfGet(ctx, jobLog{string(log)})
+	}
+}
+
+// @Summary		WatchJobs
+// @Description	watch jobs of the type until all of them are done
+// @Tags			Job
+// @Param			type	path	string	true	"job type: training, finetune, aiccfinetune"
+// @Param			scope	query	string	false	"project id of training or model name of aicc finetune"
+// @Accept			json
+// @Success		200	{object}		[]app.JobDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/ws [get]
+func (ctl *JobController) WatchJobs(ctx *gin.Context) {
+	ctl.watch(ctx, "")
+}
+
+// @Summary		WatchJob
+// @Description	watch job with its log until it is done
+// @Tags			Job
+// @Param			type	path	string	true	"job type: training, finetune, aiccfinetune"
+// @Param			id		path	string	true	"job id"
+// @Param			scope	query	string	false	"project id of training or model name of aicc finetune"
+// @Accept			json
+// @Success		200	{object}		jobDetail
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/{id}/ws [get]
+func (ctl *JobController) WatchJob(ctx *gin.Context) {
+	ctl.watch(ctx, ctx.Param("id"))
+}
+
+func (ctl *JobController) watch(ctx *gin.Context, id string) {
+	t, err := domain.NewJobType(ctx.Param("type"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	index := domain.JobIndex{
+		Type:  t,
+		Scope: ctl.jobScope(ctx),
+		Id:    id,
+	}
+
+	if id == "" {
+		ctl.watchJobsByWS(ctx, ctl.hub, "jobs", index, jobListRefreshInterval, ctl.jobsView)
+	} else {
+		ctl.watchJobsByWS(ctx, ctl.hub, "job", index, jobLogRefreshInterval, ctl.jobView)
+	}
+}
+
+func (ctl *JobController) jobsView(index *domain.JobIndex) jobView {
+	return func() (*responseData, bool, error) {
+		v, code, err := ctl.js.List(index.Type, index.Owner, index.Scope)
+		if err != nil {
+			if code != "" {
+				data := newResponseCodeError(code, err)
+
+				return &data, true, nil
+			}
+
+			return nil, false, err
+		}
+
+		done := true
+		for i := range v {
+			if !v[i].IsDone {
+				done = false
+
+				break
+			}
+		}
+
+		data := newResponseData(v)
+
+		return &data, done, nil
+	}
+}
+
+func (ctl *JobController) jobView(index *domain.JobIndex) jobView {
+	return func() (*responseData, bool, error) {
+		v, code, err := ctl.js.Get(index)
+		if err != nil {
+			if code != "" {
+				data := newResponseCodeError(code, err)
+
+				return &data, true, nil
+			}
+
+			return nil, false, err
+		}
+
+		detail := jobDetail{JobDTO: v}
+		if log, err := downloadLog(v.LogPreviewURL); err == nil {
+			detail.Log = string(log)
+		}
+
+		data := newResponseData(detail)
+
+		return &data, v.IsDone, nil
+	}
+}
+
+func (ctl *JobController) jobIndex(ctx *gin.Context) (index domain.JobIndex, ok bool) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	t, err := domain.NewJobType(ctx.Param("type"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return index, false
+	}
+
+	index = domain.JobIndex{
+		Type:  t,
+		Owner: pl.DomainAccount(),
+		Scope: ctl.jobScope(ctx),
+		Id:    ctx.Param("id"),
+	}
+
+	return
+}
+
+func (ctl *JobController) jobScope(ctx *gin.Context) string {
+	if v := ctx.Param("scope"); v != "" {
+		return v
+	}
+
+	return ctl.getQueryParameter(ctx, "scope")
+}
+
+// watchJobsByWS upgrades the request to websocket and watches the jobs of
+// the user through the hub until they are done. The view is built for the
+// index whose owner is the user.
+func (ctl *baseController) watchJobsByWS(
+	ctx *gin.Context, hub *JobHub, name string, index domain.JobIndex,
+	refresh time.Duration, view func(*domain.JobIndex) jobView,
+) {
+	ctl.watchTickingJobsByWS(
+		ctx, hub, name, index, refresh,
+		func(index *domain.JobIndex) (jobView, jobTicker) {
+			return view(index), nil
+		},
+	)
+}
+
+// watchTickingJobsByWS is the same as watchJobsByWS except that the data is
+// also advanced by the ticker every second.
+func (ctl *baseController) watchTickingJobsByWS(
+	ctx *gin.Context, hub *JobHub, name string, index domain.JobIndex,
+	refresh time.Duration, view func(*domain.JobIndex) (jobView, jobTicker),
+) {
+	pl, csrftoken, _, ok := ctl.checkTokenForWebsocket(ctx, false)
+	if !ok {
+		return
+	}
+
+	ws, ok := ctl.upgradeToWS(ctx, csrftoken)
+	if !ok {
+		return
+	}
+
+	defer ws.Close()
+
+	index.Owner = pl.DomainAccount()
+
+	v, tick := view(&index)
+
+	hub.watch(ws, name, &index, refresh, v, tick)
+}
+
+func (ctl *baseController) upgradeToWS(ctx *gin.Context, csrftoken string) (*websocket.Conn, bool) {
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{csrftoken},
+		CheckOrigin: func(r *http.Request) bool {
+			return r.Header.Get(headerSecWebsocket) == csrftoken
+		},
+	}
+
+	ws, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return nil, false
+	}
+
+	return ws, true
+}
+
+func downloadLog(link string) ([]byte, error) {
+	if link == "" {
+		return nil, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cli := libutils.NewHttpClient(3)
+	v, _, err := cli.Download(req)
+
+	return v, err
+}
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/opensourceways/xihe-server/job/domain"
+	"github.com/opensourceways/xihe-server/job/domain/message"
+)
+
+const (
+	jobLogRefreshInterval = 5 * time.Second
+	jobViewRetryInterval  = time.Second
+
+	// jobListRefreshInterval reloads the jobs slowly in case some events
+	// are lost, such as when redis is unavailable for a while.
+	jobListRefreshInterval = 30 * time.Second
+)
+
+// jobView loads the data which is sent to the websockets. It returns nil data
+// if there is nothing to send, true if the jobs are all done or can't be
+// watched, and error if it fails temporarily.
+type jobView func() (*responseData, bool, error)
+
+// jobTicker advances the data sent last time by one second, such as the
+// duration of running job which is reported by the job server rarely. It
+// returns false if there is nothing to advance.
+type jobTicker func(*responseData) bool
+
+// JobHub pushes the jobs to the websockets watching them each time the job
+// server reports their status. The websockets watching the same view of jobs
+// share a topic, so the view is loaded once for all of them.
+type JobHub struct {
+	lock   sync.Mutex
+	topics map[string]*jobTopic
+}
+
+type jobTopic struct {
+	index       domain.JobIndex
+	view        jobView
+	tick        jobTicker
+	refresh     time.Duration
+	notify      chan struct{}
+	subscribers map[chan responseData]struct{}
+}
+
+func (t *jobTopic) wake() {
+	select {
+	case t.notify <- struct{}{}:
+	default:
+	}
+}
+
+func NewJobHub(s message.JobEventSubscriber) *JobHub {
+	h := &JobHub{topics: map[string]*jobTopic{}}
+
+	s.SubscribeJobDetailUpdatedEvent(h.onEvent)
+
+	return h
+}
+
+func (h *JobHub) onEvent(e *domain.JobDetailUpdatedEvent) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for _, t := range h.topics {
+		if e.IsAbout(&t.index) {
+			t.wake()
+		}
+	}
+}
+
+// watch blocks until the jobs are done or the websocket is closed. The name
+// distinguishes the views of the same jobs, and the id of index can be empty
+// to watch all the jobs in the scope. The view is also reloaded every refresh
+// if it is not 0, which is for the content not reported by the job server,
+// such as the log, and for the events which are lost. The data is advanced
+// by the tick every second between the reloads if it is not nil.
+func (h *JobHub) watch(
+	ws *websocket.Conn, name string, index *domain.JobIndex,
+	refresh time.Duration, view jobView, tick jobTicker,
+) {
+	key, ch := h.subscribe(name, index, refresh, view, tick)
+	defer h.unsubscribe(key, ch)
+
+	// the websocket is closed by client if reading fails
+	closed := make(chan struct{})
+	go func() {
+		for {
+			if _, _, err := ws.NextReader(); err != nil {
+				close(closed)
+
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-closed:
+			return
+
+		case data, ok := <-ch:
+			if !ok {
+				return
+			}
+
+			if err := ws.WriteJSON(data); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *JobHub) subscribe(
+	name string, index *domain.JobIndex, refresh time.Duration,
+	view jobView, tick jobTicker,
+) (string, chan responseData) {
+	key := name + "/" + index.Type.JobType() + "/" + index.Owner.Account() +
+		"/" + index.Scope + "/" + index.Id
+
+	ch := make(chan responseData, 1)
+
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	t := h.topics[key]
+	if t == nil {
+		t = &jobTopic{
+			index:       *index,
+			view:        view,
+			tick:        tick,
+			refresh:     refresh,
+			notify:      make(chan struct{}, 1),
+			subscribers: map[chan responseData]struct{}{},
+		}
+		h.topics[key] = t
+
+		go h.run(key, t)
+	}
+
+	t.subscribers[ch] = struct{}{}
+
+	// load the view for the new subscriber
+	t.wake()
+
+	return key, ch
+}
+
+func (h *JobHub) unsubscribe(key string, ch chan responseData) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if t := h.topics[key]; t != nil {
+		delete(t.subscribers, ch)
+
+		// let the topic check whether it is idle
+		t.wake()
+	}
+}
+
+func (h *JobHub) run(key string, t *jobTopic) {
+	var refresh <-chan time.Time
+	if t.refresh > 0 {
+		ticker := time.NewTicker(t.refresh)
+		defer ticker.Stop()
+
+		refresh = ticker.C
+	}
+
+	var second <-chan time.Time
+	if t.tick != nil {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+
+		second = ticker.C
+	}
+
+	var last *responseData
+
+	for {
+		reload := true
+
+		select {
+		case <-t.notify:
+		case <-refresh:
+		case <-second:
+			reload = false
+		}
+
+		if h.closeIfIdle(key, t) {
+			return
+		}
+
+		if !reload {
+			if last != nil && t.tick(last) {
+				h.publish(key, t, last, false)
+			}
+
+			continue
+		}
+
+		data, done, err := t.view()
+		if err != nil {
+			time.AfterFunc(jobViewRetryInterval, t.wake)
+
+			continue
+		}
+
+		if data != nil {
+			last = data
+		}
+
+		if h.publish(key, t, data, done) {
+			return
+		}
+	}
+}
+
+func (h *JobHub) closeIfIdle(key string, t *jobTopic) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if len(t.subscribers) > 0 {
+		return false
+	}
+
+	delete(h.topics, key)
+
+	return true
+}
+
+// publish sends the data to the subscribers which only keep the latest one,
+// and closes the topic if the jobs are done.
+func (h *JobHub) publish(key string, t *jobTopic, data *responseData, done bool) bool {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	for ch := range t.subscribers {
+		if data != nil {
+			select {
+			case <-ch:
+			default:
+			}
+
+			ch <- *data
+		}
+
+		if done {
+			close(ch)
+		}
+	}
+
+	if done {
+		delete(h.topics, key)
+	}
+
+	return done
+}
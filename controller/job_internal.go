@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/job/app"
+	"github.com/opensourceways/xihe-server/job/domain"
+)
+
+func AddRouterForJobInternalController(
+	rg *gin.RouterGroup,
+	s app.JobInternalService,
+	es app.JobEvaluationInternalService,
+) {
+	ctl := JobInternalController{
+		s:  s,
+		es: es,
+	}
+
+	rg.PUT(
+		"/v1/job/:type/:owner/:id/detail",
+		internalApiCheckMiddleware(&ctl.baseController), ctl.UpdateJobDetail,
+	)
+	rg.PUT(
+		"/v1/job/:type/:owner/:id/evaluation/:eid",
+		internalApiCheckMiddleware(&ctl.baseController), ctl.UpdateEvaluation,
+	)
+}
+
+type JobInternalController struct {
+	baseController
+
+	s  app.JobInternalService
+	es app.JobEvaluationInternalService
+}
+
+// @Summary		UpdateJobDetail
+// @Description	update the status of job reported by the job server
+// @Tags			JobInternal
+// @Param			type	path	string					true	"job type: training, finetune, aiccfinetune"
+// @Param			owner	path	string					true	"owner of job"
+// @Param			id		path	string					true	"job id"
+// @Param			body	body	JobDetailUpdateRequest	true	"body of job detail"
+// @Accept			json
+// @Success		202
+// @Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/job/{type}/{owner}/{id}/detail [put]
+func (ctl *JobInternalController) UpdateJobDetail(ctx *gin.Context) {
+	req := JobDetailUpdateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	t, err := domain.NewJobType(ctx.Param("type"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	owner, err := types.NewAccount(ctx.Param("owner"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	detail, ok := req.toDetail()
+	if !ok {
+		ctl.sendBadRequestParam(ctx, errors.New("invalid job detail"))
+
+		return
+	}
+
+	index := domain.JobIndex{
+		Type:  t,
+		Owner: owner,
+		Scope: req.Scope,
+		Id:    ctx.Param("id"),
+	}
+
+	if code, err := ctl.s.UpdateJobDetail(&index, &detail); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+// @Summary		UpdateEvaluation
+// @Description	update the result of evaluation reported by the model service
+// @Tags			JobInternal
+// @Param			type	path	string						true	"job type: finetune, aiccfinetune"
+// @Param			owner	path	string						true	"owner of job"
+// @Param			id		path	string						true	"job id"
+// @Param			eid		path	string						true	"evaluation id"
+// @Param			body	body	JobEvaluationUpdateRequest	true	"body of evaluation result"
+// @Accept			json
+// @Success		202
+// @Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/job/{type}/{owner}/{id}/evaluation/{eid} [put]
+func (ctl *JobInternalController) UpdateEvaluation(ctx *gin.Context) {
+	req := JobEvaluationUpdateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	t, err := domain.NewJobType(ctx.Param("type"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	owner, err := types.NewAccount(ctx.Param("owner"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	r, ok := req.toResult()
+	if !ok {
+		ctl.sendBadRequestParam(ctx, errors.New("invalid evaluation result"))
+
+		return
+	}
+
+	index := domain.JobIndex{
+		Type:  t,
+		Owner: owner,
+		Id:    ctx.Param("id"),
+	}
+
+	if err := ctl.es.UpdateEvaluation(&index, ctx.Param("eid"), &r); err != nil {
+		ctl.sendCodeMessage(ctx, "", err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
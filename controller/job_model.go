@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/job/app"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// @Summary		Evaluate
+// @Description	evaluate the checkpoint output by the finetune against a benchmark
+// @Tags			Job
+// @Param			type	path	string				true	"job type: finetune, aiccfinetune"
+// @Param			id		path	string				true	"job id"
+// @Param			scope	query	string				false	"model name of aicc finetune"
+// @Param			body	body	jobEvaluateRequest	true	"body of evaluation"
+// @Accept			json
+// @Success		201	{object}			jobEvaluateResp
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/job/{type}/{id}/evaluation [post]
+func (ctl *JobController) Evaluate(ctx *gin.Context) {
+	req := jobEvaluateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	index, ok := ctl.jobIndex(ctx)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, index.Owner.Account(), OPERATE_TYPE_USER, "evaluate job")
+
+	cmd := app.JobEvaluateCmd{
+		JobIndex:  index,
+		Benchmark: req.Benchmark,
+	}
+
+	v, code, err := ctl.ms.Evaluate(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", index.Owner.Account(), "evaluate job",
+		fmt.Sprintf("type: %s, jobid: %s, benchmark: %s", index.Type.JobType(), index.Id, req.Benchmark),
+		"success",
+	)
+
+	ctl.sendRespOfPost(ctx, jobEvaluateResp{v})
+}
+
+// @Summary		ListEvaluations
+// @Description	list the evaluations of job with their scores
+// @Tags			Job
+// @Param			type	path	string	true	"job type: finetune, aiccfinetune"
+// @Param			id		path	string	true	"job id"
+// @Param			scope	query	string	false	"model name of aicc finetune"
+// @Accept			json
+// @Success		200	{object}		[]app.JobEvaluationDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/{id}/evaluation [get]
+func (ctl *JobController) ListEvaluations(ctx *gin.Context) {
+	index, ok := ctl.jobIndex(ctx)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.ms.ListEvaluations(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		Chat
+// @Description	chat with the model output by the finetune for a while after it is done
+// @Tags			Job
+// @Param			type	path	string			true	"job type: finetune, aiccfinetune"
+// @Param			id		path	string			true	"job id"
+// @Param			scope	query	string			false	"model name of aicc finetune"
+// @Param			body	body	jobChatRequest	true	"body of chat"
+// @Accept			json
+// @Success		202	{object}		string
+// @Failure		500	system_error	system	error
+// @Router			/v1/job/{type}/{id}/playground [post]
+func (ctl *JobController) Chat(ctx *gin.Context) {
+	req := jobChatRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	index, ok := ctl.jobIndex(ctx)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, index.Owner.Account(), OPERATE_TYPE_USER, "chat with finetuned model")
+
+	ch := make(chan string, chBufferSize)
+	cmd, err := req.toCmd(&index, ch)
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.ms.Chat(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	// don't block the sender if the client leaves early
+	defer func() {
+		go func() {
+			for range ch {
+			}
+		}()
+	}()
+
+	ctx.Header("Content-Type", "text/event-stream; charset=utf-8")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+
+	ctx.Stream(func(w io.Writer) bool {
+		if msg, ok := <-ch; ok {
+			ctx.SSEvent("message", msg)
+
+			return true
+		}
+
+		ctx.SSEvent("status", "done")
+
+		return false
+	})
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"github.com/opensourceways/xihe-server/job/app"
+	"github.com/opensourceways/xihe-server/job/domain"
+)
+
+type jobDetail struct {
+	app.JobDTO
+	Log string `json:"log"`
+}
+
+// jobResultResp keeps the field of the result responses before the job
+// subsystem, which are still served by the routes of each type.
+type jobResultResp struct {
+	URL string `json:"log_url"`
+}
+
+type jobLog struct {
+	Log string `json:"log"`
+}
+
+type JobDetailUpdateRequest struct {
+	Scope      string `json:"scope"`
+	Status     string `json:"status"`
+	Error      string `json:"error"`
+	LogPath    string `json:"log_path"`
+	OutputPath string `json:"output_path"`
+	Duration   int    `json:"duration"`
+}
+
+func (req *JobDetailUpdateRequest) toDetail() (domain.JobDetail, bool) {
+	return domain.JobDetail{
+		Status:     req.Status,
+		Error:      req.Error,
+		LogPath:    req.LogPath,
+		OutputPath: req.OutputPath,
+		Duration:   req.Duration,
+	}, req.Status != "" && req.Duration >= 0
+}
+
+type jobEvaluateRequest struct {
+	Benchmark string `json:"benchmark"`
+}
+
+type jobEvaluateResp struct {
+	Id string `json:"id"`
+}
+
+type jobChatRequest struct {
+	Text        string      `json:"text"`
+	History     [][2]string `json:"history"`
+	Temperature float64     `json:"temperature"`
+}
+
+func (req *jobChatRequest) toCmd(index *domain.JobIndex, ch chan string) (app.JobChatCmd, error) {
+	cmd := app.JobChatCmd{
+		JobIndex:    *index,
+		CH:          ch,
+		Text:        req.Text,
+		History:     req.History,
+		Temperature: req.Temperature,
+	}
+
+	return cmd, cmd.Validate()
+}
+
+type evaluationScore struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+}
+
+type JobEvaluationUpdateRequest struct {
+	Status string            `json:"status"`
+	Error  string            `json:"error"`
+	Scores []evaluationScore `json:"scores"`
+}
+
+func (req *JobEvaluationUpdateRequest) toResult() (r domain.EvaluationResult, ok bool) {
+	r = domain.EvaluationResult{
+		Status: req.Status,
+		Error:  req.Error,
+	}
+
+	// only the final result is accepted
+	if !r.IsDone() {
+		return
+	}
+
+	if n := len(req.Scores); n > 0 {
+		r.Scores = make([]domain.EvaluationScore, n)
+
+		for i := range req.Scores {
+			if req.Scores[i].Metric == "" {
+				return
+			}
+
+			r.Scores[i] = domain.EvaluationScore{
+				Metric: req.Scores[i].Metric,
+				Value:  req.Scores[i].Value,
+			}
+		}
+	}
+
+	return r, true
+}
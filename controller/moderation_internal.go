@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/moderation/app"
+)
+
+func AddRouterForModerationInternalController(
+	rg *gin.RouterGroup,
+	s app.ModerationService,
+) {
+	ctl := ModerationInternalController{
+		s: s,
+	}
+
+	rg.GET("/v1/moderation/record", internalApiCheckMiddleware(&ctl.baseController), ctl.ListRecords)
+	rg.PUT("/v1/moderation/record/:id", internalApiCheckMiddleware(&ctl.baseController), ctl.Review)
+}
+
+type ModerationInternalController struct {
+	baseController
+
+	s app.ModerationService
+}
+
+// @Summary		ListRecords
+// @Description	list the blocked or warned content, the latest first
+// @Tags			ModerationInternal
+// @Param			status			query	string	false	"pending, approved or rejected"
+// @Param			surface			query	string	false	"surface, such as chat_prompt, chat_output, title"
+// @Param			count_per_page	query	int		false	"count per page"
+// @Param			page_num		query	int		false	"page num which starts from 1"
+// @Accept			json
+// @Success		200	{object}			app.RecordsDTO
+// @Failure		400	bad_request_param	some	parameter	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/moderation/record [get]
+func (ctl *ModerationInternalController) ListRecords(ctx *gin.Context) {
+	cmd, err := ctl.getRecordListParameter(ctx)
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.s.ListRecords(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		Review
+// @Description	review the pending record
+// @Tags			ModerationInternal
+// @Param			id		path	string					true	"record id"
+// @Param			body	body	moderationReviewRequest	true	"body of review"
+// @Accept			json
+// @Success		202
+// @Failure		400	bad_request_body	can't	parse	request	body
+// @Failure		500	system_error		system	error
+// @Router			/v1/moderation/record/{id} [put]
+func (ctl *ModerationInternalController) Review(ctx *gin.Context) {
+	req := moderationReviewRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	cmd, err := req.toCmd(ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.s.Review(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
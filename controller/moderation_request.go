@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/moderation/app"
+	"github.com/opensourceways/xihe-server/moderation/domain"
+)
+
+type moderationReviewRequest struct {
+	Status   string `json:"status"`
+	Reviewer string `json:"reviewer"`
+	Comment  string `json:"comment"`
+}
+
+func (req *moderationReviewRequest) toCmd(id string) (cmd app.ReviewCmd, err error) {
+	if req.Status == domain.ReviewStatusPending {
+		err = errors.New("invalid review status")
+
+		return
+	}
+
+	if cmd.Status, err = domain.NewReviewStatus(req.Status); err != nil {
+		return
+	}
+
+	if req.Reviewer == "" {
+		err = errors.New("missing reviewer")
+
+		return
+	}
+
+	cmd.Id = id
+	cmd.Reviewer = req.Reviewer
+	cmd.Comment = req.Comment
+
+	return
+}
+
+func (ctl *ModerationInternalController) getRecordListParameter(
+	ctx *gin.Context,
+) (cmd app.RecordListCmd, err error) {
+	if v := ctl.getQueryParameter(ctx, "status"); v != "" {
+		if cmd.Status, err = domain.NewReviewStatus(v); err != nil {
+			return
+		}
+	}
+
+	if v := ctl.getQueryParameter(ctx, "surface"); v != "" {
+		if cmd.Surface, err = domain.NewSurface(v); err != nil {
+			return
+		}
+	}
+
+	if v := ctl.getQueryParameter(ctx, "count_per_page"); v != "" {
+		if cmd.CountPerPage, err = strconv.Atoi(v); err != nil {
+			return
+		}
+
+		if cmd.CountPerPage > 100 || cmd.CountPerPage <= 0 {
+			err = errors.New("bad count_per_page")
+
+			return
+		}
+	}
+
+	if v := ctl.getQueryParameter(ctx, "page_num"); v != "" {
+		if cmd.PageNum, err = strconv.Atoi(v); err != nil {
+			return
+		}
+	}
+
+	return
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/opensourceways/xihe-server/app"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
 	"github.com/opensourceways/xihe-server/domain/training"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	jobdomain "github.com/opensourceways/xihe-server/job/domain"
 	spacerepo "github.com/opensourceways/xihe-server/space/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
 )
@@ -24,39 +27,79 @@ func AddRouterForTrainingController(
 	model repository.Model,
 	project spacerepo.Project,
 	dataset repository.Dataset,
+	sweep repository.TrainingSweep,
+	metric repository.TrainingMetric,
+	pipeline repository.TrainingPipeline,
+	schedule repository.TrainingSchedule,
 	sender message.MessageProducer,
+	modelService app.ModelService,
+	repoFile platform.RepoFile,
+	newPlatformRepository func(token, namespace string) platform.Repository,
+	hub *JobHub,
+	notifier jobapp.JobDetailNotifier,
 ) {
 	ctl := TrainingController{
 		ts: app.NewTrainingService(
-			ts, repo, sender, apiConfig.MaxTrainingRecordNum,
+			ts, repo, sweep, metric, pipeline, sender, notifier,
+			apiConfig.MaxTrainingRecordNum,
+		),
+		ms: app.NewTrainingMetricService(metric, repo),
+		ss: app.NewTrainingScheduleService(repo, schedule),
+		ps: app.NewTrainingPublishService(
+			ts, repo, model, modelService, project, repoFile,
+			apiConfig.MaxTrainingPublishFileSize,
 		),
 		model:   model,
 		project: project,
 		dataset: dataset,
+		hub:     hub,
+
+		newPlatformRepository: newPlatformRepository,
 	}
 
 	rg.POST("/v1/train/project/:pid/training", checkUserEmailMiddleware(&ctl.baseController), ctl.Create)
 	rg.POST("/v1/train/project/:pid/training/:id", ctl.Recreate)
-	rg.PUT("/v1/train/project/:pid/training/:id", ctl.Terminate)
 	rg.GET("/v1/train/project/:pid/training", checkUserEmailMiddleware(&ctl.baseController), ctl.List)
 	rg.GET("/v1/train/project/:pid/training/ws", ctl.ListByWS)
-	rg.GET(
-		"/v1/train/project/:pid/training/:id/result/:type", checkUserEmailMiddleware(&ctl.baseController),
-		ctl.GetResultDownloadURL,
-	)
 	rg.GET("/v1/train/project/:pid/training/:id", ctl.Get)
+	rg.GET("/v1/train/project/:pid/training/:id/metric", ctl.ListMetrics)
+	rg.POST(
+		"/v1/train/project/:pid/training/:id/model", checkUserEmailMiddleware(&ctl.baseController),
+		ctl.Publish,
+	)
+	rg.POST("/v1/train/project/:pid/training/:id/resume", ctl.Resume)
+	rg.GET("/v1/train/project/:pid/metric", ctl.CompareMetrics)
 	rg.GET("/v1/train/project/:pid/config", ctl.GetLastTrainingConfig)
 	rg.DELETE("v1/train/project/:pid/training/:id", ctl.Delete)
+
+	rg.POST("/v1/train/project/:pid/sweep", checkUserEmailMiddleware(&ctl.baseController), ctl.CreateSweep)
+	rg.GET("/v1/train/project/:pid/sweep", ctl.ListSweeps)
+	rg.GET("/v1/train/project/:pid/sweep/:id", ctl.GetSweep)
+
+	rg.POST("/v1/train/project/:pid/pipeline", checkUserEmailMiddleware(&ctl.baseController), ctl.CreatePipeline)
+	rg.GET("/v1/train/project/:pid/pipeline", ctl.ListPipelines)
+	rg.GET("/v1/train/project/:pid/pipeline/:id", ctl.GetPipeline)
+
+	rg.POST("/v1/train/project/:pid/schedule", checkUserEmailMiddleware(&ctl.baseController), ctl.CreateSchedule)
+	rg.GET("/v1/train/project/:pid/schedule", ctl.ListSchedules)
+	rg.GET("/v1/train/project/:pid/schedule/:id", ctl.GetSchedule)
+	rg.DELETE("/v1/train/project/:pid/schedule/:id", ctl.DeleteSchedule)
 }
 
 type TrainingController struct {
 	baseController
 
 	ts app.TrainingService
+	ms app.TrainingMetricService
+	ps app.TrainingPublishService
+	ss app.TrainingScheduleService
 
 	model   repository.Model
 	project spacerepo.Project
 	dataset repository.Dataset
+	hub     *JobHub
+
+	newPlatformRepository func(token, namespace string) platform.Repository
 }
 
 // @Summary		Create
@@ -191,36 +234,6 @@ func (ctl *TrainingController) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusNoContent, newResponseData("success"))
 }
 
-// @Summary		Terminate
-// @Description	terminate training
-// @Tags			Training
-// @Param			pid	path	string	true	"project id"
-// @Param			id	path	string	true	"training id"
-// @Accept			json
-// @Success		202
-// @Failure		500	system_error	system	error
-// @Router			/v1/train/project/{pid}/training/{id} [put]
-func (ctl *TrainingController) Terminate(ctx *gin.Context) {
-	info, ok := ctl.getTrainingInfo(ctx)
-	if !ok {
-		return
-	}
-
-	pl, _, _ := ctl.checkUserApiToken(ctx, false)
-	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "terminate training")
-
-	if err := ctl.ts.Terminate(&info); err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
-
-		return
-	}
-
-	utils.DoLog("", info.Project.Owner.Account(), "terminate training",
-		fmt.Sprintf("projectid: %s, trainingid: %s", info.Project.Id, info.TrainingId), "success")
-
-	ctx.JSON(http.StatusAccepted, newResponseData("success"))
-}
-
 // @Summary		Get
 // @Description	get training info
 // @Tags			Training
@@ -231,101 +244,70 @@ func (ctl *TrainingController) Terminate(ctx *gin.Context) {
 // @Failure		500	system_error	system	error
 // @Router			/v1/train/project/{pid}/training/{id} [get]
 func (ctl *TrainingController) Get(ctx *gin.Context) {
-	pl, csrftoken, _, ok := ctl.checkTokenForWebsocket(ctx, false)
-	if !ok {
-		return
+	index := jobdomain.JobIndex{
+		Type:  jobdomain.JobTypeTraining,
+		Scope: ctx.Param("pid"),
+		Id:    ctx.Param("id"),
 	}
 
-	index := domain.TrainingIndex{
-		Project: domain.ResourceIndex{
-			Owner: pl.DomainAccount(),
-			Id:    ctx.Param("pid"),
-		},
-		TrainingId: ctx.Param("id"),
-	}
+	ctl.watchTickingJobsByWS(ctx, ctl.hub, "training", index, jobLogRefreshInterval, ctl.trainingView)
+}
 
-	// setup websocket
-	upgrader := websocket.Upgrader{
-		Subprotocols: []string{csrftoken},
-		CheckOrigin: func(r *http.Request) bool {
-			return r.Header.Get(headerSecWebsocket) == csrftoken
+// trainingView counts the duration of running training locally, because the
+// job server reports it rarely.
+func (ctl *TrainingController) trainingView(index *jobdomain.JobIndex) (jobView, jobTicker) {
+	info := domain.TrainingIndex{
+		Project: domain.ResourceIndex{
+			Owner: index.Owner,
+			Id:    index.Scope,
 		},
+		TrainingId: index.Id,
 	}
 
-	ws, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
-	if err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
-
-		return
-	}
-
-	defer ws.Close()
-
-	ctl.watchTraining(ws, &index)
-}
-
-func (ctl *TrainingController) watchTraining(ws *websocket.Conn, index *domain.TrainingIndex) {
 	duration := 0
-	sleep := func() {
-		time.Sleep(time.Second)
-
-		if duration > 0 {
-			duration++
-		}
-	}
-
-	data := &trainingDetail{}
-
-	start, end := 4, 5
-	i := start
-	for {
-		if i++; i == end {
-			v, code, err := ctl.ts.Get(index)
-			if err != nil {
-				if code == app.ErrorTrainNotFound {
-					break
-				}
-
-				i = start
-				sleep()
 
-				continue
+	view := func() (*responseData, bool, error) {
+		v, code, err := ctl.ts.Get(&info)
+		if err != nil {
+			if code == app.ErrorTrainNotFound {
+				return nil, true, nil
 			}
 
-			data.TrainingDTO = v
+			return nil, false, err
+		}
 
-			if duration == 0 {
-				duration = v.Duration
-			} else {
-				data.Duration = duration
-			}
+		if v.IsDone || v.Duration > duration {
+			duration = v.Duration
+		} else {
+			v.Duration = duration
+		}
 
-			log, err := downloadLog(v.LogPreviewURL)
-			if err == nil && len(log) > 0 {
-				data.Log = string(log)
-			}
+		data := trainingDetail{TrainingDTO: v}
 
-			if err = ws.WriteJSON(newResponseData(data)); err != nil {
-				break
-			}
+		log, err := downloadLog(v.LogPreviewURL)
+		if err == nil && len(log) > 0 {
+			data.Log = string(log)
+		}
 
-			if v.IsDone {
-				break
-			}
+		resp := newResponseData(data)
 
-			i = 0
-		} else {
-			if data.Duration > 0 {
-				data.Duration++
+		return &resp, v.IsDone, nil
+	}
 
-				if err := ws.WriteJSON(newResponseData(data)); err != nil {
-					break
-				}
-			}
+	tick := func(resp *responseData) bool {
+		data, ok := resp.Data.(trainingDetail)
+		if !ok || data.IsDone || duration == 0 {
+			return false
 		}
 
-		sleep()
+		duration++
+		data.Duration = duration
+		resp.Data = data
+
+		return true
 	}
+
+	return view, tick
 }
 
 // @Summary		List
@@ -355,40 +337,46 @@ func (ctl *TrainingController) List(ctx *gin.Context) {
 // @Summary		List
 // @Description	get trainings
 // @Tags			Training
-// @Param			pid	path	string	true	"project id"
+// @Param			pid		path	string	true	"project id"
+// @Param			metrics	query	bool	false	"whether to watch the metrics of running training"
 // @Accept			json
 // @Success		200	{object}		app.TrainingSummaryDTO
 // @Failure		500	system_error	system	error
 // @Router			/v1/train/project/{pid}/training/ws [get]
 func (ctl *TrainingController) ListByWS(ctx *gin.Context) {
-	pl, csrftoken, _, ok := ctl.checkTokenForWebsocket(ctx, false)
-	if !ok {
-		return
-	}
-
 	pid := ctx.Param("pid")
 
-	// setup websocket
-	upgrader := websocket.Upgrader{
-		Subprotocols: []string{csrftoken},
-		CheckOrigin: func(r *http.Request) bool {
-			return r.Header.Get(headerSecWebsocket) == csrftoken
-		},
+	if ctx.Query("metrics") != "true" {
+		index := jobdomain.JobIndex{
+			Type:  jobdomain.JobTypeTraining,
+			Scope: pid,
+		}
+
+		ctl.watchJobsByWS(ctx, ctl.hub, "trainings", index, jobListRefreshInterval, ctl.trainingsView)
+
+		return
 	}
 
-	ws, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
-	if err != nil {
-		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	pl, csrftoken, _, ok := ctl.checkTokenForWebsocket(ctx, false)
+	if !ok {
+		return
+	}
 
+	ws, ok := ctl.upgradeToWS(ctx, csrftoken)
+	if !ok {
 		return
 	}
 
 	defer ws.Close()
 
-	ctl.watchTrainings(ws, pl.DomainAccount(), pid)
+	ctl.watchTrainingsWithMetrics(ws, pl.DomainAccount(), pid)
 }
 
-func (ctl *TrainingController) watchTrainings(ws *websocket.Conn, user domain.Account, pid string) {
+func (ctl *TrainingController) watchTrainingsWithMetrics(
+	ws *websocket.Conn, user domain.Account, pid string,
+) {
+	// the metrics are not reported by the job server, so they are still
+	// polled for each websocket.
 	finished := func(v []app.TrainingSummaryDTO) (b bool, i int) {
 		for i = range v {
 			if !v[i].IsDone {
@@ -410,10 +398,48 @@ func (ctl *TrainingController) watchTrainings(ws *websocket.Conn, user domain.Ac
 		}
 	}
 
+	// the metrics of running training are sent along with the trainings
+	// only if the client asks for them, so the old clients are not affected.
+	var since int64
+	var running *app.TrainingSummaryDTO
+	var metrics []app.TrainingMetricSeriesDTO
+	write := func(v []app.TrainingSummaryDTO) error {
+		resp := trainingWatchResp{Trainings: v}
+		if running != nil {
+			resp.Metrics = &trainingWatchMetrics{
+				TrainingId: running.Id,
+				Series:     metrics,
+			}
+		}
+
+		// the metrics are sent only once
+		metrics = nil
+
+		return ws.WriteJSON(newResponseData(resp))
+	}
+
+	fetchMetrics := func() {
+		if running == nil {
+			return
+		}
+
+		index := domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: user,
+				Id:    pid,
+			},
+			TrainingId: running.Id,
+		}
+
+		v, t, err := ctl.ms.ListSince(&index, since)
+		if err == nil {
+			metrics, since = v, t
+		}
+	}
+
 	// start loop
 	var err error
 	var v []app.TrainingSummaryDTO
-	var running *app.TrainingSummaryDTO
 
 	start, end := 4, 5
 	i := start
@@ -433,14 +459,21 @@ func (ctl *TrainingController) watchTrainings(ws *websocket.Conn, user domain.Ac
 
 			done, index := finished(v)
 			if done {
-				if err = ws.WriteJSON(newResponseData(v)); err != nil {
+				fetchMetrics()
+
+				if err = write(v); err != nil {
 					return
 				}
 
 				break
 			}
 
+			if running == nil || running.Id != v[index].Id {
+				since = 0
+			}
+
 			running = &v[index]
+			fetchMetrics()
 
 			if duration == 0 {
 				duration = running.Duration
@@ -448,7 +481,7 @@ func (ctl *TrainingController) watchTrainings(ws *websocket.Conn, user domain.Ac
 				running.Duration = duration
 			}
 
-			if err = ws.WriteJSON(newResponseData(v)); err != nil {
+			if err = write(v); err != nil {
 				break
 			}
 
@@ -457,7 +490,7 @@ func (ctl *TrainingController) watchTrainings(ws *websocket.Conn, user domain.Ac
 			if running.Duration > 0 {
 				running.Duration++
 
-				if err = ws.WriteJSON(newResponseData(v)); err != nil {
+				if err = write(v); err != nil {
 					break
 				}
 			}
@@ -467,6 +500,32 @@ func (ctl *TrainingController) watchTrainings(ws *websocket.Conn, user domain.Ac
 	}
 }
 
+func (ctl *TrainingController) trainingsView(index *jobdomain.JobIndex) jobView {
+	return func() (*responseData, bool, error) {
+		v, err := ctl.ts.List(index.Owner, index.Scope)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if len(v) == 0 {
+			return nil, true, nil
+		}
+
+		done := true
+		for i := range v {
+			if !v[i].IsDone {
+				done = false
+
+				break
+			}
+		}
+
+		data := newResponseData(v)
+
+		return &data, done, nil
+	}
+}
+
 // @Summary		GetLastTrainingConfig
 // @Description	get user last preset training config
 // @Tags			Training
@@ -494,55 +553,6 @@ func (ctl *TrainingController) GetLastTrainingConfig(ctx *gin.Context) {
 	}
 }
 
-// @Summary		GetLog
-// @Description	get log url of training for downloading
-// @Tags			Training
-// @Param			pid		path	string	true	"project id"
-// @Param			id		path	string	true	"training id"
-// @Param			type	path	string	true	"training result: log, output"
-// @Accept			json
-// @Success		200	{object}		trainingLogResp
-// @Failure		500	system_error	system	error
-// @Router			/v1/train/project/{pid}/training/{id}/result/{type} [get]
-func (ctl *TrainingController) GetResultDownloadURL(ctx *gin.Context) {
-	pl, _, ok := ctl.checkUserApiToken(ctx, false)
-	if !ok {
-		return
-	}
-
-	info := domain.TrainingIndex{
-		Project: domain.ResourceIndex{
-			Owner: pl.DomainAccount(),
-			Id:    ctx.Param("pid"),
-		},
-		TrainingId: ctx.Param("id"),
-	}
-
-	v, code := "", ""
-	var err error
-
-	switch ctx.Param("type") {
-	case "log":
-		v, code, err = ctl.ts.GetLogDownloadURL(&info)
-
-	case "output":
-		v, code, err = ctl.ts.GetOutputDownloadURL(&info)
-
-	default:
-		ctl.sendBadRequest(ctx, newResponseCodeMsg(
-			errorBadRequestParam, "unknown result type",
-		))
-
-		return
-	}
-
-	if err != nil {
-		ctl.sendCodeMessage(ctx, code, err)
-	} else {
-		ctl.sendRespOfGet(ctx, trainingLogResp{v})
-	}
-}
-
 func (ctl *TrainingController) getTrainingInfo(ctx *gin.Context) (domain.TrainingIndex, bool) {
 	pl, _, ok := ctl.checkUserApiToken(ctx, false)
 	if !ok {
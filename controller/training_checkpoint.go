@@ -0,0 +1,73 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingCheckpointAddRequest struct {
+	Path string `json:"path"`
+	Step int    `json:"step"`
+}
+
+func (req *TrainingCheckpointAddRequest) toCmd(cmd *app.TrainingCheckpointAddCmd) (err error) {
+	cmd.Path, err = domain.NewTrainingOutputFilePath(req.Path)
+	cmd.Step = req.Step
+
+	return
+}
+
+type TrainingResumeRequest struct {
+	Step int `json:"step"`
+}
+
+// @Summary		Resume
+// @Description	resume a failed or terminated training from its checkpoint
+// @Tags			Training
+// @Param			pid		path	string					true	"project id"
+// @Param			id		path	string					true	"training id"
+// @Param			body	body	TrainingResumeRequest	true	"body of resuming training"
+// @Accept			json
+// @Success		201	{object}			trainingCreateResp
+// @Failure		400	bad_request_body	can't	parse		request	body
+// @Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/project/{pid}/training/{id}/resume [post]
+func (ctl *TrainingController) Resume(ctx *gin.Context) {
+	req := TrainingResumeRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, respBadRequestBody)
+
+		return
+	}
+
+	info, ok := ctl.getTrainingInfo(ctx)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, info.Project.Owner.Account(), OPERATE_TYPE_USER, "resume training")
+
+	v, code, err := ctl.ts.Resume(&app.TrainingResumeCmd{
+		TrainingIndex: info,
+		Step:          req.Step,
+	})
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", info.Project.Owner.Account(), "resume training",
+		fmt.Sprintf("projectid: %s, trainingid: %s, step: %d", info.Project.Id, info.TrainingId, req.Step),
+		"success",
+	)
+
+	ctx.JSON(http.StatusCreated, newResponseData(trainingCreateResp{v}))
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+func AddRouterForTrainingInternalController(
+	rg *gin.RouterGroup,
+	repo repository.Training,
+	metric repository.TrainingMetric,
+) {
+	ctl := TrainingInternalController{
+		ms: app.NewTrainingMetricService(metric, repo),
+		cs: app.NewTrainingCheckpointService(repo),
+	}
+
+	rg.POST(
+		"/v1/train/:owner/project/:pid/training/:id/metric",
+		internalApiCheckMiddleware(&ctl.baseController), ctl.AddMetrics,
+	)
+
+	rg.POST(
+		"/v1/train/:owner/project/:pid/training/:id/checkpoint",
+		internalApiCheckMiddleware(&ctl.baseController), ctl.AddCheckpoint,
+	)
+}
+
+type TrainingInternalController struct {
+	baseController
+
+	ms app.TrainingMetricService
+	cs app.TrainingCheckpointService
+}
+
+// @Summary		AddMetrics
+// @Description	add scalar metrics reported by the job of training
+// @Tags			TrainingInternal
+// @Param			owner	path	string						true	"owner of project"
+// @Param			pid		path	string						true	"project id"
+// @Param			id		path	string						true	"training id"
+// @Param			body	body	TrainingMetricsAddRequest	true	"body of metrics"
+// @Accept			json
+// @Success		201
+// @Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/{owner}/project/{pid}/training/{id}/metric [post]
+func (ctl *TrainingInternalController) AddMetrics(ctx *gin.Context) {
+	req := TrainingMetricsAddRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	owner, err := domain.NewAccount(ctx.Param("owner"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := app.TrainingMetricAddCmd{
+		TrainingIndex: domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: owner,
+				Id:    ctx.Param("pid"),
+			},
+			TrainingId: ctx.Param("id"),
+		},
+	}
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.ms.Add(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, "success")
+	}
+}
+
+// @Summary		AddCheckpoint
+// @Description	register the checkpoint saved by the job of training
+// @Tags			TrainingInternal
+// @Param			owner	path	string							true	"owner of project"
+// @Param			pid		path	string							true	"project id"
+// @Param			id		path	string							true	"training id"
+// @Param			body	body	TrainingCheckpointAddRequest	true	"body of checkpoint"
+// @Accept			json
+// @Success		201
+// @Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/{owner}/project/{pid}/training/{id}/checkpoint [post]
+func (ctl *TrainingInternalController) AddCheckpoint(ctx *gin.Context) {
+	req := TrainingCheckpointAddRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	owner, err := domain.NewAccount(ctx.Param("owner"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := app.TrainingCheckpointAddCmd{
+		TrainingIndex: domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: owner,
+				Id:    ctx.Param("pid"),
+			},
+			TrainingId: ctx.Param("id"),
+		},
+	}
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.cs.Add(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, "success")
+	}
+}
@@ -0,0 +1,121 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type trainingWatchResp struct {
+	Trainings []app.TrainingSummaryDTO `json:"trainings"`
+	Metrics   *trainingWatchMetrics    `json:"metrics,omitempty"`
+}
+
+// trainingWatchMetrics is the metrics of running training which are
+// reported since the last message.
+type trainingWatchMetrics struct {
+	TrainingId string                        `json:"training_id"`
+	Series     []app.TrainingMetricSeriesDTO `json:"series"`
+}
+
+type TrainingMetricRequest struct {
+	Name  string  `json:"name"`
+	Step  int     `json:"step"`
+	Value float64 `json:"value"`
+}
+
+type TrainingMetricsAddRequest struct {
+	Metrics []TrainingMetricRequest `json:"metrics"`
+}
+
+func (req *TrainingMetricsAddRequest) toCmd(cmd *app.TrainingMetricAddCmd) (err error) {
+	cmd.Metrics = make([]domain.TrainingMetric, len(req.Metrics))
+
+	for i := range req.Metrics {
+		item := &req.Metrics[i]
+		v := &cmd.Metrics[i]
+
+		if v.Name, err = domain.NewMetricName(item.Name); err != nil {
+			return
+		}
+
+		v.Step = item.Step
+		v.Value = item.Value
+	}
+
+	return
+}
+
+func (ctl *TrainingController) getMetricNames(ctx *gin.Context) []string {
+	if s := ctl.getQueryParameter(ctx, "names"); s != "" {
+		return strings.Split(s, ",")
+	}
+
+	return nil
+}
+
+// @Summary		ListMetrics
+// @Description	get metrics of training as time series
+// @Tags			Training
+// @Param			pid		path	string	true	"project id"
+// @Param			id		path	string	true	"training id"
+// @Param			names	query	string	false	"names of metrics separated by comma"
+// @Accept			json
+// @Success		200	{object}		app.TrainingMetricSeriesDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/training/{id}/metric [get]
+func (ctl *TrainingController) ListMetrics(ctx *gin.Context) {
+	info, ok := ctl.getTrainingInfo(ctx)
+	if !ok {
+		return
+	}
+
+	if v, err := ctl.ms.List(&info, ctl.getMetricNames(ctx)); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+// @Summary		CompareMetrics
+// @Description	compare metrics of several trainings of project
+// @Tags			Training
+// @Param			pid		path	string	true	"project id"
+// @Param			ids		query	string	true	"ids of trainings separated by comma"
+// @Param			names	query	string	false	"names of metrics separated by comma"
+// @Accept			json
+// @Success		200	{object}		app.TrainingMetricComparisonDTO
+// @Failure		400	bad_request_param	some	parameter	of	query	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/project/{pid}/metric [get]
+func (ctl *TrainingController) CompareMetrics(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.TrainingMetricCompareCmd{
+		User:      pl.DomainAccount(),
+		ProjectId: ctx.Param("pid"),
+		Names:     ctl.getMetricNames(ctx),
+	}
+
+	if s := ctl.getQueryParameter(ctx, "ids"); s != "" {
+		cmd.TrainingIds = strings.Split(s, ",")
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, code, err := ctl.ms.Compare(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
@@ -0,0 +1,208 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingPipelineCreateRequest struct {
+	Name  string                `json:"name"`
+	Steps []PipelineStepRequest `json:"steps"`
+}
+
+type PipelineStepRequest struct {
+	Name       string               `json:"name"`
+	DependsOn  []PipelineDependency `json:"depends_on"`
+	MaxRetries int                  `json:"max_retries"`
+
+	// Config is the config of training which runs the step,
+	// its name is ignored.
+	Config TrainingCreateRequest `json:"config"`
+}
+
+type PipelineDependency struct {
+	Step     string `json:"step"`
+	InputKey string `json:"input_key"`
+}
+
+func (req *PipelineStepRequest) toPipelineStep(cmd *app.TrainingCreateCmd) (
+	r domain.PipelineStep, err error,
+) {
+	if r.Name, err = domain.NewTrainingName(req.Name); err != nil {
+		return
+	}
+
+	req.Config.Name = req.Name
+	if err = req.Config.toCmd(cmd); err != nil {
+		return
+	}
+
+	r.DependsOn = make([]domain.PipelineDependency, len(req.DependsOn))
+	for i, d := range req.DependsOn {
+		v := &r.DependsOn[i]
+
+		if v.Step, err = domain.NewTrainingName(d.Step); err != nil {
+			return
+		}
+
+		if v.InputKey, err = domain.NewCustomizedKey(d.InputKey); err != nil {
+			return
+		}
+	}
+
+	r.MaxRetries = req.MaxRetries
+
+	return
+}
+
+type trainingPipelineCreateResp struct {
+	Id string `json:"id"`
+}
+
+// @Summary		CreatePipeline
+// @Description	create a pipeline which runs trainings as a DAG
+// @Tags			Training
+// @Param			pid		path	string							true	"project id"
+// @Param			body	body	TrainingPipelineCreateRequest	true	"body of creating pipeline"
+// @Accept			json
+// @Success		201	{object}			trainingPipelineCreateResp
+// @Failure		400	bad_request_body	can't	parse		request	body
+// @Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/project/{pid}/pipeline [post]
+func (ctl *TrainingController) CreatePipeline(ctx *gin.Context) {
+	req := TrainingPipelineCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, respBadRequestBody)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "create training pipeline")
+
+	user := pl.DomainAccount()
+	pid := ctx.Param("pid")
+
+	name, err := domain.NewTrainingName(req.Name)
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := app.TrainingPipelineCreateCmd{
+		User:      user,
+		ProjectId: pid,
+		Name:      name,
+		Steps:     make([]domain.PipelineStep, len(req.Steps)),
+	}
+
+	for i := range req.Steps {
+		item := &req.Steps[i]
+		step := new(app.TrainingCreateCmd)
+
+		if cmd.Steps[i], err = item.toPipelineStep(step); err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		if !ctl.setProjectInfo(ctx, step, user, pid) {
+			return
+		}
+
+		if !ctl.setModelsInput(ctx, step, user, item.Config.Models) {
+			return
+		}
+
+		if !ctl.setDatasetsInput(ctx, step, user, item.Config.Datasets) {
+			return
+		}
+
+		if err := step.Validate(); err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		cmd.Steps[i].Config = step.TrainingConfig
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, code, err := ctl.ts.CreatePipeline(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "create training pipeline",
+		fmt.Sprintf("projectid: %s, pipelineid: %s", pid, v), "success")
+
+	ctx.JSON(http.StatusCreated, newResponseData(trainingPipelineCreateResp{v}))
+}
+
+// @Summary		ListPipelines
+// @Description	get pipelines of project
+// @Tags			Training
+// @Param			pid	path	string	true	"project id"
+// @Accept			json
+// @Success		200	{object}		app.TrainingPipelineSummaryDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/pipeline [get]
+func (ctl *TrainingController) ListPipelines(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.ts.ListPipelines(pl.DomainAccount(), ctx.Param("pid"))
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newResponseData(v))
+}
+
+// @Summary		GetPipeline
+// @Description	get pipeline and the status of its steps
+// @Tags			Training
+// @Param			pid	path	string	true	"project id"
+// @Param			id	path	string	true	"pipeline id"
+// @Accept			json
+// @Success		200	{object}		app.TrainingPipelineDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/pipeline/{id} [get]
+func (ctl *TrainingController) GetPipeline(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.ts.GetPipeline(pl.DomainAccount(), ctx.Param("pid"), ctx.Param("id"))
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newResponseData(v))
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingPublishRequest struct {
+	Name     string `json:"name"`
+	Title    string `json:"title"`
+	Desc     string `json:"desc"`
+	Protocol string `json:"protocol"`
+	RepoType string `json:"repo_type"`
+	Dir      string `json:"dir"`
+}
+
+func (req *TrainingPublishRequest) toCmd(cmd *app.TrainingPublishCmd) (err error) {
+	if cmd.ModelName, err = domain.NewResourceName(req.Name); err != nil {
+		return
+	}
+
+	if req.Title == "" {
+		req.Title = req.Name
+	}
+
+	if cmd.ModelTitle, err = domain.NewResourceTitle(req.Title); err != nil {
+		return
+	}
+
+	if cmd.ModelDesc, err = domain.NewResourceDesc(req.Desc); err != nil {
+		return
+	}
+
+	if cmd.Protocol, err = domain.NewProtocolName(req.Protocol); err != nil {
+		return
+	}
+
+	if cmd.RepoType, err = domain.NewRepoType(req.RepoType); err != nil {
+		return
+	}
+
+	cmd.Dir, err = domain.NewDirectory(req.Dir)
+
+	return
+}
+
+// @Summary		Publish
+// @Description	publish the output of training as a model
+// @Tags			Training
+// @Param			pid		path	string					true	"project id"
+// @Param			id		path	string					true	"training id"
+// @Param			body	body	TrainingPublishRequest	true	"body of publishing training"
+// @Accept			json
+// @Success		201	{object}			app.ModelDetailDTO
+// @Failure		400	bad_request_body	can't	parse		request	body
+// @Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/project/{pid}/training/{id}/model [post]
+func (ctl *TrainingController) Publish(ctx *gin.Context) {
+	req := TrainingPublishRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, respBadRequestBody)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "publish training as model")
+
+	cmd := app.TrainingPublishCmd{
+		TrainingIndex: domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: pl.DomainAccount(),
+				Id:    ctx.Param("pid"),
+			},
+			TrainingId: ctx.Param("id"),
+		},
+	}
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	u := pl.PlatformUserInfo()
+	pr := ctl.newPlatformRepository(
+		pl.PlatformToken, pl.PlatformUserNamespaceId,
+	)
+
+	v, code, err := ctl.ps.Publish(&u, &cmd, pr)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "publish training as model",
+		fmt.Sprintf("projectid: %s, trainingid: %s, model: %s", ctx.Param("pid"), ctx.Param("id"), v.Id),
+		"success")
+
+	ctx.JSON(http.StatusCreated, newResponseData(v))
+}
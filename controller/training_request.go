@@ -16,10 +16,6 @@ type trainingCreateResp struct {
 	Id string `json:"id"`
 }
 
-type trainingLogResp struct {
-	LogURL string `json:"log_url"`
-}
-
 type trainingDetail struct {
 	app.TrainingDTO
 	Log string `json:"log"`
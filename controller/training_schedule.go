@@ -0,0 +1,167 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingScheduleCreateRequest struct {
+	Cron       string `json:"cron"`
+	TrainingId string `json:"training_id"`
+}
+
+func (req *TrainingScheduleCreateRequest) toCmd(cmd *app.TrainingScheduleCreateCmd) (err error) {
+	cmd.Cron, err = domain.NewCronExpr(req.Cron)
+	cmd.TrainingId = req.TrainingId
+
+	return
+}
+
+type trainingScheduleCreateResp struct {
+	Id string `json:"id"`
+}
+
+// @Summary		CreateSchedule
+// @Description	create a schedule which creates trainings periodically
+// @Tags			Training
+// @Param			pid		path	string							true	"project id"
+// @Param			body	body	TrainingScheduleCreateRequest	true	"body of creating schedule"
+// @Accept			json
+// @Success		201	{object}			trainingScheduleCreateResp
+// @Failure		400	bad_request_body	can't	parse		request	body
+// @Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/project/{pid}/schedule [post]
+func (ctl *TrainingController) CreateSchedule(ctx *gin.Context) {
+	req := TrainingScheduleCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, respBadRequestBody)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "create training schedule")
+
+	cmd := app.TrainingScheduleCreateCmd{
+		User:      pl.DomainAccount(),
+		ProjectId: ctx.Param("pid"),
+	}
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	v, code, err := ctl.ss.Create(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "create training schedule",
+		fmt.Sprintf("projectid: %s, scheduleid: %s", ctx.Param("pid"), v), "success")
+
+	ctx.JSON(http.StatusCreated, newResponseData(trainingScheduleCreateResp{v}))
+}
+
+// @Summary		ListSchedules
+// @Description	get schedules of project
+// @Tags			Training
+// @Param			pid	path	string	true	"project id"
+// @Accept			json
+// @Success		200	{object}		app.TrainingScheduleDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/schedule [get]
+func (ctl *TrainingController) ListSchedules(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.ss.List(pl.DomainAccount(), ctx.Param("pid"))
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newResponseData(v))
+}
+
+// @Summary		GetSchedule
+// @Description	get schedule with its history and next run time
+// @Tags			Training
+// @Param			pid	path	string	true	"project id"
+// @Param			id	path	string	true	"schedule id"
+// @Accept			json
+// @Success		200	{object}		app.TrainingScheduleDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/schedule/{id} [get]
+func (ctl *TrainingController) GetSchedule(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.ss.Get(pl.DomainAccount(), ctx.Param("pid"), ctx.Param("id"))
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newResponseData(v))
+}
+
+// @Summary		DeleteSchedule
+// @Description	delete schedule
+// @Tags			Training
+// @Param			pid	path	string	true	"project id"
+// @Param			id	path	string	true	"schedule id"
+// @Accept			json
+// @Success		204
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/schedule/{id} [delete]
+func (ctl *TrainingController) DeleteSchedule(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "delete training schedule")
+
+	code, err := ctl.ss.Delete(pl.DomainAccount(), ctx.Param("pid"), ctx.Param("id"))
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "delete training schedule",
+		fmt.Sprintf("projectid: %s, scheduleid: %s", ctx.Param("pid"), ctx.Param("id")), "success")
+
+	ctx.JSON(http.StatusNoContent, newResponseData("success"))
+}
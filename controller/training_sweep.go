@@ -0,0 +1,216 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingSweepCreateRequest struct {
+	TrainingCreateRequest
+
+	Algorithm   string              `json:"algorithm"`
+	Params      []SweepParameter    `json:"parameters"`
+	MaxTrials   int                 `json:"max_trials"`
+	Parallelism int                 `json:"parallelism"`
+	Metric      TrainingSweepMetric `json:"metric"`
+}
+
+type SweepParameter struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+	Min    float64  `json:"min"`
+	Max    float64  `json:"max"`
+	Step   float64  `json:"step"`
+}
+
+func (p *SweepParameter) toSweepParameter() (r domain.SweepParameter, err error) {
+	if r.Key, err = domain.NewCustomizedKey(p.Key); err != nil {
+		return
+	}
+
+	if n := len(p.Values); n > 0 {
+		r.Values = make([]domain.CustomizedValue, n)
+		for i := range p.Values {
+			if r.Values[i], err = domain.NewCustomizedValue(p.Values[i]); err != nil {
+				return
+			}
+		}
+	}
+
+	r.Min = p.Min
+	r.Max = p.Max
+	r.Step = p.Step
+
+	return
+}
+
+type TrainingSweepMetric struct {
+	Name string `json:"name"`
+	Goal string `json:"goal"`
+}
+
+func (req *TrainingSweepCreateRequest) toCmd(cmd *app.TrainingSweepCreateCmd) (err error) {
+	if err = req.TrainingCreateRequest.toCmd(&cmd.TrainingCreateCmd); err != nil {
+		return
+	}
+
+	if cmd.Algorithm, err = domain.NewSweepAlgorithm(req.Algorithm); err != nil {
+		return
+	}
+
+	if len(req.Params) == 0 {
+		return errors.New("missing sweep parameters")
+	}
+
+	cmd.Params = make([]domain.SweepParameter, len(req.Params))
+	for i := range req.Params {
+		if cmd.Params[i], err = req.Params[i].toSweepParameter(); err != nil {
+			return
+		}
+	}
+
+	if req.Metric.Name == "" {
+		return errors.New("missing metric")
+	}
+
+	cmd.Metric.Name = req.Metric.Name
+	if cmd.Metric.Goal, err = domain.NewMetricGoal(req.Metric.Goal); err != nil {
+		return
+	}
+
+	cmd.MaxTrials = req.MaxTrials
+	cmd.Parallelism = req.Parallelism
+
+	return
+}
+
+type trainingSweepCreateResp struct {
+	Id string `json:"id"`
+}
+
+// @Summary		CreateSweep
+// @Description	create a sweep which searches hyperparameters by trainings
+// @Tags			Training
+// @Param			pid		path	string						true	"project id"
+// @Param			body	body	TrainingSweepCreateRequest	true	"body of creating sweep"
+// @Accept			json
+// @Success		201	{object}			trainingSweepCreateResp
+// @Failure		400	bad_request_body	can't	parse		request	body
+// @Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+// @Failure		500	system_error		system	error
+// @Router			/v1/train/project/{pid}/sweep [post]
+func (ctl *TrainingController) CreateSweep(ctx *gin.Context) {
+	req := TrainingSweepCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, respBadRequestBody)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	prepareOperateLog(ctx, pl.Account, OPERATE_TYPE_USER, "create training sweep")
+
+	cmd := new(app.TrainingSweepCreateCmd)
+
+	if err := req.toCmd(cmd); err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	base := &cmd.TrainingCreateCmd
+
+	if !ctl.setProjectInfo(ctx, base, pl.DomainAccount(), ctx.Param("pid")) {
+		return
+	}
+
+	if !ctl.setModelsInput(ctx, base, pl.DomainAccount(), req.Models) {
+		return
+	}
+
+	if !ctl.setDatasetsInput(ctx, base, pl.DomainAccount(), req.Datasets) {
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	v, code, err := ctl.ts.CreateSweep(cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "create training sweep",
+		fmt.Sprintf("projectid: %s, sweepid: %s", ctx.Param("pid"), v), "success")
+
+	ctx.JSON(http.StatusCreated, newResponseData(trainingSweepCreateResp{v}))
+}
+
+// @Summary		ListSweeps
+// @Description	get sweeps of project
+// @Tags			Training
+// @Param			pid	path	string	true	"project id"
+// @Accept			json
+// @Success		200	{object}		app.TrainingSweepSummaryDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/sweep [get]
+func (ctl *TrainingController) ListSweeps(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.ts.ListSweeps(pl.DomainAccount(), ctx.Param("pid"))
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newResponseData(v))
+}
+
+// @Summary		GetSweep
+// @Description	get sweep and its trials ranked by the metric
+// @Tags			Training
+// @Param			pid	path	string	true	"project id"
+// @Param			id	path	string	true	"sweep id"
+// @Accept			json
+// @Success		200	{object}		app.TrainingSweepDTO
+// @Failure		500	system_error	system	error
+// @Router			/v1/train/project/{pid}/sweep/{id} [get]
+func (ctl *TrainingController) GetSweep(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.ts.GetSweep(pl.DomainAccount(), ctx.Param("pid"), ctx.Param("id"))
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newResponseData(v))
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/course/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+// issueCertificate returns the certificate of the user,
+// it will be generated when the user gets it at the first time.
+func (s *courseService) issueCertificate(c *domain.Course, user types.Account, score float32) (
+	cert domain.Certificate, err error,
+) {
+	if cert, err = s.certRepo.FindCertificate(c.Id, user); err == nil {
+		return
+	}
+
+	if !repoerr.IsErrorResourceNotExists(err) {
+		return
+	}
+
+	student, err := s.userCli.GetUserRegInfo(user)
+	if err != nil {
+		return
+	}
+
+	if cert, err = domain.NewCertificate(c, &student, score); err != nil {
+		return
+	}
+
+	err = s.saveCertificate(&cert, c.Template())
+
+	return
+}
+
+func (s *courseService) saveCertificate(cert *domain.Certificate, tmpl string) error {
+	if err := s.cert.Generate(cert, tmpl); err != nil {
+		return err
+	}
+
+	err := s.certRepo.AddCertificate(cert)
+	if err != nil && repoerr.IsErrorDuplicateCreating(err) {
+		// it was issued concurrently, use that one.
+		*cert, err = s.certRepo.FindCertificate(cert.CourseId, cert.Account)
+	}
+
+	return err
+}
+
+// VerifyCertificate checks the signature presented by the caller against the
+// certificate issued with the serial.
+func (s *courseService) VerifyCertificate(cmd *CertVerifyCmd) (dto CertVerifyDTO, code string, err error) {
+	cert, err := s.certRepo.FindCertificateBySerial(cmd.Serial)
+	if err != nil {
+		if repoerr.IsErrorResourceNotExists(err) {
+			code = errorCertNotExists
+		}
+
+		return
+	}
+
+	dto = toCertVerifyDTO(&cert, cmd.Signature)
+
+	return
+}
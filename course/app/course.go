@@ -3,6 +3,8 @@ package app
 import (
 	"strings"
 
+	"github.com/opensourceways/xihe-server/course/domain/certificate"
+	"github.com/opensourceways/xihe-server/course/domain/grader"
 	"github.com/opensourceways/xihe-server/course/domain/message"
 	"github.com/opensourceways/xihe-server/course/domain/repository"
 	"github.com/opensourceways/xihe-server/course/domain/user"
@@ -11,6 +13,7 @@ import (
 	spacedomain "github.com/opensourceways/xihe-server/space/domain"
 	spacerepo "github.com/opensourceways/xihe-server/space/domain/repository"
 	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
 )
 
 type CourseService interface {
@@ -26,6 +29,20 @@ type CourseService interface {
 	GetCertification(*CourseGetCmd) (CertInfoDTO, error)
 	GetAssignment(*AsgGetCmd) (AsgDTO, error)
 	AddPlayRecord(*RecordAddCmd) (string, error)
+	GetProgress(*CourseGetCmd) (CourseProgressDTO, string, error)
+	GetAnalytics(cid string) (CourseAnalyticsDTO, error)
+	VerifyCertificate(*CertVerifyCmd) (CertVerifyDTO, string, error)
+	Submit(*SubmitCmd) (SubmissionDTO, string, error)
+	ListSubmissions(*AsgGetCmd) ([]SubmissionDTO, string, error)
+
+	// learning path
+	AddPath(*LearningPathCmd) (LearningPathDTO, string, error)
+	UpdatePath(*LearningPathUpdateCmd) (LearningPathDTO, string, error)
+	DeletePath(id string) error
+	ListPaths() ([]LearningPathDTO, error)
+	GetPath(id string) (LearningPathDTO, error)
+	GetPathProgress(*PathGetCmd) (PathProgressDTO, error)
+	GetPathCertification(*PathGetCmd) (CertInfoDTO, error)
 }
 
 func NewCourseService(
@@ -37,6 +54,11 @@ func NewCourseService(
 	playerRepo repository.Player,
 	workRepo repository.Work,
 	recordRepo repository.Record,
+	certRepo repository.Certificate,
+	cert certificate.Certificate,
+	submissionRepo repository.Submission,
+	grader grader.Grader,
+	pathRepo repository.LearningPath,
 	producer message.MessageProducer,
 	userRepo userrepo.User,
 ) *courseService {
@@ -48,8 +70,14 @@ func NewCourseService(
 		playerRepo: playerRepo,
 		workRepo:   workRepo,
 		recordRepo: recordRepo,
+		certRepo:   certRepo,
+		cert:       cert,
 		producer:   producer,
 		userRepo:   userRepo,
+
+		submissionRepo: submissionRepo,
+		grader:         grader,
+		pathRepo:       pathRepo,
 	}
 }
 
@@ -62,7 +90,13 @@ type courseService struct {
 	playerRepo repository.Player
 	workRepo   repository.Work
 	recordRepo repository.Record
+	certRepo   repository.Certificate
+	cert       certificate.Certificate
 	producer   message.MessageProducer
+
+	submissionRepo repository.Submission
+	grader         grader.Grader
+	pathRepo       repository.LearningPath
 }
 
 // List
@@ -273,6 +307,8 @@ func (s *courseService) AddPlayRecord(cmd *RecordAddCmd) (
 		return
 	}
 	r := cmd.toRecord()
+	r.UpdatedAt = utils.Now()
+
 	if _, err = s.recordRepo.FindPlayRecord(&r); err != nil {
 		if repoerr.IsErrorResourceNotExists(err) {
 			err = s.recordRepo.AddPlayRecord(&r)
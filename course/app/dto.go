@@ -244,6 +244,39 @@ type GetSubmissionCmd struct {
 	Status domain.WorkStatus
 }
 
+type SubmitCmd struct {
+	AsgGetCmd
+
+	Commit domain.CommitId
+}
+
+type SubmissionDTO struct {
+	Id          string  `json:"id"`
+	Project     string  `json:"project"`
+	Commit      string  `json:"commit"`
+	Status      string  `json:"status"`
+	Score       float32 `json:"score"`
+	Feedback    string  `json:"feedback"`
+	SubmittedAt string  `json:"submitted_at"`
+	GradedAt    string  `json:"graded_at"`
+}
+
+func toSubmissionDTO(s *domain.Submission) SubmissionDTO {
+	_, submittedAt := utils.DateAndTime(s.SubmittedAt)
+	_, gradedAt := utils.DateAndTime(s.GradedAt)
+
+	return SubmissionDTO{
+		Id:          s.Id,
+		Project:     s.Project,
+		Commit:      s.Commit.CommitId(),
+		Status:      s.Status.SubmissionStatus(),
+		Score:       s.Score,
+		Feedback:    s.Feedback,
+		SubmittedAt: submittedAt,
+		GradedAt:    gradedAt,
+	}
+}
+
 type RecordAddCmd domain.Record
 
 func (cmd *RecordAddCmd) Validate() error {
@@ -251,6 +284,10 @@ func (cmd *RecordAddCmd) Validate() error {
 		return errors.New("invalid cid or pointid")
 	}
 
+	if cmd.Position < 0 || cmd.TimeSpent < 0 {
+		return errors.New("invalid position or time spent")
+	}
+
 	return nil
 }
 
@@ -327,10 +364,12 @@ func toProjectSummuryDTO(p *spacedomain.Project, dto *ProjectSummuryDTO) {
 }
 
 type CertInfoDTO struct {
-	Owner  string `json:"owner"`
-	Name   string `json:"name"`
-	Cert   string `json:"cert"`
-	IsPass bool   `json:"is_pass"`
+	Owner    string `json:"owner"`
+	Name     string `json:"name"`
+	Cert     string `json:"cert"`
+	IsPass   bool   `json:"is_pass"`
+	Serial   string `json:"serial,omitempty"`
+	Download string `json:"download,omitempty"`
 }
 
 func toCertInfoDTO(user types.Account, c *domain.Course, pass bool, dto *CertInfoDTO) {
@@ -341,3 +380,216 @@ func toCertInfoDTO(user types.Account, c *domain.Course, pass bool, dto *CertInf
 		IsPass: pass,
 	}
 }
+
+type CertVerifyDTO struct {
+	Serial   string  `json:"serial"`
+	Valid    bool    `json:"valid"`
+	Name     string  `json:"name,omitempty"`
+	Course   string  `json:"course,omitempty"`
+	Score    float32 `json:"score,omitempty"`
+	IssuedAt string  `json:"issued_at,omitempty"`
+}
+
+type CertVerifyCmd struct {
+	Serial    string
+	Signature string
+}
+
+func toCertVerifyDTO(c *domain.Certificate, signature string) CertVerifyDTO {
+	if !c.Verify(signature) {
+		return CertVerifyDTO{Serial: c.Serial}
+	}
+
+	return CertVerifyDTO{
+		Serial:   c.Serial,
+		Valid:    true,
+		Name:     c.Name,
+		Course:   c.CourseName,
+		Score:    c.Score,
+		IssuedAt: utils.ToDate(c.IssuedAt),
+	}
+}
+
+// Progress
+type CourseProgressDTO struct {
+	Percentage  float32              `json:"percentage"`
+	TimeSpent   int                  `json:"time_spent"`
+	LastWatched *LastWatchedDTO      `json:"last_watched,omitempty"`
+	Sections    []SectionProgressDTO `json:"sections"`
+}
+
+type SectionProgressDTO struct {
+	Id         string  `json:"id"`
+	Name       string  `json:"name"`
+	Total      int     `json:"total"`
+	Finished   int     `json:"finished"`
+	Percentage float32 `json:"percentage"`
+}
+
+type LastWatchedDTO struct {
+	SectionId string `json:"section_id"`
+	LessonId  string `json:"lesson_id"`
+	PointId   string `json:"point_id"`
+	Position  int    `json:"position"`
+	WatchedAt string `json:"watched_at"`
+}
+
+func toCourseProgressDTO(p *domain.CourseProgress, dto *CourseProgressDTO) {
+	dto.Percentage = p.Percentage()
+	dto.TimeSpent = p.TimeSpent
+
+	if r := p.LastWatched; r != nil {
+		dto.LastWatched = &LastWatchedDTO{
+			SectionId: r.SectionId.SectionId(),
+			LessonId:  r.LessonId.LessonId(),
+			PointId:   r.PointId,
+			Position:  r.Position,
+			WatchedAt: utils.ToDate(r.UpdatedAt),
+		}
+	}
+
+	dto.Sections = make([]SectionProgressDTO, len(p.Sections))
+	for i := range p.Sections {
+		item := &p.Sections[i]
+
+		dto.Sections[i] = SectionProgressDTO{
+			Id:         item.Section.Id,
+			Name:       item.Section.Name.SectionName(),
+			Total:      item.Total,
+			Finished:   item.Finished,
+			Percentage: item.Percentage(),
+		}
+	}
+}
+
+// Analytics
+type CourseAnalyticsDTO struct {
+	PlayerCount int                `json:"player_count"`
+	Lessons     []LessonDropOffDTO `json:"lessons"`
+	Assignments []AsgScoreDTO      `json:"assignments"`
+}
+
+type LessonDropOffDTO struct {
+	SectionId   string  `json:"section_id"`
+	LessonId    string  `json:"lesson_id"`
+	LessonName  string  `json:"lesson_name"`
+	Started     int     `json:"started"`
+	Finished    int     `json:"finished"`
+	DropOffRate float32 `json:"drop_off_rate"`
+}
+
+type AsgScoreDTO struct {
+	AsgId        string           `json:"asg_id"`
+	AsgName      string           `json:"asg_name"`
+	Count        int              `json:"count"`
+	Average      float32          `json:"average"`
+	Max          float32          `json:"max"`
+	Min          float32          `json:"min"`
+	Distribution []ScoreBucketDTO `json:"distribution"`
+}
+
+type ScoreBucketDTO struct {
+	Range string `json:"range"`
+	Count int    `json:"count"`
+}
+
+func toLessonDropOffDTO(v *domain.LessonDropOff) LessonDropOffDTO {
+	return LessonDropOffDTO{
+		SectionId:   v.Section.Id,
+		LessonId:    v.Lesson.Id,
+		LessonName:  v.Lesson.Name.LessonName(),
+		Started:     v.Started,
+		Finished:    v.Finished,
+		DropOffRate: v.DropOffRate(),
+	}
+}
+
+func toAsgScoreDTO(a *domain.Assignment, d *domain.ScoreDistribution) AsgScoreDTO {
+	dto := AsgScoreDTO{
+		AsgId:   a.Id,
+		AsgName: a.Name.AsgName(),
+		Count:   d.Count,
+		Average: d.Average,
+		Max:     d.Max,
+		Min:     d.Min,
+	}
+
+	dto.Distribution = make([]ScoreBucketDTO, len(d.Buckets))
+	for i := range d.Buckets {
+		dto.Distribution[i] = ScoreBucketDTO{
+			Range: d.BucketRange(i),
+			Count: d.Buckets[i],
+		}
+	}
+
+	return dto
+}
+
+// Learning Path
+type LearningPathCmd struct {
+	Name    domain.PathName
+	Desc    domain.PathDesc
+	Poster  domain.URL
+	Courses []domain.PathCourse
+}
+
+type LearningPathUpdateCmd struct {
+	LearningPathCmd
+
+	Id string
+}
+
+type PathGetCmd struct {
+	Id   string
+	User types.Account
+}
+
+type LearningPathDTO struct {
+	Id      string          `json:"id"`
+	Name    string          `json:"name"`
+	Desc    string          `json:"desc"`
+	Poster  string          `json:"poster"`
+	Courses []PathCourseDTO `json:"courses"`
+}
+
+type PathCourseDTO struct {
+	Id            string   `json:"id"`
+	Name          string   `json:"name"`
+	Prerequisites []string `json:"prerequisites"`
+}
+
+func toLearningPathDTO(p *domain.LearningPath, names map[string]string, dto *LearningPathDTO) {
+	*dto = LearningPathDTO{
+		Id:      p.Id,
+		Name:    p.Name.PathName(),
+		Desc:    p.Desc.PathDesc(),
+		Poster:  p.Poster.URL(),
+		Courses: make([]PathCourseDTO, len(p.Courses)),
+	}
+
+	for i := range p.Courses {
+		c := &p.Courses[i]
+
+		dto.Courses[i] = PathCourseDTO{
+			Id:            c.CourseId,
+			Name:          names[c.CourseId],
+			Prerequisites: c.Prerequisites,
+		}
+	}
+}
+
+type PathProgressDTO struct {
+	Id       string                  `json:"id"`
+	Name     string                  `json:"name"`
+	Courses  []PathCourseProgressDTO `json:"courses"`
+	Passed   int                     `json:"passed"`
+	Finished bool                    `json:"finished"`
+}
+
+type PathCourseProgressDTO struct {
+	Id         string  `json:"id"`
+	Name       string  `json:"name"`
+	Applied    bool    `json:"applied"`
+	Passed     bool    `json:"passed"`
+	Percentage float32 `json:"percentage"`
+}
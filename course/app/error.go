@@ -4,4 +4,11 @@ const (
 	errorNoPermission      = "course_no_permission"
 	errorDuplicateApply    = "course_user_duplicate_apply"
 	errorDoesnotOwnProject = "course_does_not_own_project"
+	errorCertNotExists     = "course_cert_not_exists"
+	errorNoRelatedProject  = "course_no_related_project"
+	errorAsgOverdue        = "course_asg_overdue"
+	errorSubmissionGrading = "course_submission_grading"
+
+	errorInvalidPath           = "course_invalid_path"
+	errorPrerequisiteNotPassed = "course_prerequisite_not_passed"
 )
@@ -0,0 +1,295 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+	"github.com/opensourceways/xihe-server/course/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+// checkPrerequisites checks whether the user has passed all the prerequisites
+// of the course in every learning path which contains it.
+func (s *courseService) checkPrerequisites(c *domain.Course, user types.Account) (
+	code string, err error,
+) {
+	paths, err := s.pathRepo.FindPathsByCourse(c.Id)
+	if err != nil {
+		return
+	}
+
+	checked := map[string]bool{}
+
+	for i := range paths {
+		for _, cid := range paths[i].Prerequisites(c.Id) {
+			if checked[cid] {
+				continue
+			}
+
+			pass, err := s.isCoursePassed(cid, user)
+			if err != nil {
+				return "", err
+			}
+
+			if !pass {
+				return errorPrerequisiteNotPassed, fmt.Errorf(
+					"the prerequisite course %s has not been passed", cid,
+				)
+			}
+
+			checked[cid] = true
+		}
+	}
+
+	return
+}
+
+func (s *courseService) isCoursePassed(cid string, user types.Account) (bool, error) {
+	c, err := s.courseRepo.FindCourse(cid)
+	if err != nil {
+		return false, err
+	}
+
+	_, pass, err := s.checkPassed(&c, user)
+
+	return pass, err
+}
+
+func (s *courseService) AddPath(cmd *LearningPathCmd) (dto LearningPathDTO, code string, err error) {
+	if code, err = s.checkPathCourses(cmd.Courses); err != nil {
+		return
+	}
+
+	p, err := domain.NewLearningPath(cmd.Name, cmd.Desc, cmd.Poster, cmd.Courses)
+	if err != nil {
+		code = errorInvalidPath
+
+		return
+	}
+
+	if err = s.pathRepo.AddPath(&p); err != nil {
+		return
+	}
+
+	dto, err = s.toLearningPathDTO(&p)
+
+	return
+}
+
+func (s *courseService) UpdatePath(cmd *LearningPathUpdateCmd) (
+	dto LearningPathDTO, code string, err error,
+) {
+	v, err := s.pathRepo.FindPath(cmd.Id)
+	if err != nil {
+		return
+	}
+
+	if code, err = s.checkPathCourses(cmd.Courses); err != nil {
+		return
+	}
+
+	p := &v.Path
+	p.Name = cmd.Name
+	p.Desc = cmd.Desc
+	p.Poster = cmd.Poster
+	p.Courses = cmd.Courses
+
+	if err = p.Validate(); err != nil {
+		code = errorInvalidPath
+
+		return
+	}
+
+	if err = s.pathRepo.SavePath(p, v.Version); err != nil {
+		return
+	}
+
+	dto, err = s.toLearningPathDTO(p)
+
+	return
+}
+
+func (s *courseService) DeletePath(id string) error {
+	return s.pathRepo.DeletePath(id)
+}
+
+func (s *courseService) checkPathCourses(courses []domain.PathCourse) (string, error) {
+	for i := range courses {
+		if _, err := s.courseRepo.FindCourse(courses[i].CourseId); err != nil {
+			if repoerr.IsErrorResourceNotExists(err) {
+				return errorInvalidPath, fmt.Errorf(
+					"course %s does not exist", courses[i].CourseId,
+				)
+			}
+
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+func (s *courseService) ListPaths() (dtos []LearningPathDTO, err error) {
+	v, err := s.pathRepo.FindPaths()
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	dtos = make([]LearningPathDTO, len(v))
+	for i := range v {
+		if dtos[i], err = s.toLearningPathDTO(&v[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return
+}
+
+func (s *courseService) GetPath(id string) (dto LearningPathDTO, err error) {
+	v, err := s.pathRepo.FindPath(id)
+	if err != nil {
+		return
+	}
+
+	return s.toLearningPathDTO(&v.Path)
+}
+
+func (s *courseService) toLearningPathDTO(p *domain.LearningPath) (dto LearningPathDTO, err error) {
+	cs, err := s.courseRepo.FindCourses(&repository.CourseListOption{
+		CourseIds: p.CourseIds(),
+	})
+	if err != nil {
+		return
+	}
+
+	names := make(map[string]string, len(cs))
+	for i := range cs {
+		names[cs[i].Id] = cs[i].Name.CourseName()
+	}
+
+	toLearningPathDTO(p, names, &dto)
+
+	return
+}
+
+func (s *courseService) GetPathProgress(cmd *PathGetCmd) (dto PathProgressDTO, err error) {
+	v, err := s.pathRepo.FindPath(cmd.Id)
+	if err != nil {
+		return
+	}
+
+	p := &v.Path
+
+	dto.Id = p.Id
+	dto.Name = p.Name.PathName()
+	dto.Courses = make([]PathCourseProgressDTO, len(p.Courses))
+
+	for i := range p.Courses {
+		if err = s.getPathCourseProgress(p.Courses[i].CourseId, cmd.User, &dto.Courses[i]); err != nil {
+			return
+		}
+
+		if dto.Courses[i].Passed {
+			dto.Passed++
+		}
+	}
+
+	dto.Finished = dto.Passed == len(p.Courses)
+
+	return
+}
+
+func (s *courseService) getPathCourseProgress(
+	cid string, user types.Account, dto *PathCourseProgressDTO,
+) error {
+	c, err := s.courseRepo.FindCourse(cid)
+	if err != nil {
+		return err
+	}
+
+	dto.Id = c.Id
+	dto.Name = c.Name.CourseName()
+
+	p, err := s.playerRepo.FindPlayer(cid, user)
+	if err != nil {
+		if repoerr.IsErrorResourceNotExists(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !c.IsApplied(&p.Player) {
+		return nil
+	}
+
+	dto.Applied = true
+
+	if _, dto.Passed, err = s.checkPassed(&c, user); err != nil {
+		return err
+	}
+
+	records, err := s.recordRepo.FindPlayRecords(cid, user)
+	if err != nil {
+		return err
+	}
+
+	progress := domain.NewCourseProgress(&c, records)
+	dto.Percentage = progress.Percentage()
+
+	return nil
+}
+
+func (s *courseService) GetPathCertification(cmd *PathGetCmd) (dto CertInfoDTO, err error) {
+	progress, err := s.GetPathProgress(cmd)
+	if err != nil {
+		return
+	}
+
+	dto.Owner = cmd.User.Account()
+	dto.Name = progress.Name
+	dto.IsPass = progress.Finished
+
+	if !dto.IsPass {
+		return
+	}
+
+	v, err := s.pathRepo.FindPath(cmd.Id)
+	if err != nil {
+		return
+	}
+
+	cert, err := s.issuePathCertificate(&v.Path, cmd.User)
+	if err != nil {
+		return
+	}
+
+	dto.Serial = cert.Serial
+	dto.Download, err = s.cert.DownloadURL(cert.File)
+
+	return
+}
+
+func (s *courseService) issuePathCertificate(p *domain.LearningPath, user types.Account) (
+	cert domain.Certificate, err error,
+) {
+	if cert, err = s.certRepo.FindCertificate(p.Id, user); err == nil {
+		return
+	}
+
+	if !repoerr.IsErrorResourceNotExists(err) {
+		return
+	}
+
+	student, err := s.userCli.GetUserRegInfo(user)
+	if err != nil {
+		return
+	}
+
+	if cert, err = domain.NewPathCertificate(p, &student); err != nil {
+		return
+	}
+
+	return cert, s.saveCertificate(&cert, p.Template())
+}
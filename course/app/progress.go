@@ -0,0 +1,81 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+)
+
+func (s *courseService) GetProgress(cmd *CourseGetCmd) (
+	dto CourseProgressDTO, code string, err error,
+) {
+	c, err := s.courseRepo.FindCourse(cmd.Cid)
+	if err != nil {
+		return
+	}
+
+	p, err := s.playerRepo.FindPlayer(cmd.Cid, cmd.User)
+	if err != nil || !c.IsApplied(&p.Player) {
+		code = errorNoPermission
+		err = errors.New("the user has not applied the course")
+
+		return
+	}
+
+	records, err := s.recordRepo.FindPlayRecords(cmd.Cid, cmd.User)
+	if err != nil {
+		return
+	}
+
+	progress := domain.NewCourseProgress(&c, records)
+	toCourseProgressDTO(&progress, &dto)
+
+	return
+}
+
+func (s *courseService) GetAnalytics(cid string) (dto CourseAnalyticsDTO, err error) {
+	c, err := s.courseRepo.FindCourse(cid)
+	if err != nil {
+		return
+	}
+
+	if dto.PlayerCount, err = s.playerRepo.PlayerCount(cid); err != nil {
+		return
+	}
+
+	// lessons
+	learners, err := s.recordRepo.CountLessonLearners(cid)
+	if err != nil {
+		return
+	}
+
+	dropOffs := domain.NewLessonDropOffs(&c, learners)
+	dto.Lessons = make([]LessonDropOffDTO, len(dropOffs))
+	for i := range dropOffs {
+		dto.Lessons[i] = toLessonDropOffDTO(&dropOffs[i])
+	}
+
+	// assignments
+	asgs, err := s.courseRepo.FindAssignments(cid)
+	if err != nil || len(asgs) == 0 {
+		return
+	}
+
+	works, err := s.workRepo.FindWorks(cid)
+	if err != nil {
+		return
+	}
+
+	scores := map[string][]float32{}
+	for i := range works {
+		scores[works[i].AsgId] = append(scores[works[i].AsgId], works[i].Score)
+	}
+
+	dto.Assignments = make([]AsgScoreDTO, len(asgs))
+	for i := range asgs {
+		d := domain.NewScoreDistribution(scores[asgs[i].Id])
+		dto.Assignments[i] = toAsgScoreDTO(&asgs[i], &d)
+	}
+
+	return
+}
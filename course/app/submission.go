@@ -0,0 +1,168 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+func (s *courseService) Submit(cmd *SubmitCmd) (dto SubmissionDTO, code string, err error) {
+	c, err := s.courseRepo.FindCourse(cmd.Cid)
+	if err != nil {
+		return
+	}
+
+	p, err := s.playerRepo.FindPlayer(cmd.Cid, cmd.User)
+	if err != nil || !c.IsApplied(&p.Player) {
+		code = errorNoPermission
+		err = errors.New("the user has not applied the course")
+
+		return
+	}
+
+	if p.Player.RelatedProject == "" {
+		code = errorNoRelatedProject
+		err = errors.New("no related project")
+
+		return
+	}
+
+	asg, err := s.courseRepo.FindAssignment(cmd.Cid, cmd.AsgId)
+	if err != nil {
+		return
+	}
+
+	if asg.IsOverdue() {
+		code = errorAsgOverdue
+		err = errors.New("the deadline of assignment has passed")
+
+		return
+	}
+
+	v, err := s.submissionRepo.FindSubmissions(cmd.Cid, cmd.User, cmd.AsgId)
+	if err != nil {
+		return
+	}
+
+	if len(v) > 0 {
+		s.expireGrading(&v[0])
+	}
+
+	if len(v) > 0 && v[0].IsGrading() {
+		code = errorSubmissionGrading
+		err = errors.New("the last submission is being graded")
+
+		return
+	}
+
+	sub := domain.NewSubmission(&p.Player, cmd.AsgId, cmd.Commit)
+
+	// the player is updated by the concurrent submission which passed the
+	// check above too, and only one of them can claim it.
+	if err = s.playerRepo.ClaimGrading(&sub, p.Version); err != nil {
+		if repoerr.IsErrorConcurrentUpdating(err) {
+			code = errorSubmissionGrading
+			err = errors.New("the last submission is being graded")
+		}
+
+		return
+	}
+
+	if err = s.submissionRepo.AddSubmission(&sub); err != nil {
+		return
+	}
+
+	go s.grade(sub)
+
+	dto = toSubmissionDTO(&sub)
+
+	return
+}
+
+func (s *courseService) grade(sub domain.Submission) {
+	r, err := s.grader.Grade(&sub)
+	if err != nil {
+		logrus.Errorf(
+			"grade submission %s of %s failed, err:%s",
+			sub.Id, sub.Account.Account(), err.Error(),
+		)
+
+		sub.Failed("failed to grade the submission, please submit again")
+	} else {
+		sub.Graded(&r)
+	}
+
+	if err = s.submissionRepo.SaveSubmission(&sub); err != nil {
+		logrus.Errorf("save submission %s failed, err:%s", sub.Id, err.Error())
+
+		return
+	}
+
+	if !sub.IsGraded() {
+		return
+	}
+
+	if err = s.updateWork(&sub); err != nil {
+		logrus.Errorf("update work by submission %s failed, err:%s", sub.Id, err.Error())
+	}
+}
+
+// expireGrading fails the submission whose grading was lost, so that the
+// learner can submit again. The grading finished later can't be saved.
+func (s *courseService) expireGrading(sub *domain.Submission) {
+	if !sub.ExpireGrading() {
+		return
+	}
+
+	if err := s.submissionRepo.SaveSubmission(sub); err != nil {
+		logrus.Errorf("expire grading of submission %s failed, err:%s", sub.Id, err.Error())
+	}
+}
+
+func (s *courseService) updateWork(sub *domain.Submission) error {
+	w, err := s.workRepo.GetWork(sub.CourseId, sub.Account, sub.AsgId, nil)
+	if err != nil {
+		if !repoerr.IsErrorResourceNotExists(err) {
+			return err
+		}
+
+		w = domain.NewWork(sub)
+
+		return s.workRepo.AddWork(&w)
+	}
+
+	if !w.Update(sub) {
+		return nil
+	}
+
+	return s.workRepo.SaveWork(&w)
+}
+
+func (s *courseService) ListSubmissions(cmd *AsgGetCmd) (
+	dtos []SubmissionDTO, code string, err error,
+) {
+	p, err := s.playerRepo.FindPlayer(cmd.Cid, cmd.User)
+	if err != nil || p.Player.CourseId != cmd.Cid {
+		code = errorNoPermission
+		err = errors.New("the user has not applied the course")
+
+		return
+	}
+
+	v, err := s.submissionRepo.FindSubmissions(cmd.Cid, cmd.User, cmd.AsgId)
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	dtos = make([]SubmissionDTO, len(v))
+	for i := range v {
+		s.expireGrading(&v[i])
+
+		dtos[i] = toSubmissionDTO(&v[i])
+	}
+
+	return
+}
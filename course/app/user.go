@@ -5,6 +5,7 @@ import (
 
 	"github.com/opensourceways/xihe-server/agreement/app"
 	"github.com/opensourceways/xihe-server/course/domain"
+	types "github.com/opensourceways/xihe-server/domain"
 	repoerr "github.com/opensourceways/xihe-server/domain/repository"
 	"github.com/sirupsen/logrus"
 )
@@ -27,6 +28,10 @@ func (s *courseService) Apply(cmd *PlayerApplyCmd) (code string, err error) {
 		return
 	}
 
+	if code, err = s.checkPrerequisites(&course, cmd.Account); err != nil {
+		return
+	}
+
 	p := cmd.toPlayer()
 
 	if err = p.CreateToday(); err != nil {
@@ -126,26 +131,47 @@ func (s *courseService) GetCertification(cmd *CourseGetCmd) (dto CertInfoDTO, er
 		return
 	}
 
-	asg, err := s.courseRepo.FindAssignments(cmd.Cid)
+	score, pass, err := s.checkPassed(&c, cmd.User)
+	if err != nil {
+		return
+	}
+
+	toCertInfoDTO(cmd.User, &c, pass, &dto)
+
+	if !pass {
+		return
+	}
+
+	cert, err := s.issueCertificate(&c, cmd.User, score)
+	if err != nil {
+		return
+	}
+
+	dto.Serial = cert.Serial
+	dto.Download, err = s.cert.DownloadURL(cert.File)
+
+	return
+}
+
+// checkPassed sums the scores of assignments and checks whether
+// the user has passed the course.
+func (s *courseService) checkPassed(c *domain.Course, user types.Account) (
+	score float32, pass bool, err error,
+) {
+	asg, err := s.courseRepo.FindAssignments(c.Id)
 	if err != nil {
 		return
 	}
 
-	var score float32
 	for i := range asg {
-		w, err := s.workRepo.GetWork(cmd.Cid, cmd.User, asg[i].Id, nil)
+		w, err := s.workRepo.GetWork(c.Id, user, asg[i].Id, nil)
 		if err != nil {
 			break
 		}
 		score += w.Score
 	}
 
-	var pass bool
-	if score >= c.PassScore.CoursePassScore() {
-		pass = true
-	}
-
-	toCertInfoDTO(cmd.User, &c, pass, &dto)
+	pass = score >= c.PassScore.CoursePassScore()
 
 	return
 }
@@ -1,15 +1,24 @@
 package course
 
 import (
+	"github.com/opensourceways/xihe-server/course/domain"
+	"github.com/opensourceways/xihe-server/course/infrastructure/certificateimpl"
+	"github.com/opensourceways/xihe-server/course/infrastructure/graderimpl"
 	coursemsg "github.com/opensourceways/xihe-server/course/infrastructure/messageadapter"
 )
 
 type Config struct {
-	Message coursemsg.Config `json:"message"`
+	Message coursemsg.Config       `json:"message"`
+	Domain  domain.Config          `json:"domain"   required:"true"`
+	Cert    certificateimpl.Config `json:"cert"     required:"true"`
+	Grader  graderimpl.Config      `json:"grader"   required:"true"`
 }
 
 func (cfg *Config) ConfigItems() []interface{} {
 	return []interface{}{
 		&cfg.Message,
+		&cfg.Domain,
+		&cfg.Cert,
+		&cfg.Grader,
 	}
 }
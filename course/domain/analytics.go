@@ -0,0 +1,114 @@
+package domain
+
+import "fmt"
+
+const (
+	scoreBucketWidth = 10
+	scoreBucketCount = 10
+)
+
+// LessonLearners is the number of learners who have finished
+// the FinishedPoints videos of the lesson.
+type LessonLearners struct {
+	SectionId      string
+	LessonId       string
+	FinishedPoints int
+	Learners       int
+}
+
+// LessonDropOff
+type LessonDropOff struct {
+	Section *Section
+	Lesson  *Lesson
+
+	Started  int
+	Finished int
+}
+
+// DropOffRate is the percentage of learners who started but did not finish the lesson.
+func (l *LessonDropOff) DropOffRate() float32 {
+	return percentage(l.Started-l.Finished, l.Started)
+}
+
+func NewLessonDropOffs(c *Course, v []LessonLearners) []LessonDropOff {
+	learners := map[string][]*LessonLearners{}
+	for i := range v {
+		k := recordKey(v[i].SectionId, v[i].LessonId, "")
+		learners[k] = append(learners[k], &v[i])
+	}
+
+	var r []LessonDropOff
+	for i := range c.Sections {
+		s := &c.Sections[i]
+
+		for j := range s.Lessons {
+			l := &s.Lessons[j]
+			item := LessonDropOff{
+				Section: s,
+				Lesson:  l,
+			}
+
+			for _, n := range learners[recordKey(s.Id, l.Id, "")] {
+				item.Started += n.Learners
+
+				if n.FinishedPoints >= l.RequiredPoints() {
+					item.Finished += n.Learners
+				}
+			}
+
+			r = append(r, item)
+		}
+	}
+
+	return r
+}
+
+// ScoreDistribution
+type ScoreDistribution struct {
+	Count   int
+	Average float32
+	Max     float32
+	Min     float32
+	Buckets [scoreBucketCount]int
+}
+
+func NewScoreDistribution(scores []float32) (d ScoreDistribution) {
+	if len(scores) == 0 {
+		return
+	}
+
+	d.Count = len(scores)
+	d.Max = scores[0]
+	d.Min = scores[0]
+
+	var total float32
+	for _, v := range scores {
+		total += v
+
+		if v > d.Max {
+			d.Max = v
+		}
+
+		if v < d.Min {
+			d.Min = v
+		}
+
+		i := int(v) / scoreBucketWidth
+		if i < 0 {
+			i = 0
+		} else if i >= scoreBucketCount {
+			i = scoreBucketCount - 1
+		}
+
+		d.Buckets[i]++
+	}
+
+	d.Average = total / float32(d.Count)
+
+	return
+}
+
+// BucketRange returns the score range of the ith bucket, such as 10-20.
+func (d *ScoreDistribution) BucketRange(i int) string {
+	return fmt.Sprintf("%d-%d", i*scoreBucketWidth, (i+1)*scoreBucketWidth)
+}
@@ -0,0 +1,97 @@
+package domain
+
+import "testing"
+
+func TestNewLessonDropOffs(t *testing.T) {
+	c := testCourse()
+
+	v := NewLessonDropOffs(&c, []LessonLearners{
+		{SectionId: "s1", LessonId: "l1", FinishedPoints: 1, Learners: 3},
+		{SectionId: "s1", LessonId: "l1", FinishedPoints: 0, Learners: 1},
+		{SectionId: "s1", LessonId: "l2", FinishedPoints: 2, Learners: 1},
+		{SectionId: "s1", LessonId: "l2", FinishedPoints: 1, Learners: 3},
+		{SectionId: "s9", LessonId: "l3", FinishedPoints: 1, Learners: 5},
+	})
+
+	cases := []struct {
+		lesson       string
+		wantStarted  int
+		wantFinished int
+		wantRate     float32
+	}{
+		{"l1", 4, 3, 25},
+		{"l2", 4, 1, 75},
+		{"l3", 0, 0, 0},
+	}
+
+	if len(v) != len(cases) {
+		t.Fatalf("NewLessonDropOffs() returns %d lessons, want %d", len(v), len(cases))
+	}
+
+	for i := range cases {
+		item := &cases[i]
+		d := &v[i]
+
+		if d.Lesson.Id != item.lesson {
+			t.Errorf("%d: Lesson = %s, want %s", i, d.Lesson.Id, item.lesson)
+		}
+
+		if d.Started != item.wantStarted || d.Finished != item.wantFinished {
+			t.Errorf(
+				"%s: Started, Finished = %d, %d, want %d, %d", item.lesson,
+				d.Started, d.Finished, item.wantStarted, item.wantFinished,
+			)
+		}
+
+		if r := d.DropOffRate(); r != item.wantRate {
+			t.Errorf("%s: DropOffRate() = %v, want %v", item.lesson, r, item.wantRate)
+		}
+	}
+}
+
+func TestNewScoreDistribution(t *testing.T) {
+	cases := []struct {
+		name        string
+		scores      []float32
+		wantAverage float32
+		wantMax     float32
+		wantMin     float32
+		wantBuckets map[int]int
+	}{
+		{
+			"no scores", nil,
+			0, 0, 0, nil,
+		},
+		{
+			"scores in buckets", []float32{5, 15, 19.5, 60},
+			24.875, 60, 5, map[int]int{0: 1, 1: 2, 6: 1},
+		},
+		{
+			"scores out of range", []float32{-5, 100, 120},
+			215.0 / 3, 120, -5, map[int]int{0: 1, 9: 2},
+		},
+	}
+
+	for i := range cases {
+		item := &cases[i]
+
+		d := NewScoreDistribution(item.scores)
+
+		if d.Count != len(item.scores) {
+			t.Errorf("%s: Count = %d, want %d", item.name, d.Count, len(item.scores))
+		}
+
+		if d.Average != item.wantAverage || d.Max != item.wantMax || d.Min != item.wantMin {
+			t.Errorf(
+				"%s: Average, Max, Min = %v, %v, %v, want %v, %v, %v", item.name,
+				d.Average, d.Max, d.Min, item.wantAverage, item.wantMax, item.wantMin,
+			)
+		}
+
+		for j, n := range d.Buckets {
+			if n != item.wantBuckets[j] {
+				t.Errorf("%s: Buckets[%s] = %d, want %d", item.name, d.BucketRange(j), n, item.wantBuckets[j])
+			}
+		}
+	}
+}
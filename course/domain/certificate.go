@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const certSerialSize = 10
+
+// Certificate
+type Certificate struct {
+	Serial     string
+	CourseId   string
+	CourseName string
+	Account    types.Account
+	Name       string
+	Score      float32
+	IssuedAt   int64
+	Signature  string
+	File       string
+}
+
+func NewCertificate(c *Course, s *Student, score float32) (Certificate, error) {
+	return newCertificate(c.Id, c.Name.CourseName(), s, score)
+}
+
+// NewPathCertificate issues the certificate of completing all the courses
+// of the learning path.
+func NewPathCertificate(p *LearningPath, s *Student) (Certificate, error) {
+	return newCertificate(p.Id, p.Name.PathName(), s, 0)
+}
+
+func newCertificate(id, name string, s *Student, score float32) (Certificate, error) {
+	b := make([]byte, certSerialSize)
+	if _, err := rand.Read(b); err != nil {
+		return Certificate{}, err
+	}
+
+	cert := Certificate{
+		Serial:     strings.ToUpper(hex.EncodeToString(b)),
+		CourseId:   id,
+		CourseName: name,
+		Account:    s.Account,
+		Name:       s.Account.Account(),
+		Score:      score,
+		IssuedAt:   utils.Now(),
+	}
+
+	if s.Name != nil {
+		cert.Name = s.Name.StudentName()
+	}
+
+	cert.Signature = cert.sign()
+
+	return cert, nil
+}
+
+// Verify checks whether the signature presented with the certificate was
+// signed by us for the canonical fields of this certificate.
+func (c *Certificate) Verify(signature string) bool {
+	if signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(strings.ToLower(signature)), []byte(c.sign()))
+}
+
+// Template returns the template used to render the certificate of the course.
+func (c *Course) Template() string {
+	if c.CertTemplate != "" {
+		return c.CertTemplate
+	}
+
+	return config.CertTemplate
+}
+
+// Template returns the template used to render the certificate of the path.
+func (p *LearningPath) Template() string {
+	return config.PathCertTemplate
+}
+
+func (c *Certificate) sign() string {
+	mac := hmac.New(sha256.New, []byte(config.CertSignKey))
+
+	mac.Write([]byte(fmt.Sprintf(
+		"%s|%s|%s|%s|%.2f|%d",
+		c.Serial, c.CourseId, c.Account.Account(),
+		c.Name, c.Score, c.IssuedAt,
+	)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
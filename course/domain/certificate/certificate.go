@@ -0,0 +1,10 @@
+package certificate
+
+import "github.com/opensourceways/xihe-server/course/domain"
+
+type Certificate interface {
+	// Generate renders the certificate by the template and stores it,
+	// the path of the stored file will be set to the certificate.
+	Generate(c *domain.Certificate, tmpl string) error
+	DownloadURL(file string) (string, error)
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+func TestCertificateVerify(t *testing.T) {
+	old := config
+	defer func() { config = old }()
+
+	config.CertSignKey = "test-key"
+
+	s := Student{Account: types.CreateAccount("alice")}
+
+	cert, err := newCertificate("course", "course name", &s, 90)
+	if err != nil {
+		t.Fatalf("newCertificate() failed, err:%s", err.Error())
+	}
+
+	tampered := cert
+	tampered.Score = 100
+
+	cases := []struct {
+		name      string
+		cert      Certificate
+		signature string
+		want      bool
+	}{
+		{"issued signature", cert, cert.Signature, true},
+		{"upper case signature", cert, strings.ToUpper(cert.Signature), true},
+		{"empty signature", cert, "", false},
+		{"wrong signature", cert, strings.Repeat("0", len(cert.Signature)), false},
+		{"tampered score", tampered, cert.Signature, false},
+	}
+
+	for _, c := range cases {
+		if got := c.cert.Verify(c.signature); got != c.want {
+			t.Errorf("%s: Verify() = %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	config.CertSignKey = "another-key"
+
+	if cert.Verify(cert.Signature) {
+		t.Errorf("Verify() passed with another sign key")
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+var config Config
+
+func Init(cfg *Config) {
+	config = *cfg
+}
+
+type Config struct {
+	// CertSignKey is the secret used to sign the serial of certificates,
+	// it must not be changed, otherwise all the issued certificates
+	// will fail in verification.
+	CertSignKey string `json:"cert_sign_key"  required:"true"`
+
+	// CertTemplate is the template used for the courses which do not
+	// have their own.
+	CertTemplate string `json:"cert_template"`
+
+	// PathCertTemplate is the template of certificate of learning path.
+	PathCertTemplate string `json:"path_cert_template"`
+
+	// GradingTimeout is the seconds after which a submission still being
+	// graded is considered failed, such as the server restarted while
+	// grading. It must be longer than the waiting and running of a grading.
+	GradingTimeout int64 `json:"grading_timeout"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.CertTemplate == "" {
+		cfg.CertTemplate = defaultCertTemplate
+	}
+
+	if cfg.PathCertTemplate == "" {
+		cfg.PathCertTemplate = defaultPathCertTemplate
+	}
+
+	if cfg.GradingTimeout <= 0 {
+		cfg.GradingTimeout = 3600
+	}
+}
+
+const defaultCertTemplate = `CERTIFICATE OF COMPLETION
+This is to certify that
+{{.Name}}
+has successfully completed the course
+{{.Course}}
+with a score of {{.Score}}
+Issued on {{.Date}}
+Serial: {{.Serial}}
+Signature: {{.Signature}}`
+
+const defaultPathCertTemplate = `CERTIFICATE OF COMPLETION
+This is to certify that
+{{.Name}}
+has successfully completed all the courses of the learning path
+{{.Course}}
+Issued on {{.Date}}
+Serial: {{.Serial}}
+Signature: {{.Signature}}`
@@ -21,12 +21,13 @@ type CourseSummary struct {
 type Course struct {
 	CourseSummary
 
-	Teacher   URL
-	Doc       URL
-	Forum     URL
-	PassScore CoursePassScore
-	Cert      URL
-	Sections  []Section
+	Teacher      URL
+	Doc          URL
+	Forum        URL
+	PassScore    CoursePassScore
+	Cert         URL
+	CertTemplate string
+	Sections     []Section
 }
 
 // CourseRepo
@@ -30,6 +30,16 @@ const (
 
 	workStatusFinish    = "finish"
 	workStatusNotFinish = "not-finish"
+
+	submissionStatusGrading = "grading"
+	submissionStatusGraded  = "graded"
+	submissionStatusFailed  = "failed"
+)
+
+var (
+	SubmissionStatusGrading = submissionStatus(submissionStatusGrading)
+	SubmissionStatusGraded  = submissionStatus(submissionStatusGraded)
+	SubmissionStatusFailed  = submissionStatus(submissionStatusFailed)
 )
 
 // StudentName
@@ -594,3 +604,94 @@ type lessonId string
 func (s lessonId) LessonId() string {
 	return string(s)
 }
+
+// CommitId
+type CommitId interface {
+	CommitId() string
+}
+
+func NewCommitId(v string) (CommitId, error) {
+	if n := len(v); n < 7 || n > 64 {
+		return nil, errors.New("invalid commit id")
+	}
+
+	for _, c := range v {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f') {
+			return nil, errors.New("invalid commit id")
+		}
+	}
+
+	return commitId(v), nil
+}
+
+type commitId string
+
+func (s commitId) CommitId() string {
+	return string(s)
+}
+
+// SubmissionStatus
+type SubmissionStatus interface {
+	SubmissionStatus() string
+	IsGrading() bool
+}
+
+func NewSubmissionStatus(v string) (SubmissionStatus, error) {
+	b := v == submissionStatusGrading ||
+		v == submissionStatusGraded ||
+		v == submissionStatusFailed
+
+	if b {
+		return submissionStatus(v), nil
+	}
+
+	return nil, errors.New("invalid submission status")
+}
+
+type submissionStatus string
+
+func (s submissionStatus) SubmissionStatus() string {
+	return string(s)
+}
+
+func (s submissionStatus) IsGrading() bool {
+	return string(s) == submissionStatusGrading
+}
+
+// PathName
+type PathName interface {
+	PathName() string
+}
+
+func NewPathName(v string) (PathName, error) {
+	if v == "" || utils.StrLen(v) > 100 {
+		return nil, errors.New("invalid path name")
+	}
+
+	return pathName(v), nil
+}
+
+type pathName string
+
+func (s pathName) PathName() string {
+	return string(s)
+}
+
+// PathDesc
+type PathDesc interface {
+	PathDesc() string
+}
+
+func NewPathDesc(v string) (PathDesc, error) {
+	if utils.StrLen(v) > 500 {
+		return nil, errors.New("invalid path desc")
+	}
+
+	return pathDesc(v), nil
+}
+
+type pathDesc string
+
+func (s pathDesc) PathDesc() string {
+	return string(s)
+}
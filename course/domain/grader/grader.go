@@ -0,0 +1,7 @@
+package grader
+
+import "github.com/opensourceways/xihe-server/course/domain"
+
+type Grader interface {
+	Grade(*domain.Submission) (domain.GradeResult, error)
+}
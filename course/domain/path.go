@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/opensourceways/xihe-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LearningPath is an ordered set of courses.
+type LearningPath struct {
+	Id        string
+	Name      PathName
+	Desc      PathDesc
+	Poster    URL
+	Courses   []PathCourse
+	CreatedAt int64
+}
+
+// PathCourse is a course of the learning path, the player must pass
+// the prerequisites before applying it.
+type PathCourse struct {
+	CourseId      string
+	Prerequisites []string
+}
+
+func NewLearningPath(name PathName, desc PathDesc, poster URL, courses []PathCourse) (
+	LearningPath, error,
+) {
+	p := LearningPath{
+		Id:        primitive.NewObjectID().Hex(),
+		Name:      name,
+		Desc:      desc,
+		Poster:    poster,
+		Courses:   courses,
+		CreatedAt: utils.Now(),
+	}
+
+	return p, p.Validate()
+}
+
+// Validate checks that the courses are not duplicate and all the prerequisites
+// of a course are the courses before it, so there is no cycle.
+func (p *LearningPath) Validate() error {
+	if len(p.Courses) == 0 {
+		return errors.New("no courses")
+	}
+
+	seen := make(map[string]bool, len(p.Courses))
+
+	for i := range p.Courses {
+		c := &p.Courses[i]
+
+		if seen[c.CourseId] {
+			return fmt.Errorf("duplicate course: %s", c.CourseId)
+		}
+
+		for _, id := range c.Prerequisites {
+			if !seen[id] {
+				return fmt.Errorf(
+					"prerequisite %s of course %s must be a course before it",
+					id, c.CourseId,
+				)
+			}
+		}
+
+		seen[c.CourseId] = true
+	}
+
+	return nil
+}
+
+func (p *LearningPath) CourseIds() []string {
+	v := make([]string, len(p.Courses))
+	for i := range p.Courses {
+		v[i] = p.Courses[i].CourseId
+	}
+
+	return v
+}
+
+func (p *LearningPath) Prerequisites(cid string) []string {
+	for i := range p.Courses {
+		if p.Courses[i].CourseId == cid {
+			return p.Courses[i].Prerequisites
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package domain
+
+// SectionProgress
+type SectionProgress struct {
+	Section *Section
+
+	Total    int
+	Finished int
+}
+
+func (p *SectionProgress) Percentage() float32 {
+	return percentage(p.Finished, p.Total)
+}
+
+// CourseProgress
+type CourseProgress struct {
+	Sections  []SectionProgress
+	TimeSpent int
+
+	// LastWatched is the record which is updated latest, it is nil if
+	// the player has not watched any videos.
+	LastWatched *Record
+}
+
+func (p *CourseProgress) Percentage() float32 {
+	total, finished := 0, 0
+	for i := range p.Sections {
+		total += p.Sections[i].Total
+		finished += p.Sections[i].Finished
+	}
+
+	return percentage(finished, total)
+}
+
+// NewCourseProgress calculates the progress of player by the play records.
+// A lesson is finished only when its video or all the videos of its points are finished.
+func NewCourseProgress(c *Course, records []Record) CourseProgress {
+	finished := map[string]bool{}
+
+	p := CourseProgress{}
+	for i := range records {
+		item := &records[i]
+
+		if item.isFinished() {
+			finished[item.key()] = true
+		}
+
+		p.TimeSpent += item.TimeSpent
+
+		if p.LastWatched == nil || item.UpdatedAt > p.LastWatched.UpdatedAt {
+			p.LastWatched = item
+		}
+	}
+
+	p.Sections = make([]SectionProgress, len(c.Sections))
+	for i := range c.Sections {
+		s := &c.Sections[i]
+
+		sp := &p.Sections[i]
+		sp.Section = s
+		sp.Total = len(s.Lessons)
+
+		for j := range s.Lessons {
+			if s.Lessons[j].isFinished(s.Id, finished) {
+				sp.Finished++
+			}
+		}
+	}
+
+	return p
+}
+
+func (l *Lesson) isFinished(sectionId string, finished map[string]bool) bool {
+	if !l.HasPoints() {
+		return finished[recordKey(sectionId, l.Id, "")]
+	}
+
+	for i := range l.Points {
+		if !finished[recordKey(sectionId, l.Id, l.Points[i].Id)] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RequiredPoints returns the number of videos to be finished to finish the lesson.
+func (l *Lesson) RequiredPoints() int {
+	if l.HasPoints() {
+		return len(l.Points)
+	}
+
+	return 1
+}
+
+func (r *Record) key() string {
+	return recordKey(r.SectionId.SectionId(), r.LessonId.LessonId(), r.PointId)
+}
+
+func recordKey(sectionId, lessonId, pointId string) string {
+	return sectionId + "/" + lessonId + "/" + pointId
+}
+
+func percentage(n, total int) float32 {
+	if total == 0 {
+		return 0
+	}
+
+	return float32(n) * 100 / float32(total)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import "testing"
+
+func testCourse() Course {
+	return Course{
+		Sections: []Section{
+			{
+				Id: "s1",
+				Lessons: []Lesson{
+					{Id: "l1"},
+					{Id: "l2", Points: []Point{{Id: "p1"}, {Id: "p2"}}},
+				},
+			},
+			{
+				Id:      "s2",
+				Lessons: []Lesson{{Id: "l3"}},
+			},
+		},
+	}
+}
+
+func testRecord(section, lesson, point string, finished, spent int, updatedAt int64) Record {
+	s, _ := NewSectionId(section)
+	l, _ := NewLessonId(lesson)
+
+	return Record{
+		SectionId:   s,
+		LessonId:    l,
+		PointId:     point,
+		FinishCount: finished,
+		TimeSpent:   spent,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+func TestNewCourseProgress(t *testing.T) {
+	c := testCourse()
+
+	cases := []struct {
+		name         string
+		records      []Record
+		wantFinished []int
+		wantPercent  float32
+		wantSpent    int
+		wantLast     string
+	}{
+		{
+			"no records",
+			nil,
+			[]int{0, 0}, 0, 0, "",
+		},
+		{
+			"lesson without points",
+			[]Record{
+				testRecord("s1", "l1", "", 1, 30, 1),
+				testRecord("s2", "l3", "", 0, 10, 2),
+			},
+			[]int{1, 0}, 100.0 / 3, 40, "l3",
+		},
+		{
+			"lesson with part of points",
+			[]Record{
+				testRecord("s1", "l2", "p1", 1, 20, 3),
+				testRecord("s1", "l2", "p2", 0, 5, 1),
+			},
+			[]int{0, 0}, 0, 25, "l2",
+		},
+		{
+			"all lessons",
+			[]Record{
+				testRecord("s1", "l1", "", 2, 10, 1),
+				testRecord("s1", "l2", "p1", 1, 10, 5),
+				testRecord("s1", "l2", "p2", 1, 10, 2),
+				testRecord("s2", "l3", "", 1, 10, 3),
+			},
+			[]int{2, 1}, 100, 40, "l2",
+		},
+	}
+
+	for i := range cases {
+		item := &cases[i]
+
+		p := NewCourseProgress(&c, item.records)
+
+		for j, want := range item.wantFinished {
+			if v := p.Sections[j].Finished; v != want {
+				t.Errorf("%s: Sections[%d].Finished = %d, want %d", item.name, j, v, want)
+			}
+		}
+
+		if v := p.Percentage(); v != item.wantPercent {
+			t.Errorf("%s: Percentage() = %v, want %v", item.name, v, item.wantPercent)
+		}
+
+		if p.TimeSpent != item.wantSpent {
+			t.Errorf("%s: TimeSpent = %d, want %d", item.name, p.TimeSpent, item.wantSpent)
+		}
+
+		last := ""
+		if p.LastWatched != nil {
+			last = p.LastWatched.LessonId.LessonId()
+		}
+
+		if last != item.wantLast {
+			t.Errorf("%s: LastWatched = %s, want %s", item.name, last, item.wantLast)
+		}
+	}
+}
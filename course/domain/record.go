@@ -13,4 +13,14 @@ type Record struct {
 	PointId     string
 	PlayCount   int
 	FinishCount int
+
+	// Position is the last watched position of video in seconds.
+	Position int
+	// TimeSpent is the seconds spent on watching the video.
+	TimeSpent int
+	UpdatedAt int64
+}
+
+func (r *Record) isFinished() bool {
+	return r.FinishCount > 0
 }
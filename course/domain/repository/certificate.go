@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/course/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type Certificate interface {
+	AddCertificate(*domain.Certificate) error
+	FindCertificate(cid string, user types.Account) (domain.Certificate, error)
+	FindCertificateBySerial(serial string) (domain.Certificate, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/course/domain"
+)
+
+type LearningPathVersion struct {
+	Path    domain.LearningPath
+	Version int
+}
+
+type LearningPath interface {
+	AddPath(*domain.LearningPath) error
+	SavePath(*domain.LearningPath, int) error
+	DeletePath(id string) error
+	FindPath(id string) (LearningPathVersion, error)
+	FindPaths() ([]domain.LearningPath, error)
+	FindPathsByCourse(cid string) ([]domain.LearningPath, error)
+}
@@ -15,5 +15,10 @@ type Player interface {
 	SavePlayer(*domain.Player) error
 	PlayerCount(cid string) (int, error)
 	SaveRepo(courseId string, a *domain.CourseProject, version int) error
+
+	// ClaimGrading records the submission on the player if the player is
+	// still at version, so that only one of the concurrent submissions of
+	// the player is accepted.
+	ClaimGrading(s *domain.Submission, version int) error
 	FindCoursesUserApplied(types.Account) ([]string, error)
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/opensourceways/xihe-server/course/domain"
+	types "github.com/opensourceways/xihe-server/domain"
 )
 
 type RecordVersion struct {
@@ -13,4 +14,6 @@ type Record interface {
 	AddPlayRecord(*domain.Record) error
 	FindPlayRecord(*domain.Record) (RecordVersion, error)
 	UpdatePlayRecord(*domain.Record, int) error
+	FindPlayRecords(cid string, user types.Account) ([]domain.Record, error)
+	CountLessonLearners(cid string) ([]domain.LessonLearners, error)
 }
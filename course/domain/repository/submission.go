@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/course/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type Submission interface {
+	AddSubmission(*domain.Submission) error
+	SaveSubmission(*domain.Submission) error
+	FindSubmissions(cid string, user types.Account, asgId string) ([]domain.Submission, error)
+}
@@ -12,4 +12,7 @@ type WorkVersion struct {
 
 type Work interface {
 	GetWork(cid string, account types.Account, asgId string, status domain.WorkStatus) (domain.Work, error)
+	FindWorks(cid string) ([]domain.Work, error)
+	AddWork(*domain.Work) error
+	SaveWork(*domain.Work) error
 }
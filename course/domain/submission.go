@@ -0,0 +1,85 @@
+package domain
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Submission is a commit of the related project which is
+// submitted by the player for an assignment.
+type Submission struct {
+	Id          string
+	CourseId    string
+	AsgId       string
+	Account     types.Account
+	Project     string
+	Commit      CommitId
+	Status      SubmissionStatus
+	Score       float32
+	Feedback    string
+	SubmittedAt int64
+	GradedAt    int64
+}
+
+type GradeResult struct {
+	Score    float32
+	Feedback string
+}
+
+func NewSubmission(p *Player, asgId string, commit CommitId) Submission {
+	return Submission{
+		Id:          primitive.NewObjectID().Hex(),
+		CourseId:    p.CourseId,
+		AsgId:       asgId,
+		Account:     p.Account,
+		Project:     p.RelatedProject,
+		Commit:      commit,
+		Status:      SubmissionStatusGrading,
+		SubmittedAt: utils.Now(),
+	}
+}
+
+func (s *Submission) IsGrading() bool {
+	return s.Status != nil && s.Status.IsGrading()
+}
+
+// ExpireGrading fails the submission whose grading has not finished in time,
+// it returns false if the submission is not being graded or not timed out.
+func (s *Submission) ExpireGrading() bool {
+	if !s.IsGrading() || utils.Now()-s.SubmittedAt < config.GradingTimeout {
+		return false
+	}
+
+	s.Failed("grading timed out, please submit again")
+
+	return true
+}
+
+func (s *Submission) IsGraded() bool {
+	return s.Status != nil && s.Status.SubmissionStatus() == submissionStatusGraded
+}
+
+func (s *Submission) Graded(r *GradeResult) {
+	s.Status = SubmissionStatusGraded
+	s.Score = r.Score
+	s.Feedback = r.Feedback
+	s.GradedAt = utils.Now()
+}
+
+func (s *Submission) Failed(reason string) {
+	s.Status = SubmissionStatusFailed
+	s.Feedback = reason
+	s.GradedAt = utils.Now()
+}
+
+// IsOverdue checks whether the deadline has passed, the assignment
+// can be submitted until the end of the day of deadline.
+func (a *Assignment) IsOverdue() bool {
+	t, err := utils.ToUnixTime(a.DeadLine.AsgDeadLine())
+	if err != nil {
+		return false
+	}
+
+	return utils.Now() >= t.AddDate(0, 0, 1).Unix()
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+func TestSubmissionExpireGrading(t *testing.T) {
+	old := config
+	defer func() { config = old }()
+
+	config.GradingTimeout = 3600
+
+	now := utils.Now()
+
+	cases := []struct {
+		name        string
+		status      SubmissionStatus
+		submittedAt int64
+		want        bool
+		wantStatus  SubmissionStatus
+	}{
+		{
+			"grading in time", SubmissionStatusGrading, now - 60,
+			false, SubmissionStatusGrading,
+		},
+		{
+			"grading timed out", SubmissionStatusGrading, now - 3600,
+			true, SubmissionStatusFailed,
+		},
+		{
+			"graded long ago", SubmissionStatusGraded, now - 7200,
+			false, SubmissionStatusGraded,
+		},
+		{
+			"failed long ago", SubmissionStatusFailed, now - 7200,
+			false, SubmissionStatusFailed,
+		},
+	}
+
+	for _, c := range cases {
+		s := Submission{Status: c.status, SubmittedAt: c.submittedAt}
+
+		if got := s.ExpireGrading(); got != c.want {
+			t.Errorf("%s: ExpireGrading() = %v, want %v", c.name, got, c.want)
+		}
+
+		if s.Status.SubmissionStatus() != c.wantStatus.SubmissionStatus() {
+			t.Errorf(
+				"%s: status = %s, want %s", c.name,
+				s.Status.SubmissionStatus(), c.wantStatus.SubmissionStatus(),
+			)
+		}
+	}
+}
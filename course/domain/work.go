@@ -8,3 +8,26 @@ type Work struct {
 	Status   string
 	Version  int
 }
+
+func NewWork(s *Submission) Work {
+	return Work{
+		PlayerId: s.Account.Account(),
+		CourseId: s.CourseId,
+		AsgId:    s.AsgId,
+		Score:    s.Score,
+		Status:   workStatusFinish,
+	}
+}
+
+// Update records the score of the graded submission,
+// the best one will be kept.
+func (w *Work) Update(s *Submission) bool {
+	if w.Status == workStatusFinish && w.Score >= s.Score {
+		return false
+	}
+
+	w.Score = s.Score
+	w.Status = workStatusFinish
+
+	return true
+}
@@ -0,0 +1,75 @@
+package certificateimpl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+	"github.com/opensourceways/xihe-server/course/domain/certificate"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+func NewCertificateImpl(cfg *Config) (certificate.Certificate, error) {
+	s, err := initOBS(&cfg.OBS)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certificateImpl{
+		obs:    s,
+		expiry: cfg.DownloadExpiry,
+	}, nil
+}
+
+type certificateImpl struct {
+	obs    obsService
+	expiry int
+}
+
+type certData struct {
+	Name      string
+	Course    string
+	Score     string
+	Date      string
+	Serial    string
+	Signature string
+}
+
+func (impl *certificateImpl) Generate(c *domain.Certificate, tmpl string) error {
+	t, err := template.New("cert").Parse(tmpl)
+	if err != nil {
+		return err
+	}
+
+	buf := new(bytes.Buffer)
+
+	err = t.Execute(buf, &certData{
+		Name:      c.Name,
+		Course:    c.CourseName,
+		Score:     fmt.Sprintf("%.1f", c.Score),
+		Date:      utils.ToDate(c.IssuedAt),
+		Serial:    c.Serial,
+		Signature: c.Signature,
+	})
+	if err != nil {
+		return err
+	}
+
+	pdf := renderPDF(strings.Split(buf.String(), "\n"))
+
+	path := impl.obs.genPath(fmt.Sprintf("%s/%s.pdf", c.CourseId, c.Serial))
+
+	if err := impl.obs.createObject(bytes.NewReader(pdf), path); err != nil {
+		return err
+	}
+
+	c.File = path
+
+	return nil
+}
+
+func (impl *certificateImpl) DownloadURL(file string) (string, error) {
+	return impl.obs.genFileDownloadURL(file, impl.expiry)
+}
@@ -0,0 +1,23 @@
+package certificateimpl
+
+type Config struct {
+	OBS OBSConfig `json:"obs"  required:"true"`
+
+	// DownloadExpiry specifies the seconds that the download url
+	// of a certificate will be valid.
+	DownloadExpiry int `json:"download_expiry"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.DownloadExpiry <= 0 {
+		cfg.DownloadExpiry = 3600
+	}
+}
+
+type OBSConfig struct {
+	Prefix    string `json:"prefix"`
+	Bucket    string `json:"bucket"         required:"true"`
+	Endpoint  string `json:"endpoint"       required:"true"`
+	AccessKey string `json:"access_key"     required:"true"`
+	SecretKey string `json:"secret_key"     required:"true"`
+}
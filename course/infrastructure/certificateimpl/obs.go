@@ -0,0 +1,61 @@
+package certificateimpl
+
+import (
+	"io"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-obs/obs"
+)
+
+func initOBS(cfg *OBSConfig) (s obsService, err error) {
+	cli, err := obs.New(cfg.AccessKey, cfg.SecretKey, cfg.Endpoint)
+	if err != nil {
+		return
+	}
+
+	s.cli = cli
+	s.bucket = cfg.Bucket
+	s.prefix = cfg.Prefix
+
+	return
+}
+
+type obsService struct {
+	cli    *obs.ObsClient
+	bucket string
+	prefix string
+}
+
+func (s *obsService) genPath(path string) string {
+	if s.prefix == "" {
+		return path
+	}
+
+	return s.prefix + "/" + path
+}
+
+func (s *obsService) createObject(f io.Reader, path string) error {
+	input := &obs.PutObjectInput{}
+	input.Bucket = s.bucket
+	input.Key = path
+	input.Body = f
+	input.ContentType = "application/pdf"
+
+	_, err := s.cli.PutObject(input)
+
+	return err
+}
+
+func (s *obsService) genFileDownloadURL(path string, expiry int) (string, error) {
+	input := &obs.CreateSignedUrlInput{}
+	input.Method = obs.HttpMethodGet
+	input.Bucket = s.bucket
+	input.Key = path
+	input.Expires = expiry
+
+	output, err := s.cli.CreateSignedUrl(input)
+	if err != nil {
+		return "", err
+	}
+
+	return output.SignedUrl, nil
+}
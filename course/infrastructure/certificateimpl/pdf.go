@@ -0,0 +1,158 @@
+package certificateimpl
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode/utf16"
+)
+
+// The certificate is a single landscape A4 page. Latin text is drawn with
+// the standard Helvetica font and the other text with the STSong-Light font
+// of the Adobe-GB1 collection which every PDF reader ships with, so no font
+// needs to be embedded.
+const (
+	pageWidth  = 842
+	pageHeight = 595
+
+	titleFontSize = 32
+	textFontSize  = 18
+
+	// the approximate widths of a glyph relative to the font size,
+	// they are used to center the lines.
+	latinGlyphWidth = 0.55
+	cjkGlyphWidth   = 1.0
+)
+
+func renderPDF(lines []string) []byte {
+	objs := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf(
+			"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] "+
+				"/Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>",
+			pageWidth, pageHeight,
+		),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type0 /BaseFont /STSong-Light /Encoding /UniGB-UCS2-H " +
+			"/DescendantFonts [7 0 R] >>",
+		"",
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /STSong-Light " +
+			"/CIDSystemInfo << /Registry (Adobe) /Ordering (GB1) /Supplement 2 >> " +
+			"/FontDescriptor 8 0 R >>",
+		"<< /Type /FontDescriptor /FontName /STSong-Light /Flags 6 " +
+			"/FontBBox [-25 -254 1000 880] /ItalicAngle 0 /Ascent 880 " +
+			"/Descent -120 /CapHeight 880 /StemV 93 >>",
+	}
+
+	content := renderContent(lines)
+	objs[5] = fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)
+
+	buf := new(bytes.Buffer)
+	buf.WriteString("%PDF-1.4\n")
+
+	offsets := make([]int, len(objs))
+	for i, obj := range objs {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(buf, "xref\n0 %d\n0000000000 65535 f \n", len(objs)+1)
+	for _, v := range offsets {
+		fmt.Fprintf(buf, "%010d 00000 n \n", v)
+	}
+
+	fmt.Fprintf(
+		buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(objs)+1, xref,
+	)
+
+	return buf.Bytes()
+}
+
+func renderContent(lines []string) string {
+	b := new(strings.Builder)
+
+	// border
+	fmt.Fprintf(b, "3 w 30 30 %d %d re S\n", pageWidth-60, pageHeight-60)
+	fmt.Fprintf(b, "1 w 40 40 %d %d re S\n", pageWidth-80, pageHeight-80)
+
+	y := pageHeight - 130
+	for i, line := range lines {
+		size := textFontSize
+		if i == 0 {
+			size = titleFontSize
+		}
+
+		if line = strings.TrimSpace(line); line != "" {
+			x := (pageWidth - textWidth(line, size)) / 2
+			if x < 50 {
+				x = 50
+			}
+
+			font, text := encodeText(line)
+			fmt.Fprintf(b, "BT /%s %d Tf %d %d Td %s Tj ET\n", font, size, x, y, text)
+		}
+
+		y -= size * 2
+	}
+
+	return b.String()
+}
+
+func isLatin(s string) bool {
+	for _, r := range s {
+		if r > 0xff {
+			return false
+		}
+	}
+
+	return true
+}
+
+func textWidth(s string, size int) int {
+	w := 0.0
+	for _, r := range s {
+		if r <= 0xff {
+			w += latinGlyphWidth
+		} else {
+			w += cjkGlyphWidth
+		}
+	}
+
+	return int(w * float64(size))
+}
+
+// encodeText returns the font and the string object of the text.
+func encodeText(s string) (string, string) {
+	if isLatin(s) {
+		b := new(strings.Builder)
+		b.WriteByte('(')
+		for _, r := range s {
+			switch r {
+			case '(', ')', '\\':
+				b.WriteByte('\\')
+				b.WriteRune(r)
+			default:
+				if r < 0x20 || r > 0x7e {
+					fmt.Fprintf(b, "\\%03o", r)
+				} else {
+					b.WriteRune(r)
+				}
+			}
+		}
+		b.WriteByte(')')
+
+		return "F1", b.String()
+	}
+
+	b := new(strings.Builder)
+	b.WriteByte('<')
+	for _, v := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(b, "%04X", v)
+	}
+	b.WriteByte('>')
+
+	return "F2", b.String()
+}
@@ -0,0 +1,26 @@
+package graderimpl
+
+type Config struct {
+	// Script is the path of the grading script. It will be called as
+	// "script <course id> <assignment id> <project> <commit>" and must
+	// print the result as json, such as {"score": 90, "feedback": "..."}.
+	Script string `json:"script"       required:"true"`
+
+	// Timeout is the seconds that a grading can take, including the waiting
+	// for the other gradings. It must be shorter than the grading timeout of
+	// the course domain.
+	Timeout int `json:"timeout"`
+
+	// Concurrency is the max number of gradings running at the same time.
+	Concurrency int `json:"concurrency"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 600
+	}
+
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 2
+	}
+}
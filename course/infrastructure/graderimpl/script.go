@@ -0,0 +1,84 @@
+package graderimpl
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+	"github.com/opensourceways/xihe-server/course/domain/grader"
+)
+
+func NewScriptGrader(cfg *Config) grader.Grader {
+	return &scriptGrader{
+		script:  cfg.Script,
+		timeout: time.Duration(cfg.Timeout) * time.Second,
+		limiter: make(chan struct{}, cfg.Concurrency),
+	}
+}
+
+// scriptGrader grades the submission by running a local script.
+type scriptGrader struct {
+	script  string
+	timeout time.Duration
+	limiter chan struct{}
+}
+
+type scriptResult struct {
+	Score    float32 `json:"score"`
+	Feedback string  `json:"feedback"`
+}
+
+// Grade waits for the others and runs the script within the timeout.
+func (impl *scriptGrader) Grade(s *domain.Submission) (r domain.GradeResult, err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), impl.timeout)
+	defer cancel()
+
+	select {
+	case impl.limiter <- struct{}{}:
+		defer func() {
+			<-impl.limiter
+		}()
+
+	case <-ctx.Done():
+		err = errors.New("timed out waiting for the other gradings")
+
+		return
+	}
+
+	// #nosec G204 -- the script is configured by the administrator
+	cmd := exec.CommandContext(
+		ctx, impl.script,
+		s.CourseId, s.AsgId, s.Project, s.Commit.CommitId(),
+	)
+
+	stderr := new(bytes.Buffer)
+	cmd.Stderr = stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		err = fmt.Errorf("run grading script failed, err:%s, stderr:%s", err.Error(), stderr.String())
+
+		return
+	}
+
+	var v scriptResult
+	if err = json.Unmarshal(out, &v); err != nil {
+		return
+	}
+
+	if v.Score < 0 {
+		err = errors.New("invalid score")
+
+		return
+	}
+
+	r.Score = v.Score
+	r.Feedback = v.Feedback
+
+	return
+}
@@ -0,0 +1,90 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+	"github.com/opensourceways/xihe-server/course/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func NewCertificateRepo(m mongodbClient) repository.Certificate {
+	return &certificateRepoImpl{m}
+}
+
+type certificateRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl *certificateRepoImpl) AddCertificate(c *domain.Certificate) error {
+	doc, err := genDoc(DCourseCert{
+		Serial:     c.Serial,
+		CourseId:   c.CourseId,
+		CourseName: c.CourseName,
+		Account:    c.Account.Account(),
+		Name:       c.Name,
+		Score:      c.Score,
+		IssuedAt:   c.IssuedAt,
+		Signature:  c.Signature,
+		File:       c.File,
+	})
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(
+			ctx, bson.M{
+				fieldCourseId: c.CourseId,
+				fieldAccount:  c.Account.Account(),
+			}, doc,
+		)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocExists(err) {
+		err = repoerr.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+func (impl *certificateRepoImpl) FindCertificate(cid string, user types.Account) (
+	domain.Certificate, error,
+) {
+	return impl.findCertificate(bson.M{
+		fieldCourseId: cid,
+		fieldAccount:  user.Account(),
+	})
+}
+
+func (impl *certificateRepoImpl) FindCertificateBySerial(serial string) (
+	domain.Certificate, error,
+) {
+	return impl.findCertificate(bson.M{fieldSerial: serial})
+}
+
+func (impl *certificateRepoImpl) findCertificate(filter bson.M) (
+	c domain.Certificate, err error,
+) {
+	var v DCourseCert
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, filter, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	err = v.toCertificate(&c)
+
+	return
+}
@@ -3,6 +3,7 @@ package repositoryimpl
 import (
 	"github.com/opensourceways/xihe-server/course/domain"
 	"github.com/opensourceways/xihe-server/course/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
 )
 
 // Course
@@ -35,6 +36,8 @@ func (doc *DCourse) toCourse(c *domain.Course) (err error) {
 		return
 	}
 
+	c.CertTemplate = doc.CertTemplate
+
 	// section
 	c.Sections = make([]domain.Section, len(doc.Sections))
 	for i := range doc.Sections {
@@ -201,7 +204,101 @@ func (doc *DCourseRecord) toRecord(w *repository.RecordVersion) (err error) {
 		return
 	}
 	w.Record.PointId = doc.PointId
+	w.Record.Position = doc.Position
+	w.Record.TimeSpent = doc.TimeSpent
+	w.Record.UpdatedAt = doc.UpdatedAt
 	w.Version = doc.Version
 
 	return
 }
+
+// Certificate
+func (doc *DCourseCert) toCertificate(c *domain.Certificate) (err error) {
+	if c.Account, err = types.NewAccount(doc.Account); err != nil {
+		return
+	}
+
+	c.Serial = doc.Serial
+	c.CourseId = doc.CourseId
+	c.CourseName = doc.CourseName
+	c.Name = doc.Name
+	c.Score = doc.Score
+	c.IssuedAt = doc.IssuedAt
+	c.Signature = doc.Signature
+	c.File = doc.File
+
+	return
+}
+
+// Submission
+func (doc *DCourseSubmission) toSubmission(s *domain.Submission) (err error) {
+	if s.Account, err = types.NewAccount(doc.Account); err != nil {
+		return
+	}
+
+	if s.Commit, err = domain.NewCommitId(doc.Commit); err != nil {
+		return
+	}
+
+	if s.Status, err = domain.NewSubmissionStatus(doc.Status); err != nil {
+		return
+	}
+
+	s.Id = doc.Id
+	s.CourseId = doc.CourseId
+	s.AsgId = doc.AsgId
+	s.Project = doc.Project
+	s.Score = doc.Score
+	s.Feedback = doc.Feedback
+	s.SubmittedAt = doc.SubmittedAt
+	s.GradedAt = doc.GradedAt
+
+	return
+}
+
+// LearningPath
+func (doc *DLearningPath) toLearningPath(p *domain.LearningPath) (err error) {
+	if p.Name, err = domain.NewPathName(doc.Name); err != nil {
+		return
+	}
+
+	if p.Desc, err = domain.NewPathDesc(doc.Desc); err != nil {
+		return
+	}
+
+	if p.Poster, err = domain.NewURL(doc.Poster); err != nil {
+		return
+	}
+
+	p.Id = doc.Id
+	p.CreatedAt = doc.CreatedAt
+
+	p.Courses = make([]domain.PathCourse, len(doc.Courses))
+	for i := range doc.Courses {
+		p.Courses[i] = domain.PathCourse{
+			CourseId:      doc.Courses[i].CourseId,
+			Prerequisites: doc.Courses[i].Prerequisites,
+		}
+	}
+
+	return
+}
+
+func toLearningPathDoc(p *domain.LearningPath) DLearningPath {
+	courses := make([]dPathCourse, len(p.Courses))
+	for i := range p.Courses {
+		courses[i] = dPathCourse{
+			CourseId:      p.Courses[i].CourseId,
+			Prerequisites: p.Courses[i].Prerequisites,
+		}
+	}
+
+	return DLearningPath{
+		Id:        p.Id,
+		Name:      p.Name.PathName(),
+		Desc:      p.Desc.PathDesc(),
+		Poster:    p.Poster.URL(),
+		Courses:   courses,
+		CreatedAt: p.CreatedAt,
+	}
+}
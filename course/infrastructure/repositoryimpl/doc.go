@@ -15,6 +15,16 @@ const (
 	fieldPointId     = "point_id"
 	fieldPlayCount   = "play_count"
 	fieldFinishCount = "finish_count"
+	fieldPosition    = "position"
+	fieldTimeSpent   = "time_spent"
+	fieldUpdatedAt   = "updated_at"
+	fieldSerial      = "serial"
+	fieldScore       = "score"
+	fieldFeedback    = "feedback"
+	fieldGradedAt    = "graded_at"
+	fieldSubmittedAt = "submitted_at"
+	fieldCreatedAt   = "created_at"
+	fieldCourses     = "courses"
 )
 
 // Course
@@ -34,6 +44,8 @@ type DCourse struct {
 	Poster    string  `bson:"poster"          json:"poster"`
 	Cert      string  `bson:"cert"            json:"cert"`
 
+	CertTemplate string `bson:"cert_template"  json:"cert_template"`
+
 	Assignments []dAssignments `bson:"assignments"  json:"-"`
 	Sections    []dSection     `bson:"sections"     json:"-"`
 }
@@ -95,5 +107,49 @@ type DCourseRecord struct {
 	PointId     string `bson:"point_id"      json:"point_id"`
 	PlayCount   int    `bson:"play_count"    json:"play_count"`
 	FinishCount int    `bson:"finish_count"  json:"finish_count"`
+	Position    int    `bson:"position"      json:"position"`
+	TimeSpent   int    `bson:"time_spent"    json:"time_spent"`
+	UpdatedAt   int64  `bson:"updated_at"    json:"updated_at"`
 	Version     int    `bson:"version"       json:"-"`
 }
+
+type DCourseCert struct {
+	Serial     string  `bson:"serial"       json:"serial"`
+	CourseId   string  `bson:"course_id"    json:"course_id"`
+	CourseName string  `bson:"course_name"  json:"course_name"`
+	Account    string  `bson:"account"      json:"account"`
+	Name       string  `bson:"name"         json:"name"`
+	Score      float32 `bson:"score"        json:"score"`
+	IssuedAt   int64   `bson:"issued_at"    json:"issued_at"`
+	Signature  string  `bson:"signature"    json:"signature"`
+	File       string  `bson:"file"         json:"file"`
+}
+
+type DCourseSubmission struct {
+	Id          string  `bson:"id"            json:"id"`
+	CourseId    string  `bson:"course_id"     json:"course_id"`
+	AsgId       string  `bson:"asg_id"        json:"asg_id"`
+	Account     string  `bson:"account"       json:"account"`
+	Project     string  `bson:"project"       json:"project"`
+	Commit      string  `bson:"commit"        json:"commit"`
+	Status      string  `bson:"status"        json:"status"`
+	Score       float32 `bson:"score"         json:"score"`
+	Feedback    string  `bson:"feedback"      json:"feedback"`
+	SubmittedAt int64   `bson:"submitted_at"  json:"submitted_at"`
+	GradedAt    int64   `bson:"graded_at"     json:"graded_at"`
+}
+
+type DLearningPath struct {
+	Id        string        `bson:"id"          json:"id"`
+	Name      string        `bson:"name"        json:"name"`
+	Desc      string        `bson:"desc"        json:"desc"`
+	Poster    string        `bson:"poster"      json:"poster"`
+	Courses   []dPathCourse `bson:"courses"     json:"courses"`
+	CreatedAt int64         `bson:"created_at"  json:"created_at"`
+	Version   int           `bson:"version"     json:"-"`
+}
+
+type dPathCourse struct {
+	CourseId      string   `bson:"course_id"      json:"course_id"`
+	Prerequisites []string `bson:"prerequisites"  json:"prerequisites"`
+}
@@ -0,0 +1,125 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+	"github.com/opensourceways/xihe-server/course/domain/repository"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewLearningPathRepo(m mongodbClient) repository.LearningPath {
+	return &learningPathRepoImpl{m}
+}
+
+type learningPathRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl *learningPathRepoImpl) AddPath(p *domain.LearningPath) error {
+	doc, err := genDoc(toLearningPathDoc(p))
+	if err != nil {
+		return err
+	}
+	doc[fieldVersion] = 1
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, bson.M{fieldId: p.Id}, doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocExists(err) {
+		err = repoerr.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+func (impl *learningPathRepoImpl) SavePath(p *domain.LearningPath, version int) error {
+	doc, err := genDoc(toLearningPathDoc(p))
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(
+			ctx, bson.M{fieldId: p.Id}, doc, mongoCmdSet, version,
+		)
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocNotExists(err) {
+		err = repoerr.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (impl *learningPathRepoImpl) DeletePath(id string) error {
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().DeleteOne(ctx, bson.M{fieldId: id})
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl *learningPathRepoImpl) FindPath(id string) (
+	r repository.LearningPathVersion, err error,
+) {
+	var v DLearningPath
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, bson.M{fieldId: id}, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	r.Version = v.Version
+	err = v.toLearningPath(&r.Path)
+
+	return
+}
+
+func (impl *learningPathRepoImpl) FindPaths() ([]domain.LearningPath, error) {
+	return impl.findPaths(bson.M{})
+}
+
+func (impl *learningPathRepoImpl) FindPathsByCourse(cid string) ([]domain.LearningPath, error) {
+	return impl.findPaths(bson.M{
+		fieldCourses: bson.M{mongoCmdElemMatch: bson.M{fieldCourseId: cid}},
+	})
+}
+
+func (impl *learningPathRepoImpl) findPaths(filter bson.M) ([]domain.LearningPath, error) {
+	var v []DLearningPath
+
+	f := func(ctx context.Context) error {
+		opts := options.FindOptions{}
+		opts.SetSort(bson.M{fieldCreatedAt: -1})
+
+		return impl.cli.GetDocs(ctx, filter, &opts, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.LearningPath, len(v))
+	for i := range v {
+		if err := v[i].toLearningPath(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
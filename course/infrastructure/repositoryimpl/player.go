@@ -122,6 +122,23 @@ func (impl *playerRepoImpl) docFilterFindPlayer(cid, account string) bson.M {
 	}
 }
 
+func (impl *playerRepoImpl) ClaimGrading(s *domain.Submission, version int) error {
+	f := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(
+			ctx,
+			impl.docFilterFindPlayer(s.CourseId, s.Account.Account()),
+			bson.M{fieldSubmittedAt: s.SubmittedAt}, mongoCmdSet, version,
+		)
+	}
+
+	err := withContext(f)
+	if err != nil && impl.cli.IsDocNotExists(err) {
+		err = repoerr.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
 func (impl *playerRepoImpl) SaveRepo(courseId string, a *domain.CourseProject, version int) error {
 	f := func(ctx context.Context) error {
 
@@ -5,6 +5,7 @@ import (
 
 	"github.com/opensourceways/xihe-server/course/domain"
 	"github.com/opensourceways/xihe-server/course/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
 	repoerr "github.com/opensourceways/xihe-server/domain/repository"
 	"go.mongodb.org/mongo-driver/bson"
 )
@@ -82,6 +83,7 @@ func (impl *recordRepoImpl) genRecordDoc(p *domain.Record) (bson.M, error) {
 		PointId:     p.PointId,
 		PlayCount:   0,
 		FinishCount: 0,
+		UpdatedAt:   p.UpdatedAt,
 	}
 
 	return genDoc(obj)
@@ -97,15 +99,34 @@ func (impl *recordRepoImpl) docFilterFindRecord(r *domain.Record) bson.M {
 	}
 }
 
+// UpdatePlayRecord increases the counts of record and then saves the position.
+// Both of them are updated only if the record is not changed by the others.
 func (impl *recordRepoImpl) UpdatePlayRecord(r *domain.Record, version int) (err error) {
 	f := func(ctx context.Context) error {
-
-		return impl.cli.UpdateIncDoc(
+		err := impl.cli.UpdateIncDoc(
 			ctx,
 			impl.docFilterFindRecord(r),
-			bson.M{fieldPlayCount: r.PlayCount, fieldFinishCount: r.FinishCount},
+			bson.M{
+				fieldPlayCount:   r.PlayCount,
+				fieldFinishCount: r.FinishCount,
+				fieldTimeSpent:   r.TimeSpent,
+			},
 			version,
 		)
+		if err != nil {
+			return err
+		}
+
+		// the version has been increased by the update above
+		return impl.cli.UpdateDoc(
+			ctx,
+			impl.docFilterFindRecord(r),
+			bson.M{
+				fieldPosition:  r.Position,
+				fieldUpdatedAt: r.UpdatedAt,
+			},
+			mongoCmdSet, version+1,
+		)
 	}
 
 	err = withContext(f)
@@ -118,3 +139,99 @@ func (impl *recordRepoImpl) UpdatePlayRecord(r *domain.Record, version int) (err
 
 	return
 }
+
+func (impl *recordRepoImpl) FindPlayRecords(cid string, user types.Account) (
+	[]domain.Record, error,
+) {
+	var v []DCourseRecord
+
+	f := func(ctx context.Context) error {
+		filter := bson.M{
+			fieldCourseId: cid,
+			fieldAccount:  user.Account(),
+		}
+
+		return impl.cli.GetDocs(ctx, filter, nil, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.Record, len(v))
+	for i := range v {
+		item := repository.RecordVersion{}
+		if err := v[i].toRecord(&item); err != nil {
+			return nil, err
+		}
+
+		item.Record.User = user
+		r[i] = item.Record
+	}
+
+	return r, nil
+}
+
+func (impl *recordRepoImpl) CountLessonLearners(cid string) (
+	[]domain.LessonLearners, error,
+) {
+	var v []struct {
+		Id struct {
+			SectionId      string `bson:"section_id"`
+			LessonId       string `bson:"lesson_id"`
+			FinishedPoints int    `bson:"finished"`
+		} `bson:"_id"`
+		Learners int `bson:"learners"`
+	}
+
+	f := func(ctx context.Context) error {
+		finished := bson.M{
+			"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$" + fieldFinishCount, 0}}, 1, 0,
+			},
+		}
+
+		pipeline := bson.A{
+			bson.M{mongoCmdMatch: bson.M{fieldCourseId: cid}},
+			bson.M{"$group": bson.M{
+				"_id": bson.M{
+					fieldSectionId: "$" + fieldSectionId,
+					fieldLessonId:  "$" + fieldLessonId,
+					fieldAccount:   "$" + fieldAccount,
+				},
+				"finished": bson.M{"$sum": finished},
+			}},
+			bson.M{"$group": bson.M{
+				"_id": bson.M{
+					fieldSectionId: "$_id." + fieldSectionId,
+					fieldLessonId:  "$_id." + fieldLessonId,
+					"finished":     "$finished",
+				},
+				"learners": bson.M{"$sum": 1},
+			}},
+		}
+
+		cursor, err := impl.cli.Collection().Aggregate(ctx, pipeline)
+		if err != nil {
+			return err
+		}
+
+		return cursor.All(ctx, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.LessonLearners, len(v))
+	for i := range v {
+		r[i] = domain.LessonLearners{
+			SectionId:      v[i].Id.SectionId,
+			LessonId:       v[i].Id.LessonId,
+			FinishedPoints: v[i].Id.FinishedPoints,
+			Learners:       v[i].Learners,
+		}
+	}
+
+	return r, nil
+}
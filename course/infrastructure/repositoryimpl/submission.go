@@ -0,0 +1,115 @@
+package repositoryimpl
+
+import (
+	"context"
+	"errors"
+
+	"github.com/opensourceways/xihe-server/course/domain"
+	"github.com/opensourceways/xihe-server/course/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewSubmissionRepo(m mongodbClient) repository.Submission {
+	return &submissionRepoImpl{m}
+}
+
+type submissionRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl *submissionRepoImpl) AddSubmission(s *domain.Submission) error {
+	doc, err := genDoc(DCourseSubmission{
+		Id:          s.Id,
+		CourseId:    s.CourseId,
+		AsgId:       s.AsgId,
+		Account:     s.Account.Account(),
+		Project:     s.Project,
+		Commit:      s.Commit.CommitId(),
+		Status:      s.Status.SubmissionStatus(),
+		SubmittedAt: s.SubmittedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, bson.M{fieldId: s.Id}, doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocExists(err) {
+		err = repoerr.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+// SaveSubmission saves the result of grading, a submission can only be graded once.
+func (impl *submissionRepoImpl) SaveSubmission(s *domain.Submission) error {
+	f := func(ctx context.Context) error {
+		v, err := impl.cli.Collection().UpdateOne(
+			ctx,
+			bson.M{
+				fieldId:     s.Id,
+				fieldStatus: domain.SubmissionStatusGrading.SubmissionStatus(),
+			},
+			bson.M{mongoCmdSet: bson.M{
+				fieldStatus:   s.Status.SubmissionStatus(),
+				fieldScore:    s.Score,
+				fieldFeedback: s.Feedback,
+				fieldGradedAt: s.GradedAt,
+			}},
+		)
+		if err != nil {
+			return err
+		}
+
+		if v.MatchedCount == 0 {
+			return repoerr.NewErrorConcurrentUpdating(
+				errors.New("the submission has been graded"),
+			)
+		}
+
+		return nil
+	}
+
+	return withContext(f)
+}
+
+func (impl *submissionRepoImpl) FindSubmissions(cid string, user types.Account, asgId string) (
+	[]domain.Submission, error,
+) {
+	var v []DCourseSubmission
+
+	f := func(ctx context.Context) error {
+		opts := options.FindOptions{}
+		opts.SetSort(bson.M{fieldSubmittedAt: -1})
+
+		return impl.cli.GetDocs(
+			ctx,
+			bson.M{
+				fieldCourseId: cid,
+				fieldAsgId:    asgId,
+				fieldAccount:  user.Account(),
+			},
+			&opts, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.Submission, len(v))
+	for i := range v {
+		if err := v[i].toSubmission(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
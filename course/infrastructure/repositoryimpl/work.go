@@ -53,3 +53,78 @@ func (impl *workRepoImpl) GetWork(cid string, user types.Account, asgId string,
 	return r[0], nil
 
 }
+
+func (impl *workRepoImpl) FindWorks(cid string) ([]domain.Work, error) {
+	var v []DCourseWork
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDocs(ctx, bson.M{fieldCourseId: cid}, nil, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.Work, len(v))
+	for i := range v {
+		if err := v[i].toCourseWork(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (impl *workRepoImpl) AddWork(w *domain.Work) error {
+	doc, err := genDoc(DCourseWork{
+		CourseId: w.CourseId,
+		Account:  w.PlayerId,
+		AsgId:    w.AsgId,
+		Score:    w.Score,
+		Status:   w.Status,
+	})
+	if err != nil {
+		return err
+	}
+	doc[fieldVersion] = 1
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, impl.docFilter(w), doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocExists(err) {
+		err = repoerr.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+func (impl *workRepoImpl) SaveWork(w *domain.Work) error {
+	f := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(
+			ctx, impl.docFilter(w),
+			bson.M{
+				fieldScore:  w.Score,
+				fieldStatus: w.Status,
+			},
+			mongoCmdSet, w.Version,
+		)
+	}
+
+	err := withContext(f)
+	if err != nil && impl.cli.IsDocNotExists(err) {
+		err = repoerr.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (impl *workRepoImpl) docFilter(w *domain.Work) bson.M {
+	return bson.M{
+		fieldCourseId: w.CourseId,
+		fieldAsgId:    w.AsgId,
+		fieldAccount:  w.PlayerId,
+	}
+}
@@ -71,6 +71,17 @@ type Config struct {
 	MinTrainingNameLength int `json:"min_training_name_length"`
 	MaxTrainingDescLength int `json:"max_training_desc_length"`
 
+	// MaxTrainingConcurrency is the max num of trainings of a project
+	// which can run at the same time when sweeping hyperparameters.
+	MaxTrainingConcurrency int `json:"max_training_concurrency"`
+	MaxSweepTrials         int `json:"max_sweep_trials"`
+	MaxMetricNameLength    int `json:"max_metric_name_length"`
+	MaxPipelineSteps       int `json:"max_pipeline_steps"`
+	MaxPipelineStepRetries int `json:"max_pipeline_step_retries"`
+	MaxSchedulesPerProject int `json:"max_schedules_per_project"`
+	MaxScheduleHistory     int `json:"max_schedule_history"`
+	MaxTrainingCheckpoints int `json:"max_training_checkpoints"`
+
 	MaxFinetuneNameLength int `json:"max_finetune_name_length"`
 	MinFinetuneNameLength int `json:"min_finetune_name_length"`
 
@@ -142,6 +153,38 @@ func (cfg *Config) SetDefault() {
 		cfg.MaxTrainingDescLength = 100
 	}
 
+	if cfg.MaxTrainingConcurrency <= 0 {
+		cfg.MaxTrainingConcurrency = 2
+	}
+
+	if cfg.MaxSweepTrials <= 0 {
+		cfg.MaxSweepTrials = 20
+	}
+
+	if cfg.MaxMetricNameLength <= 0 {
+		cfg.MaxMetricNameLength = 50
+	}
+
+	if cfg.MaxPipelineSteps <= 0 {
+		cfg.MaxPipelineSteps = 10
+	}
+
+	if cfg.MaxPipelineStepRetries <= 0 {
+		cfg.MaxPipelineStepRetries = 3
+	}
+
+	if cfg.MaxSchedulesPerProject <= 0 {
+		cfg.MaxSchedulesPerProject = 3
+	}
+
+	if cfg.MaxScheduleHistory <= 0 {
+		cfg.MaxScheduleHistory = 30
+	}
+
+	if cfg.MaxTrainingCheckpoints <= 0 {
+		cfg.MaxTrainingCheckpoints = 20
+	}
+
 	if cfg.WuKongPictureMaxDescLength <= 0 {
 		cfg.WuKongPictureMaxDescLength = 75
 	}
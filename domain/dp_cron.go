@@ -0,0 +1,162 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the fields of cron expression: minute, hour, day of month, month, day of week
+var cronFieldRanges = [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+
+// CronExpr
+type CronExpr interface {
+	CronExpr() string
+
+	// Next returns the first time after t which matches the expression.
+	// It returns zero time if there is no such time in the next years.
+	Next(t time.Time) time.Time
+}
+
+func NewCronExpr(v string) (CronExpr, error) {
+	items := strings.Fields(v)
+	if len(items) != len(cronFieldRanges) {
+		return nil, errors.New("cron expression should have 5 fields")
+	}
+
+	r := cronExpr{expr: strings.Join(items, " ")}
+
+	for i, item := range items {
+		b, err := parseCronField(item, cronFieldRanges[i][0], cronFieldRanges[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid field %d of cron expression, %s", i+1, err.Error())
+		}
+
+		r.fields[i] = b
+	}
+
+	// both 0 and 7 are sunday
+	if r.fields[4]&(1<<7) != 0 {
+		r.fields[4] |= 1
+	}
+
+	r.anyDom = items[2] == "*"
+	r.anyDow = items[4] == "*"
+
+	if r.Next(time.Now()).IsZero() {
+		return nil, errors.New("cron expression never matches")
+	}
+
+	return r, nil
+}
+
+func parseCronField(v string, min, max int) (r uint64, err error) {
+	for _, item := range strings.Split(v, ",") {
+		rng, step := item, 1
+
+		if i := strings.Index(item, "/"); i >= 0 {
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, errors.New("invalid step")
+			}
+
+			rng = item[:i]
+		}
+
+		lo, hi := min, max
+
+		if rng != "*" {
+			if i := strings.Index(rng, "-"); i >= 0 {
+				lo, err = strconv.Atoi(rng[:i])
+				if err == nil {
+					hi, err = strconv.Atoi(rng[i+1:])
+				}
+			} else {
+				lo, err = strconv.Atoi(rng)
+				if step == 1 {
+					hi = lo
+				}
+			}
+
+			if err != nil {
+				return 0, errors.New("invalid value")
+			}
+		}
+
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("value should be between %d and %d", min, max)
+		}
+
+		for i := lo; i <= hi; i += step {
+			r |= 1 << uint(i)
+		}
+	}
+
+	return
+}
+
+type cronExpr struct {
+	expr   string
+	fields [5]uint64
+	anyDom bool
+	anyDow bool
+}
+
+func (r cronExpr) CronExpr() string {
+	return r.expr
+}
+
+func (r cronExpr) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	end := t.AddDate(5, 0, 0)
+
+	for t.Before(end) {
+		y, m, d := t.Date()
+
+		if !r.has(3, int(m)) {
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, t.Location())
+
+			continue
+		}
+
+		if !r.matchDay(t) {
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, t.Location())
+
+			continue
+		}
+
+		if !r.has(1, t.Hour()) {
+			t = time.Date(y, m, d, t.Hour()+1, 0, 0, 0, t.Location())
+
+			continue
+		}
+
+		if !r.has(0, t.Minute()) {
+			t = t.Add(time.Minute)
+
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay follows the convention of cron that the day matches if either of
+// day of month and day of week matches when both of them are restricted.
+func (r cronExpr) matchDay(t time.Time) bool {
+	dom := r.has(2, t.Day())
+	dow := r.has(4, int(t.Weekday()))
+
+	if r.anyDom || r.anyDow {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+func (r cronExpr) has(field, v int) bool {
+	return r.fields[field]&(1<<uint(v)) != 0
+}
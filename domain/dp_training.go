@@ -18,6 +18,12 @@ const (
 	computeFlaverAscend = "modelarts.kat1.xlarge.public"
 	computeFlaverGPU    = "modelarts.p3.large.public"
 
+	sweepAlgorithmGrid   = "grid"
+	sweepAlgorithmRandom = "random"
+
+	metricGoalMaximize = "maximize"
+	metricGoalMinimize = "minimize"
+
 	computeVersionCudaMS13   = "mindspore_1.3.0-cuda_10.1-py_3.7-ubuntu_1804-x86_64"
 	computeVersionCannMS17   = "mindspore_1.7.0-cann_5.1.0-py_3.7-euler_2.8.3-aarch64"
 	computeVersionCannMS19_1 = "mindspore_1.9.0-cann_6.0.RC1-py_3.7-ubuntu_18.04-amd64"
@@ -307,8 +313,98 @@ func NewInputeFilePath(v string) (InputeFilePath, error) {
 	return inputeFilePath(v), nil
 }
 
+// NewTrainingOutputFilePath is used for the output of training which is
+// generated by the training center rather than typed by the user.
+func NewTrainingOutputFilePath(v string) (InputeFilePath, error) {
+	if v == "" {
+		return nil, errors.New("empty output path")
+	}
+
+	if max := 500; len(v) > max {
+		return nil, fmt.Errorf("the length of output path should be less than %d", max)
+	}
+
+	return inputeFilePath(v), nil
+}
+
 type inputeFilePath string
 
 func (r inputeFilePath) InputeFilePath() string {
 	return string(r)
 }
+
+// SweepAlgorithm
+type SweepAlgorithm interface {
+	SweepAlgorithm() string
+	IsGrid() bool
+}
+
+func NewSweepAlgorithm(v string) (SweepAlgorithm, error) {
+	if v != sweepAlgorithmGrid && v != sweepAlgorithmRandom {
+		return nil, errors.New("unsupported sweep algorithm")
+	}
+
+	return sweepAlgorithm(v), nil
+}
+
+type sweepAlgorithm string
+
+func (r sweepAlgorithm) SweepAlgorithm() string {
+	return string(r)
+}
+
+func (r sweepAlgorithm) IsGrid() bool {
+	return string(r) == sweepAlgorithmGrid
+}
+
+// MetricGoal
+type MetricGoal interface {
+	MetricGoal() string
+	IsMaximize() bool
+}
+
+func NewMetricGoal(v string) (MetricGoal, error) {
+	if v != metricGoalMaximize && v != metricGoalMinimize {
+		return nil, errors.New("unsupported metric goal")
+	}
+
+	return metricGoal(v), nil
+}
+
+type metricGoal string
+
+func (r metricGoal) MetricGoal() string {
+	return string(r)
+}
+
+func (r metricGoal) IsMaximize() bool {
+	return string(r) == metricGoalMaximize
+}
+
+// MetricName
+type MetricName interface {
+	MetricName() string
+}
+
+func NewMetricName(v string) (MetricName, error) {
+	if v == "" {
+		return nil, errors.New("empty metric name")
+	}
+
+	max := DomainConfig.MaxMetricNameLength
+	if utils.StrLen(v) > max {
+		return nil, fmt.Errorf("the length of metric name should be less than %d", max)
+	}
+
+	if utils.XSSFilter(v) != v {
+		return nil, errors.New("invalid metric name")
+	}
+
+	return metricName(v), nil
+}
+
+type metricName string
+
+func (r metricName) MetricName() string {
+	return string(r)
+}
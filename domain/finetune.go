@@ -13,8 +13,8 @@ type Finetune struct {
 	FinetuneConfig
 
 	// following fields is not under the controlling of version
-	Job       FinetuneJobInfo
-	JobDetail FinetuneJobDetail
+	Job       JobInfo
+	JobDetail JobDetail
 }
 
 type FinetuneConfig struct {
@@ -99,28 +99,17 @@ func (p finetuneParameter) Hyperparameters() map[string]string {
 }
 
 type FinetuneJob struct {
-	FinetuneJobInfo
+	JobInfo
 
 	Status string
 }
 
-type FinetuneJobInfo struct {
-	JobId    string
-	Endpoint string
-}
-
-type FinetuneJobDetail struct {
-	Error    string
-	Status   string
-	Duration int
-}
-
 type FinetuneSummary struct {
 	Id        string
 	Name      FinetuneName
 	CreatedAt int64
 
-	FinetuneJobDetail
+	JobDetail
 }
 
 type FinetuneUserInfo struct {
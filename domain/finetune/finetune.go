@@ -5,10 +5,11 @@ import (
 )
 
 type Finetune interface {
-	CreateJob(info *domain.FinetuneIndex, t *domain.FinetuneConfig) (domain.FinetuneJobInfo, error)
+	CreateJob(info *domain.FinetuneIndex, t *domain.FinetuneConfig) (domain.JobInfo, error)
 	DeleteJob(jobId string) error
 	TerminateJob(jobId string) error
 	GetLogPreviewURL(jobId string) (string, error)
 	IsJobDone(status string) bool
+	IsJobSucceeded(status string) bool
 	CanTerminate(status string) bool
 }
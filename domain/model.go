@@ -12,6 +12,9 @@ type Model struct {
 
 	RelatedDatasets RelatedResources
 
+	// Lineages are the trainings whose output is published to the model.
+	Lineages []ModelLineage
+
 	CreatedAt int64
 	UpdatedAt int64
 
@@ -65,6 +68,12 @@ func (m *Model) RelatedResources() []ResourceObjects {
 	return r
 }
 
+type ModelLineage struct {
+	ProjectId   string
+	TrainingId  string
+	PublishedAt int64
+}
+
 type ModelModifiableProperty struct {
 	Name     ResourceName
 	Desc     ResourceDesc
@@ -100,6 +100,7 @@ type RepoFile interface {
 	IsLFSFile(data []byte) (is bool, sha string)
 	GenLFSDownloadURL(sha string) (string, error)
 	GetDirFileInfo(u *UserInfo, d *RepoDirFile) (sha string, exist bool, err error)
+	GetLastCommit(u *UserInfo, d *RepoDir) (sha string, err error)
 	DownloadRepo(u *UserInfo, repoId string, handle func(io.Reader, int64)) error
 }
 
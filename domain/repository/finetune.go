@@ -18,7 +18,7 @@ type Finetune interface {
 	List(user domain.Account) (UserFinetunes, error)
 
 	GetJob(*domain.FinetuneIndex) (domain.FinetuneJob, error)
-	SaveJob(*domain.FinetuneIndex, *domain.FinetuneJobInfo) error
+	SaveJob(*domain.FinetuneIndex, *domain.JobInfo) error
 
-	UpdateJobDetail(*domain.FinetuneIndex, *domain.FinetuneJobDetail) error
+	UpdateJobDetail(*domain.FinetuneIndex, *domain.JobDetail) error
 }
@@ -15,6 +15,12 @@ type ModelPropertyUpdateInfo struct {
 	Property domain.ModelModifiableProperty
 }
 
+type ModelLineageInfo struct {
+	ResourceToUpdate
+
+	Lineage domain.ModelLineage
+}
+
 type UserModelsInfo struct {
 	Models []domain.ModelSummary
 	Total  int
@@ -50,6 +56,7 @@ type Model interface {
 	RemoveRelatedProject(*domain.ReverselyRelatedResourceInfo) error
 
 	UpdateProperty(*ModelPropertyUpdateInfo) error
+	AddLineage(*ModelLineageInfo) error
 
 	IncreaseDownload(*domain.ResourceIndex) error
 }
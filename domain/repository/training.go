@@ -18,4 +18,6 @@ type Training interface {
 
 	UpdateJobDetail(*domain.TrainingIndex, *domain.JobDetail) error
 	GetJobDetail(*domain.TrainingIndex) (domain.JobDetail, string, error)
+
+	AddCheckpoint(*domain.TrainingIndex, *domain.TrainingCheckpoint) error
 }
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type TrainingMetricOption struct {
+	// Names is the names of metrics to find, all metrics are found if it is empty.
+	Names []string
+
+	// Since is a timestamp, only the metrics reported after it are found.
+	Since int64
+}
+
+type TrainingMetric interface {
+	Add(*domain.TrainingIndex, []domain.TrainingMetric) error
+	Find(*domain.TrainingIndex, *TrainingMetricOption) ([]domain.TrainingMetric, error)
+	Delete(*domain.TrainingIndex) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type TrainingPipeline interface {
+	Save(*domain.TrainingPipeline) (string, error)
+	Get(user domain.Account, id string) (domain.TrainingPipeline, int, error)
+	GetByTraining(*domain.TrainingIndex) (domain.TrainingPipeline, int, error)
+	List(user domain.Account, projectId string) ([]domain.TrainingPipeline, error)
+
+	UpdateSteps(*domain.TrainingPipeline, int) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type TrainingSchedule interface {
+	Save(*domain.TrainingSchedule) (string, error)
+	Get(user domain.Account, id string) (domain.TrainingSchedule, error)
+	List(user domain.Account, projectId string) ([]domain.TrainingSchedule, error)
+	Delete(user domain.Account, id string) error
+
+	// FindDue returns the schedules which should run at now.
+	FindDue(now int64) ([]domain.TrainingSchedule, error)
+	UpdateRun(*domain.TrainingSchedule) error
+}
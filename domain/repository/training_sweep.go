@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type TrainingSweep interface {
+	Save(*domain.TrainingSweep) (string, error)
+	Get(user domain.Account, id string) (domain.TrainingSweep, int, error)
+	List(user domain.Account, projectId string) ([]domain.TrainingSweep, error)
+
+	UpdateTrials(*domain.TrainingSweep, int) error
+}
@@ -7,11 +7,18 @@ type UserTraining struct {
 
 	TrainingConfig
 
+	// SweepId is the id of sweep which the training is a trial of.
+	SweepId string
+
+	// ResumedFrom is the checkpoint of other training which the training resumes from.
+	ResumedFrom TrainingResumption
+
 	CreatedAt int64
 
 	// following fields is not under the controlling of version
-	Job       JobInfo
-	JobDetail JobDetail
+	Job         JobInfo
+	JobDetail   JobDetail
+	Checkpoints []TrainingCheckpoint
 }
 
 type TrainingConfig struct {
@@ -72,6 +79,9 @@ type JobDetail struct {
 	AimPath    string
 	OutputPath string
 	Duration   int
+
+	// Metrics are the final scalar metrics reported by the job.
+	Metrics map[string]float64
 }
 
 type TrainingSummary struct {
@@ -81,6 +91,8 @@ type TrainingSummary struct {
 	Error     string
 	Status    string
 	Duration  int
+	SweepId   string
+	Metrics   map[string]float64
 	CreatedAt int64
 }
 
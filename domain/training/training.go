@@ -4,11 +4,22 @@ import (
 	"github.com/opensourceways/xihe-server/domain"
 )
 
+// ErrorFileTooLarge is returned by DownloadFile if the file exceeds the max size.
+type ErrorFileTooLarge struct {
+	error
+}
+
+func NewErrorFileTooLarge(err error) ErrorFileTooLarge {
+	return ErrorFileTooLarge{err}
+}
+
 type Training interface {
 	CreateJob(endpoint string, info *domain.TrainingIndex, t *domain.TrainingConfig) (domain.JobInfo, error)
 	DeleteJob(endpoint, jobId string) error
 	TerminateJob(endpoint, jobId string) error
 	GetLogPreviewURL(endpoint, jobId string) (string, error)
 	IsJobDone(status string) bool
+	IsJobSucceeded(status string) bool
 	GetFileDownloadURL(endpoint, file string) (string, error)
+	DownloadFile(endpoint, file string, maxSize int64) ([]byte, error)
 }
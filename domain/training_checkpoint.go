@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strconv"
+)
+
+// checkpointInputKey is the key of input by which the resumed training
+// receives the checkpoint.
+const checkpointInputKey = "checkpoint"
+
+// TrainingCheckpoint is the artefact saved by the job of training at Step.
+type TrainingCheckpoint struct {
+	Path      InputeFilePath
+	Step      int
+	CreatedAt int64
+}
+
+// TrainingResumption is empty if the training doesn't resume from any checkpoint.
+type TrainingResumption struct {
+	TrainingId string
+	Step       int
+}
+
+func (r *TrainingResumption) IsEmpty() bool {
+	return r.TrainingId == ""
+}
+
+func (t *UserTraining) HasCheckpoint(step int) bool {
+	return t.Checkpoint(step) != nil
+}
+
+func (t *UserTraining) Checkpoint(step int) *TrainingCheckpoint {
+	for i := range t.Checkpoints {
+		if t.Checkpoints[i].Step == step {
+			return &t.Checkpoints[i]
+		}
+	}
+
+	return nil
+}
+
+// ResumeConfig returns the config of training which resumes from the checkpoint cp.
+// The checkpoint is passed to the training as an input which replaces the
+// checkpoint input of the original training if it has.
+func (t *UserTraining) ResumeConfig(cp *TrainingCheckpoint, now int64) (TrainingConfig, error) {
+	c := t.TrainingConfig
+
+	name, err := NewTrainingName(
+		c.Name.TrainingName() + "-" + strconv.FormatInt(now, 10),
+	)
+	if err != nil {
+		return c, err
+	}
+
+	c.Name = name
+
+	key, err := NewCustomizedKey(checkpointInputKey)
+	if err != nil {
+		return c, err
+	}
+
+	c.Inputs = make([]Input, 0, len(t.Inputs)+1)
+	for i := range t.Inputs {
+		if t.Inputs[i].Key.CustomizedKey() != checkpointInputKey {
+			c.Inputs = append(c.Inputs, t.Inputs[i])
+		}
+	}
+
+	c.Inputs = append(c.Inputs, Input{
+		Key: key,
+		ResourceRef: ResourceRef{
+			User:   t.Owner,
+			Type:   ResourceTypeProject,
+			RepoId: c.ProjectRepoId,
+			File:   cp.Path,
+			Name:   c.ProjectName,
+		},
+	})
+
+	return c, nil
+}
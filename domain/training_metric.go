@@ -0,0 +1,11 @@
+package domain
+
+// TrainingMetric is the scalar value of a metric reported by the job at a step.
+type TrainingMetric struct {
+	Name  MetricName
+	Step  int
+	Value float64
+
+	// CreatedAt is the time in milliseconds when the metric is received.
+	CreatedAt int64
+}
@@ -0,0 +1,365 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	pipelineStepStatusPending   = "pending"
+	pipelineStepStatusRunning   = "running"
+	pipelineStepStatusSucceeded = "succeeded"
+	pipelineStepStatusFailed    = "failed"
+	pipelineStepStatusSkipped   = "skipped"
+
+	pipelineStatusRunning   = "running"
+	pipelineStatusSucceeded = "succeeded"
+	pipelineStatusFailed    = "failed"
+)
+
+// PipelineDependency wires the output of Step to the input of the step
+// which depends on it. The input is identified by InputKey.
+type PipelineDependency struct {
+	Step     TrainingName
+	InputKey CustomizedKey
+}
+
+// PipelineStep is a training of pipeline. It starts when all the steps it
+// depends on succeed, and it is retried at most MaxRetries times if it fails.
+type PipelineStep struct {
+	Name       TrainingName
+	Config     TrainingConfig
+	DependsOn  []PipelineDependency
+	MaxRetries int
+
+	Status     string
+	Retries    int
+	TrainingId string
+	OutputPath string
+}
+
+func (s *PipelineStep) IsPending() bool {
+	return s.Status == pipelineStepStatusPending
+}
+
+func (s *PipelineStep) IsRunning() bool {
+	return s.Status == pipelineStepStatusRunning
+}
+
+func (s *PipelineStep) IsSucceeded() bool {
+	return s.Status == pipelineStepStatusSucceeded
+}
+
+func (s *PipelineStep) IsFailed() bool {
+	return s.Status == pipelineStepStatusFailed
+}
+
+// TrainingPipeline is a DAG of trainings in a project.
+type TrainingPipeline struct {
+	Id        string
+	Owner     Account
+	ProjectId string
+	Name      TrainingName
+	Steps     []PipelineStep
+	CreatedAt int64
+}
+
+func NewTrainingPipeline(
+	owner Account, projectId string, name TrainingName,
+	steps []PipelineStep, now int64,
+) (TrainingPipeline, error) {
+	if n := len(steps); n == 0 || n > DomainConfig.MaxPipelineSteps {
+		return TrainingPipeline{}, fmt.Errorf(
+			"the num of steps should be between 1 and %d", DomainConfig.MaxPipelineSteps,
+		)
+	}
+
+	p := TrainingPipeline{
+		Owner:     owner,
+		ProjectId: projectId,
+		Name:      name,
+		Steps:     steps,
+		CreatedAt: now,
+	}
+
+	if err := p.validate(); err != nil {
+		return TrainingPipeline{}, err
+	}
+
+	for i := range p.Steps {
+		s := &p.Steps[i]
+
+		s.Status = pipelineStepStatusPending
+		s.Retries = 0
+		s.TrainingId = ""
+		s.OutputPath = ""
+	}
+
+	return p, nil
+}
+
+func (p *TrainingPipeline) validate() error {
+	index := map[string]int{}
+	for i := range p.Steps {
+		name := p.Steps[i].Name.TrainingName()
+		if _, ok := index[name]; ok {
+			return fmt.Errorf("duplicate step %s", name)
+		}
+
+		index[name] = i
+	}
+
+	for i := range p.Steps {
+		s := &p.Steps[i]
+
+		if s.MaxRetries < 0 || s.MaxRetries > DomainConfig.MaxPipelineStepRetries {
+			return fmt.Errorf(
+				"the retries of step should be between 0 and %d",
+				DomainConfig.MaxPipelineStepRetries,
+			)
+		}
+
+		for _, d := range s.DependsOn {
+			j, ok := index[d.Step.TrainingName()]
+			if !ok || j == i {
+				return fmt.Errorf(
+					"step %s depends on invalid step %s",
+					s.Name.TrainingName(), d.Step.TrainingName(),
+				)
+			}
+
+			if !p.Steps[j].Config.EnableOutput {
+				return fmt.Errorf(
+					"step %s must enable output", d.Step.TrainingName(),
+				)
+			}
+		}
+	}
+
+	if p.hasCycle(index) {
+		return errors.New("the steps have cycle")
+	}
+
+	return nil
+}
+
+// hasCycle checks the steps by topological sorting.
+func (p *TrainingPipeline) hasCycle(index map[string]int) bool {
+	degree := make([]int, len(p.Steps))
+	next := make([][]int, len(p.Steps))
+
+	for i := range p.Steps {
+		for _, d := range p.Steps[i].DependsOn {
+			j := index[d.Step.TrainingName()]
+
+			next[j] = append(next[j], i)
+			degree[i]++
+		}
+	}
+
+	queue := []int{}
+	for i := range degree {
+		if degree[i] == 0 {
+			queue = append(queue, i)
+		}
+	}
+
+	n := 0
+	for len(queue) > 0 {
+		i := queue[0]
+		queue = queue[1:]
+		n++
+
+		for _, j := range next[i] {
+			if degree[j]--; degree[j] == 0 {
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	return n != len(p.Steps)
+}
+
+func (p *TrainingPipeline) step(name TrainingName) *PipelineStep {
+	for i := range p.Steps {
+		if p.Steps[i].Name.TrainingName() == name.TrainingName() {
+			return &p.Steps[i]
+		}
+	}
+
+	return nil
+}
+
+// StepOfTraining returns the index of step which runs the training, or -1.
+func (p *TrainingPipeline) StepOfTraining(trainingId string) int {
+	for i := range p.Steps {
+		if p.Steps[i].TrainingId == trainingId {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// ReadySteps returns the index of pending steps whose dependencies all
+// succeeded. The num is limited by the trainings running in the project.
+func (p *TrainingPipeline) ReadySteps(running int) []int {
+	n := DomainConfig.MaxTrainingConcurrency - running
+	if n <= 0 {
+		return nil
+	}
+
+	r := []int{}
+	for i := range p.Steps {
+		if len(r) == n {
+			break
+		}
+
+		if s := &p.Steps[i]; s.IsPending() && p.isReady(s) {
+			r = append(r, i)
+		}
+	}
+
+	return r
+}
+
+func (p *TrainingPipeline) isReady(s *PipelineStep) bool {
+	for _, d := range s.DependsOn {
+		if v := p.step(d.Step); v == nil || !v.IsSucceeded() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// StepTrainingName returns the name of training which runs the step.
+// It is suffixed by the num of retries when the step is retried.
+func (p *TrainingPipeline) StepTrainingName(i int) (TrainingName, error) {
+	s := &p.Steps[i]
+
+	name := fmt.Sprintf("%s-%s", p.Name.TrainingName(), s.Name.TrainingName())
+	if s.Retries > 0 {
+		name = fmt.Sprintf("%s-%d", name, s.Retries)
+	}
+
+	return NewTrainingName(name)
+}
+
+// StepConfig returns the config of training for the step. The outputs
+// of steps it depends on are appended to the inputs.
+func (p *TrainingPipeline) StepConfig(i int) (TrainingConfig, error) {
+	s := &p.Steps[i]
+
+	c := s.Config
+
+	var err error
+	if c.Name, err = p.StepTrainingName(i); err != nil {
+		return c, err
+	}
+
+	c.Inputs = append([]Input{}, s.Config.Inputs...)
+
+	for _, d := range s.DependsOn {
+		v := p.step(d.Step)
+
+		file, err := NewTrainingOutputFilePath(v.OutputPath)
+		if err != nil {
+			return c, err
+		}
+
+		c.Inputs = append(c.Inputs, Input{
+			Key: d.InputKey,
+			ResourceRef: ResourceRef{
+				User:   p.Owner,
+				Type:   ResourceTypeProject,
+				RepoId: c.ProjectRepoId,
+				File:   file,
+				Name:   c.ProjectName,
+			},
+		})
+	}
+
+	return c, nil
+}
+
+func (p *TrainingPipeline) StartStep(i int, trainingId string) {
+	s := &p.Steps[i]
+
+	s.Status = pipelineStepStatusRunning
+	s.TrainingId = trainingId
+}
+
+// FinishStep records the result of step. The step is pending again if it
+// fails and can be retried, otherwise all the steps depending on it are skipped.
+func (p *TrainingPipeline) FinishStep(i int, succeeded bool, outputPath string) {
+	s := &p.Steps[i]
+
+	if succeeded {
+		s.Status = pipelineStepStatusSucceeded
+		s.OutputPath = outputPath
+
+		return
+	}
+
+	if s.Retries < s.MaxRetries {
+		s.Retries++
+		s.Status = pipelineStepStatusPending
+		s.TrainingId = ""
+
+		return
+	}
+
+	p.AbortStep(i)
+}
+
+// AbortStep fails the step without retrying it.
+func (p *TrainingPipeline) AbortStep(i int) {
+	s := &p.Steps[i]
+
+	s.Status = pipelineStepStatusFailed
+	p.skipDownstream(s.Name)
+}
+
+func (p *TrainingPipeline) skipDownstream(name TrainingName) {
+	for i := range p.Steps {
+		s := &p.Steps[i]
+
+		if !s.IsPending() {
+			continue
+		}
+
+		for _, d := range s.DependsOn {
+			if d.Step.TrainingName() == name.TrainingName() {
+				s.Status = pipelineStepStatusSkipped
+				p.skipDownstream(s.Name)
+
+				break
+			}
+		}
+	}
+}
+
+func (p *TrainingPipeline) IsDone() bool {
+	for i := range p.Steps {
+		if s := &p.Steps[i]; s.IsPending() || s.IsRunning() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p *TrainingPipeline) Status() string {
+	if !p.IsDone() {
+		return pipelineStatusRunning
+	}
+
+	for i := range p.Steps {
+		if p.Steps[i].IsFailed() {
+			return pipelineStatusFailed
+		}
+	}
+
+	return pipelineStatusSucceeded
+}
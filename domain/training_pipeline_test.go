@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testStep(name string, output bool, maxRetries int, deps ...string) PipelineStep {
+	s := PipelineStep{
+		Name:       trainingName(name),
+		Config:     TrainingConfig{EnableOutput: output},
+		MaxRetries: maxRetries,
+	}
+
+	for _, d := range deps {
+		s.DependsOn = append(s.DependsOn, PipelineDependency{
+			Step:     trainingName(d),
+			InputKey: customizedKey(d),
+		})
+	}
+
+	return s
+}
+
+// testPipeline returns the pipeline: a -> b, a -> c, (b, c) -> d.
+func testPipeline(t *testing.T, maxRetries int) TrainingPipeline {
+	p, err := NewTrainingPipeline(
+		CreateAccount("alice"), "pid", trainingName("pipeline"),
+		[]PipelineStep{
+			testStep("a", true, maxRetries),
+			testStep("b", true, maxRetries, "a"),
+			testStep("c", true, maxRetries, "a"),
+			testStep("d", false, maxRetries, "b", "c"),
+		},
+		1,
+	)
+	if err != nil {
+		t.Fatalf("NewTrainingPipeline() failed, err:%s", err.Error())
+	}
+
+	return p
+}
+
+func stepStatus(p *TrainingPipeline) []string {
+	r := make([]string, len(p.Steps))
+	for i := range p.Steps {
+		r[i] = p.Steps[i].Status
+	}
+
+	return r
+}
+
+func TestNewTrainingPipeline(t *testing.T) {
+	initSweepConfig(t)
+
+	cases := []struct {
+		name    string
+		steps   []PipelineStep
+		wantErr bool
+	}{
+		{
+			name:  "dag",
+			steps: []PipelineStep{testStep("a", true, 1), testStep("b", false, 0, "a")},
+		},
+		{
+			name:    "no step",
+			wantErr: true,
+		},
+		{
+			name:    "duplicate step",
+			steps:   []PipelineStep{testStep("a", true, 0), testStep("a", true, 0)},
+			wantErr: true,
+		},
+		{
+			name:    "unknown dependency",
+			steps:   []PipelineStep{testStep("a", true, 0, "x")},
+			wantErr: true,
+		},
+		{
+			name:    "self dependency",
+			steps:   []PipelineStep{testStep("a", true, 0, "a")},
+			wantErr: true,
+		},
+		{
+			name:    "dependency without output",
+			steps:   []PipelineStep{testStep("a", false, 0), testStep("b", true, 0, "a")},
+			wantErr: true,
+		},
+		{
+			name:    "too many retries",
+			steps:   []PipelineStep{testStep("a", true, DomainConfig.MaxPipelineStepRetries+1)},
+			wantErr: true,
+		},
+		{
+			name: "cycle",
+			steps: []PipelineStep{
+				testStep("a", true, 0, "c"),
+				testStep("b", true, 0, "a"),
+				testStep("c", true, 0, "b"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		p, err := NewTrainingPipeline(
+			CreateAccount("alice"), "pid", trainingName("pipeline"), c.steps, 1,
+		)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: NewTrainingPipeline() err = %v, wantErr %v", c.name, err, c.wantErr)
+
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		for i := range p.Steps {
+			if !p.Steps[i].IsPending() {
+				t.Errorf("%s: step %d is %s, want pending", c.name, i, p.Steps[i].Status)
+			}
+		}
+	}
+}
+
+func TestTrainingPipelineAdvance(t *testing.T) {
+	initSweepConfig(t)
+
+	type result struct {
+		index     int
+		succeeded bool
+	}
+
+	cases := []struct {
+		name       string
+		maxRetries int
+		finish     []result
+		status     []string
+		ready      []int
+		pipeline   string
+	}{
+		{
+			name:     "start",
+			status:   []string{"pending", "pending", "pending", "pending"},
+			ready:    []int{0},
+			pipeline: pipelineStatusRunning,
+		},
+		{
+			name:     "fan out",
+			finish:   []result{{0, true}},
+			status:   []string{"succeeded", "pending", "pending", "pending"},
+			ready:    []int{1, 2},
+			pipeline: pipelineStatusRunning,
+		},
+		{
+			name:     "wait for all dependencies",
+			finish:   []result{{0, true}, {1, true}},
+			status:   []string{"succeeded", "succeeded", "pending", "pending"},
+			ready:    []int{2},
+			pipeline: pipelineStatusRunning,
+		},
+		{
+			name:     "fan in",
+			finish:   []result{{0, true}, {1, true}, {2, true}},
+			status:   []string{"succeeded", "succeeded", "succeeded", "pending"},
+			ready:    []int{3},
+			pipeline: pipelineStatusRunning,
+		},
+		{
+			name:     "all succeeded",
+			finish:   []result{{0, true}, {1, true}, {2, true}, {3, true}},
+			status:   []string{"succeeded", "succeeded", "succeeded", "succeeded"},
+			ready:    []int{},
+			pipeline: pipelineStatusSucceeded,
+		},
+		{
+			name:       "retry",
+			maxRetries: 1,
+			finish:     []result{{0, false}},
+			status:     []string{"pending", "pending", "pending", "pending"},
+			ready:      []int{0},
+			pipeline:   pipelineStatusRunning,
+		},
+		{
+			name:       "retries exhausted",
+			maxRetries: 1,
+			finish:     []result{{0, false}, {0, false}},
+			status:     []string{"failed", "skipped", "skipped", "skipped"},
+			ready:      []int{},
+			pipeline:   pipelineStatusFailed,
+		},
+		{
+			name:     "skip downstream only",
+			finish:   []result{{0, true}, {1, false}},
+			status:   []string{"succeeded", "failed", "pending", "skipped"},
+			ready:    []int{2},
+			pipeline: pipelineStatusRunning,
+		},
+		{
+			name:     "failed after the others finish",
+			finish:   []result{{0, true}, {1, false}, {2, true}},
+			status:   []string{"succeeded", "failed", "succeeded", "skipped"},
+			ready:    []int{},
+			pipeline: pipelineStatusFailed,
+		},
+	}
+
+	for _, c := range cases {
+		p := testPipeline(t, c.maxRetries)
+
+		for _, r := range c.finish {
+			p.StartStep(r.index, "tid")
+			p.FinishStep(r.index, r.succeeded, "output")
+		}
+
+		if v := stepStatus(&p); !reflect.DeepEqual(v, c.status) {
+			t.Errorf("%s: status = %v, want %v", c.name, v, c.status)
+		}
+
+		if v := p.ReadySteps(0); !reflect.DeepEqual(v, c.ready) {
+			t.Errorf("%s: ReadySteps() = %v, want %v", c.name, v, c.ready)
+		}
+
+		if v := p.Status(); v != c.pipeline {
+			t.Errorf("%s: Status() = %s, want %s", c.name, v, c.pipeline)
+		}
+	}
+}
+
+func TestTrainingPipelineReadyStepsLimit(t *testing.T) {
+	initSweepConfig(t)
+
+	p := testPipeline(t, 0)
+	p.StartStep(0, "tid")
+	p.FinishStep(0, true, "output")
+
+	n := DomainConfig.MaxTrainingConcurrency
+
+	cases := []struct {
+		name    string
+		running int
+		want    []int
+	}{
+		{"no running", 0, []int{1, 2}},
+		{"one slot", n - 1, []int{1}},
+		{"no slot", n, nil},
+	}
+
+	for _, c := range cases {
+		if v := p.ReadySteps(c.running); !reflect.DeepEqual(v, c.want) {
+			t.Errorf("%s: ReadySteps() = %v, want %v", c.name, v, c.want)
+		}
+	}
+}
+
+func TestTrainingPipelineStepTrainingName(t *testing.T) {
+	initSweepConfig(t)
+
+	p := testPipeline(t, 1)
+
+	cases := []struct {
+		name    string
+		retries int
+		want    string
+	}{
+		{"first run", 0, "pipeline-b"},
+		{"retried", 1, "pipeline-b-1"},
+	}
+
+	for _, c := range cases {
+		p.Steps[1].Retries = c.retries
+
+		v, err := p.StepTrainingName(1)
+		if err != nil {
+			t.Errorf("%s: StepTrainingName() failed, err:%s", c.name, err.Error())
+
+			continue
+		}
+
+		if v.TrainingName() != c.want {
+			t.Errorf("%s: StepTrainingName() = %s, want %s", c.name, v.TrainingName(), c.want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	scheduleRunStatusCreated = "created"
+	scheduleRunStatusSkipped = "skipped"
+	scheduleRunStatusFailed  = "failed"
+)
+
+// ScheduleRun is the record of a run of schedule.
+type ScheduleRun struct {
+	RunAt      int64
+	Status     string
+	TrainingId string
+	Reason     string
+}
+
+// TrainingSchedule creates a training of the project by Config each time
+// the Cron is due.
+type TrainingSchedule struct {
+	Id        string
+	Owner     Account
+	ProjectId string
+	Cron      CronExpr
+	Config    TrainingConfig
+	CreatedAt int64
+
+	// NextRunAt is 0 if the schedule will never run again.
+	NextRunAt int64
+
+	// InputsCommit is the commit of inputs which the last training was created with.
+	InputsCommit string
+
+	// History is the latest runs in the order of running.
+	History []ScheduleRun
+
+	Version int
+}
+
+func NewTrainingSchedule(
+	owner Account, projectId string, cron CronExpr,
+	config *TrainingConfig, now int64,
+) TrainingSchedule {
+	s := TrainingSchedule{
+		Owner:     owner,
+		ProjectId: projectId,
+		Cron:      cron,
+		Config:    *config,
+		CreatedAt: now,
+	}
+
+	s.NextRunAt = s.nextRunAt(now)
+
+	return s
+}
+
+func (s *TrainingSchedule) nextRunAt(now int64) int64 {
+	t := s.Cron.Next(time.Unix(now, 0))
+	if t.IsZero() {
+		return 0
+	}
+
+	return t.Unix()
+}
+
+// IsInputsChanged checks whether the inputs have changed since the last training.
+// It is regarded as changed if the commit of inputs is unknown.
+func (s *TrainingSchedule) IsInputsChanged(commit string) bool {
+	return commit == "" || s.InputsCommit != commit
+}
+
+// Advance moves the schedule to the next run after now.
+func (s *TrainingSchedule) Advance(now int64) {
+	s.NextRunAt = s.nextRunAt(now)
+}
+
+// TrainingConfigAt returns the config of training which runs at now.
+// The name of training is suffixed with the time to be unique.
+func (s *TrainingSchedule) TrainingConfigAt(now int64) (TrainingConfig, error) {
+	c := s.Config
+
+	name, err := NewTrainingName(
+		c.Name.TrainingName() + "-" + strconv.FormatInt(now, 10),
+	)
+	if err != nil {
+		return c, err
+	}
+
+	c.Name = name
+
+	return c, nil
+}
+
+func (s *TrainingSchedule) Created(trainingId, commit string, now int64) {
+	s.InputsCommit = commit
+
+	s.addRun(ScheduleRun{
+		RunAt:      now,
+		Status:     scheduleRunStatusCreated,
+		TrainingId: trainingId,
+	})
+}
+
+func (s *TrainingSchedule) Skipped(reason string, now int64) {
+	s.addRun(ScheduleRun{
+		RunAt:  now,
+		Status: scheduleRunStatusSkipped,
+		Reason: reason,
+	})
+}
+
+func (s *TrainingSchedule) Failed(reason string, now int64) {
+	s.addRun(ScheduleRun{
+		RunAt:  now,
+		Status: scheduleRunStatusFailed,
+		Reason: reason,
+	})
+}
+
+func (s *TrainingSchedule) addRun(r ScheduleRun) {
+	s.History = append(s.History, r)
+
+	if n := len(s.History) - DomainConfig.MaxScheduleHistory; n > 0 {
+		s.History = s.History[n:]
+	}
+
+	s.Advance(r.RunAt)
+}
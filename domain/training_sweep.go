@@ -0,0 +1,317 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+// SweepParameter is the search space of a hyperparameter. It is either
+// a list of values or a numeric range from Min to Max. The range is split
+// by Step for grid search, and is sampled uniformly for random search.
+type SweepParameter struct {
+	Key    CustomizedKey
+	Values []CustomizedValue
+
+	Min  float64
+	Max  float64
+	Step float64
+}
+
+func (p *SweepParameter) isRange() bool {
+	return len(p.Values) == 0
+}
+
+func (p *SweepParameter) validate(isGrid bool) error {
+	if p.Key == nil {
+		return errors.New("missing key of sweep parameter")
+	}
+
+	if !p.isRange() {
+		return nil
+	}
+
+	if p.Min > p.Max || p.Step < 0 || (isGrid && p.Step == 0) {
+		return fmt.Errorf("invalid range of sweep parameter %s", p.Key.CustomizedKey())
+	}
+
+	return nil
+}
+
+func (p *SweepParameter) grid() []string {
+	if !p.isRange() {
+		r := make([]string, len(p.Values))
+		for i := range p.Values {
+			r[i] = p.Values[i].CustomizedValue()
+		}
+
+		return r
+	}
+
+	r := []string{}
+	for i := 0; ; i++ {
+		v := p.Min + float64(i)*p.Step
+		if v > p.Max+p.Step/1e6 {
+			break
+		}
+
+		r = append(r, formatSweepValue(v))
+	}
+
+	return r
+}
+
+func (p *SweepParameter) sample(rnd *rand.Rand) string {
+	if !p.isRange() {
+		return p.Values[rnd.Intn(len(p.Values))].CustomizedValue()
+	}
+
+	v := p.Min + rnd.Float64()*(p.Max-p.Min)
+	if p.Step > 0 {
+		v = p.Min + math.Round((v-p.Min)/p.Step)*p.Step
+	}
+
+	return formatSweepValue(v)
+}
+
+func formatSweepValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', 6, 64)
+}
+
+// SweepMetric is the metric reported in JobDetail to rank the trials.
+type SweepMetric struct {
+	Name string
+	Goal MetricGoal
+}
+
+// SweepTrial is a training of sweep. It is pending until TrainingId is set.
+type SweepTrial struct {
+	Name            TrainingName
+	Hyperparameters []KeyValue
+	TrainingId      string
+}
+
+func (t *SweepTrial) IsPending() bool {
+	return t.TrainingId == ""
+}
+
+// TrainingSweep searches hyperparameters by running a training for each
+// combination of the values, at most Parallelism trainings run at the same time.
+type TrainingSweep struct {
+	Id          string
+	Owner       Account
+	ProjectId   string
+	Name        TrainingName
+	Algorithm   SweepAlgorithm
+	Params      []SweepParameter
+	Metric      SweepMetric
+	Parallelism int
+
+	// Config is the base config of trials.
+	Config TrainingConfig
+	Trials []SweepTrial
+
+	CreatedAt int64
+}
+
+func NewTrainingSweep(
+	owner Account, projectId string, name TrainingName,
+	algorithm SweepAlgorithm, params []SweepParameter, maxTrials int,
+	metric SweepMetric, parallelism int, config *TrainingConfig, now int64,
+) (TrainingSweep, error) {
+	if len(params) == 0 {
+		return TrainingSweep{}, errors.New("no sweep parameters")
+	}
+
+	for i := range params {
+		if err := params[i].validate(algorithm.IsGrid()); err != nil {
+			return TrainingSweep{}, err
+		}
+	}
+
+	if parallelism <= 0 || parallelism > DomainConfig.MaxTrainingConcurrency {
+		return TrainingSweep{}, fmt.Errorf(
+			"parallelism should be between 1 and %d", DomainConfig.MaxTrainingConcurrency,
+		)
+	}
+
+	s := TrainingSweep{
+		Owner:       owner,
+		ProjectId:   projectId,
+		Name:        name,
+		Algorithm:   algorithm,
+		Params:      params,
+		Metric:      metric,
+		Parallelism: parallelism,
+		Config:      *config,
+		CreatedAt:   now,
+	}
+
+	var values [][]string
+	if algorithm.IsGrid() {
+		values = s.gridValues()
+	} else {
+		values = s.randomValues(maxTrials, now)
+	}
+
+	if n := len(values); n == 0 || n > DomainConfig.MaxSweepTrials {
+		return TrainingSweep{}, fmt.Errorf(
+			"the num of trials should be between 1 and %d", DomainConfig.MaxSweepTrials,
+		)
+	}
+
+	if err := s.initTrials(values); err != nil {
+		return TrainingSweep{}, err
+	}
+
+	return s, nil
+}
+
+func (s *TrainingSweep) gridValues() [][]string {
+	r := [][]string{{}}
+
+	for i := range s.Params {
+		g := s.Params[i].grid()
+
+		next := make([][]string, 0, len(r)*len(g))
+		for _, prefix := range r {
+			for _, v := range g {
+				item := append(append([]string{}, prefix...), v)
+				next = append(next, item)
+			}
+		}
+
+		if r = next; len(r) > DomainConfig.MaxSweepTrials {
+			break
+		}
+	}
+
+	return r
+}
+
+func (s *TrainingSweep) randomValues(n int, seed int64) [][]string {
+	rnd := rand.New(rand.NewSource(seed)) // #nosec G404 -- sampling doesn't need to be secure
+
+	r := make([][]string, n)
+	for i := range r {
+		r[i] = make([]string, len(s.Params))
+		for j := range s.Params {
+			r[i][j] = s.Params[j].sample(rnd)
+		}
+	}
+
+	return r
+}
+
+func (s *TrainingSweep) initTrials(values [][]string) error {
+	s.Trials = make([]SweepTrial, len(values))
+
+	for i := range values {
+		t := &s.Trials[i]
+
+		name, err := NewTrainingName(fmt.Sprintf("%s-%d", s.Name.TrainingName(), i+1))
+		if err != nil {
+			return err
+		}
+		t.Name = name
+
+		if t.Hyperparameters, err = s.hyperparameters(values[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hyperparameters overrides the hyperparameters of base config with the values.
+func (s *TrainingSweep) hyperparameters(values []string) ([]KeyValue, error) {
+	r := make([]KeyValue, 0, len(s.Config.Hyperparameters)+len(values))
+
+	swept := map[string]bool{}
+	for i := range s.Params {
+		swept[s.Params[i].Key.CustomizedKey()] = true
+	}
+
+	for _, kv := range s.Config.Hyperparameters {
+		if !swept[kv.Key.CustomizedKey()] {
+			r = append(r, kv)
+		}
+	}
+
+	for i := range s.Params {
+		v, err := NewCustomizedValue(values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		r = append(r, KeyValue{Key: s.Params[i].Key, Value: v})
+	}
+
+	return r, nil
+}
+
+// TrialConfig returns the config of training for the trial.
+func (s *TrainingSweep) TrialConfig(i int) TrainingConfig {
+	c := s.Config
+	c.Name = s.Trials[i].Name
+	c.Hyperparameters = s.Trials[i].Hyperparameters
+
+	return c
+}
+
+// NextTrials returns the index of pending trials which can be started
+// when there are running trainings in the project.
+func (s *TrainingSweep) NextTrials(running int) []int {
+	n := s.Parallelism - running
+	if n <= 0 {
+		return nil
+	}
+
+	r := make([]int, 0, n)
+	for i := range s.Trials {
+		if len(r) == n {
+			break
+		}
+
+		if s.Trials[i].IsPending() {
+			r = append(r, i)
+		}
+	}
+
+	return r
+}
+
+func (s *TrainingSweep) HasPendingTrials() bool {
+	for i := range s.Trials {
+		if s.Trials[i].IsPending() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Rank sorts the trainings of trials by the metric, the best one is the first.
+// The trainings without the metric are put at the end.
+func (s *TrainingSweep) Rank(trainings []TrainingSummary) {
+	name := s.Metric.Name
+	max := s.Metric.Goal.IsMaximize()
+
+	sort.SliceStable(trainings, func(i, j int) bool {
+		vi, oki := trainings[i].Metrics[name]
+		vj, okj := trainings[j].Metrics[name]
+
+		if !oki || !okj {
+			return oki
+		}
+
+		if max {
+			return vi > vj
+		}
+
+		return vi < vj
+	})
+}
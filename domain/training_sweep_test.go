@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func initSweepConfig(t *testing.T) {
+	old := DomainConfig
+	t.Cleanup(func() { DomainConfig = old })
+
+	cfg := Config{}
+	cfg.SetDefault()
+	DomainConfig = cfg
+}
+
+func testSweep(t *testing.T, algorithm string, params []SweepParameter, maxTrials int) (
+	TrainingSweep, error,
+) {
+	name, err := NewTrainingName("sweep")
+	if err != nil {
+		t.Fatalf("NewTrainingName() failed, err:%s", err.Error())
+	}
+
+	a, err := NewSweepAlgorithm(algorithm)
+	if err != nil {
+		t.Fatalf("NewSweepAlgorithm() failed, err:%s", err.Error())
+	}
+
+	config := TrainingConfig{
+		Hyperparameters: []KeyValue{
+			{Key: customizedKey("lr"), Value: customizedValue("0.5")},
+			{Key: customizedKey("epochs"), Value: customizedValue("10")},
+		},
+	}
+
+	return NewTrainingSweep(
+		CreateAccount("alice"), "pid", name, a, params, maxTrials,
+		SweepMetric{Name: "accuracy", Goal: metricGoal(metricGoalMaximize)},
+		1, &config, 1,
+	)
+}
+
+func TestNewTrainingSweep(t *testing.T) {
+	initSweepConfig(t)
+
+	lr := SweepParameter{Key: customizedKey("lr"), Min: 0.1, Max: 0.3, Step: 0.1}
+	batch := SweepParameter{
+		Key:    customizedKey("batch"),
+		Values: []CustomizedValue{customizedValue("16"), customizedValue("32")},
+	}
+	large := SweepParameter{Key: customizedKey("lr"), Min: 0, Max: 1, Step: 0.01}
+
+	cases := []struct {
+		name      string
+		algorithm string
+		params    []SweepParameter
+		maxTrials int
+		trials    int
+		wantErr   bool
+	}{
+		{"grid", sweepAlgorithmGrid, []SweepParameter{lr, batch}, 0, 6, false},
+		{"random", sweepAlgorithmRandom, []SweepParameter{lr, batch}, 4, 4, false},
+		{"no parameters", sweepAlgorithmGrid, nil, 0, 0, true},
+		{"grid without step", sweepAlgorithmGrid, []SweepParameter{{Key: customizedKey("lr"), Max: 1}}, 0, 0, true},
+		{"too many trials of grid", sweepAlgorithmGrid, []SweepParameter{large}, 0, 0, true},
+		{"too many trials of random", sweepAlgorithmRandom, []SweepParameter{lr}, 100, 0, true},
+		{"no trials of random", sweepAlgorithmRandom, []SweepParameter{lr}, 0, 0, true},
+	}
+
+	for _, c := range cases {
+		s, err := testSweep(t, c.algorithm, c.params, c.maxTrials)
+		if (err != nil) != c.wantErr {
+			t.Errorf("%s: err = %v, want error: %v", c.name, err, c.wantErr)
+
+			continue
+		}
+
+		if len(s.Trials) != c.trials {
+			t.Errorf("%s: got %d trials, want %d", c.name, len(s.Trials), c.trials)
+		}
+	}
+}
+
+func TestTrainingSweepTrialConfig(t *testing.T) {
+	initSweepConfig(t)
+
+	s, err := testSweep(t, sweepAlgorithmGrid, []SweepParameter{
+		{Key: customizedKey("lr"), Min: 0.1, Max: 0.2, Step: 0.1},
+	}, 0)
+	if err != nil {
+		t.Fatalf("NewTrainingSweep() failed, err:%s", err.Error())
+	}
+
+	want := [][]string{
+		{"sweep-1", "epochs=10", "lr=0.1"},
+		{"sweep-2", "epochs=10", "lr=0.2"},
+	}
+
+	for i := range s.Trials {
+		c := s.TrialConfig(i)
+
+		got := []string{c.Name.TrainingName()}
+		for _, kv := range c.Hyperparameters {
+			got = append(got, kv.Key.CustomizedKey()+"="+kv.Value.CustomizedValue())
+		}
+
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("trial %d: got %v, want %v", i, got, want[i])
+		}
+	}
+}
+
+func TestTrainingSweepNextTrials(t *testing.T) {
+	newSweep := func(started ...bool) TrainingSweep {
+		s := TrainingSweep{Parallelism: 2, Trials: make([]SweepTrial, len(started))}
+		for i, v := range started {
+			if v {
+				s.Trials[i].TrainingId = "tid"
+			}
+		}
+
+		return s
+	}
+
+	cases := []struct {
+		name        string
+		sweep       TrainingSweep
+		running     int
+		want        []int
+		wantPending bool
+	}{
+		{"none started", newSweep(false, false, false), 0, []int{0, 1}, true},
+		{"one running", newSweep(true, false, false), 1, []int{1}, true},
+		{"all slots running", newSweep(true, true, false), 2, nil, true},
+		{"more running than parallelism", newSweep(true, false), 3, nil, true},
+		{"skip the started", newSweep(true, false, true, false), 0, []int{1, 3}, true},
+		{"all started", newSweep(true, true), 0, []int{}, false},
+	}
+
+	for _, c := range cases {
+		if got := c.sweep.NextTrials(c.running); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: NextTrials() = %v, want %v", c.name, got, c.want)
+		}
+
+		if got := c.sweep.HasPendingTrials(); got != c.wantPending {
+			t.Errorf("%s: HasPendingTrials() = %v, want %v", c.name, got, c.wantPending)
+		}
+	}
+}
+
+func TestTrainingSweepRank(t *testing.T) {
+	trainings := func() []TrainingSummary {
+		return []TrainingSummary{
+			{Id: "a", Metrics: map[string]float64{"accuracy": 0.8}},
+			{Id: "b"},
+			{Id: "c", Metrics: map[string]float64{"accuracy": 0.9}},
+			{Id: "d", Metrics: map[string]float64{"accuracy": 0.7}},
+		}
+	}
+
+	cases := []struct {
+		name string
+		goal string
+		want []string
+	}{
+		{"maximize", metricGoalMaximize, []string{"c", "a", "d", "b"}},
+		{"minimize", metricGoalMinimize, []string{"d", "a", "c", "b"}},
+	}
+
+	for _, c := range cases {
+		s := TrainingSweep{Metric: SweepMetric{Name: "accuracy", Goal: metricGoal(c.goal)}}
+
+		v := trainings()
+		s.Rank(v)
+
+		got := make([]string, len(v))
+		for i := range v {
+			got[i] = v[i].Id
+		}
+
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%s: Rank() = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package app
+
+import (
+	"errors"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/image/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type ImageRegisterCmd struct {
+	// Owner is the organization if OrgOwned is true, otherwise it is the User.
+	User      types.Account
+	Owner     types.Account
+	OrgOwned  bool
+	Name      domain.ImageName
+	Tag       domain.ImageTag
+	Processor domain.ImageProcessor
+
+	// Project is the project whose repo contains the Dockerfile
+	Project    types.ResourceSummary
+	Dockerfile types.FilePath
+
+	Reference domain.ImageReference
+	Digest    domain.ImageDigest
+}
+
+func (cmd *ImageRegisterCmd) Validate() error {
+	b := cmd.User != nil &&
+		cmd.Owner != nil &&
+		cmd.Name != nil &&
+		cmd.Tag != nil &&
+		cmd.Processor != nil
+
+	if !b {
+		return errors.New("invalid cmd")
+	}
+
+	if cmd.Dockerfile != nil {
+		if cmd.Project.Owner == nil || cmd.Project.Owner.Account() != cmd.Owner.Account() {
+			return errors.New("can't build image from the project of others")
+		}
+
+		return nil
+	}
+
+	if cmd.Reference == nil || cmd.Digest == nil {
+		return errors.New("missing dockerfile or digest")
+	}
+
+	return nil
+}
+
+func (cmd *ImageRegisterCmd) toImageSource() domain.ImageSource {
+	if cmd.Dockerfile != nil {
+		return domain.ImageSource{
+			ProjectId:     cmd.Project.Id,
+			ProjectRepoId: cmd.Project.RepoId,
+			Dockerfile:    cmd.Dockerfile,
+		}
+	}
+
+	return domain.ImageSource{
+		Reference: cmd.Reference,
+		Digest:    cmd.Digest,
+	}
+}
+
+type ImageListCmd struct {
+	User types.Account
+
+	// Org is the organization whose images are listed, it is optional.
+	Org types.Account
+}
+
+type ImageDeleteCmd struct {
+	User types.Account
+	Id   string
+}
+
+type ImageUsageCmd struct {
+	User types.Account
+	Id   string
+
+	// Processor is the processor which the image will run on
+	Processor string
+}
+
+type ImageScanCmd struct {
+	Id      string
+	Address string
+	Passed  bool
+	Reason  string
+}
+
+type ImageReviewCmd struct {
+	Id       string
+	Approved bool
+	Reason   string
+}
+
+type ImageDTO struct {
+	Id         string `json:"id"`
+	Owner      string `json:"owner"`
+	OrgOwned   bool   `json:"org_owned"`
+	Name       string `json:"name"`
+	Tag        string `json:"tag"`
+	Processor  string `json:"processor"`
+	Source     string `json:"source"`
+	Dockerfile string `json:"dockerfile,omitempty"`
+	Digest     string `json:"digest,omitempty"`
+	Status     string `json:"status"`
+	Address    string `json:"address"`
+	Reason     string `json:"reason"`
+	CreatedAt  string `json:"created_at"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+func toImageDTO(img *domain.Image) ImageDTO {
+	dto := ImageDTO{
+		Id:        img.Id,
+		Owner:     img.Owner.Account(),
+		OrgOwned:  img.OrgOwned,
+		Name:      img.Name.ImageName(),
+		Tag:       img.Tag.ImageTag(),
+		Processor: img.Processor.ImageProcessor(),
+		Source:    img.Source.Type(),
+		Status:    img.Status.ImageStatus(),
+		Address:   img.Address,
+		Reason:    img.Reason,
+		CreatedAt: utils.ToDate(img.CreatedAt),
+		UpdatedAt: utils.ToDate(img.UpdatedAt),
+	}
+
+	if img.Source.IsDockerfile() {
+		dto.Dockerfile = img.Source.Dockerfile.FilePath()
+	} else {
+		dto.Digest = img.Source.Digest.ImageDigest()
+	}
+
+	return dto
+}
@@ -0,0 +1,10 @@
+package app
+
+const (
+	errorNoPermission      = "image_no_permission"
+	errorInvalidImage      = "image_invalid"
+	errorImageNotApproved  = "image_not_approved"
+	errorImageIncompatible = "image_incompatible"
+	errorImageNotScanning  = "image_not_scanning"
+	errorImageNotReviewing = "image_not_reviewing"
+)
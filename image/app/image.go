@@ -0,0 +1,259 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/image/domain"
+	"github.com/opensourceways/xihe-server/image/domain/message"
+	"github.com/opensourceways/xihe-server/image/domain/org"
+	"github.com/opensourceways/xihe-server/image/domain/repository"
+)
+
+type ImageService interface {
+	Register(*ImageRegisterCmd) (ImageDTO, string, error)
+	List(*ImageListCmd) ([]ImageDTO, string, error)
+	Delete(*ImageDeleteCmd) (string, error)
+
+	// GetUsableImage returns the address of the image
+	GetUsableImage(*ImageUsageCmd) (string, string, error)
+}
+
+func NewImageService(
+	repo repository.Image,
+	producer message.MessageProducer,
+	org org.Org,
+) ImageService {
+	return &imageService{
+		repo:     repo,
+		producer: producer,
+		org:      org,
+	}
+}
+
+type imageService struct {
+	repo     repository.Image
+	producer message.MessageProducer
+	org      org.Org
+}
+
+func (s *imageService) checkMember(org, user types.Account) (code string, err error) {
+	b, err := s.org.HasMember(org, user)
+	if err == nil && !b {
+		code = errorNoPermission
+		err = errors.New("not a member of the organization")
+	}
+
+	return
+}
+
+// isOwner checks whether the user owns the image, the members of
+// organization own the images of it.
+func (s *imageService) isOwner(img *domain.Image, user types.Account) (bool, error) {
+	isMember := false
+
+	if img.OrgOwned {
+		v, err := s.org.HasMember(img.Owner, user)
+		if err != nil {
+			return false, err
+		}
+
+		isMember = v
+	}
+
+	return img.IsOwner(user, isMember), nil
+}
+
+func (s *imageService) Register(cmd *ImageRegisterCmd) (dto ImageDTO, code string, err error) {
+	if cmd.OrgOwned {
+		if code, err = s.checkMember(cmd.Owner, cmd.User); err != nil {
+			return
+		}
+	}
+
+	img, err := domain.NewImage(
+		cmd.Owner, cmd.OrgOwned, cmd.Name, cmd.Tag, cmd.Processor, cmd.toImageSource(),
+	)
+	if err != nil {
+		code = errorInvalidImage
+
+		return
+	}
+
+	if err = s.repo.AddImage(&img); err != nil {
+		return
+	}
+
+	e := domain.NewImageRegisteredEvent(&img)
+	if err1 := s.producer.SendImageRegisteredEvent(&e); err1 != nil {
+		logrus.Errorf(
+			"send image registered event failed, image:%s, err:%s",
+			img.Id, err1.Error(),
+		)
+	}
+
+	dto = toImageDTO(&img)
+
+	return
+}
+
+func (s *imageService) List(cmd *ImageListCmd) (dtos []ImageDTO, code string, err error) {
+	owner := cmd.User
+
+	if cmd.Org != nil {
+		if code, err = s.checkMember(cmd.Org, cmd.User); err != nil {
+			return
+		}
+
+		owner = cmd.Org
+	}
+
+	v, err := s.repo.FindImages(owner)
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	dtos = make([]ImageDTO, 0, len(v))
+	for i := range v {
+		// an account may have owned images as a user and as an organization.
+		if v[i].OrgOwned == (cmd.Org != nil) {
+			dtos = append(dtos, toImageDTO(&v[i]))
+		}
+	}
+
+	return
+}
+
+func (s *imageService) Delete(cmd *ImageDeleteCmd) (code string, err error) {
+	v, err := s.repo.FindImage(cmd.Id)
+	if err != nil {
+		return
+	}
+
+	b, err := s.isOwner(&v.Image, cmd.User)
+	if err != nil {
+		return
+	}
+
+	if !b {
+		code = errorNoPermission
+		err = errors.New("no permission to delete the image")
+
+		return
+	}
+
+	err = s.repo.DeleteImage(cmd.Id)
+
+	return
+}
+
+func (s *imageService) GetUsableImage(cmd *ImageUsageCmd) (addr string, code string, err error) {
+	v, err := s.repo.FindImage(cmd.Id)
+	if err != nil {
+		return
+	}
+
+	img := &v.Image
+
+	b, err := s.isOwner(img, cmd.User)
+	if err != nil {
+		return
+	}
+
+	if !b {
+		code = errorNoPermission
+		err = errors.New("no permission to use the image")
+
+		return
+	}
+
+	if !img.IsApproved() {
+		code = errorImageNotApproved
+		err = errors.New("the image has not been approved")
+
+		return
+	}
+
+	if !img.IsCompatible(cmd.Processor) {
+		code = errorImageIncompatible
+		err = errors.New("the image is incompatible with the processor")
+
+		return
+	}
+
+	addr = img.Address
+
+	return
+}
+
+// ImageInternalService
+type ImageInternalService interface {
+	ListReviewing() ([]ImageDTO, error)
+	SetScanResult(*ImageScanCmd) (string, error)
+	Review(*ImageReviewCmd) (string, error)
+}
+
+func NewImageInternalService(repo repository.Image) ImageInternalService {
+	return &imageInternalService{repo: repo}
+}
+
+type imageInternalService struct {
+	repo repository.Image
+}
+
+func (s *imageInternalService) ListReviewing() ([]ImageDTO, error) {
+	v, err := s.repo.FindImagesByStatus(domain.ImageStatusReviewing)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	dtos := make([]ImageDTO, len(v))
+	for i := range v {
+		dtos[i] = toImageDTO(&v[i])
+	}
+
+	return dtos, nil
+}
+
+func (s *imageInternalService) SetScanResult(cmd *ImageScanCmd) (code string, err error) {
+	v, err := s.repo.FindImage(cmd.Id)
+	if err != nil {
+		return
+	}
+
+	if !v.Image.Status.IsScanning() {
+		code = errorImageNotScanning
+		err = errors.New("the image is not being scanned")
+
+		return
+	}
+
+	if err = v.Image.SetScanResult(cmd.Address, cmd.Passed, cmd.Reason); err != nil {
+		code = errorInvalidImage
+
+		return
+	}
+
+	err = s.repo.SaveImage(&v.Image, v.Version)
+
+	return
+}
+
+func (s *imageInternalService) Review(cmd *ImageReviewCmd) (code string, err error) {
+	v, err := s.repo.FindImage(cmd.Id)
+	if err != nil {
+		return
+	}
+
+	if err = v.Image.Review(cmd.Approved, cmd.Reason); err != nil {
+		code = errorImageNotReviewing
+
+		return
+	}
+
+	err = s.repo.SaveImage(&v.Image, v.Version)
+
+	return
+}
@@ -0,0 +1,15 @@
+package image
+
+import (
+	imagemsg "github.com/opensourceways/xihe-server/image/infrastructure/messageadapter"
+)
+
+type Config struct {
+	Message imagemsg.Config `json:"message"`
+}
+
+func (cfg *Config) ConfigItems() []interface{} {
+	return []interface{}{
+		&cfg.Message,
+	}
+}
@@ -0,0 +1,174 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	ProcessorCPU    = "cpu"
+	ProcessorGPU    = "gpu"
+	ProcessorAscend = "ascend"
+
+	imageStatusScanning  = "scanning"
+	imageStatusReviewing = "reviewing"
+	imageStatusApproved  = "approved"
+	imageStatusRejected  = "rejected"
+
+	imageSourceDockerfile = "dockerfile"
+	imageSourceDigest     = "digest"
+)
+
+var (
+	ImageStatusScanning  = imageStatus(imageStatusScanning)
+	ImageStatusReviewing = imageStatus(imageStatusReviewing)
+	ImageStatusApproved  = imageStatus(imageStatusApproved)
+	ImageStatusRejected  = imageStatus(imageStatusRejected)
+
+	reImageName      = regexp.MustCompile("^[a-z0-9]+([._-][a-z0-9]+)*$")
+	reImageTag       = regexp.MustCompile("^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$")
+	reImageDigest    = regexp.MustCompile("^sha256:[a-f0-9]{64}$")
+	reImageReference = regexp.MustCompile("^[a-z0-9]+([._-][a-z0-9]+)*(:[0-9]+)?(/[a-z0-9]+([._-][a-z0-9]+)*)+$")
+)
+
+// ImageName
+type ImageName interface {
+	ImageName() string
+}
+
+func NewImageName(v string) (ImageName, error) {
+	if len(v) > 64 || !reImageName.MatchString(v) {
+		return nil, errors.New("invalid image name")
+	}
+
+	return imageName(v), nil
+}
+
+type imageName string
+
+func (r imageName) ImageName() string {
+	return string(r)
+}
+
+// ImageTag
+type ImageTag interface {
+	ImageTag() string
+}
+
+func NewImageTag(v string) (ImageTag, error) {
+	if !reImageTag.MatchString(v) {
+		return nil, errors.New("invalid image tag")
+	}
+
+	return imageTag(v), nil
+}
+
+type imageTag string
+
+func (r imageTag) ImageTag() string {
+	return string(r)
+}
+
+// ImageProcessor
+type ImageProcessor interface {
+	ImageProcessor() string
+}
+
+func NewImageProcessor(v string) (ImageProcessor, error) {
+	v = strings.ToLower(v)
+
+	b := v == ProcessorCPU ||
+		v == ProcessorGPU ||
+		v == ProcessorAscend
+
+	if !b {
+		return nil, errors.New("invalid image processor")
+	}
+
+	return imageProcessor(v), nil
+}
+
+type imageProcessor string
+
+func (r imageProcessor) ImageProcessor() string {
+	return string(r)
+}
+
+// ImageStatus
+type ImageStatus interface {
+	ImageStatus() string
+	IsScanning() bool
+	IsReviewing() bool
+	IsApproved() bool
+}
+
+func NewImageStatus(v string) (ImageStatus, error) {
+	b := v == imageStatusScanning ||
+		v == imageStatusReviewing ||
+		v == imageStatusApproved ||
+		v == imageStatusRejected
+
+	if !b {
+		return nil, errors.New("invalid image status")
+	}
+
+	return imageStatus(v), nil
+}
+
+type imageStatus string
+
+func (r imageStatus) ImageStatus() string {
+	return string(r)
+}
+
+func (r imageStatus) IsScanning() bool {
+	return string(r) == imageStatusScanning
+}
+
+func (r imageStatus) IsReviewing() bool {
+	return string(r) == imageStatusReviewing
+}
+
+func (r imageStatus) IsApproved() bool {
+	return string(r) == imageStatusApproved
+}
+
+// ImageDigest
+type ImageDigest interface {
+	ImageDigest() string
+}
+
+func NewImageDigest(v string) (ImageDigest, error) {
+	if !reImageDigest.MatchString(v) {
+		return nil, errors.New("invalid image digest")
+	}
+
+	return imageDigest(v), nil
+}
+
+type imageDigest string
+
+func (r imageDigest) ImageDigest() string {
+	return string(r)
+}
+
+// ImageReference is the repository of image without tag and digest,
+// such as swr.cn-north-4.myhuaweicloud.com/xihe/notebook.
+type ImageReference interface {
+	ImageReference() string
+}
+
+func NewImageReference(v string) (ImageReference, error) {
+	if len(v) > 255 || !reImageReference.MatchString(v) {
+		return nil, errors.New("invalid image reference")
+	}
+
+	return imageReference(v), nil
+}
+
+type imageReference string
+
+func (r imageReference) ImageReference() string {
+	return string(r)
+}
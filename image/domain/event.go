@@ -0,0 +1,31 @@
+package domain
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// ImageRegisteredEvent asks to build the image if needed and scan it.
+type ImageRegisteredEvent struct {
+	Owner         types.Account
+	ImageId       string
+	Source        string
+	ProjectRepoId string
+	Dockerfile    string
+	Address       string
+}
+
+func NewImageRegisteredEvent(img *Image) ImageRegisteredEvent {
+	e := ImageRegisteredEvent{
+		Owner:   img.Owner,
+		ImageId: img.Id,
+		Source:  img.Source.Type(),
+		Address: img.Address,
+	}
+
+	if img.Source.IsDockerfile() {
+		e.ProjectRepoId = img.Source.ProjectRepoId
+		e.Dockerfile = img.Source.Dockerfile.FilePath()
+	}
+
+	return e
+}
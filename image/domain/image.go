@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// ImageSource is where the image comes from. It is either built from
+// the Dockerfile in a project repo or referenced by digest.
+type ImageSource struct {
+	ProjectId     string
+	ProjectRepoId string
+	Dockerfile    types.FilePath
+
+	Reference ImageReference
+	Digest    ImageDigest
+}
+
+func (s *ImageSource) IsDockerfile() bool {
+	return s.Dockerfile != nil
+}
+
+func (s *ImageSource) Type() string {
+	if s.IsDockerfile() {
+		return imageSourceDockerfile
+	}
+
+	return imageSourceDigest
+}
+
+func (s *ImageSource) validate() error {
+	if s.IsDockerfile() {
+		if s.ProjectId == "" || s.ProjectRepoId == "" {
+			return errors.New("missing project of Dockerfile")
+		}
+
+		return nil
+	}
+
+	if s.Reference == nil || s.Digest == nil {
+		return errors.New("missing reference or digest of image")
+	}
+
+	return nil
+}
+
+// Image is the custom image registered by user or organization.
+// It can't be used until it passes the scan and is approved.
+type Image struct {
+	Id    string
+	Owner types.Account
+
+	// OrgOwned is true if the owner is an organization,
+	// then the image is shared with all its members.
+	OrgOwned bool
+
+	Name      ImageName
+	Tag       ImageTag
+	Processor ImageProcessor
+	Source    ImageSource
+	Status    ImageStatus
+	Address   string
+	Reason    string
+	CreatedAt int64
+	UpdatedAt int64
+}
+
+func NewImage(
+	owner types.Account, orgOwned bool, name ImageName, tag ImageTag,
+	processor ImageProcessor, source ImageSource,
+) (Image, error) {
+	if err := source.validate(); err != nil {
+		return Image{}, err
+	}
+
+	now := utils.Now()
+
+	img := Image{
+		Id:        primitive.NewObjectID().Hex(),
+		Owner:     owner,
+		OrgOwned:  orgOwned,
+		Name:      name,
+		Tag:       tag,
+		Processor: processor,
+		Source:    source,
+		Status:    ImageStatusScanning,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if !source.IsDockerfile() {
+		img.Address = source.Reference.ImageReference() + "@" + source.Digest.ImageDigest()
+	}
+
+	return img, nil
+}
+
+// IsOwner checks whether the user owns the image. isMember tells whether
+// the user is a member of the organization if the image is owned by it.
+func (img *Image) IsOwner(user types.Account, isMember bool) bool {
+	if img.OrgOwned {
+		return isMember
+	}
+
+	return img.Owner.Account() == user.Account()
+}
+
+func (img *Image) IsApproved() bool {
+	return img.Status.IsApproved()
+}
+
+// IsCompatible checks whether the image can run on the processor
+// which is one of cpu, gpu and ascend.
+func (img *Image) IsCompatible(processor string) bool {
+	return strings.ToLower(processor) == img.Processor.ImageProcessor()
+}
+
+// SetScanResult records the result of building and scanning the image.
+// The address is the image built from Dockerfile, it is ignored if
+// the image is referenced by digest.
+func (img *Image) SetScanResult(address string, passed bool, reason string) error {
+	if !img.Status.IsScanning() {
+		return errors.New("the image is not being scanned")
+	}
+
+	if passed {
+		if img.Source.IsDockerfile() {
+			if address == "" {
+				return errors.New("missing address of the built image")
+			}
+
+			img.Address = address
+		}
+
+		img.Status = ImageStatusReviewing
+	} else {
+		img.Status = ImageStatusRejected
+	}
+
+	img.Reason = reason
+	img.UpdatedAt = utils.Now()
+
+	return nil
+}
+
+func (img *Image) Review(approved bool, reason string) error {
+	if !img.Status.IsReviewing() {
+		return errors.New("the image is not being reviewed")
+	}
+
+	if approved {
+		img.Status = ImageStatusApproved
+	} else {
+		img.Status = ImageStatusRejected
+	}
+
+	img.Reason = reason
+	img.UpdatedAt = utils.Now()
+
+	return nil
+}
@@ -0,0 +1,7 @@
+package message
+
+import "github.com/opensourceways/xihe-server/image/domain"
+
+type MessageProducer interface {
+	SendImageRegisteredEvent(*domain.ImageRegisteredEvent) error
+}
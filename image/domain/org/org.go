@@ -0,0 +1,8 @@
+package org
+
+import types "github.com/opensourceways/xihe-server/domain"
+
+// Org checks the membership of organization.
+type Org interface {
+	HasMember(org, user types.Account) (bool, error)
+}
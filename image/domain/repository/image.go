@@ -0,0 +1,20 @@
+package repository
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/image/domain"
+)
+
+type ImageVersion struct {
+	Image   domain.Image
+	Version int
+}
+
+type Image interface {
+	AddImage(*domain.Image) error
+	SaveImage(*domain.Image, int) error
+	DeleteImage(id string) error
+	FindImage(id string) (ImageVersion, error)
+	FindImages(owner types.Account) ([]domain.Image, error)
+	FindImagesByStatus(domain.ImageStatus) ([]domain.Image, error)
+}
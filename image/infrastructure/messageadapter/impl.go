@@ -0,0 +1,43 @@
+package messageadapter
+
+import (
+	"fmt"
+
+	common "github.com/opensourceways/xihe-server/common/domain/message"
+	"github.com/opensourceways/xihe-server/image/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+func MessageAdapter(cfg *Config, p common.Publisher) *messageAdapter {
+	return &messageAdapter{cfg: *cfg, publisher: p}
+}
+
+type messageAdapter struct {
+	cfg       Config
+	publisher common.Publisher
+}
+
+func (impl *messageAdapter) SendImageRegisteredEvent(v *domain.ImageRegisteredEvent) error {
+	cfg := &impl.cfg.ImageRegistered
+
+	msg := common.MsgNormal{
+		Type: cfg.Name,
+		User: v.Owner.Account(),
+		Desc: fmt.Sprintf("registered image of %s", v.ImageId),
+		Details: map[string]string{
+			"image_id":        v.ImageId,
+			"source":          v.Source,
+			"project_repo_id": v.ProjectRepoId,
+			"dockerfile":      v.Dockerfile,
+			"address":         v.Address,
+		},
+		CreatedAt: utils.Now(),
+	}
+
+	return impl.publisher.Publish(cfg.Topic, &msg, nil)
+}
+
+// Config
+type Config struct {
+	ImageRegistered common.TopicConfig `json:"image_registered"`
+}
@@ -0,0 +1,35 @@
+package orgimpl
+
+import (
+	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
+	compdomain "github.com/opensourceways/xihe-server/computility/domain"
+	comprepo "github.com/opensourceways/xihe-server/computility/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/image/domain/org"
+)
+
+// NewOrgImpl checks the membership by the computility details which are
+// synchronized for every member of organization.
+func NewOrgImpl(detail comprepo.ComputilityDetailRepositoryAdapter) org.Org {
+	return &orgImpl{detail: detail}
+}
+
+type orgImpl struct {
+	detail comprepo.ComputilityDetailRepositoryAdapter
+}
+
+func (impl *orgImpl) HasMember(org, user types.Account) (bool, error) {
+	_, err := impl.detail.FindByIndex(&compdomain.ComputilityIndex{
+		OrgName:  org,
+		UserName: user,
+	})
+	if err == nil {
+		return true, nil
+	}
+
+	if commonrepo.IsErrorResourceNotExists(err) {
+		return false, nil
+	}
+
+	return false, err
+}
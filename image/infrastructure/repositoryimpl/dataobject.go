@@ -0,0 +1,86 @@
+package repositoryimpl
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/image/domain"
+)
+
+func (doc *DImage) toImage(img *domain.Image) (err error) {
+	if img.Owner, err = types.NewAccount(doc.Owner); err != nil {
+		return
+	}
+
+	if img.Name, err = domain.NewImageName(doc.Name); err != nil {
+		return
+	}
+
+	if img.Tag, err = domain.NewImageTag(doc.Tag); err != nil {
+		return
+	}
+
+	if img.Processor, err = domain.NewImageProcessor(doc.Processor); err != nil {
+		return
+	}
+
+	if img.Status, err = domain.NewImageStatus(doc.Status); err != nil {
+		return
+	}
+
+	if err = doc.toImageSource(&img.Source); err != nil {
+		return
+	}
+
+	img.Id = doc.Id
+	img.OrgOwned = doc.OrgOwned
+	img.Address = doc.Address
+	img.Reason = doc.Reason
+	img.CreatedAt = doc.CreatedAt
+	img.UpdatedAt = doc.UpdatedAt
+
+	return
+}
+
+func (doc *DImage) toImageSource(s *domain.ImageSource) (err error) {
+	if doc.Dockerfile != "" {
+		s.ProjectId = doc.ProjectId
+		s.ProjectRepoId = doc.ProjectRepoId
+		s.Dockerfile, err = types.NewFilePath(doc.Dockerfile)
+
+		return
+	}
+
+	if s.Reference, err = domain.NewImageReference(doc.Reference); err != nil {
+		return
+	}
+
+	s.Digest, err = domain.NewImageDigest(doc.Digest)
+
+	return
+}
+
+func toImageDoc(img *domain.Image) DImage {
+	doc := DImage{
+		Id:        img.Id,
+		Owner:     img.Owner.Account(),
+		OrgOwned:  img.OrgOwned,
+		Name:      img.Name.ImageName(),
+		Tag:       img.Tag.ImageTag(),
+		Processor: img.Processor.ImageProcessor(),
+		Status:    img.Status.ImageStatus(),
+		Address:   img.Address,
+		Reason:    img.Reason,
+		CreatedAt: img.CreatedAt,
+		UpdatedAt: img.UpdatedAt,
+	}
+
+	if s := &img.Source; s.IsDockerfile() {
+		doc.ProjectId = s.ProjectId
+		doc.ProjectRepoId = s.ProjectRepoId
+		doc.Dockerfile = s.Dockerfile.FilePath()
+	} else {
+		doc.Reference = s.Reference.ImageReference()
+		doc.Digest = s.Digest.ImageDigest()
+	}
+
+	return doc
+}
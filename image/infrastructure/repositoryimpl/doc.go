@@ -0,0 +1,29 @@
+package repositoryimpl
+
+const (
+	fieldId        = "id"
+	fieldOwner     = "owner"
+	fieldStatus    = "status"
+	fieldVersion   = "version"
+	fieldCreatedAt = "created_at"
+)
+
+type DImage struct {
+	Id            string `bson:"id"               json:"id"`
+	Owner         string `bson:"owner"            json:"owner"`
+	OrgOwned      bool   `bson:"org_owned"        json:"org_owned"`
+	Name          string `bson:"name"             json:"name"`
+	Tag           string `bson:"tag"              json:"tag"`
+	Processor     string `bson:"processor"        json:"processor"`
+	ProjectId     string `bson:"project_id"       json:"project_id,omitempty"`
+	ProjectRepoId string `bson:"project_repo_id"  json:"project_repo_id,omitempty"`
+	Dockerfile    string `bson:"dockerfile"       json:"dockerfile,omitempty"`
+	Reference     string `bson:"reference"        json:"reference,omitempty"`
+	Digest        string `bson:"digest"           json:"digest,omitempty"`
+	Status        string `bson:"status"           json:"status"`
+	Address       string `bson:"address"          json:"address"`
+	Reason        string `bson:"reason"           json:"reason"`
+	CreatedAt     int64  `bson:"created_at"       json:"created_at"`
+	UpdatedAt     int64  `bson:"updated_at"       json:"updated_at"`
+	Version       int    `bson:"version"          json:"-"`
+}
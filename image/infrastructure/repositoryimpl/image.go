@@ -0,0 +1,124 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/image/domain"
+	"github.com/opensourceways/xihe-server/image/domain/repository"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func NewImageRepo(m mongodbClient) repository.Image {
+	return &imageRepoImpl{m}
+}
+
+type imageRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl *imageRepoImpl) AddImage(img *domain.Image) error {
+	doc, err := genDoc(toImageDoc(img))
+	if err != nil {
+		return err
+	}
+	doc[fieldVersion] = 1
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, bson.M{fieldId: img.Id}, doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocExists(err) {
+		err = repoerr.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+func (impl *imageRepoImpl) SaveImage(img *domain.Image, version int) error {
+	doc, err := genDoc(toImageDoc(img))
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(
+			ctx, bson.M{fieldId: img.Id}, doc, mongoCmdSet, version,
+		)
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocNotExists(err) {
+		err = repoerr.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (impl *imageRepoImpl) DeleteImage(id string) error {
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().DeleteOne(ctx, bson.M{fieldId: id})
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl *imageRepoImpl) FindImage(id string) (
+	r repository.ImageVersion, err error,
+) {
+	var v DImage
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, bson.M{fieldId: id}, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	r.Version = v.Version
+	err = v.toImage(&r.Image)
+
+	return
+}
+
+func (impl *imageRepoImpl) FindImages(owner types.Account) ([]domain.Image, error) {
+	return impl.findImages(bson.M{fieldOwner: owner.Account()})
+}
+
+func (impl *imageRepoImpl) FindImagesByStatus(s domain.ImageStatus) ([]domain.Image, error) {
+	return impl.findImages(bson.M{fieldStatus: s.ImageStatus()})
+}
+
+func (impl *imageRepoImpl) findImages(filter bson.M) ([]domain.Image, error) {
+	var v []DImage
+
+	f := func(ctx context.Context) error {
+		opts := options.FindOptions{}
+		opts.SetSort(bson.M{fieldCreatedAt: -1})
+
+		return impl.cli.GetDocs(ctx, filter, &opts, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.Image, len(v))
+	for i := range v {
+		if err := v[i].toImage(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
@@ -4,4 +4,13 @@ type Config struct {
 	Endpoint           string   `json:"endpoint"              required:"true"`
 	JobDoneStatus      []string `json:"job_done_status"       required:"true"`
 	CanTerminateStatus []string `json:"can_terminate_status"  required:"true"`
+
+	// JobSucceededStatus is the status of job which is done successfully.
+	JobSucceededStatus []string `json:"job_succeeded_status"`
+}
+
+func (cfg *Config) SetDefault() {
+	if len(cfg.JobSucceededStatus) == 0 {
+		cfg.JobSucceededStatus = []string{"Completed"}
+	}
 }
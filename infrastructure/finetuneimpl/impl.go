@@ -13,6 +13,7 @@ func NewFinetune(cfg *Config) finetune.Finetune {
 		cli:                sdk.New(cfg.Endpoint),
 		doneStatus:         sets.New[string](cfg.JobDoneStatus...),
 		canTerminateStatus: sets.New[string](cfg.CanTerminateStatus...),
+		succeededStatus:    sets.New[string](cfg.JobSucceededStatus...),
 	}
 }
 
@@ -20,18 +21,23 @@ type finetuneImpl struct {
 	cli                sdk.Finetune
 	doneStatus         sets.Set[string]
 	canTerminateStatus sets.Set[string]
+	succeededStatus    sets.Set[string]
 }
 
 func (impl *finetuneImpl) IsJobDone(status string) bool {
 	return impl.doneStatus.Has(status)
 }
 
+func (impl *finetuneImpl) IsJobSucceeded(status string) bool {
+	return impl.succeededStatus.Has(status)
+}
+
 func (impl *finetuneImpl) CanTerminate(status string) bool {
 	return impl.canTerminateStatus.Has(status)
 }
 
 func (impl *finetuneImpl) CreateJob(info *domain.FinetuneIndex, cfg *domain.FinetuneConfig) (
-	job domain.FinetuneJobInfo, err error,
+	job domain.JobInfo, err error,
 ) {
 	p := cfg.Param
 	opt := sdk.FinetuneCreateOption{
@@ -402,6 +402,54 @@ func (impl *repoFile) GetDirFileInfo(u *platform.UserInfo, info *platform.RepoDi
 	return
 }
 
+func (impl *repoFile) GetLastCommit(u *platform.UserInfo, info *platform.RepoDir) (
+	sha string, err error,
+) {
+	body := `
+{
+	"query":"query {
+		project(fullPath: \"%s\") {
+			repository {
+				tree(ref: \"%s\", path: \"%s\") {
+					lastCommit {
+						sha
+					},
+				},
+			}
+		}
+	}"
+}
+`
+	data := fmt.Sprintf(
+		body,
+		u.User.Account()+"/"+info.RepoName.ResourceName(),
+		defaultBranch, info.Path.Directory(),
+	)
+
+	data = strings.ReplaceAll(data, "\n", "")
+	data = strings.ReplaceAll(data, "\t", "")
+
+	req, err := http.NewRequest(http.MethodPost, graphqlEndpoint, strings.NewReader(data))
+	if err != nil {
+		return
+	}
+
+	h := &req.Header
+	if u.Token != "" {
+		h.Add("Authorization", "Bearer "+u.Token)
+	}
+	h.Add("Content-Type", "application/json")
+
+	v := graphqlResult{}
+	if _, err = impl.cli.ForwardTo(req, &v); err != nil {
+		return
+	}
+
+	sha = v.Data.Project.Repo.Tree.LastCommit.SHA
+
+	return
+}
+
 func (impl *repoFile) DownloadRepo(
 	u *platform.UserInfo, repoId string,
 	handle func(io.Reader, int64),
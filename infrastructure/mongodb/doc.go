@@ -62,6 +62,16 @@ const (
 	fieldChoices        = "choices"
 	fieldCompletions    = "completions"
 	fieldHardwareType   = "hardware_type"
+	fieldSweepId        = "sweep_id"
+	fieldTrials         = "trials"
+	fieldStep           = "step"
+	fieldSteps          = "steps"
+	fieldTrainingId     = "training_id"
+	fieldLineages       = "lineages"
+	fieldNextRunAt      = "next_run_at"
+	fieldInputsCommit   = "inputs_commit"
+	fieldHistory        = "history"
+	fieldCheckpoints    = "checkpoints"
 )
 
 type dProject struct {
@@ -145,6 +155,9 @@ type modelItem struct {
 	RelatedDatasets []ResourceIndex `bson:"datasets" json:"-"`
 	RelatedProjects []ResourceIndex `bson:"projects" json:"-"`
 
+	// Lineages is appended by publishing the output of training.
+	Lineages []dModelLineage `bson:"lineages" json:"-"`
+
 	// Version, LikeCount will be increased by 1 automatically.
 	// So, don't marshal it to avoid setting it occasionally.
 	Version       int `bson:"version"           json:"-"`
@@ -152,6 +165,12 @@ type modelItem struct {
 	DownloadCount int `bson:"download_count"    json:"-"`
 }
 
+type dModelLineage struct {
+	ProjectId   string `bson:"pid"           json:"pid"`
+	TrainingId  string `bson:"tid"           json:"tid"`
+	PublishedAt int64  `bson:"published_at"  json:"published_at"`
+}
+
 type ModelPropertyItem struct {
 	FL       byte     `bson:"fl"         json:"fl"`
 	Level    int      `bson:"level"      json:"level"`
@@ -319,9 +338,24 @@ type trainingItem struct {
 	EnableOutput    bool        `bson:"output"        json:"output"`
 	Env             []dKeyValue `bson:"env"           json:"env"`
 	Hyperparameters []dKeyValue `bson:"parameters"    json:"parameters"`
+	SweepId         string      `bson:"sweep_id"      json:"sweep_id,omitempty"`
 	CreatedAt       int64       `bson:"created_at"    json:"created_at"`
 	Job             dJobInfo    `bson:"job"           json:"-"`
 	JobDetail       dJobDetail  `bson:"detail"        json:"-"`
+
+	ResumedFrom *dTrainingResumption  `bson:"resumed_from" json:"resumed_from,omitempty"`
+	Checkpoints []dTrainingCheckpoint `bson:"checkpoints"  json:"-"`
+}
+
+type dTrainingResumption struct {
+	TrainingId string `bson:"training_id"  json:"training_id"`
+	Step       int    `bson:"step"         json:"step"`
+}
+
+type dTrainingCheckpoint struct {
+	Path      string `bson:"path"         json:"path"`
+	Step      int    `bson:"step"         json:"step"`
+	CreatedAt int64  `bson:"created_at"   json:"created_at"`
 }
 
 type dCompute struct {
@@ -330,6 +364,98 @@ type dCompute struct {
 	Version string `bson:"version"       json:"version"`
 }
 
+type dTrainingSweep struct {
+	Id          string            `bson:"id"           json:"id"`
+	Owner       string            `bson:"owner"        json:"owner"`
+	ProjectId   string            `bson:"pid"          json:"pid"`
+	ProjectName string            `bson:"name"         json:"name"`
+	RepoId      string            `bson:"rid"          json:"rid"`
+	Name        string            `bson:"sweep_name"   json:"sweep_name"`
+	Algorithm   string            `bson:"algorithm"    json:"algorithm"`
+	Params      []dSweepParameter `bson:"params"       json:"params"`
+	MetricName  string            `bson:"metric_name"  json:"metric_name"`
+	MetricGoal  string            `bson:"metric_goal"  json:"metric_goal"`
+	Parallelism int               `bson:"parallelism"  json:"parallelism"`
+	Config      trainingItem      `bson:"config"       json:"config"`
+	Trials      []dSweepTrial     `bson:"trials"       json:"trials"`
+	CreatedAt   int64             `bson:"created_at"   json:"created_at"`
+	Version     int               `bson:"version"      json:"-"`
+}
+
+type dSweepParameter struct {
+	Key    string   `bson:"key"     json:"key"`
+	Values []string `bson:"values"  json:"values,omitempty"`
+	Min    float64  `bson:"min"     json:"min"`
+	Max    float64  `bson:"max"     json:"max"`
+	Step   float64  `bson:"step"    json:"step"`
+}
+
+type dTrainingPipeline struct {
+	Id          string          `bson:"id"            json:"id"`
+	Owner       string          `bson:"owner"         json:"owner"`
+	ProjectId   string          `bson:"pid"           json:"pid"`
+	ProjectName string          `bson:"name"          json:"name"`
+	RepoId      string          `bson:"rid"           json:"rid"`
+	Name        string          `bson:"pipeline_name" json:"pipeline_name"`
+	Steps       []dPipelineStep `bson:"steps"         json:"steps"`
+	CreatedAt   int64           `bson:"created_at"    json:"created_at"`
+	Version     int             `bson:"version"       json:"-"`
+}
+
+type dPipelineStep struct {
+	Name       string                `bson:"name"          json:"name"`
+	Config     trainingItem          `bson:"config"        json:"config"`
+	DependsOn  []dPipelineDependency `bson:"depends_on"    json:"depends_on"`
+	MaxRetries int                   `bson:"max_retries"   json:"max_retries"`
+	Status     string                `bson:"status"        json:"status"`
+	Retries    int                   `bson:"retries"       json:"retries"`
+	TrainingId string                `bson:"training_id"   json:"training_id"`
+	OutputPath string                `bson:"output_path"   json:"output_path"`
+}
+
+type dPipelineDependency struct {
+	Step     string `bson:"step"       json:"step"`
+	InputKey string `bson:"input_key"  json:"input_key"`
+}
+
+type dTrainingSchedule struct {
+	Id           string         `bson:"id"            json:"id"`
+	Owner        string         `bson:"owner"         json:"owner"`
+	ProjectId    string         `bson:"pid"           json:"pid"`
+	ProjectName  string         `bson:"name"          json:"name"`
+	RepoId       string         `bson:"rid"           json:"rid"`
+	Cron         string         `bson:"cron"          json:"cron"`
+	Config       trainingItem   `bson:"config"        json:"config"`
+	CreatedAt    int64          `bson:"created_at"    json:"created_at"`
+	NextRunAt    int64          `bson:"next_run_at"   json:"next_run_at"`
+	InputsCommit string         `bson:"inputs_commit" json:"inputs_commit"`
+	History      []dScheduleRun `bson:"history"       json:"history"`
+	Version      int            `bson:"version"       json:"-"`
+}
+
+type dScheduleRun struct {
+	RunAt      int64  `bson:"run_at"       json:"run_at"`
+	Status     string `bson:"status"       json:"status"`
+	TrainingId string `bson:"training_id"  json:"training_id"`
+	Reason     string `bson:"reason"       json:"reason"`
+}
+
+type dTrainingMetric struct {
+	Owner      string  `bson:"owner"       json:"owner"`
+	ProjectId  string  `bson:"pid"         json:"pid"`
+	TrainingId string  `bson:"tid"         json:"tid"`
+	Name       string  `bson:"name"        json:"name"`
+	Step       int     `bson:"step"        json:"step"`
+	Value      float64 `bson:"value"       json:"value"`
+	CreatedAt  int64   `bson:"created_at"  json:"created_at"`
+}
+
+type dSweepTrial struct {
+	Name            string      `bson:"name"          json:"name"`
+	Hyperparameters []dKeyValue `bson:"parameters"    json:"parameters"`
+	TrainingId      string      `bson:"training_id"   json:"training_id"`
+}
+
 type dKeyValue struct {
 	Key   string `bson:"key"             json:"key"`
 	Value string `bson:"value"           json:"value"`
@@ -359,6 +485,8 @@ type dJobDetail struct {
 	LogPath    string `bson:"log"        json:"log,omitempty"`
 	AimPath    string `bson:"aim"        json:"aim,omitempty"`
 	OutputPath string `bson:"output"     json:"output,omitempty"`
+
+	Metrics map[string]float64 `bson:"metrics" json:"metrics,omitempty"`
 }
 
 type dInference struct {
@@ -264,4 +264,18 @@ func (col model) toModelDO(owner string, item *modelItem, do *repositories.Model
 		RelatedDatasets: toResourceIndexDO(item.RelatedDatasets),
 		RelatedProjects: toResourceIndexDO(item.RelatedProjects),
 	}
+
+	if n := len(item.Lineages); n > 0 {
+		do.Lineages = make([]repositories.ModelLineageDO, n)
+
+		for i := range item.Lineages {
+			v := &item.Lineages[i]
+
+			do.Lineages[i] = repositories.ModelLineageDO{
+				ProjectId:   v.ProjectId,
+				TrainingId:  v.TrainingId,
+				PublishedAt: v.PublishedAt,
+			}
+		}
+	}
 }
@@ -1,6 +1,11 @@
 package mongodb
 
 import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
 	"github.com/opensourceways/xihe-server/infrastructure/repositories"
 )
 
@@ -20,6 +25,39 @@ func (col model) AddRelatedDataset(do *repositories.RelatedResourceDO) error {
 	return updateRelatedResource(col.collectionName, fieldDatasets, true, do)
 }
 
+func (col model) AddLineage(
+	do *repositories.ResourceToUpdateDO, lineage *repositories.ModelLineageDO,
+) error {
+	doc := bson.M{
+		fieldLineages: dModelLineage{
+			ProjectId:   lineage.ProjectId,
+			TrainingId:  lineage.TrainingId,
+			PublishedAt: lineage.PublishedAt,
+		},
+	}
+
+	updated := false
+	f := func(ctx context.Context) (err error) {
+		updated, err = cli.pushNestedArrayElem(
+			ctx, col.collectionName, fieldItems,
+			resourceOwnerFilter(do.Owner), resourceIdFilter(do.Id), doc,
+			do.Version, do.UpdatedAt,
+		)
+
+		return
+	}
+
+	if err := withContext(f); err != nil {
+		return err
+	}
+
+	if !updated {
+		return repositories.NewErrorConcurrentUpdating(errors.New("no update"))
+	}
+
+	return nil
+}
+
 func (col model) RemoveRelatedDataset(do *repositories.RelatedResourceDO) error {
 	return updateRelatedResource(col.collectionName, fieldDatasets, false, do)
 }
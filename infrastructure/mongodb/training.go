@@ -109,6 +109,7 @@ func (col training) List(user, projectId string) ([]repositories.TrainingSummary
 				subfieldOfItems(fieldName):      1,
 				subfieldOfItems(fieldDesc):      1,
 				subfieldOfItems(fieldDetail):    1,
+				subfieldOfItems(fieldSweepId):   1,
 				subfieldOfItems(fieldCreatedAt): 1,
 			}, &v)
 	}
@@ -301,6 +302,7 @@ func (col training) UpdateJobDetail(info *repositories.TrainingIndexDO,
 		LogPath:    detail.LogPath,
 		AimPath:    detail.AimPath,
 		OutputPath: detail.OutputPath,
+		Metrics:    detail.Metrics,
 	}
 
 	doc, err := genDoc(v)
@@ -322,6 +324,31 @@ func (col training) UpdateJobDetail(info *repositories.TrainingIndexDO,
 	return withContext(f)
 }
 
+func (col training) AddCheckpoint(info *repositories.TrainingIndexDO,
+	cp *repositories.TrainingCheckpointDO) error {
+	doc, err := genDoc(dTrainingCheckpoint{
+		Path:      cp.Path,
+		Step:      cp.Step,
+		CreatedAt: cp.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.modifyArrayElemWithoutVersion(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(info.User, info.ProjectId),
+			resourceIdFilter(info.TrainingId),
+			bson.M{fieldCheckpoints: doc}, mongoCmdPush,
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
 func (col training) GetJobDetail(info *repositories.TrainingIndexDO) (
 	do repositories.TrainingJobDetailDO, endpoint string, err error,
 ) {
@@ -363,6 +390,8 @@ func (col training) toTrainingSummary(t *trainingItem, s *repositories.TrainingS
 		Error:     t.JobDetail.Error,
 		Status:    t.JobDetail.Status,
 		Duration:  t.JobDetail.Duration,
+		SweepId:   t.SweepId,
+		Metrics:   t.JobDetail.Metrics,
 		CreatedAt: t.CreatedAt,
 	}
 }
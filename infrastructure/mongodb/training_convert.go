@@ -7,19 +7,32 @@ import (
 )
 
 func (col training) toTrainingDoc(do *repositories.UserTrainingDO) (bson.M, error) {
-	cfg := &do.TrainingConfigDO
+	docObj := col.toTrainingItem(&do.TrainingConfigDO)
+	docObj.Id = do.Id
+	docObj.SweepId = do.SweepId
+	docObj.CreatedAt = do.CreatedAt
+
+	if r := &do.ResumedFrom; !r.IsEmpty() {
+		docObj.ResumedFrom = &dTrainingResumption{
+			TrainingId: r.TrainingId,
+			Step:       r.Step,
+		}
+	}
+
+	return genDoc(docObj)
+}
+
+func (col training) toTrainingItem(cfg *repositories.TrainingConfigDO) trainingItem {
 	c := &cfg.Compute
 
-	docObj := trainingItem{
-		Id:              do.Id,
+	return trainingItem{
 		Name:            cfg.Name,
 		Desc:            cfg.Desc,
 		CodeDir:         cfg.CodeDir,
 		BootFile:        cfg.BootFile,
-		CreatedAt:       do.CreatedAt,
 		Inputs:          col.toInputDoc(cfg.Inputs),
-		EnableAim:       do.EnableAim,
-		EnableOutput:    do.EnableOutput,
+		EnableAim:       cfg.EnableAim,
+		EnableOutput:    cfg.EnableOutput,
 		Env:             col.toKeyValueDoc(cfg.Env),
 		Hyperparameters: col.toKeyValueDoc(cfg.Hyperparameters),
 		Compute: dCompute{
@@ -28,7 +41,6 @@ func (col training) toTrainingDoc(do *repositories.UserTrainingDO) (bson.M, erro
 			Version: c.Version,
 		},
 	}
-	return genDoc(docObj)
 }
 
 func (col training) toKeyValueDoc(kv []repositories.KeyValueDO) []dKeyValue {
@@ -74,19 +86,44 @@ func (col training) toInputDoc(v []repositories.InputDO) []dInput {
 func (col training) toTrainingDetailDO(doc *dTraining, do *repositories.TrainingDetailDO) {
 	item := &doc.Items[0]
 
+	do.SweepId = item.SweepId
 	do.CreatedAt = item.CreatedAt
+
+	if r := item.ResumedFrom; r != nil {
+		do.ResumedFrom = repositories.TrainingResumptionDO{
+			TrainingId: r.TrainingId,
+			Step:       r.Step,
+		}
+	}
+
+	if n := len(item.Checkpoints); n > 0 {
+		do.Checkpoints = make([]repositories.TrainingCheckpointDO, n)
+		for i := range item.Checkpoints {
+			cp := &item.Checkpoints[i]
+
+			do.Checkpoints[i] = repositories.TrainingCheckpointDO{
+				Path:      cp.Path,
+				Step:      cp.Step,
+				CreatedAt: cp.CreatedAt,
+			}
+		}
+	}
 	col.toTrainingJobInfoDO(&item.Job, &do.Job)
 	col.toTrainingJobDetailDO(&item.JobDetail, &do.JobDetail)
 	col.toTrainingConfigDO(doc, &do.TrainingConfigDO)
 }
 
 func (col training) toTrainingConfigDO(doc *dTraining, do *repositories.TrainingConfigDO) {
-	item := &doc.Items[0]
+	col.toTrainingConfigDOOfItem(&doc.Items[0], do)
+
+	do.ProjectName = doc.ProjectName
+	do.ProjectRepoId = doc.ProjectRepoId
+}
+
+func (col training) toTrainingConfigDOOfItem(item *trainingItem, do *repositories.TrainingConfigDO) {
 	c := &item.Compute
 
 	*do = repositories.TrainingConfigDO{
-		ProjectName:     doc.ProjectName,
-		ProjectRepoId:   doc.ProjectRepoId,
 		Name:            item.Name,
 		Desc:            item.Desc,
 		CodeDir:         item.CodeDir,
@@ -162,5 +199,6 @@ func (col training) toTrainingJobDetailDO(doc *dJobDetail, do *repositories.Trai
 		LogPath:    doc.LogPath,
 		AimPath:    doc.AimPath,
 		OutputPath: doc.OutputPath,
+		Metrics:    doc.Metrics,
 	}
 }
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewTrainingMetricMapper(name string) repositories.TrainingMetricMapper {
+	return trainingMetric{name}
+}
+
+func trainingMetricFilter(info *repositories.TrainingIndexDO) bson.M {
+	return bson.M{
+		fieldOwner: info.User,
+		fieldPId:   info.ProjectId,
+		fieldTId:   info.TrainingId,
+	}
+}
+
+type trainingMetric struct {
+	collectionName string
+}
+
+func (col trainingMetric) Insert(
+	info *repositories.TrainingIndexDO, v []repositories.TrainingMetricDO,
+) error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	docs := make([]interface{}, len(v))
+	for i := range v {
+		docs[i] = dTrainingMetric{
+			Owner:      info.User,
+			ProjectId:  info.ProjectId,
+			TrainingId: info.TrainingId,
+			Name:       v[i].Name,
+			Step:       v[i].Step,
+			Value:      v[i].Value,
+			CreatedAt:  v[i].CreatedAt,
+		}
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.collection(col.collectionName).InsertMany(ctx, docs)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (col trainingMetric) List(
+	info *repositories.TrainingIndexDO, opt *repositories.TrainingMetricOptionDO,
+) ([]repositories.TrainingMetricDO, error) {
+	filter := trainingMetricFilter(info)
+
+	if opt != nil {
+		if len(opt.Names) > 0 {
+			filter[fieldName] = bson.M{"$in": opt.Names}
+		}
+
+		if opt.Since > 0 {
+			filter[fieldCreatedAt] = bson.M{"$gt": opt.Since}
+		}
+	}
+
+	var v []dTrainingMetric
+
+	f := func(ctx context.Context) error {
+		opts := options.FindOptions{}
+		opts.SetSort(bson.D{{Key: fieldName, Value: 1}, {Key: fieldStep, Value: 1}})
+
+		return cli.getDocs(ctx, col.collectionName, filter, &opts, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]repositories.TrainingMetricDO, len(v))
+	for i := range v {
+		r[i] = repositories.TrainingMetricDO{
+			Name:      v[i].Name,
+			Step:      v[i].Step,
+			Value:     v[i].Value,
+			CreatedAt: v[i].CreatedAt,
+		}
+	}
+
+	return r, nil
+}
+
+func (col trainingMetric) Delete(info *repositories.TrainingIndexDO) error {
+	f := func(ctx context.Context) error {
+		_, err := cli.collection(col.collectionName).DeleteMany(
+			ctx, trainingMetricFilter(info),
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
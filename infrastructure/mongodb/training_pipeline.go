@@ -0,0 +1,215 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewTrainingPipelineMapper(name string) repositories.TrainingPipelineMapper {
+	return trainingPipeline{name}
+}
+
+type trainingPipeline struct {
+	collectionName string
+}
+
+func (col trainingPipeline) Insert(do *repositories.TrainingPipelineDO) (identity string, err error) {
+	identity = newId()
+	do.Id = identity
+
+	doc, err := genDoc(col.toTrainingPipelineDoc(do))
+	if err != nil {
+		return
+	}
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, bson.M{fieldId: identity}, doc,
+		)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && isDocExists(err) {
+		err = repositories.NewErrorDuplicateCreating(err)
+	}
+
+	return
+}
+
+func (col trainingPipeline) Get(owner, id string) (
+	do repositories.TrainingPipelineDO, version int, err error,
+) {
+	return col.get(bson.M{fieldId: id, fieldOwner: owner})
+}
+
+func (col trainingPipeline) GetByTraining(info *repositories.TrainingIndexDO) (
+	do repositories.TrainingPipelineDO, version int, err error,
+) {
+	return col.get(bson.M{
+		fieldOwner:                         info.User,
+		fieldPId:                           info.ProjectId,
+		fieldSteps + "." + fieldTrainingId: info.TrainingId,
+	})
+}
+
+func (col trainingPipeline) get(filter bson.M) (
+	do repositories.TrainingPipelineDO, version int, err error,
+) {
+	var v dTrainingPipeline
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(ctx, col.collectionName, filter, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if isDocNotExists(err) {
+			err = repositories.NewErrorDataNotExists(err)
+		}
+
+		return
+	}
+
+	col.toTrainingPipelineDO(&v, &do)
+	version = v.Version
+
+	return
+}
+
+func (col trainingPipeline) List(owner, projectId string) (
+	[]repositories.TrainingPipelineDO, error,
+) {
+	var v []dTrainingPipeline
+
+	f := func(ctx context.Context) error {
+		opts := options.FindOptions{}
+		opts.SetSort(bson.M{fieldCreatedAt: -1})
+
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{fieldOwner: owner, fieldPId: projectId},
+			&opts, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]repositories.TrainingPipelineDO, len(v))
+	for i := range v {
+		col.toTrainingPipelineDO(&v[i], &r[i])
+	}
+
+	return r, nil
+}
+
+func (col trainingPipeline) UpdateSteps(
+	owner, id string, steps []repositories.PipelineStepDO, version int,
+) error {
+	v := col.toPipelineStepDocs(steps)
+
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName,
+			bson.M{fieldId: id, fieldOwner: owner},
+			bson.M{fieldSteps: v}, mongoCmdSet, version,
+		)
+	}
+
+	err := withContext(f)
+	if err != nil && isDocNotExists(err) {
+		err = repositories.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (col trainingPipeline) toTrainingPipelineDoc(do *repositories.TrainingPipelineDO) dTrainingPipeline {
+	doc := dTrainingPipeline{
+		Id:        do.Id,
+		Owner:     do.Owner,
+		ProjectId: do.ProjectId,
+		Name:      do.Name,
+		Steps:     col.toPipelineStepDocs(do.Steps),
+		CreatedAt: do.CreatedAt,
+	}
+
+	// all the steps are trainings of the same project
+	if len(do.Steps) > 0 {
+		doc.ProjectName = do.Steps[0].Config.ProjectName
+		doc.RepoId = do.Steps[0].Config.ProjectRepoId
+	}
+
+	return doc
+}
+
+func (col trainingPipeline) toPipelineStepDocs(v []repositories.PipelineStepDO) []dPipelineStep {
+	r := make([]dPipelineStep, len(v))
+	for i := range v {
+		s := &v[i]
+
+		deps := make([]dPipelineDependency, len(s.DependsOn))
+		for j, d := range s.DependsOn {
+			deps[j] = dPipelineDependency{
+				Step:     d.Step,
+				InputKey: d.InputKey,
+			}
+		}
+
+		r[i] = dPipelineStep{
+			Name:       s.Name,
+			Config:     training{}.toTrainingItem(&s.Config),
+			DependsOn:  deps,
+			MaxRetries: s.MaxRetries,
+			Status:     s.Status,
+			Retries:    s.Retries,
+			TrainingId: s.TrainingId,
+			OutputPath: s.OutputPath,
+		}
+	}
+
+	return r
+}
+
+func (col trainingPipeline) toTrainingPipelineDO(doc *dTrainingPipeline, do *repositories.TrainingPipelineDO) {
+	*do = repositories.TrainingPipelineDO{
+		Id:        doc.Id,
+		Owner:     doc.Owner,
+		ProjectId: doc.ProjectId,
+		Name:      doc.Name,
+		CreatedAt: doc.CreatedAt,
+	}
+
+	do.Steps = make([]repositories.PipelineStepDO, len(doc.Steps))
+	for i := range doc.Steps {
+		s := &doc.Steps[i]
+		v := &do.Steps[i]
+
+		*v = repositories.PipelineStepDO{
+			Name:       s.Name,
+			MaxRetries: s.MaxRetries,
+			Status:     s.Status,
+			Retries:    s.Retries,
+			TrainingId: s.TrainingId,
+			OutputPath: s.OutputPath,
+		}
+
+		training{}.toTrainingConfigDOOfItem(&s.Config, &v.Config)
+		v.Config.ProjectName = doc.ProjectName
+		v.Config.ProjectRepoId = doc.RepoId
+
+		v.DependsOn = make([]repositories.PipelineDependencyDO, len(s.DependsOn))
+		for j, d := range s.DependsOn {
+			v.DependsOn[j] = repositories.PipelineDependencyDO{
+				Step:     d.Step,
+				InputKey: d.InputKey,
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewTrainingScheduleMapper(name string) repositories.TrainingScheduleMapper {
+	return trainingSchedule{name}
+}
+
+type trainingSchedule struct {
+	collectionName string
+}
+
+func (col trainingSchedule) Insert(do *repositories.TrainingScheduleDO) (identity string, err error) {
+	identity = newId()
+	do.Id = identity
+
+	doc, err := genDoc(col.toTrainingScheduleDoc(do))
+	if err != nil {
+		return
+	}
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, bson.M{fieldId: identity}, doc,
+		)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && isDocExists(err) {
+		err = repositories.NewErrorDuplicateCreating(err)
+	}
+
+	return
+}
+
+func (col trainingSchedule) Get(owner, id string) (do repositories.TrainingScheduleDO, err error) {
+	var v dTrainingSchedule
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName,
+			bson.M{fieldId: id, fieldOwner: owner}, nil, &v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		if isDocNotExists(err) {
+			err = repositories.NewErrorDataNotExists(err)
+		}
+
+		return
+	}
+
+	col.toTrainingScheduleDO(&v, &do)
+
+	return
+}
+
+func (col trainingSchedule) List(owner, projectId string) (
+	[]repositories.TrainingScheduleDO, error,
+) {
+	return col.list(bson.M{fieldOwner: owner, fieldPId: projectId})
+}
+
+func (col trainingSchedule) ListDue(now int64) ([]repositories.TrainingScheduleDO, error) {
+	return col.list(bson.M{fieldNextRunAt: bson.M{"$gt": 0, "$lte": now}})
+}
+
+func (col trainingSchedule) list(filter bson.M) ([]repositories.TrainingScheduleDO, error) {
+	var v []dTrainingSchedule
+
+	f := func(ctx context.Context) error {
+		opts := options.FindOptions{}
+		opts.SetSort(bson.M{fieldCreatedAt: -1})
+
+		return cli.getDocs(ctx, col.collectionName, filter, &opts, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]repositories.TrainingScheduleDO, len(v))
+	for i := range v {
+		col.toTrainingScheduleDO(&v[i], &r[i])
+	}
+
+	return r, nil
+}
+
+func (col trainingSchedule) Delete(owner, id string) error {
+	f := func(ctx context.Context) error {
+		_, err := cli.collection(col.collectionName).DeleteOne(
+			ctx, bson.M{fieldId: id, fieldOwner: owner},
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (col trainingSchedule) UpdateRun(do *repositories.TrainingScheduleDO) error {
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName,
+			bson.M{fieldId: do.Id, fieldOwner: do.Owner},
+			bson.M{
+				fieldNextRunAt:    do.NextRunAt,
+				fieldInputsCommit: do.InputsCommit,
+				fieldHistory:      col.toScheduleRunDocs(do.History),
+			},
+			mongoCmdSet, do.Version,
+		)
+	}
+
+	err := withContext(f)
+	if err != nil && isDocNotExists(err) {
+		err = repositories.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (col trainingSchedule) toTrainingScheduleDoc(do *repositories.TrainingScheduleDO) dTrainingSchedule {
+	return dTrainingSchedule{
+		Id:           do.Id,
+		Owner:        do.Owner,
+		ProjectId:    do.ProjectId,
+		ProjectName:  do.Config.ProjectName,
+		RepoId:       do.Config.ProjectRepoId,
+		Cron:         do.Cron,
+		Config:       training{}.toTrainingItem(&do.Config),
+		CreatedAt:    do.CreatedAt,
+		NextRunAt:    do.NextRunAt,
+		InputsCommit: do.InputsCommit,
+		History:      col.toScheduleRunDocs(do.History),
+	}
+}
+
+func (col trainingSchedule) toScheduleRunDocs(v []repositories.ScheduleRunDO) []dScheduleRun {
+	r := make([]dScheduleRun, len(v))
+	for i := range v {
+		r[i] = dScheduleRun{
+			RunAt:      v[i].RunAt,
+			Status:     v[i].Status,
+			TrainingId: v[i].TrainingId,
+			Reason:     v[i].Reason,
+		}
+	}
+
+	return r
+}
+
+func (col trainingSchedule) toTrainingScheduleDO(doc *dTrainingSchedule, do *repositories.TrainingScheduleDO) {
+	*do = repositories.TrainingScheduleDO{
+		Id:           doc.Id,
+		Owner:        doc.Owner,
+		ProjectId:    doc.ProjectId,
+		Cron:         doc.Cron,
+		CreatedAt:    doc.CreatedAt,
+		NextRunAt:    doc.NextRunAt,
+		InputsCommit: doc.InputsCommit,
+		Version:      doc.Version,
+	}
+
+	training{}.toTrainingConfigDOOfItem(&doc.Config, &do.Config)
+	do.Config.ProjectName = doc.ProjectName
+	do.Config.ProjectRepoId = doc.RepoId
+
+	do.History = make([]repositories.ScheduleRunDO, len(doc.History))
+	for i := range doc.History {
+		h := &doc.History[i]
+
+		do.History[i] = repositories.ScheduleRunDO{
+			RunAt:      h.RunAt,
+			Status:     h.Status,
+			TrainingId: h.TrainingId,
+			Reason:     h.Reason,
+		}
+	}
+}
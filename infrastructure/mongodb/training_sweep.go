@@ -0,0 +1,205 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewTrainingSweepMapper(name string) repositories.TrainingSweepMapper {
+	return trainingSweep{name}
+}
+
+type trainingSweep struct {
+	collectionName string
+}
+
+func (col trainingSweep) Insert(do *repositories.TrainingSweepDO) (identity string, err error) {
+	identity = newId()
+	do.Id = identity
+
+	doc, err := genDoc(col.toTrainingSweepDoc(do))
+	if err != nil {
+		return
+	}
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, bson.M{fieldId: identity}, doc,
+		)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && isDocExists(err) {
+		err = repositories.NewErrorDuplicateCreating(err)
+	}
+
+	return
+}
+
+func (col trainingSweep) Get(owner, id string) (
+	do repositories.TrainingSweepDO, version int, err error,
+) {
+	var v dTrainingSweep
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName,
+			bson.M{fieldId: id, fieldOwner: owner}, nil, &v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		if isDocNotExists(err) {
+			err = repositories.NewErrorDataNotExists(err)
+		}
+
+		return
+	}
+
+	col.toTrainingSweepDO(&v, &do)
+	version = v.Version
+
+	return
+}
+
+func (col trainingSweep) List(owner, projectId string) (
+	[]repositories.TrainingSweepDO, error,
+) {
+	var v []dTrainingSweep
+
+	f := func(ctx context.Context) error {
+		opts := options.FindOptions{}
+		opts.SetSort(bson.M{fieldCreatedAt: -1})
+
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{fieldOwner: owner, fieldPId: projectId},
+			&opts, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]repositories.TrainingSweepDO, len(v))
+	for i := range v {
+		col.toTrainingSweepDO(&v[i], &r[i])
+	}
+
+	return r, nil
+}
+
+func (col trainingSweep) UpdateTrials(
+	owner, id string, trials []repositories.SweepTrialDO, version int,
+) error {
+	v := col.toSweepTrialDocs(trials)
+
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName,
+			bson.M{fieldId: id, fieldOwner: owner},
+			bson.M{fieldTrials: v}, mongoCmdSet, version,
+		)
+	}
+
+	err := withContext(f)
+	if err != nil && isDocNotExists(err) {
+		err = repositories.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (col trainingSweep) toTrainingSweepDoc(do *repositories.TrainingSweepDO) dTrainingSweep {
+	params := make([]dSweepParameter, len(do.Params))
+	for i := range do.Params {
+		p := &do.Params[i]
+
+		params[i] = dSweepParameter{
+			Key:    p.Key,
+			Values: p.Values,
+			Min:    p.Min,
+			Max:    p.Max,
+			Step:   p.Step,
+		}
+	}
+
+	return dTrainingSweep{
+		Id:          do.Id,
+		Owner:       do.Owner,
+		ProjectId:   do.ProjectId,
+		Name:        do.Name,
+		Algorithm:   do.Algorithm,
+		Params:      params,
+		MetricName:  do.MetricName,
+		MetricGoal:  do.MetricGoal,
+		Parallelism: do.Parallelism,
+		Config:      training{}.toTrainingItem(&do.Config),
+		ProjectName: do.Config.ProjectName,
+		RepoId:      do.Config.ProjectRepoId,
+		Trials:      col.toSweepTrialDocs(do.Trials),
+		CreatedAt:   do.CreatedAt,
+	}
+}
+
+func (col trainingSweep) toSweepTrialDocs(v []repositories.SweepTrialDO) []dSweepTrial {
+	r := make([]dSweepTrial, len(v))
+	for i := range v {
+		r[i] = dSweepTrial{
+			Name:            v[i].Name,
+			Hyperparameters: training{}.toKeyValueDoc(v[i].Hyperparameters),
+			TrainingId:      v[i].TrainingId,
+		}
+	}
+
+	return r
+}
+
+func (col trainingSweep) toTrainingSweepDO(doc *dTrainingSweep, do *repositories.TrainingSweepDO) {
+	*do = repositories.TrainingSweepDO{
+		Id:          doc.Id,
+		Owner:       doc.Owner,
+		ProjectId:   doc.ProjectId,
+		Name:        doc.Name,
+		Algorithm:   doc.Algorithm,
+		MetricName:  doc.MetricName,
+		MetricGoal:  doc.MetricGoal,
+		Parallelism: doc.Parallelism,
+		CreatedAt:   doc.CreatedAt,
+	}
+
+	training{}.toTrainingConfigDOOfItem(&doc.Config, &do.Config)
+	do.Config.ProjectName = doc.ProjectName
+	do.Config.ProjectRepoId = doc.RepoId
+
+	do.Params = make([]repositories.SweepParameterDO, len(doc.Params))
+	for i := range doc.Params {
+		p := &doc.Params[i]
+
+		do.Params[i] = repositories.SweepParameterDO{
+			Key:    p.Key,
+			Values: p.Values,
+			Min:    p.Min,
+			Max:    p.Max,
+			Step:   p.Step,
+		}
+	}
+
+	do.Trials = make([]repositories.SweepTrialDO, len(doc.Trials))
+	for i := range doc.Trials {
+		t := &doc.Trials[i]
+
+		do.Trials[i] = repositories.SweepTrialDO{
+			Name:            t.Name,
+			Hyperparameters: training{}.toKeyValues(t.Hyperparameters),
+			TrainingId:      t.TrainingId,
+		}
+	}
+}
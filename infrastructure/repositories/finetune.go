@@ -109,7 +109,7 @@ func (impl finetuneImpl) GetJob(index *domain.FinetuneIndex) (domain.FinetuneJob
 }
 
 func (impl finetuneImpl) SaveJob(
-	index *domain.FinetuneIndex, job *domain.FinetuneJobInfo,
+	index *domain.FinetuneIndex, job *domain.JobInfo,
 ) error {
 	do := impl.toFinetuneIndexDO(index)
 
@@ -121,7 +121,7 @@ func (impl finetuneImpl) SaveJob(
 }
 
 func (impl finetuneImpl) UpdateJobDetail(
-	index *domain.FinetuneIndex, job *domain.FinetuneJobDetail,
+	index *domain.FinetuneIndex, job *domain.JobDetail,
 ) error {
 	do := impl.toFinetuneIndexDO(index)
 
@@ -62,8 +62,8 @@ func (do *FinetuneConfigDO) toFinetuneConfig(cfg *domain.FinetuneConfig) (err er
 }
 
 type FinetuneJobDO = domain.FinetuneJob
-type FinetuneJobInfoDO = domain.FinetuneJobInfo
-type FinetuneJobDetailDO = domain.FinetuneJobDetail
+type FinetuneJobInfoDO = domain.JobInfo
+type FinetuneJobDetailDO = domain.JobDetail
 
 type FinetuneDetailDO struct {
 	Id        string
@@ -104,7 +104,7 @@ func (do *FinetuneSummaryDO) toFinetuneSummary(obj *domain.FinetuneSummary) (err
 
 	obj.Id = do.Id
 	obj.CreatedAt = do.CreatedAt
-	obj.FinetuneJobDetail = do.FinetuneJobDetailDO
+	obj.JobDetail = do.FinetuneJobDetailDO
 
 	return
 }
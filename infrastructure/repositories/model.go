@@ -41,6 +41,7 @@ type ModelMapper interface {
 	RemoveRelatedProject(*ReverselyRelatedResourceInfoDO) error
 
 	UpdateProperty(*ModelPropertyDO) error
+	AddLineage(*ResourceToUpdateDO, *ModelLineageDO) error
 }
 
 func NewModelRepository(mapper ModelMapper) repository.Model {
@@ -224,8 +225,12 @@ type ModelDO struct {
 
 	RelatedDatasets []ResourceIndexDO
 	RelatedProjects []ResourceIndexDO
+
+	Lineages []ModelLineageDO
 }
 
+type ModelLineageDO = domain.ModelLineage
+
 func (do *ModelDO) toModel(r *domain.Model) (err error) {
 	r.Id = do.Id
 
@@ -264,6 +269,7 @@ func (do *ModelDO) toModel(r *domain.Model) (err error) {
 	r.RepoId = do.RepoId
 	r.Tags = do.Tags
 	r.TagKinds = do.TagKinds
+	r.Lineages = do.Lineages
 	r.Version = do.Version
 	r.CreatedAt = do.CreatedAt
 	r.UpdatedAt = do.UpdatedAt
@@ -42,6 +42,16 @@ func (impl model) AddRelatedDataset(info *repository.RelatedResourceInfo) error
 	return nil
 }
 
+func (impl model) AddLineage(info *repository.ModelLineageInfo) error {
+	do := ToResourceToUpdateDO(&info.ResourceToUpdate)
+
+	if err := impl.mapper.AddLineage(&do, &info.Lineage); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
 func (impl model) RemoveRelatedDataset(info *repository.RelatedResourceInfo) error {
 	do := ToRelatedResourceDO(info)
 
@@ -18,6 +18,7 @@ type TrainingMapper interface {
 	GetJobInfo(*TrainingIndexDO) (TrainingJobInfoDO, error)
 	UpdateJobDetail(*TrainingIndexDO, *TrainingJobDetailDO) error
 	GetJobDetail(*TrainingIndexDO) (TrainingJobDetailDO, string, error)
+	AddCheckpoint(*TrainingIndexDO, *TrainingCheckpointDO) error
 }
 
 func NewTrainingRepository(mapper TrainingMapper) repository.Training {
@@ -154,3 +155,14 @@ func (impl training) UpdateJobDetail(info *domain.TrainingIndex, detail *domain.
 
 	return nil
 }
+
+func (impl training) AddCheckpoint(info *domain.TrainingIndex, cp *domain.TrainingCheckpoint) error {
+	do := impl.toTrainingIndexDO(info)
+	v := impl.toTrainingCheckpointDO(cp)
+
+	if err := impl.mapper.AddCheckpoint(&do, &v); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
@@ -9,7 +9,9 @@ type UserTrainingDO struct {
 
 	TrainingConfigDO
 
-	CreatedAt int64
+	SweepId     string
+	ResumedFrom TrainingResumptionDO
+	CreatedAt   int64
 }
 
 type TrainingConfigDO struct {
@@ -165,7 +167,14 @@ func (do *InputDO) toInput() (r domain.Input, err error) {
 	}
 
 	r.RepoId = do.RepoId
-	if r.File, err = domain.NewInputeFilePath(do.File); err != nil {
+
+	// the input of project type is the output of other training
+	if r.Type.ResourceType() == domain.ResourceTypeProject.ResourceType() {
+		r.File, err = domain.NewTrainingOutputFilePath(do.File)
+	} else {
+		r.File, err = domain.NewInputeFilePath(do.File)
+	}
+	if err != nil {
 		return
 	}
 
@@ -181,39 +190,44 @@ func (do *InputDO) toInput() (r domain.Input, err error) {
 }
 
 func (impl training) toUserTrainingDO(ut *domain.UserTraining) UserTrainingDO {
-	t := &ut.TrainingConfig
+	return UserTrainingDO{
+		Id:          ut.Id,
+		Owner:       ut.Owner.Account(),
+		ProjectId:   ut.ProjectId,
+		SweepId:     ut.SweepId,
+		ResumedFrom: ut.ResumedFrom,
+		CreatedAt:   ut.CreatedAt,
+
+		TrainingConfigDO: impl.toTrainingConfigDO(&ut.TrainingConfig),
+	}
+}
+
+func (impl training) toTrainingConfigDO(t *domain.TrainingConfig) TrainingConfigDO {
 	c := &t.Compute
 
-	do := UserTrainingDO{
-		Id:        ut.Id,
-		Owner:     ut.Owner.Account(),
-		ProjectId: ut.ProjectId,
-		CreatedAt: ut.CreatedAt,
-
-		TrainingConfigDO: TrainingConfigDO{
-			Name:          t.Name.TrainingName(),
-			ProjectName:   t.ProjectName.ResourceName(),
-			ProjectRepoId: t.ProjectRepoId,
-
-			CodeDir:  t.CodeDir.Directory(),
-			BootFile: t.BootFile.FilePath(),
-
-			Hyperparameters: impl.toKeyValueDOs(t.Hyperparameters),
-			Env:             impl.toKeyValueDOs(t.Env),
-			Inputs:          impl.toInputDOs(t.Inputs),
-			EnableAim:       t.EnableAim,
-			EnableOutput:    t.EnableOutput,
-
-			Compute: ComputeDO{
-				Type:    c.Type.ComputeType(),
-				Flavor:  c.Flavor.ComputeFlavor(),
-				Version: c.Version.ComputeVersion(),
-			},
+	do := TrainingConfigDO{
+		Name:          t.Name.TrainingName(),
+		ProjectName:   t.ProjectName.ResourceName(),
+		ProjectRepoId: t.ProjectRepoId,
+
+		CodeDir:  t.CodeDir.Directory(),
+		BootFile: t.BootFile.FilePath(),
+
+		Hyperparameters: impl.toKeyValueDOs(t.Hyperparameters),
+		Env:             impl.toKeyValueDOs(t.Env),
+		Inputs:          impl.toInputDOs(t.Inputs),
+		EnableAim:       t.EnableAim,
+		EnableOutput:    t.EnableOutput,
+
+		Compute: ComputeDO{
+			Type:    c.Type.ComputeType(),
+			Flavor:  c.Flavor.ComputeFlavor(),
+			Version: c.Version.ComputeVersion(),
 		},
 	}
 
 	if t.Desc != nil {
-		do.TrainingConfigDO.Desc = t.Desc.TrainingDesc()
+		do.Desc = t.Desc.TrainingDesc()
 	}
 
 	return do
@@ -269,6 +283,8 @@ type TrainingSummaryDO struct {
 	Error     string
 	Status    string
 	Duration  int
+	SweepId   string
+	Metrics   map[string]float64
 	CreatedAt int64
 }
 
@@ -287,6 +303,8 @@ func (do *TrainingSummaryDO) toTrainingSummary(t *domain.TrainingSummary) (
 	t.Error = do.Error
 	t.Status = do.Status
 	t.Duration = do.Duration
+	t.SweepId = do.SweepId
+	t.Metrics = do.Metrics
 	t.CreatedAt = do.CreatedAt
 
 	return
@@ -309,12 +327,42 @@ func (impl training) toTrainingIndexDO(info *domain.TrainingIndex) TrainingIndex
 type TrainingJobInfoDO = domain.JobInfo
 type TrainingJobDetailDO = domain.JobDetail
 
+type TrainingResumptionDO = domain.TrainingResumption
+
+type TrainingCheckpointDO struct {
+	Path      string
+	Step      int
+	CreatedAt int64
+}
+
+func (impl training) toTrainingCheckpointDO(cp *domain.TrainingCheckpoint) TrainingCheckpointDO {
+	return TrainingCheckpointDO{
+		Path:      cp.Path.InputeFilePath(),
+		Step:      cp.Step,
+		CreatedAt: cp.CreatedAt,
+	}
+}
+
+func (do *TrainingCheckpointDO) toTrainingCheckpoint() (r domain.TrainingCheckpoint, err error) {
+	if r.Path, err = domain.NewTrainingOutputFilePath(do.Path); err != nil {
+		return
+	}
+
+	r.Step = do.Step
+	r.CreatedAt = do.CreatedAt
+
+	return
+}
+
 type TrainingDetailDO struct {
 	TrainingConfigDO
 
-	Job       TrainingJobInfoDO
-	JobDetail TrainingJobDetailDO
-	CreatedAt int64
+	Job         TrainingJobInfoDO
+	JobDetail   TrainingJobDetailDO
+	SweepId     string
+	ResumedFrom TrainingResumptionDO
+	Checkpoints []TrainingCheckpointDO
+	CreatedAt   int64
 }
 
 func (do *TrainingDetailDO) toUserTraining(
@@ -324,8 +372,19 @@ func (do *TrainingDetailDO) toUserTraining(
 		return
 	}
 
+	if n := len(do.Checkpoints); n > 0 {
+		ut.Checkpoints = make([]domain.TrainingCheckpoint, n)
+		for i := range do.Checkpoints {
+			if ut.Checkpoints[i], err = do.Checkpoints[i].toTrainingCheckpoint(); err != nil {
+				return
+			}
+		}
+	}
+
 	ut.Job = do.Job
 	ut.JobDetail = do.JobDetail
+	ut.SweepId = do.SweepId
+	ut.ResumedFrom = do.ResumedFrom
 	ut.CreatedAt = do.CreatedAt
 
 	ut.Id = index.TrainingId
@@ -0,0 +1,83 @@
+package repositories
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type TrainingMetricMapper interface {
+	Insert(*TrainingIndexDO, []TrainingMetricDO) error
+	List(*TrainingIndexDO, *TrainingMetricOptionDO) ([]TrainingMetricDO, error)
+	Delete(*TrainingIndexDO) error
+}
+
+func NewTrainingMetricRepository(mapper TrainingMetricMapper) repository.TrainingMetric {
+	return trainingMetric{mapper}
+}
+
+type trainingMetric struct {
+	mapper TrainingMetricMapper
+}
+
+func (impl trainingMetric) Add(info *domain.TrainingIndex, v []domain.TrainingMetric) error {
+	do := training{}.toTrainingIndexDO(info)
+
+	items := make([]TrainingMetricDO, len(v))
+	for i := range v {
+		items[i] = TrainingMetricDO{
+			Name:      v[i].Name.MetricName(),
+			Step:      v[i].Step,
+			Value:     v[i].Value,
+			CreatedAt: v[i].CreatedAt,
+		}
+	}
+
+	return convertError(impl.mapper.Insert(&do, items))
+}
+
+func (impl trainingMetric) Find(
+	info *domain.TrainingIndex, opt *repository.TrainingMetricOption,
+) ([]domain.TrainingMetric, error) {
+	do := training{}.toTrainingIndexDO(info)
+
+	v, err := impl.mapper.List(&do, opt)
+	if err != nil || len(v) == 0 {
+		return nil, convertError(err)
+	}
+
+	r := make([]domain.TrainingMetric, len(v))
+	for i := range v {
+		if err = v[i].toTrainingMetric(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (impl trainingMetric) Delete(info *domain.TrainingIndex) error {
+	do := training{}.toTrainingIndexDO(info)
+
+	return convertError(impl.mapper.Delete(&do))
+}
+
+type TrainingMetricOptionDO = repository.TrainingMetricOption
+
+type TrainingMetricDO struct {
+	Name      string
+	Step      int
+	Value     float64
+	CreatedAt int64
+}
+
+func (do *TrainingMetricDO) toTrainingMetric(r *domain.TrainingMetric) (err error) {
+	if r.Name, err = domain.NewMetricName(do.Name); err != nil {
+		return
+	}
+
+	r.Step = do.Step
+	r.Value = do.Value
+	r.CreatedAt = do.CreatedAt
+
+	return
+}
@@ -0,0 +1,210 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type TrainingPipelineMapper interface {
+	Insert(*TrainingPipelineDO) (string, error)
+	Get(owner, id string) (TrainingPipelineDO, int, error)
+	GetByTraining(*TrainingIndexDO) (TrainingPipelineDO, int, error)
+	List(owner, projectId string) ([]TrainingPipelineDO, error)
+	UpdateSteps(owner, id string, steps []PipelineStepDO, version int) error
+}
+
+func NewTrainingPipelineRepository(mapper TrainingPipelineMapper) repository.TrainingPipeline {
+	return trainingPipeline{mapper}
+}
+
+type trainingPipeline struct {
+	mapper TrainingPipelineMapper
+}
+
+func (impl trainingPipeline) Save(p *domain.TrainingPipeline) (string, error) {
+	if p.Id != "" {
+		return "", errors.New("must be a new pipeline")
+	}
+
+	do := impl.toTrainingPipelineDO(p)
+
+	v, err := impl.mapper.Insert(&do)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return v, nil
+}
+
+func (impl trainingPipeline) Get(user domain.Account, id string) (
+	r domain.TrainingPipeline, version int, err error,
+) {
+	v, version, err := impl.mapper.Get(user.Account(), id)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toTrainingPipeline(&r)
+	}
+
+	return
+}
+
+func (impl trainingPipeline) GetByTraining(info *domain.TrainingIndex) (
+	r domain.TrainingPipeline, version int, err error,
+) {
+	do := training{}.toTrainingIndexDO(info)
+
+	v, version, err := impl.mapper.GetByTraining(&do)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toTrainingPipeline(&r)
+	}
+
+	return
+}
+
+func (impl trainingPipeline) List(user domain.Account, projectId string) (
+	r []domain.TrainingPipeline, err error,
+) {
+	v, err := impl.mapper.List(user.Account(), projectId)
+	if err != nil || len(v) == 0 {
+		return nil, convertError(err)
+	}
+
+	r = make([]domain.TrainingPipeline, len(v))
+	for i := range v {
+		if err = v[i].toTrainingPipeline(&r[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (impl trainingPipeline) UpdateSteps(p *domain.TrainingPipeline, version int) error {
+	err := impl.mapper.UpdateSteps(
+		p.Owner.Account(), p.Id, impl.toPipelineStepDOs(p.Steps), version,
+	)
+
+	return convertError(err)
+}
+
+func (impl trainingPipeline) toTrainingPipelineDO(p *domain.TrainingPipeline) TrainingPipelineDO {
+	return TrainingPipelineDO{
+		Owner:     p.Owner.Account(),
+		ProjectId: p.ProjectId,
+		Name:      p.Name.TrainingName(),
+		Steps:     impl.toPipelineStepDOs(p.Steps),
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func (impl trainingPipeline) toPipelineStepDOs(v []domain.PipelineStep) []PipelineStepDO {
+	r := make([]PipelineStepDO, len(v))
+	for i := range v {
+		s := &v[i]
+
+		deps := make([]PipelineDependencyDO, len(s.DependsOn))
+		for j, d := range s.DependsOn {
+			deps[j] = PipelineDependencyDO{
+				Step:     d.Step.TrainingName(),
+				InputKey: d.InputKey.CustomizedKey(),
+			}
+		}
+
+		r[i] = PipelineStepDO{
+			Name:       s.Name.TrainingName(),
+			Config:     training{}.toTrainingConfigDO(&s.Config),
+			DependsOn:  deps,
+			MaxRetries: s.MaxRetries,
+			Status:     s.Status,
+			Retries:    s.Retries,
+			TrainingId: s.TrainingId,
+			OutputPath: s.OutputPath,
+		}
+	}
+
+	return r
+}
+
+type TrainingPipelineDO struct {
+	Id        string
+	Owner     string
+	ProjectId string
+	Name      string
+	Steps     []PipelineStepDO
+	CreatedAt int64
+}
+
+type PipelineStepDO struct {
+	Name       string
+	Config     TrainingConfigDO
+	DependsOn  []PipelineDependencyDO
+	MaxRetries int
+	Status     string
+	Retries    int
+	TrainingId string
+	OutputPath string
+}
+
+type PipelineDependencyDO struct {
+	Step     string
+	InputKey string
+}
+
+func (do *TrainingPipelineDO) toTrainingPipeline(p *domain.TrainingPipeline) (err error) {
+	if p.Owner, err = domain.NewAccount(do.Owner); err != nil {
+		return
+	}
+
+	if p.Name, err = domain.NewTrainingName(do.Name); err != nil {
+		return
+	}
+
+	p.Steps = make([]domain.PipelineStep, len(do.Steps))
+	for i := range do.Steps {
+		if err = do.Steps[i].toPipelineStep(&p.Steps[i]); err != nil {
+			return
+		}
+	}
+
+	p.Id = do.Id
+	p.ProjectId = do.ProjectId
+	p.CreatedAt = do.CreatedAt
+
+	return
+}
+
+func (do *PipelineStepDO) toPipelineStep(s *domain.PipelineStep) (err error) {
+	if s.Name, err = domain.NewTrainingName(do.Name); err != nil {
+		return
+	}
+
+	if s.Config, err = do.Config.toTrainingConfig(); err != nil {
+		return
+	}
+
+	s.DependsOn = make([]domain.PipelineDependency, len(do.DependsOn))
+	for i, d := range do.DependsOn {
+		v := &s.DependsOn[i]
+
+		if v.Step, err = domain.NewTrainingName(d.Step); err != nil {
+			return
+		}
+
+		if v.InputKey, err = domain.NewCustomizedKey(d.InputKey); err != nil {
+			return
+		}
+	}
+
+	s.MaxRetries = do.MaxRetries
+	s.Status = do.Status
+	s.Retries = do.Retries
+	s.TrainingId = do.TrainingId
+	s.OutputPath = do.OutputPath
+
+	return
+}
@@ -0,0 +1,154 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type TrainingScheduleMapper interface {
+	Insert(*TrainingScheduleDO) (string, error)
+	Get(owner, id string) (TrainingScheduleDO, error)
+	List(owner, projectId string) ([]TrainingScheduleDO, error)
+	Delete(owner, id string) error
+	ListDue(now int64) ([]TrainingScheduleDO, error)
+	UpdateRun(*TrainingScheduleDO) error
+}
+
+func NewTrainingScheduleRepository(mapper TrainingScheduleMapper) repository.TrainingSchedule {
+	return trainingSchedule{mapper}
+}
+
+type trainingSchedule struct {
+	mapper TrainingScheduleMapper
+}
+
+func (impl trainingSchedule) Save(s *domain.TrainingSchedule) (string, error) {
+	if s.Id != "" {
+		return "", errors.New("must be a new schedule")
+	}
+
+	do := impl.toTrainingScheduleDO(s)
+
+	v, err := impl.mapper.Insert(&do)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return v, nil
+}
+
+func (impl trainingSchedule) Get(user domain.Account, id string) (
+	r domain.TrainingSchedule, err error,
+) {
+	v, err := impl.mapper.Get(user.Account(), id)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toTrainingSchedule(&r)
+	}
+
+	return
+}
+
+func (impl trainingSchedule) List(user domain.Account, projectId string) (
+	[]domain.TrainingSchedule, error,
+) {
+	v, err := impl.mapper.List(user.Account(), projectId)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return impl.toTrainingSchedules(v)
+}
+
+func (impl trainingSchedule) Delete(user domain.Account, id string) error {
+	return convertError(impl.mapper.Delete(user.Account(), id))
+}
+
+func (impl trainingSchedule) FindDue(now int64) ([]domain.TrainingSchedule, error) {
+	v, err := impl.mapper.ListDue(now)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return impl.toTrainingSchedules(v)
+}
+
+func (impl trainingSchedule) UpdateRun(s *domain.TrainingSchedule) error {
+	do := impl.toTrainingScheduleDO(s)
+
+	return convertError(impl.mapper.UpdateRun(&do))
+}
+
+func (impl trainingSchedule) toTrainingSchedules(v []TrainingScheduleDO) (
+	r []domain.TrainingSchedule, err error,
+) {
+	if len(v) == 0 {
+		return
+	}
+
+	r = make([]domain.TrainingSchedule, len(v))
+	for i := range v {
+		if err = v[i].toTrainingSchedule(&r[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (impl trainingSchedule) toTrainingScheduleDO(s *domain.TrainingSchedule) TrainingScheduleDO {
+	return TrainingScheduleDO{
+		Id:           s.Id,
+		Owner:        s.Owner.Account(),
+		ProjectId:    s.ProjectId,
+		Cron:         s.Cron.CronExpr(),
+		Config:       training{}.toTrainingConfigDO(&s.Config),
+		CreatedAt:    s.CreatedAt,
+		NextRunAt:    s.NextRunAt,
+		InputsCommit: s.InputsCommit,
+		History:      s.History,
+		Version:      s.Version,
+	}
+}
+
+type TrainingScheduleDO struct {
+	Id           string
+	Owner        string
+	ProjectId    string
+	Cron         string
+	Config       TrainingConfigDO
+	CreatedAt    int64
+	NextRunAt    int64
+	InputsCommit string
+	History      []ScheduleRunDO
+	Version      int
+}
+
+type ScheduleRunDO = domain.ScheduleRun
+
+func (do *TrainingScheduleDO) toTrainingSchedule(s *domain.TrainingSchedule) (err error) {
+	if s.Owner, err = domain.NewAccount(do.Owner); err != nil {
+		return
+	}
+
+	if s.Cron, err = domain.NewCronExpr(do.Cron); err != nil {
+		return
+	}
+
+	if s.Config, err = do.Config.toTrainingConfig(); err != nil {
+		return
+	}
+
+	s.Id = do.Id
+	s.ProjectId = do.ProjectId
+	s.CreatedAt = do.CreatedAt
+	s.NextRunAt = do.NextRunAt
+	s.InputsCommit = do.InputsCommit
+	s.History = do.History
+	s.Version = do.Version
+
+	return
+}
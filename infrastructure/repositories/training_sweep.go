@@ -0,0 +1,225 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type TrainingSweepMapper interface {
+	Insert(*TrainingSweepDO) (string, error)
+	Get(owner, id string) (TrainingSweepDO, int, error)
+	List(owner, projectId string) ([]TrainingSweepDO, error)
+	UpdateTrials(owner, id string, trials []SweepTrialDO, version int) error
+}
+
+func NewTrainingSweepRepository(mapper TrainingSweepMapper) repository.TrainingSweep {
+	return trainingSweep{mapper}
+}
+
+type trainingSweep struct {
+	mapper TrainingSweepMapper
+}
+
+func (impl trainingSweep) Save(s *domain.TrainingSweep) (string, error) {
+	if s.Id != "" {
+		return "", errors.New("must be a new sweep")
+	}
+
+	do := impl.toTrainingSweepDO(s)
+
+	v, err := impl.mapper.Insert(&do)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return v, nil
+}
+
+func (impl trainingSweep) Get(user domain.Account, id string) (
+	r domain.TrainingSweep, version int, err error,
+) {
+	v, version, err := impl.mapper.Get(user.Account(), id)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toTrainingSweep(&r)
+	}
+
+	return
+}
+
+func (impl trainingSweep) List(user domain.Account, projectId string) (
+	r []domain.TrainingSweep, err error,
+) {
+	v, err := impl.mapper.List(user.Account(), projectId)
+	if err != nil || len(v) == 0 {
+		return nil, convertError(err)
+	}
+
+	r = make([]domain.TrainingSweep, len(v))
+	for i := range v {
+		if err = v[i].toTrainingSweep(&r[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (impl trainingSweep) UpdateTrials(s *domain.TrainingSweep, version int) error {
+	err := impl.mapper.UpdateTrials(
+		s.Owner.Account(), s.Id, impl.toSweepTrialDOs(s.Trials), version,
+	)
+
+	return convertError(err)
+}
+
+func (impl trainingSweep) toTrainingSweepDO(s *domain.TrainingSweep) TrainingSweepDO {
+	params := make([]SweepParameterDO, len(s.Params))
+	for i := range s.Params {
+		p := &s.Params[i]
+
+		params[i] = SweepParameterDO{
+			Key:  p.Key.CustomizedKey(),
+			Min:  p.Min,
+			Max:  p.Max,
+			Step: p.Step,
+		}
+
+		for _, v := range p.Values {
+			params[i].Values = append(params[i].Values, v.CustomizedValue())
+		}
+	}
+
+	return TrainingSweepDO{
+		Owner:       s.Owner.Account(),
+		ProjectId:   s.ProjectId,
+		Name:        s.Name.TrainingName(),
+		Algorithm:   s.Algorithm.SweepAlgorithm(),
+		Params:      params,
+		MetricName:  s.Metric.Name,
+		MetricGoal:  s.Metric.Goal.MetricGoal(),
+		Parallelism: s.Parallelism,
+		Config:      training{}.toTrainingConfigDO(&s.Config),
+		Trials:      impl.toSweepTrialDOs(s.Trials),
+		CreatedAt:   s.CreatedAt,
+	}
+}
+
+func (impl trainingSweep) toSweepTrialDOs(v []domain.SweepTrial) []SweepTrialDO {
+	r := make([]SweepTrialDO, len(v))
+	for i := range v {
+		r[i] = SweepTrialDO{
+			Name:            v[i].Name.TrainingName(),
+			Hyperparameters: training{}.toKeyValueDOs(v[i].Hyperparameters),
+			TrainingId:      v[i].TrainingId,
+		}
+	}
+
+	return r
+}
+
+type TrainingSweepDO struct {
+	Id          string
+	Owner       string
+	ProjectId   string
+	Name        string
+	Algorithm   string
+	Params      []SweepParameterDO
+	MetricName  string
+	MetricGoal  string
+	Parallelism int
+	Config      TrainingConfigDO
+	Trials      []SweepTrialDO
+	CreatedAt   int64
+}
+
+type SweepParameterDO struct {
+	Key    string
+	Values []string
+	Min    float64
+	Max    float64
+	Step   float64
+}
+
+type SweepTrialDO struct {
+	Name            string
+	Hyperparameters []KeyValueDO
+	TrainingId      string
+}
+
+func (do *TrainingSweepDO) toTrainingSweep(s *domain.TrainingSweep) (err error) {
+	if s.Owner, err = domain.NewAccount(do.Owner); err != nil {
+		return
+	}
+
+	if s.Name, err = domain.NewTrainingName(do.Name); err != nil {
+		return
+	}
+
+	if s.Algorithm, err = domain.NewSweepAlgorithm(do.Algorithm); err != nil {
+		return
+	}
+
+	if s.Metric.Goal, err = domain.NewMetricGoal(do.MetricGoal); err != nil {
+		return
+	}
+
+	if s.Config, err = do.Config.toTrainingConfig(); err != nil {
+		return
+	}
+
+	s.Params = make([]domain.SweepParameter, len(do.Params))
+	for i := range do.Params {
+		if err = do.Params[i].toSweepParameter(&s.Params[i]); err != nil {
+			return
+		}
+	}
+
+	s.Trials = make([]domain.SweepTrial, len(do.Trials))
+	for i := range do.Trials {
+		t := &s.Trials[i]
+
+		if t.Name, err = domain.NewTrainingName(do.Trials[i].Name); err != nil {
+			return
+		}
+
+		if t.Hyperparameters, err = do.Config.toKeyValues(do.Trials[i].Hyperparameters); err != nil {
+			return
+		}
+
+		t.TrainingId = do.Trials[i].TrainingId
+	}
+
+	s.Id = do.Id
+	s.ProjectId = do.ProjectId
+	s.Metric.Name = do.MetricName
+	s.Parallelism = do.Parallelism
+	s.CreatedAt = do.CreatedAt
+
+	return
+}
+
+func (do *SweepParameterDO) toSweepParameter(p *domain.SweepParameter) (err error) {
+	if p.Key, err = domain.NewCustomizedKey(do.Key); err != nil {
+		return
+	}
+
+	if n := len(do.Values); n > 0 {
+		p.Values = make([]domain.CustomizedValue, n)
+
+		for i := range do.Values {
+			if p.Values[i], err = domain.NewCustomizedValue(do.Values[i]); err != nil {
+				return
+			}
+		}
+	}
+
+	p.Min = do.Min
+	p.Max = do.Max
+	p.Step = do.Step
+
+	return
+}
@@ -2,4 +2,20 @@ package trainingimpl
 
 type Config struct {
 	JobDoneStatus []string `json:"job_done_status"  required:"true"`
+
+	// JobSucceededStatus is the status of job which is done successfully.
+	JobSucceededStatus []string `json:"job_succeeded_status"`
+
+	// DownloadTimeout is the timeout in seconds of downloading the output of job.
+	DownloadTimeout int `json:"download_timeout"`
+}
+
+func (cfg *Config) SetDefault() {
+	if len(cfg.JobSucceededStatus) == 0 {
+		cfg.JobSucceededStatus = []string{"Completed"}
+	}
+
+	if cfg.DownloadTimeout <= 0 {
+		cfg.DownloadTimeout = 300
+	}
 }
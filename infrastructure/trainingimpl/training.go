@@ -1,6 +1,9 @@
 package trainingimpl
 
 import (
+	"fmt"
+	"io"
+	"net/http"
 	"strings"
 
 	"github.com/opensourceways/xihe-training-center/sdk"
@@ -9,22 +12,31 @@ import (
 
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/training"
+	"github.com/opensourceways/xihe-server/utils"
 )
 
 func NewTraining(cfg *Config) training.Training {
 	return &trainingImpl{
-		doneStatus: sets.New[string](cfg.JobDoneStatus...),
+		doneStatus:      sets.New[string](cfg.JobDoneStatus...),
+		succeededStatus: sets.New[string](cfg.JobSucceededStatus...),
+		cli:             utils.NewHttpClient(3, cfg.DownloadTimeout),
 	}
 }
 
 type trainingImpl struct {
-	doneStatus sets.Set[string]
+	doneStatus      sets.Set[string]
+	succeededStatus sets.Set[string]
+	cli             utils.HttpClient
 }
 
 func (impl *trainingImpl) IsJobDone(status string) bool {
 	return impl.doneStatus.Has(status)
 }
 
+func (impl *trainingImpl) IsJobSucceeded(status string) bool {
+	return impl.succeededStatus.Has(status)
+}
+
 func (impl *trainingImpl) CreateJob(endpoint string, info *domain.TrainingIndex, t *domain.TrainingConfig) (
 	job domain.JobInfo, err error,
 ) {
@@ -106,6 +118,37 @@ func (impl *trainingImpl) GetFileDownloadURL(endpoint, file string) (string, err
 	return v.URL, nil
 }
 
+func (impl *trainingImpl) DownloadFile(endpoint, file string, maxSize int64) (
+	data []byte, err error,
+) {
+	link, err := impl.GetFileDownloadURL(endpoint, file)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return
+	}
+
+	err = impl.cli.SendAndHandle(req, func(_ http.Header, r io.Reader) (err error) {
+		if data, err = io.ReadAll(io.LimitReader(r, maxSize+1)); err != nil {
+			return
+		}
+
+		if int64(len(data)) > maxSize {
+			data = nil
+			err = training.NewErrorFileTooLarge(
+				fmt.Errorf("file exceeds the max size of %d bytes", maxSize),
+			)
+		}
+
+		return
+	})
+
+	return
+}
+
 func (impl *trainingImpl) toCompute(c *domain.Compute) sdk.Compute {
 	return sdk.Compute{
 		Type:    c.Type.ComputeType(),
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/job/domain"
+	"github.com/opensourceways/xihe-server/job/domain/jobserver"
+	"github.com/opensourceways/xihe-server/job/domain/repository"
+)
+
+// Driver plugs a type of workload into the job subsystem.
+type Driver interface {
+	Type() domain.JobType
+
+	repository.Job
+	jobserver.JobServer
+}
+
+type drivers map[string]Driver
+
+func newDrivers(v []Driver) drivers {
+	r := make(drivers, len(v))
+	for _, d := range v {
+		r[d.Type().JobType()] = d
+	}
+
+	return r
+}
+
+func (ds drivers) get(t domain.JobType) (d Driver, code string, err error) {
+	if d = ds[t.JobType()]; d == nil {
+		code = ErrorJobUnknownType
+		err = errors.New("unknown job type")
+	}
+
+	return
+}
@@ -0,0 +1,39 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/job/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type JobDTO struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase"`
+	Status    string `json:"status"`
+	Error     string `json:"error"`
+	IsDone    bool   `json:"is_done"`
+	Duration  int    `json:"duration"`
+	CreatedAt string `json:"created_at"`
+
+	LogPreviewURL string `json:"-"`
+}
+
+func toJobDTO(t domain.JobType, j *domain.Job, l domain.JobLifecycle) JobDTO {
+	status := j.Detail.Status
+	if status == "" {
+		status = domain.JobStatusScheduling
+	}
+
+	return JobDTO{
+		Id:        j.Id,
+		Type:      t.JobType(),
+		Name:      j.Name,
+		Phase:     l.Phase(status),
+		Status:    status,
+		Error:     j.Detail.Error,
+		IsDone:    l.IsDone(status),
+		Duration:  j.Detail.Duration,
+		CreatedAt: utils.ToDate(j.CreatedAt),
+	}
+}
@@ -0,0 +1,15 @@
+package app
+
+const (
+	ErrorJobNotFound        = "job_not_found"
+	ErrorJobUnknownType     = "job_unknown_type"
+	ErrorJobNoLog           = "job_no_log"
+	ErrorJobNoOutput        = "job_no_output"
+	ErrorJobCannotTerminate = "job_cannot_terminate"
+
+	ErrorJobNotSucceeded         = "job_not_succeeded"
+	ErrorJobUnknownBenchmark     = "job_unknown_benchmark"
+	ErrorJobEvaluationRunning    = "job_evaluation_running"
+	ErrorJobExccedMaxEvaluations = "job_excced_max_evaluations"
+	ErrorJobPlaygroundExpired    = "job_playground_expired"
+)
@@ -0,0 +1,172 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	orepo "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/job/domain"
+)
+
+const (
+	JobResultLog    = "log"
+	JobResultOutput = "output"
+)
+
+type JobService interface {
+	List(t domain.JobType, owner types.Account, scope string) ([]JobDTO, string, error)
+	Get(*domain.JobIndex) (JobDTO, string, error)
+	Terminate(*domain.JobIndex) (string, error)
+	GetResultDownloadURL(index *domain.JobIndex, result string) (string, string, error)
+}
+
+func NewJobService(v ...Driver) JobService {
+	return jobService{newDrivers(v)}
+}
+
+type jobService struct {
+	drivers drivers
+}
+
+func (s jobService) List(t domain.JobType, owner types.Account, scope string) (
+	[]JobDTO, string, error,
+) {
+	d, code, err := s.drivers.get(t)
+	if err != nil {
+		return nil, code, err
+	}
+
+	v, err := d.ListJobs(owner, scope)
+	if err != nil || len(v) == 0 {
+		return nil, "", err
+	}
+
+	l := domain.NewJobLifecycle(d)
+
+	r := make([]JobDTO, len(v))
+	for i := range v {
+		r[i] = toJobDTO(t, &v[i], l)
+	}
+
+	return r, "", nil
+}
+
+func (s jobService) Get(index *domain.JobIndex) (dto JobDTO, code string, err error) {
+	d, job, code, err := s.getJob(index)
+	if err != nil {
+		return
+	}
+
+	dto = toJobDTO(index.Type, &job, domain.NewJobLifecycle(d))
+
+	if job.Info.JobId != "" {
+		dto.LogPreviewURL, err = d.GetLogPreviewURL(&job.Info)
+	}
+
+	return
+}
+
+func (s jobService) Terminate(index *domain.JobIndex) (code string, err error) {
+	d, job, code, err := s.getJob(index)
+	if err != nil || job.Info.JobId == "" {
+		return
+	}
+
+	if !d.CanTerminate(job.Detail.Status) {
+		code = ErrorJobCannotTerminate
+		err = errors.New("can't terminate now")
+
+		return
+	}
+
+	err = d.TerminateJob(&job.Info)
+
+	return
+}
+
+func (s jobService) GetResultDownloadURL(index *domain.JobIndex, result string) (
+	string, string, error,
+) {
+	d, job, code, err := s.getJob(index)
+	if err != nil {
+		return "", code, err
+	}
+
+	var file string
+
+	switch result {
+	case JobResultLog:
+		file, code = job.Detail.LogPath, ErrorJobNoLog
+
+	case JobResultOutput:
+		file, code = job.Detail.OutputPath, ErrorJobNoOutput
+
+	default:
+		return "", "", errors.New("unknown result")
+	}
+
+	if file == "" {
+		return "", code, errors.New("not ready")
+	}
+
+	link, err := d.GetFileDownloadURL(job.Info.Endpoint, file)
+
+	return link, "", err
+}
+
+func (s jobService) getJob(index *domain.JobIndex) (
+	d Driver, job domain.Job, code string, err error,
+) {
+	if d, code, err = s.drivers.get(index.Type); err != nil {
+		return
+	}
+
+	if job, err = d.GetJob(index); err != nil {
+		if orepo.IsErrorResourceNotExists(err) {
+			code = ErrorJobNotFound
+		}
+	}
+
+	return
+}
+
+// JobInternalService
+type JobInternalService interface {
+	UpdateJobDetail(*domain.JobIndex, *domain.JobDetail) (string, error)
+}
+
+func NewJobInternalService(v ...Driver) JobInternalService {
+	return jobInternalService{
+		jobService: jobService{newDrivers(v)},
+	}
+}
+
+type jobInternalService struct {
+	jobService
+}
+
+// UpdateJobDetail receives the status reported by the job server. The report
+// which is out of order is ignored if the job has been done.
+func (s jobInternalService) UpdateJobDetail(index *domain.JobIndex, v *domain.JobDetail) (
+	code string, err error,
+) {
+	d, job, code, err := s.getJob(index)
+	if err != nil {
+		return
+	}
+
+	if !domain.NewJobLifecycle(d).CanTransit(job.Detail.Status, v.Status) {
+		logrus.Warnf(
+			"ignore the status(%s) of %s job(%s) which is %s",
+			v.Status, index.Type.JobType(), index.Id, job.Detail.Status,
+		)
+
+		return
+	}
+
+	err = d.UpdateJobDetail(index, v)
+
+	return
+}
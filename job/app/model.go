@@ -0,0 +1,301 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/opensourceways/xihe-server/job/domain"
+	"github.com/opensourceways/xihe-server/job/domain/modelservice"
+	"github.com/opensourceways/xihe-server/job/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	maxChatTextLen    = 2000
+	maxChatHistoryNum = 10
+	maxTemperature    = 2
+)
+
+// ModelConfig
+type ModelConfig struct {
+	// Benchmarks are the names of benchmarks against which the checkpoint
+	// can be evaluated. The held_out is the data kept out of the finetune.
+	Benchmarks []string `json:"benchmarks"`
+
+	// MaxEvaluationNum is the max number of evaluations of a job.
+	MaxEvaluationNum int `json:"max_evaluation_num"`
+
+	// PlaygroundExpiry is the hours during which the playground of the job
+	// is available after the job is done.
+	PlaygroundExpiry int `json:"playground_expiry"`
+}
+
+func (cfg *ModelConfig) SetDefault() {
+	if len(cfg.Benchmarks) == 0 {
+		cfg.Benchmarks = []string{"held_out"}
+	}
+
+	if cfg.MaxEvaluationNum <= 0 {
+		cfg.MaxEvaluationNum = 10
+	}
+
+	if cfg.PlaygroundExpiry <= 0 {
+		cfg.PlaygroundExpiry = 72
+	}
+}
+
+type JobEvaluateCmd struct {
+	domain.JobIndex
+
+	Benchmark string
+}
+
+type JobChatCmd struct {
+	domain.JobIndex
+
+	CH          chan string
+	Text        string
+	History     [][2]string
+	Temperature float64
+}
+
+func (cmd *JobChatCmd) Validate() error {
+	if n := utils.StrLen(cmd.Text); n == 0 || n > maxChatTextLen {
+		return fmt.Errorf("the length of text should be between 1 to %d", maxChatTextLen)
+	}
+
+	if len(cmd.History) > maxChatHistoryNum {
+		return fmt.Errorf("the history should be less than %d", maxChatHistoryNum)
+	}
+
+	if cmd.Temperature < 0 || cmd.Temperature > maxTemperature {
+		return fmt.Errorf("the temperature should be between 0 to %d", maxTemperature)
+	}
+
+	return nil
+}
+
+type EvaluationScoreDTO struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+}
+
+type JobEvaluationDTO struct {
+	Id        string               `json:"id"`
+	Benchmark string               `json:"benchmark"`
+	Status    string               `json:"status"`
+	Error     string               `json:"error"`
+	Scores    []EvaluationScoreDTO `json:"scores"`
+	CreatedAt string               `json:"created_at"`
+}
+
+func toJobEvaluationDTO(e *domain.JobEvaluation) JobEvaluationDTO {
+	scores := make([]EvaluationScoreDTO, len(e.Scores))
+	for i := range e.Scores {
+		scores[i] = EvaluationScoreDTO{
+			Metric: e.Scores[i].Metric,
+			Value:  e.Scores[i].Value,
+		}
+	}
+
+	return JobEvaluationDTO{
+		Id:        e.Id,
+		Benchmark: e.Benchmark,
+		Status:    e.Status,
+		Error:     e.Error,
+		Scores:    scores,
+		CreatedAt: utils.ToDate(e.CreatedAt),
+	}
+}
+
+// JobModelService evaluates and plays with the checkpoint output by the
+// finetune which is done successfully.
+type JobModelService interface {
+	Evaluate(*JobEvaluateCmd) (string, string, error)
+	ListEvaluations(*domain.JobIndex) ([]JobEvaluationDTO, string, error)
+	Chat(*JobChatCmd) (string, error)
+}
+
+func NewJobModelService(
+	cfg *ModelConfig,
+	repo repository.JobEvaluation,
+	ms modelservice.ModelService,
+	v ...Driver,
+) JobModelService {
+	return jobModelService{
+		jobService:       jobService{newDrivers(v)},
+		repo:             repo,
+		ms:               ms,
+		benchmarks:       sets.New[string](cfg.Benchmarks...),
+		maxEvaluationNum: cfg.MaxEvaluationNum,
+		playgroundExpiry: int64(cfg.PlaygroundExpiry) * 3600,
+	}
+}
+
+type jobModelService struct {
+	jobService
+
+	repo             repository.JobEvaluation
+	ms               modelservice.ModelService
+	benchmarks       sets.Set[string]
+	maxEvaluationNum int
+	playgroundExpiry int64
+}
+
+func (s jobModelService) Evaluate(cmd *JobEvaluateCmd) (string, string, error) {
+	if !s.benchmarks.Has(cmd.Benchmark) {
+		return "", ErrorJobUnknownBenchmark, errors.New("unknown benchmark")
+	}
+
+	index := &cmd.JobIndex
+
+	cp, _, code, err := s.getCheckpoint(index)
+	if err != nil {
+		return "", code, err
+	}
+
+	v, err := s.repo.List(index)
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(v) >= s.maxEvaluationNum {
+		return "", ErrorJobExccedMaxEvaluations, errors.New("too many evaluations")
+	}
+
+	for i := range v {
+		if !v[i].IsDone() {
+			return "", ErrorJobEvaluationRunning, errors.New("an evaluation is running")
+		}
+	}
+
+	e := domain.JobEvaluation{
+		Benchmark: cmd.Benchmark,
+		CreatedAt: utils.Now(),
+		EvaluationResult: domain.EvaluationResult{
+			Status: domain.EvaluationStatusRunning,
+		},
+	}
+
+	id, err := s.repo.Add(index, &e)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.ms.Evaluate(&modelservice.EvaluationOption{
+		Checkpoint:   cp,
+		EvaluationId: id,
+		Benchmark:    cmd.Benchmark,
+	})
+	if err != nil {
+		r := domain.EvaluationResult{
+			Status: domain.EvaluationStatusFailed,
+			Error:  err.Error(),
+		}
+
+		if err1 := s.repo.UpdateResult(index, id, &r); err1 != nil {
+			logrus.Errorf("update evaluation(%s) failed, err:%s", id, err1.Error())
+		}
+
+		return "", "", err
+	}
+
+	return id, "", nil
+}
+
+func (s jobModelService) ListEvaluations(index *domain.JobIndex) ([]JobEvaluationDTO, string, error) {
+	if _, _, code, err := s.getJob(index); err != nil {
+		return nil, code, err
+	}
+
+	v, err := s.repo.List(index)
+	if err != nil || len(v) == 0 {
+		return nil, "", err
+	}
+
+	r := make([]JobEvaluationDTO, len(v))
+	for i := range v {
+		r[i] = toJobEvaluationDTO(&v[i])
+	}
+
+	return r, "", nil
+}
+
+// Chat streams the completion of the finetuned model. The playground is
+// temporary and expires after a while since the job is done.
+func (s jobModelService) Chat(cmd *JobChatCmd) (string, error) {
+	cp, job, code, err := s.getCheckpoint(&cmd.JobIndex)
+	if err != nil {
+		return code, err
+	}
+
+	if doneAt := job.CreatedAt + int64(job.Detail.Duration); utils.Now() > doneAt+s.playgroundExpiry {
+		return ErrorJobPlaygroundExpired, errors.New("the playground is expired")
+	}
+
+	return "", s.ms.Chat(cmd.CH, &modelservice.ChatOption{
+		Checkpoint:  cp,
+		Text:        cmd.Text,
+		History:     cmd.History,
+		Temperature: cmd.Temperature,
+	})
+}
+
+func (s jobModelService) getCheckpoint(index *domain.JobIndex) (
+	cp modelservice.Checkpoint, job domain.Job, code string, err error,
+) {
+	if !isFinetune(index.Type) {
+		code = ErrorJobUnknownType
+		err = errors.New("only the finetune has the model")
+
+		return
+	}
+
+	d, job, code, err := s.getJob(index)
+	if err != nil {
+		return
+	}
+
+	if !d.IsJobSucceeded(job.Detail.Status) {
+		code = ErrorJobNotSucceeded
+		err = errors.New("the job is not done successfully")
+
+		return
+	}
+
+	cp = modelservice.Checkpoint{
+		Job:        index,
+		Info:       job.Info,
+		OutputPath: job.Detail.OutputPath,
+	}
+
+	return
+}
+
+func isFinetune(t domain.JobType) bool {
+	v := t.JobType()
+
+	return v == domain.JobTypeFinetune.JobType() || v == domain.JobTypeAICCFinetune.JobType()
+}
+
+// JobEvaluationInternalService receives the result of evaluation.
+type JobEvaluationInternalService interface {
+	UpdateEvaluation(index *domain.JobIndex, id string, r *domain.EvaluationResult) error
+}
+
+func NewJobEvaluationInternalService(repo repository.JobEvaluation) JobEvaluationInternalService {
+	return jobEvaluationInternalService{repo}
+}
+
+type jobEvaluationInternalService struct {
+	repo repository.JobEvaluation
+}
+
+func (s jobEvaluationInternalService) UpdateEvaluation(
+	index *domain.JobIndex, id string, r *domain.EvaluationResult,
+) error {
+	return s.repo.UpdateResult(index, id, r)
+}
@@ -0,0 +1,33 @@
+package app
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/job/domain"
+	"github.com/opensourceways/xihe-server/job/domain/message"
+)
+
+// JobDetailNotifier notifies the websockets watching the job by the event.
+// It must be called each time the detail of job is saved.
+type JobDetailNotifier interface {
+	NotifyJobDetailUpdated(*domain.JobIndex, *domain.JobDetail)
+}
+
+func NewJobDetailNotifier(publisher message.JobEventPublisher) JobDetailNotifier {
+	return jobDetailNotifier{publisher}
+}
+
+type jobDetailNotifier struct {
+	publisher message.JobEventPublisher
+}
+
+func (n jobDetailNotifier) NotifyJobDetailUpdated(index *domain.JobIndex, v *domain.JobDetail) {
+	e := domain.NewJobDetailUpdatedEvent(index, v)
+
+	if err := n.publisher.SendJobDetailUpdatedEvent(&e); err != nil {
+		logrus.Errorf(
+			"send event of updating %s job(%s) failed, err:%s",
+			index.Type.JobType(), index.Id, err.Error(),
+		)
+	}
+}
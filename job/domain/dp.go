@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+)
+
+const (
+	jobTypeTraining     = "training"
+	jobTypeFinetune     = "finetune"
+	jobTypeAICCFinetune = "aiccfinetune"
+)
+
+var (
+	JobTypeTraining     = jobType(jobTypeTraining)
+	JobTypeFinetune     = jobType(jobTypeFinetune)
+	JobTypeAICCFinetune = jobType(jobTypeAICCFinetune)
+
+	reJobType = regexp.MustCompile("^[a-z]{1,32}$")
+)
+
+// JobType
+type JobType interface {
+	JobType() string
+}
+
+// NewJobType only checks the format, whether the type is supported
+// depends on the drivers which are registered.
+func NewJobType(v string) (JobType, error) {
+	if !reJobType.MatchString(v) {
+		return nil, errors.New("invalid job type")
+	}
+
+	return jobType(v), nil
+}
+
+type jobType string
+
+func (r jobType) JobType() string {
+	return string(r)
+}
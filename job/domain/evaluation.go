@@ -0,0 +1,33 @@
+package domain
+
+const (
+	EvaluationStatusRunning   = "running"
+	EvaluationStatusSucceeded = "succeeded"
+	EvaluationStatusFailed    = "failed"
+)
+
+// JobEvaluation is the evaluation of the checkpoint output by the job
+// against a benchmark.
+type JobEvaluation struct {
+	Id        string
+	Benchmark string
+	CreatedAt int64
+
+	EvaluationResult
+}
+
+// EvaluationResult is reported by the model service when the evaluation is done.
+type EvaluationResult struct {
+	Status string
+	Error  string
+	Scores []EvaluationScore
+}
+
+func (r *EvaluationResult) IsDone() bool {
+	return r.Status == EvaluationStatusSucceeded || r.Status == EvaluationStatusFailed
+}
+
+type EvaluationScore struct {
+	Metric string
+	Value  float64
+}
@@ -0,0 +1,24 @@
+package domain
+
+// JobDetailUpdatedEvent is sent each time the job server reports the status of job.
+type JobDetailUpdatedEvent struct {
+	JobIndex
+
+	Status string
+}
+
+func NewJobDetailUpdatedEvent(index *JobIndex, detail *JobDetail) JobDetailUpdatedEvent {
+	return JobDetailUpdatedEvent{
+		JobIndex: *index,
+		Status:   detail.Status,
+	}
+}
+
+// IsAbout checks whether the event is about the job of index. The id of
+// index can be empty which means any job in the scope.
+func (e *JobDetailUpdatedEvent) IsAbout(index *JobIndex) bool {
+	return e.Type.JobType() == index.Type.JobType() &&
+		e.Owner.Account() == index.Owner.Account() &&
+		e.Scope == index.Scope &&
+		(index.Id == "" || e.Id == index.Id)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// JobIndex locates a job of any type.
+// Scope is the resource which the job belongs to, such as the project of
+// training and the model of aicc finetune. It is empty if the job belongs
+// to the user directly.
+type JobIndex struct {
+	Type  JobType
+	Owner types.Account
+	Scope string
+	Id    string
+}
+
+// JobInfo and JobDetail are the ones of training which are the superset of
+// all the types of job.
+type JobInfo = types.JobInfo
+type JobDetail = types.JobDetail
+
+// Job is the common view of the workloads which run as a job.
+type Job struct {
+	Id        string
+	Name      string
+	CreatedAt int64
+
+	Info   JobInfo
+	Detail JobDetail
+}
@@ -0,0 +1,16 @@
+package jobserver
+
+import (
+	"github.com/opensourceways/xihe-server/job/domain"
+)
+
+// JobServer is the server which runs the jobs of a type.
+type JobServer interface {
+	IsJobDone(status string) bool
+	IsJobSucceeded(status string) bool
+	CanTerminate(status string) bool
+
+	TerminateJob(*domain.JobInfo) error
+	GetLogPreviewURL(*domain.JobInfo) (string, error)
+	GetFileDownloadURL(endpoint, file string) (string, error)
+}
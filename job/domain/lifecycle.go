@@ -0,0 +1,76 @@
+package domain
+
+const (
+	// JobStatusScheduling is the status of job before it is created on the job server.
+	JobStatusScheduling = "scheduling"
+
+	// JobStatusScheduleFailed is the status of job which can't be created on the job server.
+	JobStatusScheduleFailed = "schedule_failed"
+
+	JobPhaseScheduling     = "scheduling"
+	JobPhaseScheduleFailed = "schedule_failed"
+	JobPhaseRunning        = "running"
+	JobPhaseSucceeded      = "succeeded"
+	JobPhaseFailed         = "failed"
+)
+
+// JobStatusClassifier classifies the status reported by the job server.
+type JobStatusClassifier interface {
+	IsJobDone(status string) bool
+	IsJobSucceeded(status string) bool
+}
+
+// JobLifecycle is the state machine shared by all types of job.
+//
+//	scheduling -> running -> succeeded | failed
+//	scheduling -> schedule_failed
+//
+// The status is the one reported by the job server and each type of job
+// only tells which of them are done or succeeded.
+type JobLifecycle struct {
+	classifier JobStatusClassifier
+}
+
+func NewJobLifecycle(c JobStatusClassifier) JobLifecycle {
+	return JobLifecycle{classifier: c}
+}
+
+func (l JobLifecycle) Phase(status string) string {
+	switch {
+	case status == "" || status == JobStatusScheduling:
+		return JobPhaseScheduling
+
+	case status == JobStatusScheduleFailed:
+		return JobPhaseScheduleFailed
+
+	case !l.classifier.IsJobDone(status):
+		return JobPhaseRunning
+
+	case l.classifier.IsJobSucceeded(status):
+		return JobPhaseSucceeded
+
+	default:
+		return JobPhaseFailed
+	}
+}
+
+func (l JobLifecycle) IsDone(status string) bool {
+	p := l.Phase(status)
+
+	return p != JobPhaseScheduling && p != JobPhaseRunning
+}
+
+func (l JobLifecycle) IsSucceeded(status string) bool {
+	return l.Phase(status) == JobPhaseSucceeded
+}
+
+// CanTransit checks whether the job can move from the status to the next one.
+// The job which is done can only be reported with the same status again, so
+// that the late reports of job server will not overwrite the final status.
+func (l JobLifecycle) CanTransit(from, to string) bool {
+	if to == "" {
+		return false
+	}
+
+	return !l.IsDone(from) || from == to
+}
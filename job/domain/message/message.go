@@ -0,0 +1,12 @@
+package message
+
+import "github.com/opensourceways/xihe-server/job/domain"
+
+type JobEventPublisher interface {
+	SendJobDetailUpdatedEvent(*domain.JobDetailUpdatedEvent) error
+}
+
+// JobEventSubscriber delivers the events to the handlers which must not block.
+type JobEventSubscriber interface {
+	SubscribeJobDetailUpdatedEvent(func(*domain.JobDetailUpdatedEvent))
+}
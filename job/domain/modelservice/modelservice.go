@@ -0,0 +1,33 @@
+package modelservice
+
+import "github.com/opensourceways/xihe-server/job/domain"
+
+// ModelService serves the checkpoint output by the job.
+type ModelService interface {
+	// Evaluate starts the evaluation whose result is reported later.
+	Evaluate(*EvaluationOption) error
+
+	// Chat sends the completion to ch piece by piece and closes it at the end.
+	Chat(ch chan string, opt *ChatOption) error
+}
+
+type Checkpoint struct {
+	Job        *domain.JobIndex
+	Info       domain.JobInfo
+	OutputPath string
+}
+
+type EvaluationOption struct {
+	Checkpoint
+
+	EvaluationId string
+	Benchmark    string
+}
+
+type ChatOption struct {
+	Checkpoint
+
+	Text        string
+	History     [][2]string
+	Temperature float64
+}
@@ -0,0 +1,9 @@
+package repository
+
+import "github.com/opensourceways/xihe-server/job/domain"
+
+type JobEvaluation interface {
+	Add(*domain.JobIndex, *domain.JobEvaluation) (string, error)
+	List(*domain.JobIndex) ([]domain.JobEvaluation, error)
+	UpdateResult(index *domain.JobIndex, id string, r *domain.EvaluationResult) error
+}
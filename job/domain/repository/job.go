@@ -0,0 +1,15 @@
+package repository
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/job/domain"
+)
+
+// Job is the operations on the jobs of a type which are common to all types.
+type Job interface {
+	GetJob(*domain.JobIndex) (domain.Job, error)
+	ListJobs(owner types.Account, scope string) ([]domain.Job, error)
+
+	// UpdateJobDetail saves the detail and notifies the watchers of job.
+	UpdateJobDetail(*domain.JobIndex, *domain.JobDetail) error
+}
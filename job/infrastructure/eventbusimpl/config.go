@@ -0,0 +1,7 @@
+package eventbusimpl
+
+type Config struct {
+	// RedisChannel is the channel of redis through which the events are
+	// sent to all the replicas.
+	RedisChannel string `json:"redis_channel" required:"true"`
+}
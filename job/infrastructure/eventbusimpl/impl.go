@@ -0,0 +1,131 @@
+package eventbusimpl
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/common/infrastructure/redis"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/job/domain"
+)
+
+const (
+	publishTimeout  = 3 * time.Second
+	resubscribeWait = 5 * time.Second
+)
+
+func NewEventBus(cfg *Config) *eventBus {
+	b := &eventBus{channel: cfg.RedisChannel}
+
+	go b.receive()
+
+	return b
+}
+
+type eventBus struct {
+	channel string
+
+	lock     sync.RWMutex
+	handlers []func(*domain.JobDetailUpdatedEvent)
+}
+
+func (b *eventBus) SubscribeJobDetailUpdatedEvent(h func(*domain.JobDetailUpdatedEvent)) {
+	b.lock.Lock()
+	b.handlers = append(b.handlers, h)
+	b.lock.Unlock()
+}
+
+// SendJobDetailUpdatedEvent sends the event through redis, and the event
+// will come back to all the replicas including this one.
+func (b *eventBus) SendJobDetailUpdatedEvent(e *domain.JobDetailUpdatedEvent) error {
+	body, err := json.Marshal(toEventDO(e))
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+
+	if err = redis.Publish(ctx, b.channel, body); err != nil {
+		// deliver it to the local subscribers at least
+		b.dispatch(e)
+	}
+
+	return err
+}
+
+func (b *eventBus) dispatch(e *domain.JobDetailUpdatedEvent) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	for _, h := range b.handlers {
+		h(e)
+	}
+}
+
+func (b *eventBus) receive() {
+	for {
+		err := redis.Subscribe(context.Background(), b.channel, b.handle)
+		if err != nil {
+			logrus.Errorf("subscribe job events failed, err:%s", err.Error())
+		}
+
+		time.Sleep(resubscribeWait)
+	}
+}
+
+func (b *eventBus) handle(payload []byte) {
+	var do eventDO
+	if err := json.Unmarshal(payload, &do); err != nil {
+		logrus.Errorf("unmarshal job event failed, err:%s", err.Error())
+
+		return
+	}
+
+	e, err := do.toEvent()
+	if err != nil {
+		logrus.Errorf("invalid job event, err:%s", err.Error())
+
+		return
+	}
+
+	b.dispatch(&e)
+}
+
+type eventDO struct {
+	Type   string `json:"type"`
+	Owner  string `json:"owner"`
+	Scope  string `json:"scope"`
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func toEventDO(e *domain.JobDetailUpdatedEvent) eventDO {
+	return eventDO{
+		Type:   e.Type.JobType(),
+		Owner:  e.Owner.Account(),
+		Scope:  e.Scope,
+		Id:     e.Id,
+		Status: e.Status,
+	}
+}
+
+func (do *eventDO) toEvent() (e domain.JobDetailUpdatedEvent, err error) {
+	if e.Type, err = domain.NewJobType(do.Type); err != nil {
+		return
+	}
+
+	if e.Owner, err = types.NewAccount(do.Owner); err != nil {
+		return
+	}
+
+	e.Scope = do.Scope
+	e.Id = do.Id
+	e.Status = do.Status
+
+	return
+}
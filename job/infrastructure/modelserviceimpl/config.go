@@ -0,0 +1,10 @@
+package modelserviceimpl
+
+type Config struct {
+	// Endpoint is the address of the service which loads the checkpoint
+	// output by the finetune for evaluation and chatting.
+	Endpoint string `json:"endpoint"  required:"true"`
+
+	// Token is set in the header of request for authentication.
+	Token string `json:"token"`
+}
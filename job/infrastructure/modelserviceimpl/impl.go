@@ -0,0 +1,170 @@
+package modelserviceimpl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	libutils "github.com/opensourceways/community-robot-lib/utils"
+
+	"github.com/opensourceways/xihe-server/job/domain/modelservice"
+)
+
+const (
+	doneStatus      = "DONE"
+	replaceResponse = "data: "
+)
+
+func NewModelService(cfg *Config) modelservice.ModelService {
+	return &modelServiceImpl{
+		hc:       libutils.NewHttpClient(3),
+		token:    cfg.Token,
+		endpoint: strings.TrimSuffix(cfg.Endpoint, "/"),
+	}
+}
+
+type modelServiceImpl struct {
+	hc       libutils.HttpClient
+	token    string
+	endpoint string
+}
+
+type checkpoint struct {
+	JobType    string `json:"job_type"`
+	Owner      string `json:"owner"`
+	JobId      string `json:"job_id"`
+	Endpoint   string `json:"endpoint"`
+	ServerJob  string `json:"server_job_id"`
+	OutputPath string `json:"output_path"`
+}
+
+func toCheckpoint(cp *modelservice.Checkpoint) checkpoint {
+	return checkpoint{
+		JobType:    cp.Job.Type.JobType(),
+		Owner:      cp.Job.Owner.Account(),
+		JobId:      cp.Job.Id,
+		Endpoint:   cp.Info.Endpoint,
+		ServerJob:  cp.Info.JobId,
+		OutputPath: cp.OutputPath,
+	}
+}
+
+type evaluationRequest struct {
+	checkpoint
+
+	EvaluationId string `json:"evaluation_id"`
+	Benchmark    string `json:"benchmark"`
+}
+
+type chatRequest struct {
+	checkpoint
+
+	Inputs      string      `json:"inputs"`
+	History     [][2]string `json:"history"`
+	Temperature float64     `json:"temperature"`
+}
+
+type chatResponse struct {
+	Reply        string `json:"reply"`
+	Code         int    `json:"code"`
+	Msg          string `json:"msg"`
+	StreamStatus string `json:"stream_status"`
+}
+
+func (impl *modelServiceImpl) Evaluate(opt *modelservice.EvaluationOption) error {
+	req, err := impl.newRequest("/v1/evaluation", &evaluationRequest{
+		checkpoint:   toCheckpoint(&opt.Checkpoint),
+		EvaluationId: opt.EvaluationId,
+		Benchmark:    opt.Benchmark,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = impl.hc.ForwardTo(req, nil)
+
+	return err
+}
+
+// Chat reads the completion in the same format as the chat models of bigmodel.
+func (impl *modelServiceImpl) Chat(ch chan string, opt *modelservice.ChatOption) error {
+	req, err := impl.newRequest("/v1/chat", &chatRequest{
+		checkpoint:  toCheckpoint(&opt.Checkpoint),
+		Inputs:      opt.Text,
+		History:     opt.History,
+		Temperature: opt.Temperature,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := impl.hc.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	if code := resp.StatusCode; code < 200 || code > 299 {
+		resp.Body.Close()
+
+		return fmt.Errorf("response has status:%s", resp.Status)
+	}
+
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+
+		reader := bufio.NewReader(resp.Body)
+
+		var r chatResponse
+		for {
+			line, err := reader.ReadString('\n')
+			if line != "" {
+				data := strings.Replace(line, replaceResponse, "", 1)
+				data = strings.TrimRight(data, "\x00")
+
+				if json.Unmarshal([]byte(data), &r) == nil {
+					if r.StreamStatus == doneStatus {
+						return
+					}
+
+					if r.Reply != "" {
+						ch <- r.Reply
+					}
+				}
+			}
+
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (impl *modelServiceImpl) newRequest(path string, body interface{}) (*http.Request, error) {
+	if impl.endpoint == "" {
+		return nil, errors.New("model service is not available")
+	}
+
+	v, err := libutils.JsonMarshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, impl.endpoint+path, bytes.NewBuffer(v))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if impl.token != "" {
+		req.Header.Set("Authorization", "Bearer "+impl.token)
+	}
+
+	return req, nil
+}
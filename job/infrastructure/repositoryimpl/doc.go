@@ -0,0 +1,34 @@
+package repositoryimpl
+
+const (
+	fieldId     = "id"
+	fieldType   = "type"
+	fieldOwner  = "owner"
+	fieldJobId  = "job_id"
+	fieldItems  = "items"
+	fieldStatus = "status"
+	fieldError  = "error"
+	fieldScores = "scores"
+)
+
+type dJobEvaluation struct {
+	Type  string `bson:"type"    json:"type"`
+	Owner string `bson:"owner"   json:"owner"`
+	JobId string `bson:"job_id"  json:"job_id"`
+
+	Items []evaluationItem `bson:"items"   json:"-"`
+}
+
+type evaluationItem struct {
+	Id        string   `bson:"id"          json:"id"`
+	Benchmark string   `bson:"benchmark"   json:"benchmark"`
+	Status    string   `bson:"status"      json:"status"`
+	Error     string   `bson:"error"       json:"error"`
+	Scores    []dScore `bson:"scores"      json:"scores"`
+	CreatedAt int64    `bson:"created_at"  json:"created_at"`
+}
+
+type dScore struct {
+	Metric string  `bson:"metric"  json:"metric"`
+	Value  float64 `bson:"value"   json:"value"`
+}
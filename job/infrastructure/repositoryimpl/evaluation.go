@@ -0,0 +1,156 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+	"github.com/opensourceways/xihe-server/job/domain"
+	"github.com/opensourceways/xihe-server/job/domain/repository"
+)
+
+// NewJobEvaluationRepo keeps the evaluations of a job in one doc.
+func NewJobEvaluationRepo(m mongodbClient) repository.JobEvaluation {
+	return jobEvaluationRepoImpl{m}
+}
+
+type jobEvaluationRepoImpl struct {
+	cli mongodbClient
+}
+
+func jobDocFilter(index *domain.JobIndex) bson.M {
+	return bson.M{
+		fieldType:  index.Type.JobType(),
+		fieldOwner: index.Owner.Account(),
+		fieldJobId: index.Id,
+	}
+}
+
+func (impl jobEvaluationRepoImpl) Add(index *domain.JobIndex, e *domain.JobEvaluation) (
+	id string, err error,
+) {
+	id = primitive.NewObjectID().Hex()
+
+	scores := make([]dScore, len(e.Scores))
+	for i := range e.Scores {
+		scores[i] = dScore{
+			Metric: e.Scores[i].Metric,
+			Value:  e.Scores[i].Value,
+		}
+	}
+
+	doc, err := genDoc(evaluationItem{
+		Id:        id,
+		Benchmark: e.Benchmark,
+		Status:    e.Status,
+		Error:     e.Error,
+		Scores:    scores,
+		CreatedAt: e.CreatedAt,
+	})
+	if err != nil {
+		return
+	}
+
+	docFilter := jobDocFilter(index)
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, docFilter, bson.M{
+			fieldType:  index.Type.JobType(),
+			fieldOwner: index.Owner.Account(),
+			fieldJobId: index.Id,
+			fieldItems: bson.A{},
+		})
+		if err != nil && !impl.cli.IsDocExists(err) {
+			return err
+		}
+
+		return impl.cli.PushArrayElem(ctx, fieldItems, docFilter, doc)
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func (impl jobEvaluationRepoImpl) List(index *domain.JobIndex) ([]domain.JobEvaluation, error) {
+	var v dJobEvaluation
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(
+			ctx, jobDocFilter(index), bson.M{fieldItems: 1}, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	r := make([]domain.JobEvaluation, len(v.Items))
+	for i := range v.Items {
+		v.Items[i].toJobEvaluation(&r[i])
+	}
+
+	return r, nil
+}
+
+func (impl jobEvaluationRepoImpl) UpdateResult(
+	index *domain.JobIndex, id string, r *domain.EvaluationResult,
+) error {
+	scores := make(bson.A, len(r.Scores))
+	for i := range r.Scores {
+		scores[i] = bson.M{
+			"metric": r.Scores[i].Metric,
+			"value":  r.Scores[i].Value,
+		}
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.ModifyArrayElem(
+			ctx, fieldItems,
+			jobDocFilter(index),
+			bson.M{fieldId: id},
+			bson.M{
+				fieldStatus: r.Status,
+				fieldError:  r.Error,
+				fieldScores: scores,
+			},
+			mongoCmdSet,
+		)
+		if err != nil && impl.cli.IsDocNotExists(err) {
+			err = repositories.NewErrorDataNotExists(errDocNotExists)
+		}
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (doc *evaluationItem) toJobEvaluation(e *domain.JobEvaluation) {
+	*e = domain.JobEvaluation{
+		Id:        doc.Id,
+		Benchmark: doc.Benchmark,
+		CreatedAt: doc.CreatedAt,
+		EvaluationResult: domain.EvaluationResult{
+			Status: doc.Status,
+			Error:  doc.Error,
+		},
+	}
+
+	if n := len(doc.Scores); n > 0 {
+		e.Scores = make([]domain.EvaluationScore, n)
+
+		for i := range doc.Scores {
+			e.Scores[i] = domain.EvaluationScore{
+				Metric: doc.Scores[i].Metric,
+				Value:  doc.Scores[i].Value,
+			}
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package repositoryimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const (
+	mongoCmdSet = "$set"
+)
+
+var errDocNotExists = errors.New("doc doesn't exist")
+
+type mongodbClient interface {
+	IsDocNotExists(error) bool
+	IsDocExists(error) bool
+
+	GetDoc(ctx context.Context, filterOfDoc, project bson.M, result interface{}) error
+
+	NewDocIfNotExist(ctx context.Context, filterOfDoc, docInfo bson.M) (string, error)
+
+	PushArrayElem(ctx context.Context, array string, filterOfDoc, value bson.M) error
+
+	ModifyArrayElem(ctx context.Context, array string,
+		filterOfDoc, filterOfArray, updateCmd bson.M, op string) (bool, error)
+}
+
+func withContext(f func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		10*time.Second,
+	)
+	defer cancel()
+
+	return f(ctx)
+}
+
+func genDoc(doc interface{}) (m bson.M, err error) {
+	v, err := json.Marshal(doc)
+	if err != nil {
+		return
+	}
+
+	err = json.Unmarshal(v, &m)
+
+	return
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/opensourceways/xihe-server/common/domain/allerror"
+	"github.com/opensourceways/xihe-server/common/domain/audit"
+	"github.com/opensourceways/xihe-server/moderation/domain"
+)
+
+// NewAuditService adapts the moderation service to the audit service which
+// is used by the resources. The content type of audit is taken as the
+// surface, and the text is not allowed to be masked.
+func NewAuditService(s ModerationService) audit.AuditService {
+	return auditService{s}
+}
+
+type auditService struct {
+	s ModerationService
+}
+
+func (impl auditService) TextAudit(content, contentType string) error {
+	surface, err := domain.NewSurface(contentType)
+	if err != nil {
+		return allerror.New(allerror.ErrorCodeCallAuditFailed, "", err)
+	}
+
+	v, code, err := impl.s.Moderate(&ModerateCmd{
+		Surface: surface,
+		Text:    content,
+	})
+	if err != nil {
+		if code == ErrorModerationBlocked {
+			return allerror.New(allerror.ErrorCodeAuditBlock, "", err)
+		}
+
+		return allerror.New(allerror.ErrorCodeCallAuditFailed, "", err)
+	}
+
+	if v.Action == domain.ActionMask {
+		return allerror.New(
+			allerror.ErrorCodeAuditBlock, "", xerrors.New("audit block"),
+		)
+	}
+
+	return nil
+}
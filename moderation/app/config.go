@@ -0,0 +1,104 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/moderation/domain"
+)
+
+// Config
+type Config struct {
+	// Policies are the ones of each surface, and the default policy is
+	// applied to the surfaces which are not listed.
+	Policies []PolicyConfig `json:"policies"`
+
+	DefaultAction    string   `json:"default_action"`
+	DefaultProviders []string `json:"default_providers"`
+
+	// Window is the number of characters of the streamed text which are
+	// checked at a time.
+	Window int `json:"window"`
+
+	// Overlap is the number of characters of the last window which are
+	// checked again with the next one, so that the unsafe text across two
+	// windows can be found. It should not be less than the longest keyword.
+	Overlap int `json:"overlap"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.DefaultAction == "" {
+		cfg.DefaultAction = domain.ActionBlock
+	}
+
+	if len(cfg.DefaultProviders) == 0 {
+		cfg.DefaultProviders = []string{"local", "audit"}
+	}
+
+	if cfg.Window <= 0 {
+		cfg.Window = 100
+	}
+
+	if cfg.Overlap <= 0 {
+		cfg.Overlap = 10
+	}
+}
+
+func (cfg *Config) Validate() error {
+	if cfg.Overlap >= cfg.Window {
+		return errors.New("the overlap must be less than the window")
+	}
+
+	if _, err := domain.NewAction(cfg.DefaultAction); err != nil {
+		return err
+	}
+
+	for i := range cfg.Policies {
+		if _, err := cfg.Policies[i].toPolicy(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (cfg *Config) policies() (map[string]*domain.Policy, error) {
+	r := make(map[string]*domain.Policy, len(cfg.Policies))
+
+	for i := range cfg.Policies {
+		p, err := cfg.Policies[i].toPolicy()
+		if err != nil {
+			return nil, err
+		}
+
+		r[p.Surface.Surface()] = &p
+	}
+
+	return r, nil
+}
+
+// PolicyConfig
+type PolicyConfig struct {
+	Surface   string   `json:"surface"    required:"true"`
+	Action    string   `json:"action"     required:"true"`
+	Providers []string `json:"providers"  required:"true"`
+}
+
+func (cfg *PolicyConfig) toPolicy() (p domain.Policy, err error) {
+	if p.Surface, err = domain.NewSurface(cfg.Surface); err != nil {
+		return
+	}
+
+	if p.Action, err = domain.NewAction(cfg.Action); err != nil {
+		return
+	}
+
+	if len(cfg.Providers) == 0 {
+		err = errors.New("missing providers of moderation policy")
+
+		return
+	}
+
+	p.Providers = cfg.Providers
+
+	return
+}
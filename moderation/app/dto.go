@@ -0,0 +1,74 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/moderation/domain"
+	"github.com/opensourceways/xihe-server/moderation/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type ModerateCmd struct {
+	Surface domain.Surface
+	User    string
+	Text    string
+}
+
+// ModerateImagesCmd
+type ModerateImagesCmd struct {
+	Surface domain.Surface
+	User    string
+	URLs    []string
+}
+
+// ModerateDTO
+type ModerateDTO struct {
+	// Action is empty if the text is passed.
+	Action string
+	Text   string
+}
+
+type RecordListCmd = repository.RecordListOption
+
+type ReviewCmd struct {
+	Id       string
+	Status   domain.ReviewStatus
+	Reviewer string
+	Comment  string
+}
+
+type RecordDTO struct {
+	Id         string   `json:"id"`
+	Surface    string   `json:"surface"`
+	User       string   `json:"user"`
+	Content    string   `json:"content"`
+	Labels     []string `json:"labels"`
+	Action     string   `json:"action"`
+	Status     string   `json:"status"`
+	Reviewer   string   `json:"reviewer,omitempty"`
+	Comment    string   `json:"comment,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	ReviewedAt string   `json:"reviewed_at,omitempty"`
+}
+
+type RecordsDTO struct {
+	Total   int         `json:"total"`
+	Records []RecordDTO `json:"records"`
+}
+
+func toRecordDTO(r *domain.Record) RecordDTO {
+	dto := RecordDTO{
+		Id:       r.Id,
+		Surface:  r.Surface.Surface(),
+		User:     r.User,
+		Content:  r.Content,
+		Labels:   r.Labels,
+		Action:   r.Action.Action(),
+		Status:   r.Status.ReviewStatus(),
+		Reviewer: r.Reviewer,
+		Comment:  r.Comment,
+	}
+
+	_, dto.CreatedAt = utils.DateAndTime(r.CreatedAt)
+	_, dto.ReviewedAt = utils.DateAndTime(r.ReviewedAt)
+
+	return dto
+}
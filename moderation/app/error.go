@@ -0,0 +1,7 @@
+package app
+
+const (
+	ErrorModerationBlocked = "moderation_blocked"
+	ErrorRecordNotFound    = "moderation_record_not_found"
+	ErrorRecordReviewed    = "moderation_record_reviewed"
+)
@@ -0,0 +1,301 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	crepository "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/moderation/domain"
+	"github.com/opensourceways/xihe-server/moderation/domain/provider"
+	"github.com/opensourceways/xihe-server/moderation/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// ModerationService checks the content of each surface by its policy, and
+// keeps the blocked or warned content for review.
+type ModerationService interface {
+	// Moderate returns the text which can be used. The code will be
+	// ErrorModerationBlocked if the text is blocked.
+	Moderate(*ModerateCmd) (ModerateDTO, string, error)
+
+	// ModerateImages checks the images by the providers of the policy which
+	// can check images, or by all of them if there is none in the policy.
+	// The code will be ErrorModerationBlocked if the images are blocked, and
+	// they are blocked if the policy is to mask them.
+	ModerateImages(*ModerateImagesCmd) (string, error)
+
+	// NewStream returns the moderator for the text which is streamed.
+	NewStream(surface domain.Surface, user string) StreamModerator
+
+	ListRecords(*RecordListCmd) (RecordsDTO, error)
+	Review(*ReviewCmd) (string, error)
+}
+
+// StreamModerator checks the streamed text window by window, so that the
+// unsafe text can be stopped before all of it is sent.
+type StreamModerator interface {
+	// Feed returns the text which is checked and can be sent. The stream
+	// should be stopped if stop is true.
+	Feed(chunk string) (text string, stop bool)
+
+	// Flush checks the rest of text when the stream ends.
+	Flush() (text string, stop bool)
+}
+
+func NewModerationService(
+	cfg *Config,
+	providers []provider.Provider,
+	repo repository.Record,
+) (ModerationService, error) {
+	policies, err := cfg.policies()
+	if err != nil {
+		return nil, err
+	}
+
+	action, err := domain.NewAction(cfg.DefaultAction)
+	if err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]provider.Provider, len(providers))
+	for _, p := range providers {
+		m[p.Name()] = p
+	}
+
+	check := func(v []string) error {
+		for _, name := range v {
+			if _, ok := m[name]; !ok {
+				return fmt.Errorf("unknown moderation provider: %s", name)
+			}
+		}
+
+		return nil
+	}
+
+	if err := check(cfg.DefaultProviders); err != nil {
+		return nil, err
+	}
+
+	for _, p := range policies {
+		if err := check(p.Providers); err != nil {
+			return nil, err
+		}
+	}
+
+	return &moderationService{
+		cfg:       cfg,
+		repo:      repo,
+		policies:  policies,
+		providers: m,
+		defaultPolicy: domain.Policy{
+			Action:    action,
+			Providers: cfg.DefaultProviders,
+		},
+	}, nil
+}
+
+type moderationService struct {
+	cfg       *Config
+	repo      repository.Record
+	policies  map[string]*domain.Policy
+	providers map[string]provider.Provider
+
+	defaultPolicy domain.Policy
+}
+
+func (s *moderationService) policy(surface domain.Surface) *domain.Policy {
+	if p, ok := s.policies[surface.Surface()]; ok {
+		return p
+	}
+
+	return &s.defaultPolicy
+}
+
+func (s *moderationService) check(p *domain.Policy, surface domain.Surface, text string) (
+	v domain.Verdict, err error,
+) {
+	for _, name := range p.Providers {
+		hits, err := s.providers[name].CheckText(surface, text)
+		if err != nil {
+			return v, fmt.Errorf("moderation provider %s failed, err:%s", name, err.Error())
+		}
+
+		v.Hits = append(v.Hits, hits...)
+	}
+
+	return
+}
+
+func (s *moderationService) addRecord(
+	surface domain.Surface, user, content string, v *domain.Verdict, action string,
+) {
+	a, _ := domain.NewAction(action)
+	status, _ := domain.NewReviewStatus(domain.ReviewStatusPending)
+
+	r := domain.Record{
+		Surface:   surface,
+		User:      user,
+		Content:   content,
+		Labels:    v.Labels(),
+		Action:    a,
+		Status:    status,
+		CreatedAt: utils.Now(),
+	}
+
+	if _, err := s.repo.Add(&r); err != nil {
+		logrus.Errorf(
+			"add moderation record of %s failed, err:%s",
+			surface.Surface(), err.Error(),
+		)
+	}
+}
+
+func (s *moderationService) Moderate(cmd *ModerateCmd) (dto ModerateDTO, code string, err error) {
+	p := s.policy(cmd.Surface)
+
+	v, err := s.check(p, cmd.Surface, cmd.Text)
+	if err != nil {
+		return
+	}
+
+	dto.Text = cmd.Text
+
+	if v.Passed() {
+		return
+	}
+
+	dto.Action = p.Decide(&v)
+
+	switch dto.Action {
+	case domain.ActionBlock:
+		s.addRecord(cmd.Surface, cmd.User, cmd.Text, &v, dto.Action)
+
+		dto.Text = ""
+		code = ErrorModerationBlocked
+		err = errors.New("the content is blocked")
+
+	case domain.ActionMask:
+		dto.Text = v.Mask(cmd.Text)
+
+	case domain.ActionWarn:
+		s.addRecord(cmd.Surface, cmd.User, cmd.Text, &v, dto.Action)
+	}
+
+	return
+}
+
+// imageProviders returns the ones of names which can check images, or all
+// of them if names is empty.
+func (s *moderationService) imageProviders(names []string) []provider.ImageProvider {
+	var r []provider.ImageProvider
+
+	if len(names) == 0 {
+		for name := range s.providers {
+			names = append(names, name)
+		}
+	}
+
+	for _, name := range names {
+		if ip, ok := s.providers[name].(provider.ImageProvider); ok {
+			r = append(r, ip)
+		}
+	}
+
+	return r
+}
+
+func (s *moderationService) ModerateImages(cmd *ModerateImagesCmd) (code string, err error) {
+	p := s.policy(cmd.Surface)
+
+	ips := s.imageProviders(p.Providers)
+	if len(ips) == 0 {
+		ips = s.imageProviders(nil)
+	}
+
+	v := domain.Verdict{}
+	for _, ip := range ips {
+		hits, err := ip.CheckImages(cmd.Surface, cmd.URLs)
+		if err != nil {
+			return "", fmt.Errorf("moderation provider %s failed, err:%s", ip.Name(), err.Error())
+		}
+
+		v.Hits = append(v.Hits, hits...)
+	}
+
+	if v.Passed() {
+		return
+	}
+
+	content := strings.Join(cmd.URLs, "\n")
+
+	if p.Decide(&v) == domain.ActionWarn {
+		s.addRecord(cmd.Surface, cmd.User, content, &v, domain.ActionWarn)
+
+		return
+	}
+
+	s.addRecord(cmd.Surface, cmd.User, content, &v, domain.ActionBlock)
+
+	code = ErrorModerationBlocked
+	err = errors.New("the image is blocked")
+
+	return
+}
+
+func (s *moderationService) NewStream(surface domain.Surface, user string) StreamModerator {
+	return &streamModerator{
+		s:       s,
+		user:    user,
+		policy:  s.policy(surface),
+		surface: surface,
+	}
+}
+
+func (s *moderationService) ListRecords(cmd *RecordListCmd) (dto RecordsDTO, err error) {
+	v, total, err := s.repo.List(cmd)
+	if err != nil {
+		return
+	}
+
+	dto.Total = total
+	dto.Records = make([]RecordDTO, len(v))
+	for i := range v {
+		dto.Records[i] = toRecordDTO(&v[i])
+	}
+
+	return
+}
+
+func (s *moderationService) Review(cmd *ReviewCmd) (string, error) {
+	r, err := s.repo.Get(cmd.Id)
+	if err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			return ErrorRecordNotFound, err
+		}
+
+		return "", err
+	}
+
+	if r.Status.ReviewStatus() != domain.ReviewStatusPending {
+		return ErrorRecordReviewed, errors.New("the record has been reviewed")
+	}
+
+	r.Status = cmd.Status
+	r.Reviewer = cmd.Reviewer
+	r.Comment = cmd.Comment
+	r.ReviewedAt = utils.Now()
+
+	if err := s.repo.Review(&r); err != nil {
+		if crepository.IsErrorResourceNotExists(err) {
+			// it is reviewed by others at the same time
+			return ErrorRecordReviewed, err
+		}
+
+		return "", err
+	}
+
+	return "", nil
+}
@@ -0,0 +1,104 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/moderation/domain"
+)
+
+// streamModerator holds the streamed text until it reaches the window, and
+// checks it together with the tail of the last window. It is not safe for
+// concurrent use.
+type streamModerator struct {
+	s       *moderationService
+	user    string
+	policy  *domain.Policy
+	surface domain.Surface
+
+	tail    []rune
+	pending []rune
+	content strings.Builder
+	warned  bool
+	stopped bool
+}
+
+func (m *streamModerator) Feed(chunk string) (string, bool) {
+	if m.stopped {
+		return "", true
+	}
+
+	m.pending = append(m.pending, []rune(chunk)...)
+	m.content.WriteString(chunk)
+
+	if len(m.pending) < m.s.cfg.Window {
+		return "", false
+	}
+
+	return m.check()
+}
+
+func (m *streamModerator) Flush() (string, bool) {
+	if m.stopped {
+		return "", true
+	}
+
+	if len(m.pending) == 0 {
+		return "", false
+	}
+
+	return m.check()
+}
+
+func (m *streamModerator) check() (string, bool) {
+	text := append(m.tail, m.pending...)
+
+	v, err := m.s.check(m.policy, m.surface, string(text))
+	if err != nil {
+		// stop the stream, since it can't tell whether the text is safe
+		logrus.Errorf("check streamed text failed, err:%s", err.Error())
+
+		return m.stop()
+	}
+
+	// the hits in the tail have been handled by the last check
+	v = v.Shift(len(m.tail))
+
+	out := string(m.pending)
+
+	if !v.Passed() {
+		switch m.policy.Decide(&v) {
+		case domain.ActionBlock:
+			m.s.addRecord(m.surface, m.user, m.content.String(), &v, domain.ActionBlock)
+
+			return m.stop()
+
+		case domain.ActionMask:
+			out = v.Mask(out)
+
+		case domain.ActionWarn:
+			if !m.warned {
+				m.warned = true
+				m.s.addRecord(m.surface, m.user, m.content.String(), &v, domain.ActionWarn)
+			}
+		}
+	}
+
+	if n := m.s.cfg.Overlap; len(text) > n {
+		text = text[len(text)-n:]
+	}
+
+	m.tail = append([]rune(nil), text...)
+	m.pending = nil
+
+	return out, false
+}
+
+func (m *streamModerator) stop() (string, bool) {
+	m.stopped = true
+	m.tail = nil
+	m.pending = nil
+
+	return "", true
+}
@@ -0,0 +1,198 @@
+package app
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/opensourceways/xihe-server/moderation/domain"
+	"github.com/opensourceways/xihe-server/moderation/domain/provider"
+	"github.com/opensourceways/xihe-server/moderation/domain/repository"
+)
+
+const testKeyword = "bad"
+
+// keywordProvider finds the keyword in the text, and fails on the text
+// which contains "fail".
+type keywordProvider struct {
+	texts []string
+}
+
+func (p *keywordProvider) Name() string {
+	return "keyword"
+}
+
+func (p *keywordProvider) CheckText(surface domain.Surface, text string) ([]domain.Hit, error) {
+	p.texts = append(p.texts, text)
+
+	if strings.Contains(text, "fail") {
+		return nil, errors.New("failed")
+	}
+
+	var hits []domain.Hit
+
+	rs := []rune(text)
+	kw := []rune(testKeyword)
+	for i := 0; i+len(kw) <= len(rs); i++ {
+		if string(rs[i:i+len(kw)]) == testKeyword {
+			hits = append(hits, domain.Hit{
+				Provider: p.Name(),
+				Label:    "keyword",
+				Start:    i,
+				End:      i + len(kw),
+			})
+		}
+	}
+
+	return hits, nil
+}
+
+type recordRepo struct {
+	records []domain.Record
+}
+
+func (r *recordRepo) Add(v *domain.Record) (string, error) {
+	r.records = append(r.records, *v)
+
+	return "", nil
+}
+
+func (r *recordRepo) Get(string) (domain.Record, error) {
+	return domain.Record{}, nil
+}
+
+func (r *recordRepo) List(*repository.RecordListOption) ([]domain.Record, int, error) {
+	return nil, 0, nil
+}
+
+func (r *recordRepo) Review(*domain.Record) error {
+	return nil
+}
+
+func testStreamService(action string) (*moderationService, *keywordProvider, *recordRepo) {
+	p := &keywordProvider{}
+	repo := &recordRepo{}
+	a, _ := domain.NewAction(action)
+
+	return &moderationService{
+		cfg:       &Config{Window: 5, Overlap: 3},
+		repo:      repo,
+		providers: map[string]provider.Provider{p.Name(): p},
+		defaultPolicy: domain.Policy{
+			Action:    a,
+			Providers: []string{p.Name()},
+		},
+	}, p, repo
+}
+
+func TestStreamModerator(t *testing.T) {
+	cases := []struct {
+		name    string
+		action  string
+		chunks  []string
+		out     []string // the text returned by each Feed and then Flush
+		stopped bool
+		checked []string
+		records int
+	}{
+		{
+			name:    "held until window",
+			action:  domain.ActionBlock,
+			chunks:  []string{"ab", "cd"},
+			out:     []string{"", "", "abcd"},
+			checked: []string{"abcd"},
+		},
+		{
+			name:    "checked with the tail of last window",
+			action:  domain.ActionBlock,
+			chunks:  []string{"hello ", "world"},
+			out:     []string{"hello ", "world", ""},
+			checked: []string{"hello ", "lo world"},
+		},
+		{
+			name:    "blocked in window",
+			action:  domain.ActionBlock,
+			chunks:  []string{"a bad", "xxxxx"},
+			out:     []string{"", "", ""},
+			stopped: true,
+			checked: []string{"a bad"},
+			records: 1,
+		},
+		{
+			name:    "blocked across windows",
+			action:  domain.ActionBlock,
+			chunks:  []string{"xxxxba", "dxxxx"},
+			out:     []string{"xxxxba", "", ""},
+			stopped: true,
+			checked: []string{"xxxxba", "xbadxxxx"},
+			records: 1,
+		},
+		{
+			name:    "masked across windows",
+			action:  domain.ActionMask,
+			chunks:  []string{"xxxxba", "dxxxx"},
+			out:     []string{"xxxxba", "*xxxx", ""},
+			checked: []string{"xxxxba", "xbadxxxx"},
+		},
+		{
+			name:    "hit in the tail not handled again",
+			action:  domain.ActionMask,
+			chunks:  []string{"xxxbad", "yyyyy"},
+			out:     []string{"xxx***", "yyyyy", ""},
+			checked: []string{"xxxbad", "badyyyyy"},
+		},
+		{
+			name:    "warned once",
+			action:  domain.ActionWarn,
+			chunks:  []string{"bad x", "bad y", "z"},
+			out:     []string{"bad x", "bad y", "", "z"},
+			checked: []string{"bad x", "d xbad y", "d yz"},
+			records: 1,
+		},
+		{
+			name:    "stopped if check fails",
+			action:  domain.ActionWarn,
+			chunks:  []string{"fail!", "xxxxx"},
+			out:     []string{"", "", ""},
+			stopped: true,
+			checked: []string{"fail!"},
+		},
+	}
+
+	for _, c := range cases {
+		s, p, repo := testStreamService(c.action)
+		m := s.NewStream(domain.SurfaceChatOutput, "alice")
+
+		var (
+			out     []string
+			stopped bool
+		)
+
+		for _, chunk := range c.chunks {
+			v, stop := m.Feed(chunk)
+			out = append(out, v)
+			stopped = stopped || stop
+		}
+
+		v, stop := m.Flush()
+		out = append(out, v)
+		stopped = stopped || stop
+
+		if !reflect.DeepEqual(out, c.out) {
+			t.Errorf("%s: out = %q, want %q", c.name, out, c.out)
+		}
+
+		if stopped != c.stopped {
+			t.Errorf("%s: stopped = %v, want %v", c.name, stopped, c.stopped)
+		}
+
+		if !reflect.DeepEqual(p.texts, c.checked) {
+			t.Errorf("%s: checked = %q, want %q", c.name, p.texts, c.checked)
+		}
+
+		if n := len(repo.records); n != c.records {
+			t.Errorf("%s: records = %d, want %d", c.name, n, c.records)
+		}
+	}
+}
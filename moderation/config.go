@@ -0,0 +1,20 @@
+package moderation
+
+import (
+	"github.com/opensourceways/xihe-server/moderation/app"
+	"github.com/opensourceways/xihe-server/moderation/infrastructure/providerimpl"
+)
+
+type Config struct {
+	App   app.Config               `json:"app"`
+	Local providerimpl.LocalConfig `json:"local"`
+	Cloud providerimpl.CloudConfig `json:"cloud"`
+}
+
+func (cfg *Config) ConfigItems() []interface{} {
+	return []interface{}{
+		&cfg.App,
+		&cfg.Local,
+		&cfg.Cloud,
+	}
+}
@@ -0,0 +1,218 @@
+package domain
+
+import "errors"
+
+const (
+	surfaceChatPrompt     = "chat_prompt"
+	surfaceChatOutput     = "chat_output"
+	surfaceWuKongPrompt   = "wukong_prompt"
+	surfaceWuKongPicture  = "wukong_picture"
+	surfaceAIDetectorText = "ai_detector_text"
+
+	ActionBlock = "block"
+	ActionMask  = "mask"
+	ActionWarn  = "warn"
+
+	ReviewStatusPending  = "pending"
+	ReviewStatusApproved = "approved"
+	ReviewStatusRejected = "rejected"
+
+	maskChar = '*'
+)
+
+var (
+	// the surfaces of the chat models
+	SurfaceChatPrompt = surface(surfaceChatPrompt)
+	SurfaceChatOutput = surface(surfaceChatOutput)
+
+	// the surfaces of the other big models
+	SurfaceWuKongPrompt   = surface(surfaceWuKongPrompt)
+	SurfaceWuKongPicture  = surface(surfaceWuKongPicture)
+	SurfaceAIDetectorText = surface(surfaceAIDetectorText)
+)
+
+// Surface is where the content comes from, such as the prompt of chat model
+// or the title of a resource. Each surface has its own policy.
+type Surface interface {
+	Surface() string
+}
+
+func NewSurface(v string) (Surface, error) {
+	if v == "" {
+		return nil, errors.New("empty surface")
+	}
+
+	return surface(v), nil
+}
+
+type surface string
+
+func (s surface) Surface() string {
+	return string(s)
+}
+
+// Action is what to do with the content which is not passed.
+//   - block: reject the content
+//   - mask: replace the unsafe parts with '*'
+//   - warn: let the content pass and keep it for review
+type Action interface {
+	Action() string
+}
+
+func NewAction(v string) (Action, error) {
+	switch v {
+	case ActionBlock, ActionMask, ActionWarn:
+		return action(v), nil
+	}
+
+	return nil, errors.New("invalid moderation action")
+}
+
+type action string
+
+func (a action) Action() string {
+	return string(a)
+}
+
+// ReviewStatus
+type ReviewStatus interface {
+	ReviewStatus() string
+}
+
+func NewReviewStatus(v string) (ReviewStatus, error) {
+	switch v {
+	case ReviewStatusPending, ReviewStatusApproved, ReviewStatusRejected:
+		return reviewStatus(v), nil
+	}
+
+	return nil, errors.New("invalid review status")
+}
+
+type reviewStatus string
+
+func (s reviewStatus) ReviewStatus() string {
+	return string(s)
+}
+
+// Hit is the part of content which is found unsafe by the provider. The
+// Start and End are the offsets of characters, and both of them are -1 if
+// the provider can't tell where it is.
+type Hit struct {
+	Provider string
+	Label    string
+	Start    int
+	End      int
+}
+
+func (h *Hit) located() bool {
+	return h.Start >= 0 && h.End > h.Start
+}
+
+// Verdict is the result of checking the content by the providers.
+type Verdict struct {
+	Hits []Hit
+}
+
+func (v *Verdict) Passed() bool {
+	return len(v.Hits) == 0
+}
+
+// Maskable tells whether all the unsafe parts are located.
+func (v *Verdict) Maskable() bool {
+	for i := range v.Hits {
+		if !v.Hits[i].located() {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (v *Verdict) Labels() []string {
+	r := make([]string, 0, len(v.Hits))
+	m := map[string]bool{}
+
+	for i := range v.Hits {
+		if l := v.Hits[i].Label; !m[l] {
+			m[l] = true
+			r = append(r, l)
+		}
+	}
+
+	return r
+}
+
+// Mask replaces the unsafe parts of text with '*'.
+func (v *Verdict) Mask(text string) string {
+	rs := []rune(text)
+
+	for i := range v.Hits {
+		h := &v.Hits[i]
+		if !h.located() {
+			continue
+		}
+
+		for j := h.Start; j < h.End && j < len(rs); j++ {
+			rs[j] = maskChar
+		}
+	}
+
+	return string(rs)
+}
+
+// Shift moves the hits by n characters and drops the ones before the start.
+func (v *Verdict) Shift(n int) Verdict {
+	r := Verdict{}
+
+	for _, h := range v.Hits {
+		if h.located() {
+			if h.End <= n {
+				continue
+			}
+
+			h.Start -= n
+			h.End -= n
+
+			if h.Start < 0 {
+				h.Start = 0
+			}
+		}
+
+		r.Hits = append(r.Hits, h)
+	}
+
+	return r
+}
+
+// Policy decides the action of the content which is not passed.
+type Policy struct {
+	Surface   Surface
+	Action    Action
+	Providers []string
+}
+
+// Decide returns the action. The content will be blocked if it is expected
+// to be masked but can't be.
+func (p *Policy) Decide(v *Verdict) string {
+	a := p.Action.Action()
+	if a == ActionMask && !v.Maskable() {
+		return ActionBlock
+	}
+
+	return a
+}
+
+// Record is the content which is blocked or warned, and is kept for review.
+type Record struct {
+	Id         string
+	Surface    Surface
+	User       string
+	Content    string
+	Labels     []string
+	Action     Action
+	Status     ReviewStatus
+	Reviewer   string
+	Comment    string
+	CreatedAt  int64
+	ReviewedAt int64
+}
@@ -0,0 +1,17 @@
+package provider
+
+import "github.com/opensourceways/xihe-server/moderation/domain"
+
+// Provider checks the text and returns the unsafe parts of it.
+type Provider interface {
+	Name() string
+	CheckText(surface domain.Surface, text string) ([]domain.Hit, error)
+}
+
+// ImageProvider is the provider which can check the images too. The label
+// of hit tells which image is unsafe.
+type ImageProvider interface {
+	Provider
+
+	CheckImages(surface domain.Surface, urls []string) ([]domain.Hit, error)
+}
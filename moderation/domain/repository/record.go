@@ -0,0 +1,22 @@
+package repository
+
+import "github.com/opensourceways/xihe-server/moderation/domain"
+
+type RecordListOption struct {
+	Status  domain.ReviewStatus
+	Surface domain.Surface
+
+	CountPerPage int
+	PageNum      int
+}
+
+type Record interface {
+	Add(*domain.Record) (string, error)
+	Get(id string) (domain.Record, error)
+
+	// List returns the records of the option, the latest first.
+	List(*RecordListOption) ([]domain.Record, int, error)
+
+	// Review updates the review of record if it is pending.
+	Review(*domain.Record) error
+}
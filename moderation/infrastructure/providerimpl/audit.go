@@ -0,0 +1,57 @@
+package providerimpl
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/common/domain/allerror"
+	"github.com/opensourceways/xihe-server/common/domain/audit"
+	"github.com/opensourceways/xihe-server/moderation/domain"
+	"github.com/opensourceways/xihe-server/moderation/domain/provider"
+)
+
+const (
+	ProviderAudit = "audit"
+
+	auditTitle   = "title"
+	auditProfile = "profile"
+)
+
+// NewAuditProvider wraps the audit service which only tells whether the
+// text is passed, so the hit of it can't be located.
+func NewAuditProvider(s audit.AuditService) provider.Provider {
+	return auditProvider{s}
+}
+
+type auditProvider struct {
+	s audit.AuditService
+}
+
+func (p auditProvider) Name() string {
+	return ProviderAudit
+}
+
+func (p auditProvider) CheckText(s domain.Surface, text string) ([]domain.Hit, error) {
+	contentType := auditProfile
+	if s.Surface() == auditTitle {
+		contentType = auditTitle
+	}
+
+	err := p.s.TextAudit(text, contentType)
+	if err == nil {
+		return nil, nil
+	}
+
+	var v interface {
+		ErrorCode() string
+	}
+	if !errors.As(err, &v) || v.ErrorCode() != allerror.ErrorCodeAuditBlock {
+		return nil, err
+	}
+
+	return []domain.Hit{{
+		Provider: ProviderAudit,
+		Label:    err.Error(),
+		Start:    -1,
+		End:      -1,
+	}}, nil
+}
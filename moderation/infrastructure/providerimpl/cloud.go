@@ -0,0 +1,144 @@
+package providerimpl
+
+import (
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/basic"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/region"
+	moderationv2 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/moderation/v2"
+	modelv2 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/moderation/v2/model"
+	regionv2 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/moderation/v2/region"
+	moderationv3 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/moderation/v3"
+	modelv3 "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/moderation/v3/model"
+
+	"github.com/opensourceways/xihe-server/moderation/domain"
+	"github.com/opensourceways/xihe-server/moderation/domain/provider"
+)
+
+const (
+	ProviderCloud = "cloud"
+
+	cloudEventType  = "comment"
+	cloudRule       = "default"
+	cloudSuggestion = "pass"
+)
+
+// CloudConfig is the account of the content moderation of huawei cloud.
+type CloudConfig struct {
+	Endpoint    string `json:"endpoint"       required:"true"`
+	AccessKey   string `json:"access_key"     required:"true"`
+	SecretKey   string `json:"secret_key"     required:"true"`
+	IAMEndpoint string `json:"iam_endpoint"   required:"true"`
+	Region      string `json:"region"         required:"true"`
+}
+
+// NewCloudProvider checks the text by the v3 api and the images by the v2
+// one, neither of which can locate the unsafe parts.
+func NewCloudProvider(cfg *CloudConfig) provider.ImageProvider {
+	auth := basic.NewCredentialsBuilder().
+		WithAk(cfg.AccessKey).
+		WithSk(cfg.SecretKey).
+		WithIamEndpointOverride(cfg.IAMEndpoint).
+		Build()
+
+	cli := moderationv3.NewModerationClient(
+		moderationv3.ModerationClientBuilder().
+			WithRegion(region.NewRegion(cfg.Region, cfg.Endpoint)).
+			WithCredential(auth).
+			Build(),
+	)
+
+	authv2 := basic.NewCredentialsBuilder().
+		WithAk(cfg.AccessKey).
+		WithSk(cfg.SecretKey).
+		Build()
+
+	cliv2 := moderationv2.NewModerationClient(
+		moderationv2.ModerationClientBuilder().
+			WithRegion(regionv2.ValueOf(cfg.Region)).
+			WithCredential(authv2).
+			Build(),
+	)
+
+	return cloudProvider{cli, cliv2}
+}
+
+type cloudProvider struct {
+	cli   *moderationv3.ModerationClient
+	cliv2 *moderationv2.ModerationClient
+}
+
+func (p cloudProvider) Name() string {
+	return ProviderCloud
+}
+
+func (p cloudProvider) CheckText(_ domain.Surface, text string) ([]domain.Hit, error) {
+	t := cloudEventType
+
+	resp, err := p.cli.RunTextModeration(&modelv3.RunTextModerationRequest{
+		Body: &modelv3.TextDetectionReq{
+			Data: &modelv3.TextDetectionDataReq{
+				Text: text,
+			},
+			EventType: &t,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := resp.Result
+	if r == nil || r.Suggestion == nil || *r.Suggestion == cloudSuggestion {
+		return nil, nil
+	}
+
+	label := *r.Suggestion
+	if r.Label != nil {
+		label = *r.Label
+	}
+
+	return []domain.Hit{{
+		Provider: ProviderCloud,
+		Label:    label,
+		Start:    -1,
+		End:      -1,
+	}}, nil
+}
+
+func (p cloudProvider) CheckImages(_ domain.Surface, urls []string) ([]domain.Hit, error) {
+	rule := cloudRule
+	categories := []modelv2.ImageBatchModerationReqCategories{
+		modelv2.GetImageBatchModerationReqCategoriesEnum().ALL,
+	}
+
+	resp, err := p.cliv2.RunImageBatchModeration(&modelv2.RunImageBatchModerationRequest{
+		Body: &modelv2.ImageBatchModerationReq{
+			ModerationRule: &rule,
+			Categories:     &categories,
+			Urls:           urls,
+		},
+	})
+	if err != nil || resp.Result == nil {
+		return nil, err
+	}
+
+	var hits []domain.Hit
+
+	for _, item := range *resp.Result {
+		if item.Suggestion == nil || *item.Suggestion == cloudSuggestion {
+			continue
+		}
+
+		label := *item.Suggestion
+		if item.Url != nil {
+			label += ": " + *item.Url
+		}
+
+		hits = append(hits, domain.Hit{
+			Provider: ProviderCloud,
+			Label:    label,
+			Start:    -1,
+			End:      -1,
+		})
+	}
+
+	return hits, nil
+}
@@ -0,0 +1,117 @@
+package providerimpl
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/opensourceways/xihe-server/moderation/domain"
+	"github.com/opensourceways/xihe-server/moderation/domain/provider"
+)
+
+const ProviderLocal = "local"
+
+// LocalConfig is the rules of the local provider which works offline.
+type LocalConfig struct {
+	Rules []LocalRule `json:"rules"`
+}
+
+func (cfg *LocalConfig) Validate() error {
+	for i := range cfg.Rules {
+		if err := cfg.Rules[i].validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LocalRule matches the keywords case-insensitively, and the patterns
+// which are regular expressions.
+type LocalRule struct {
+	Label    string   `json:"label"     required:"true"`
+	Keywords []string `json:"keywords"`
+	Patterns []string `json:"patterns"`
+}
+
+func (r *LocalRule) validate() error {
+	if len(r.Keywords) == 0 && len(r.Patterns) == 0 {
+		return errors.New("missing keywords and patterns of moderation rule")
+	}
+
+	_, err := r.compile()
+
+	return err
+}
+
+func (r *LocalRule) compile() (*regexp.Regexp, error) {
+	items := make([]string, 0, len(r.Keywords)+len(r.Patterns))
+
+	for _, k := range r.Keywords {
+		if k != "" {
+			items = append(items, regexp.QuoteMeta(k))
+		}
+	}
+
+	items = append(items, r.Patterns...)
+
+	return regexp.Compile("(?i)" + strings.Join(items, "|"))
+}
+
+func NewLocalProvider(cfg *LocalConfig) (provider.Provider, error) {
+	p := localProvider{
+		rules: make([]localRule, len(cfg.Rules)),
+	}
+
+	for i := range cfg.Rules {
+		item := &cfg.Rules[i]
+
+		re, err := item.compile()
+		if err != nil {
+			return nil, err
+		}
+
+		p.rules[i] = localRule{
+			label: item.Label,
+			re:    re,
+		}
+	}
+
+	return p, nil
+}
+
+type localRule struct {
+	label string
+	re    *regexp.Regexp
+}
+
+type localProvider struct {
+	rules []localRule
+}
+
+func (p localProvider) Name() string {
+	return ProviderLocal
+}
+
+func (p localProvider) CheckText(_ domain.Surface, text string) ([]domain.Hit, error) {
+	var r []domain.Hit
+
+	for i := range p.rules {
+		rule := &p.rules[i]
+
+		for _, loc := range rule.re.FindAllStringIndex(text, -1) {
+			// the offsets of bytes are converted to the ones of characters
+			start := utf8.RuneCountInString(text[:loc[0]])
+
+			r = append(r, domain.Hit{
+				Provider: ProviderLocal,
+				Label:    rule.label,
+				Start:    start,
+				End:      start + utf8.RuneCountInString(text[loc[0]:loc[1]]),
+			})
+		}
+	}
+
+	return r, nil
+}
@@ -0,0 +1,25 @@
+package repositoryimpl
+
+const (
+	fieldId         = "id"
+	fieldSurface    = "surface"
+	fieldStatus     = "status"
+	fieldReviewer   = "reviewer"
+	fieldComment    = "comment"
+	fieldCreatedAt  = "created_at"
+	fieldReviewedAt = "reviewed_at"
+)
+
+type dRecord struct {
+	Id         string   `bson:"id"           json:"id"`
+	Surface    string   `bson:"surface"      json:"surface"`
+	User       string   `bson:"user"         json:"user"`
+	Content    string   `bson:"content"      json:"content"`
+	Labels     []string `bson:"labels"       json:"labels"`
+	Action     string   `bson:"action"       json:"action"`
+	Status     string   `bson:"status"       json:"status"`
+	Reviewer   string   `bson:"reviewer"     json:"reviewer,omitempty"`
+	Comment    string   `bson:"comment"      json:"comment,omitempty"`
+	CreatedAt  int64    `bson:"created_at"   json:"created_at"`
+	ReviewedAt int64    `bson:"reviewed_at"  json:"reviewed_at,omitempty"`
+}
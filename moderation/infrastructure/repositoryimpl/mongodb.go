@@ -0,0 +1,56 @@
+package repositoryimpl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const mongoCmdSet = "$set"
+
+var errDocNotExists = errors.New("doc doesn't exist")
+
+type mongodbClient interface {
+	IsDocNotExists(error) bool
+
+	Collection() *mongo.Collection
+
+	GetDoc(ctx context.Context, filterOfDoc, project bson.M, result interface{}) error
+
+	GetDocs(ctx context.Context, filterOfDoc bson.M, opts *options.FindOptions, result interface{}) error
+
+	NewDocIfNotExist(ctx context.Context, filterOfDoc, docInfo bson.M) (string, error)
+}
+
+func withContext(f func(context.Context) error) error {
+	ctx, cancel := context.WithTimeout(
+		context.Background(),
+		10*time.Second, // TODO use config
+	)
+	defer cancel()
+
+	return f(ctx)
+}
+
+func genDoc(doc interface{}) (m bson.M, err error) {
+	v, err := json.Marshal(doc)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(v, &m); err != nil {
+		return
+	}
+
+	return
+}
+
+func newId() string {
+	return primitive.NewObjectID().Hex()
+}
@@ -0,0 +1,171 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/moderation/domain"
+	"github.com/opensourceways/xihe-server/moderation/domain/repository"
+)
+
+func NewRecordRepo(m mongodbClient) repository.Record {
+	return recordRepoImpl{m}
+}
+
+type recordRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl recordRepoImpl) Add(r *domain.Record) (string, error) {
+	doc, err := genDoc(toRecordDoc(r))
+	if err != nil {
+		return "", err
+	}
+
+	id := newId()
+	doc[fieldId] = id
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, bson.M{fieldId: id}, doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (impl recordRepoImpl) Get(id string) (r domain.Record, err error) {
+	var v dRecord
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, bson.M{fieldId: id}, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	err = v.toRecord(&r)
+
+	return
+}
+
+func (impl recordRepoImpl) List(opt *repository.RecordListOption) (
+	[]domain.Record, int, error,
+) {
+	filter := bson.M{}
+	if opt.Status != nil {
+		filter[fieldStatus] = opt.Status.ReviewStatus()
+	}
+	if opt.Surface != nil {
+		filter[fieldSurface] = opt.Surface.Surface()
+	}
+
+	findOpts := options.Find().SetSort(bson.D{{Key: fieldCreatedAt, Value: -1}})
+	if opt.CountPerPage > 0 {
+		findOpts.SetLimit(int64(opt.CountPerPage))
+
+		if opt.PageNum > 1 {
+			findOpts.SetSkip(int64(opt.CountPerPage * (opt.PageNum - 1)))
+		}
+	}
+
+	var v []dRecord
+	var total int64
+
+	f := func(ctx context.Context) (err error) {
+		if total, err = impl.cli.Collection().CountDocuments(ctx, filter); err != nil {
+			return
+		}
+
+		return impl.cli.GetDocs(ctx, filter, findOpts, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, int(total), err
+	}
+
+	r := make([]domain.Record, len(v))
+	for i := range v {
+		if err := v[i].toRecord(&r[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return r, int(total), nil
+}
+
+func (impl recordRepoImpl) Review(r *domain.Record) error {
+	filter := bson.M{
+		fieldId:     r.Id,
+		fieldStatus: domain.ReviewStatusPending,
+	}
+
+	update := bson.M{
+		mongoCmdSet: bson.M{
+			fieldStatus:     r.Status.ReviewStatus(),
+			fieldReviewer:   r.Reviewer,
+			fieldComment:    r.Comment,
+			fieldReviewedAt: r.ReviewedAt,
+		},
+	}
+
+	f := func(ctx context.Context) error {
+		v, err := impl.cli.Collection().UpdateOne(ctx, filter, update)
+		if err == nil && v.MatchedCount == 0 {
+			err = repoerr.NewErrorResourceNotExists(errDocNotExists)
+		}
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func toRecordDoc(r *domain.Record) dRecord {
+	return dRecord{
+		Surface:   r.Surface.Surface(),
+		User:      r.User,
+		Content:   r.Content,
+		Labels:    r.Labels,
+		Action:    r.Action.Action(),
+		Status:    r.Status.ReviewStatus(),
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func (doc *dRecord) toRecord(r *domain.Record) (err error) {
+	if r.Surface, err = domain.NewSurface(doc.Surface); err != nil {
+		return
+	}
+
+	if r.Action, err = domain.NewAction(doc.Action); err != nil {
+		return
+	}
+
+	if r.Status, err = domain.NewReviewStatus(doc.Status); err != nil {
+		return
+	}
+
+	r.Id = doc.Id
+	r.User = doc.User
+	r.Content = doc.Content
+	r.Labels = doc.Labels
+	r.Reviewer = doc.Reviewer
+	r.Comment = doc.Comment
+	r.CreatedAt = doc.CreatedAt
+	r.ReviewedAt = doc.ReviewedAt
+
+	return
+}
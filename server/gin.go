@@ -22,8 +22,11 @@ import (
 	bigmodelasynccli "github.com/opensourceways/xihe-server/bigmodel/infrastructure/asynccli"
 	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/bigmodels"
 	bigmodelmsg "github.com/opensourceways/xihe-server/bigmodel/infrastructure/messageadapter"
+	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/ratelimiterimpl"
 	bigmodelrepo "github.com/opensourceways/xihe-server/bigmodel/infrastructure/repositoryimpl"
 	cloudapp "github.com/opensourceways/xihe-server/cloud/app"
+	clouddomain "github.com/opensourceways/xihe-server/cloud/domain"
+	cloudimage "github.com/opensourceways/xihe-server/cloud/infrastructure/imageimpl"
 	cloudmsg "github.com/opensourceways/xihe-server/cloud/infrastructure/messageadapter"
 	cloudrepo "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
 	"github.com/opensourceways/xihe-server/common/infrastructure/audit"
@@ -38,14 +41,21 @@ import (
 	"github.com/opensourceways/xihe-server/config"
 	"github.com/opensourceways/xihe-server/controller"
 	courseapp "github.com/opensourceways/xihe-server/course/app"
+	coursecert "github.com/opensourceways/xihe-server/course/infrastructure/certificateimpl"
+	coursegrader "github.com/opensourceways/xihe-server/course/infrastructure/graderimpl"
 	coursemsg "github.com/opensourceways/xihe-server/course/infrastructure/messageadapter"
 	courserepo "github.com/opensourceways/xihe-server/course/infrastructure/repositoryimpl"
 	courseusercli "github.com/opensourceways/xihe-server/course/infrastructure/usercli"
 	"github.com/opensourceways/xihe-server/docs"
+	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/platform"
 	filescan "github.com/opensourceways/xihe-server/filescan/app"
 	filescaninfra "github.com/opensourceways/xihe-server/filescan/infrastructure"
 	filescanrepo "github.com/opensourceways/xihe-server/filescan/infrastructure/repositoryadapter"
+	imageapp "github.com/opensourceways/xihe-server/image/app"
+	imagemsg "github.com/opensourceways/xihe-server/image/infrastructure/messageadapter"
+	imageorg "github.com/opensourceways/xihe-server/image/infrastructure/orgimpl"
+	imagerepo "github.com/opensourceways/xihe-server/image/infrastructure/repositoryimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/authingimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/challengeimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/competitionimpl"
@@ -55,6 +65,14 @@ import (
 	"github.com/opensourceways/xihe-server/infrastructure/mongodb"
 	"github.com/opensourceways/xihe-server/infrastructure/repositories"
 	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
+	jobapp "github.com/opensourceways/xihe-server/job/app"
+	"github.com/opensourceways/xihe-server/job/infrastructure/eventbusimpl"
+	"github.com/opensourceways/xihe-server/job/infrastructure/modelserviceimpl"
+	jobrepo "github.com/opensourceways/xihe-server/job/infrastructure/repositoryimpl"
+	moderationapp "github.com/opensourceways/xihe-server/moderation/app"
+	moderationdomainprovider "github.com/opensourceways/xihe-server/moderation/domain/provider"
+	moderationprovider "github.com/opensourceways/xihe-server/moderation/infrastructure/providerimpl"
+	moderationrepo "github.com/opensourceways/xihe-server/moderation/infrastructure/repositoryimpl"
 	pointsapp "github.com/opensourceways/xihe-server/points/app"
 	pointsservice "github.com/opensourceways/xihe-server/points/domain/service"
 	pointsrepo "github.com/opensourceways/xihe-server/points/infrastructure/repositoryadapter"
@@ -149,6 +167,30 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 		),
 	)
 
+	trainingSweep := repositories.NewTrainingSweepRepository(
+		mongodb.NewTrainingSweepMapper(
+			collections.TrainingSweep,
+		),
+	)
+
+	trainingMetric := repositories.NewTrainingMetricRepository(
+		mongodb.NewTrainingMetricMapper(
+			collections.TrainingMetric,
+		),
+	)
+
+	trainingPipeline := repositories.NewTrainingPipelineRepository(
+		mongodb.NewTrainingPipelineMapper(
+			collections.TrainingPipeline,
+		),
+	)
+
+	trainingSchedule := repositories.NewTrainingScheduleRepository(
+		mongodb.NewTrainingScheduleMapper(
+			collections.TrainingSchedule,
+		),
+	)
+
 	finetune := repositories.NewFinetuneRepository(
 		mongodb.NewFinetuneMapper(
 			collections.Finetune,
@@ -200,8 +242,27 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 	// resource producer
 	resProducer := messages.NewResourceMessageAdapter(&cfg.Resource, publisher, operator)
 
+	// moderation
+	moderationLocal, err := moderationprovider.NewLocalProvider(&cfg.Moderation.Local)
+	if err != nil {
+		return err
+	}
+
+	moderationAppService, err := moderationapp.NewModerationService(
+		&cfg.Moderation.App,
+		[]moderationdomainprovider.Provider{
+			moderationLocal,
+			moderationprovider.NewAuditProvider(audit.NewAuditService()),
+			moderationprovider.NewCloudProvider(&cfg.Moderation.Cloud),
+		},
+		moderationrepo.NewRecordRepo(mongodb.NewCollection(collections.ModerationRecord)),
+	)
+	if err != nil {
+		return err
+	}
+
 	//audit
-	audit := audit.NewAuditService()
+	auditService := moderationapp.NewAuditService(moderationAppService)
 
 	userRegService := userapp.NewRegService(
 		userrepoimpl.NewUserRegRepo(
@@ -220,13 +281,27 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 
 	asyncAppService := asyncapp.NewTaskService(asyncrepoimpl.NewAsyncTaskRepo(&cfg.Postgresql.Async))
 
+	competitionWorkRepo, err := competitionrepo.NewWorkRepo(
+		mongodb.NewCollection(collections.CompetitionWork),
+	)
+	if err != nil {
+		return err
+	}
+
 	competitionAppService := competitionapp.NewCompetitionService(
 		competitionrepo.NewCompetitionRepo(mongodb.NewCollection(collections.Competition)),
-		competitionrepo.NewWorkRepo(mongodb.NewCollection(collections.CompetitionWork)),
+		competitionWorkRepo,
 		competitionrepo.NewPlayerRepo(mongodb.NewCollection(collections.CompetitionPlayer)),
 		competitionmsg.MessageAdapter(&cfg.Competition.Message, publisher), uploader,
 		competitionusercli.NewUserCli(userRegService),
 		user,
+		competitionrepo.NewCheatingFlagRepo(mongodb.NewCollection(collections.CompetitionFlag)),
+	)
+
+	competitionCheatingService := competitionapp.NewCompetitionCheatingService(
+		competitionrepo.NewCompetitionRepo(mongodb.NewCollection(collections.Competition)),
+		competitionWorkRepo,
+		competitionrepo.NewCheatingFlagRepo(mongodb.NewCollection(collections.CompetitionFlag)),
 	)
 
 	err = spacerepo.Init(pgsql.DB(), &cfg.Space.Tables)
@@ -236,6 +311,11 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 
 	proj := spacerepo.ProjectAdapter()
 
+	courseCert, err := coursecert.NewCertificateImpl(&cfg.Course.Cert)
+	if err != nil {
+		return err
+	}
+
 	courseAppService := courseapp.NewCourseService(
 		courseusercli.NewUserCli(userRegService),
 		proj,
@@ -243,27 +323,87 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 		courserepo.NewPlayerRepo(mongodb.NewCollection(collections.CoursePlayer)),
 		courserepo.NewWorkRepo(mongodb.NewCollection(collections.CourseWork)),
 		courserepo.NewRecordRepo(mongodb.NewCollection(collections.CourseRecord)),
+		courserepo.NewCertificateRepo(mongodb.NewCollection(collections.CourseCert)),
+		courseCert,
+		courserepo.NewSubmissionRepo(mongodb.NewCollection(collections.CourseSubmission)),
+		coursegrader.NewScriptGrader(&cfg.Course.Grader),
+		courserepo.NewLearningPathRepo(mongodb.NewCollection(collections.CoursePath)),
 		coursemsg.MessageAdapter(&cfg.Course.Message, publisher),
 		user,
 	)
 
+	err = comprepositoryadapter.Init(pgsql.DB(), &cfg.Computility.Tables)
+	if err != nil {
+		return err
+	}
+
+	imageRepo := imagerepo.NewImageRepo(mongodb.NewCollection(collections.CustomImage))
+	imageAppService := imageapp.NewImageService(
+		imageRepo, imagemsg.MessageAdapter(&cfg.Image.Message, publisher),
+		imageorg.NewOrgImpl(comprepositoryadapter.ComputilityDetailAdapter()),
+	)
+
+	cloudRepo := cloudrepo.NewCloudRepo(mongodb.NewCollection(collections.CloudConf))
+	podRepo := cloudrepo.NewPodRepo(&cfg.Postgresql.Cloud)
+	cloudPublisher := cloudmsg.NewPublisher(&cfg.Cloud.Config, publisher)
+
+	podRequestRepo, err := cloudrepo.NewPodRequestRepo(&cfg.Postgresql.Cloud)
+	if err != nil {
+		return err
+	}
+
 	cloudAppService := cloudapp.NewCloudService(
-		cloudrepo.NewCloudRepo(mongodb.NewCollection(collections.CloudConf)),
-		cloudrepo.NewPodRepo(&cfg.Postgresql.Cloud),
-		cloudmsg.NewPublisher(&cfg.Cloud, publisher),
-		whitelist,
+		cloudRepo, podRepo, podRequestRepo, cloudPublisher, whitelist,
+		cloudimage.NewImageImpl(imageAppService),
+	)
+
+	go startCloudScheduler(
+		cloudapp.NewCloudScheduleService(cloudRepo, podRepo, podRequestRepo, cloudPublisher),
+	)
+
+	wukongGallery, err := bigmodelrepo.NewWuKongGalleryRepo(
+		mongodb.NewCollection(collections.WuKongGallery),
 	)
+	if err != nil {
+		return err
+	}
 
 	bigmodelAppService := bigmodelapp.NewBigModelService(
 		bigmodel, user,
 		bigmodelrepo.NewLuoJiaRepo(mongodb.NewCollection(collections.LuoJia)),
 		bigmodelrepo.NewWuKongRepo(mongodb.NewCollection(collections.WuKong)),
 		bigmodelrepo.NewWuKongPictureRepo(mongodb.NewCollection(collections.WuKongPicture)),
+		wukongGallery,
 		bigmodelasynccli.NewAsyncCli(asyncAppService),
 		bigmodelmsg.NewMessageAdapter(&cfg.BigModel.Message, publisher),
 		bigmodelrepo.NewApiService(mongodb.NewCollection(collections.ApiApply)),
 		bigmodelrepo.NewApiInfo(mongodb.NewCollection(collections.ApiInfo)),
-		userRegService,
+		userRegService, moderationAppService,
+	)
+
+	bigmodelApiKeyService := bigmodelapp.NewApiKeyService(
+		&cfg.BigModel.ApiKey,
+		bigmodelrepo.NewApiKeyRepo(mongodb.NewCollection(collections.ApiKey)),
+		bigmodelrepo.NewApiService(mongodb.NewCollection(collections.ApiApply)),
+	)
+
+	bigmodelApiUsageService := bigmodelapp.NewApiUsageService(
+		&cfg.BigModel.ApiLimit,
+		bigmodelrepo.NewApiUsageRepo(mongodb.NewCollection(collections.ApiUsage)),
+		ratelimiterimpl.NewRateLimiter(),
+	)
+
+	bigmodelConversationService := bigmodelapp.NewConversationService(
+		&cfg.BigModel.Conversation,
+		bigmodelrepo.NewConversationRepo(mongodb.NewCollection(collections.Conversation)),
+		bigmodelAppService,
+	)
+
+	go startConversationCleaner(bigmodelConversationService)
+
+	bigmodelWuKongCollectionService := bigmodelapp.NewWuKongCollectionService(
+		bigmodelrepo.NewWuKongCollectionRepo(mongodb.NewCollection(collections.WuKongCollection)),
+		bigmodelAppService,
 	)
 
 	//Init filescan
@@ -279,10 +419,6 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 	)
 	fileScanService := filescan.NewFileScanService(fileScanAdapter, moderationEventPublisher)
 
-	err = comprepositoryadapter.Init(pgsql.DB(), &cfg.Computility.Tables)
-	if err != nil {
-		return err
-	}
 	computilityService := computilityapp.NewComputilityInternalAppService(
 		comprepositoryadapter.ComputilityDetailAdapter(),
 		comprepositoryadapter.ComputilityAccountAdapter(),
@@ -297,12 +433,12 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 	spaceProducer := spaceinfra.NewSpaceProducer(&cfg.Space.Topics, publisher)
 
 	projectService := spaceapp.NewProjectService(
-		user, proj, model, dataset, activity, resProducer, computilityService, spaceProducer, audit,
+		user, proj, model, dataset, activity, resProducer, computilityService, spaceProducer, auditService,
 	)
 
-	modelService := app.NewModelService(user, model, proj, dataset, activity, nil, resProducer, audit)
+	modelService := app.NewModelService(user, model, proj, dataset, activity, nil, resProducer, auditService)
 
-	datasetService := app.NewDatasetService(user, dataset, proj, model, activity, nil, resProducer, audit)
+	datasetService := app.NewDatasetService(user, dataset, proj, model, activity, nil, resProducer, auditService)
 
 	v1 := engine.Group(docs.SwaggerInfo.BasePath)
 	internal := engine.Group("internal")
@@ -314,7 +450,7 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 
 	userAppService := userapp.NewUserService(
 		user, gitlabUser, usermsg.MessageAdapter(&cfg.User.Message, publisher),
-		pointsAppService, controller.EncryptHelperToken(), audit,
+		pointsAppService, controller.EncryptHelperToken(), auditService,
 	)
 
 	promotionAppService := promotionapp.NewPromotionService(
@@ -324,11 +460,20 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 		promotionRepo,
 	)
 
+	aiccRepo := aiccrepo.NewAICCFinetuneRepo(mongodb.NewCollection(collections.AICCFinetune))
+
+	jobEventBus := eventbusimpl.NewEventBus(&cfg.JobEvent)
+	jobNotifier := jobapp.NewJobDetailNotifier(jobEventBus)
+	aiccInternalService := aiccapp.NewAICCFinetuneInternalService(aiccRepo, jobNotifier)
+
 	aiccAppService := aiccapp.NewAICCFinetuneService(
 		aiccFinetune,
 		aiccmsg.NewMessageAdapter(&cfg.AICCFinetune.Message, publisher),
 		aiccUploader,
-		aiccrepo.NewAICCFinetuneRepo(mongodb.NewCollection(collections.AICCFinetune)),
+		aiccRepo,
+		aiccrepo.NewDatasetRepo(mongodb.NewCollection(collections.AICCFinetune)),
+		&cfg.AICCFinetune.Dataset,
+		aiccInternalService,
 		5,
 	)
 
@@ -350,21 +495,21 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 	{
 		controller.AddRouterForProjectController(
 			v1, user, model, dataset, activity, tags, like, resProducer,
-			newPlatformRepository, computilityService, spaceProducer, audit, proj,
+			newPlatformRepository, computilityService, spaceProducer, auditService, proj,
 		)
 		controller.AddRouterForProjectInternalController(
 			internal, user, model, dataset, activity, tags, like, resProducer,
-			newPlatformRepository, computilityService, spaceProducer, proj, audit,
+			newPlatformRepository, computilityService, spaceProducer, proj, auditService,
 		)
 
 		controller.AddRouterForModelController(
 			v1, user, model, proj, dataset, activity, tags, like, resProducer,
-			newPlatformRepository, audit,
+			newPlatformRepository, auditService,
 		)
 
 		controller.AddRouterForDatasetController(
 			v1, user, dataset, model, proj, activity, tags, like, resProducer,
-			newPlatformRepository, audit,
+			newPlatformRepository, auditService,
 		)
 
 		controller.AddRouterForUserController(
@@ -384,8 +529,10 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 			v1, activity, user, proj, model, dataset,
 		)
 
+		jobHub := controller.NewJobHub(jobEventBus)
+
 		controller.AddRouterForAICCFinetuneController(
-			v1, aiccAppService, promotionAppService,
+			v1, aiccAppService, promotionAppService, jobHub,
 		)
 
 		controller.AddRouterForTagsController(
@@ -393,18 +540,80 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 		)
 
 		controller.AddRouterForBigModelController(
-			v1, bigmodelAppService, userRegService,
+			v1, bigmodelAppService, bigmodelApiKeyService, bigmodelApiUsageService,
+			bigmodelConversationService, bigmodelWuKongCollectionService, userRegService,
+		)
+
+		controller.AddRouterForBigModelInternalController(
+			internal, bigmodelapp.NewEndpointService(bigmodels.NewEndpointRegistry()),
+			bigmodelAppService, bigmodelWuKongCollectionService,
+		)
+
+		controller.AddRouterForModerationInternalController(
+			internal, moderationAppService,
+		)
+
+		trainingSender := messages.NewTrainingMessageAdapter(
+			&cfg.Training.Message, publisher,
 		)
 
 		controller.AddRouterForTrainingController(
 			v1, trainingAdapter, training, model, proj, dataset,
-			messages.NewTrainingMessageAdapter(
-				&cfg.Training.Message, publisher,
+			trainingSweep, trainingMetric, trainingPipeline, trainingSchedule,
+			trainingSender, modelService, gitlabRepo, newPlatformRepository,
+			jobHub, jobNotifier,
+		)
+
+		trainingService := app.NewTrainingService(
+			trainingAdapter, training, trainingSweep, trainingMetric, trainingPipeline,
+			trainingSender, jobNotifier, cfg.API.MaxTrainingRecordNum,
+		)
+
+		go startTrainingScheduler(
+			app.NewTrainingScheduler(
+				trainingService, trainingSchedule, gitlabRepo,
+				func(a domain.Account) (string, error) {
+					u, err := userAppService.GetByAccount(a)
+
+					return u.Platform.Token, err
+				},
 			),
 		)
 
+		controller.AddRouterForTrainingInternalController(
+			internal, training, trainingMetric,
+		)
+
 		controller.AddRouterForFinetuneController(
-			v1, finetuneImpl, finetune, sender,
+			v1, finetuneImpl, finetune, sender, jobHub,
+		)
+
+		jobDrivers := []jobapp.Driver{
+			app.NewTrainingJobDriver(trainingAdapter, training, trainingService),
+			app.NewFinetuneJobDriver(
+				finetuneImpl, finetune,
+				app.NewFinetuneInternalService(finetune, jobNotifier),
+			),
+			aiccapp.NewAICCFinetuneJobDriver(aiccFinetune, aiccRepo, aiccInternalService),
+		}
+
+		jobEvaluation := jobrepo.NewJobEvaluationRepo(
+			mongodb.NewCollection(collections.JobEvaluation),
+		)
+
+		controller.AddRouterForJobController(
+			v1, jobapp.NewJobService(jobDrivers...),
+			jobapp.NewJobModelService(
+				&cfg.JobModel.App, jobEvaluation,
+				modelserviceimpl.NewModelService(&cfg.JobModel.Service),
+				jobDrivers...,
+			),
+			jobHub,
+		)
+
+		controller.AddRouterForJobInternalController(
+			internal, jobapp.NewJobInternalService(jobDrivers...),
+			jobapp.NewJobEvaluationInternalService(jobEvaluation),
 		)
 
 		controller.AddRouterForRepoFileController(
@@ -431,6 +640,10 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 			v1, competitionAppService, userRegService, proj,
 		)
 
+		controller.AddRouterForCompetitionInternalController(
+			internal, competitionCheatingService,
+		)
+
 		controller.AddRouterForPromotionController(
 			v1, promotionAppService, promotionpointsAppService,
 		)
@@ -443,6 +656,10 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 			v1, courseAppService, userRegService, proj, user,
 		)
 
+		controller.AddRouterForCourseInternalController(
+			internal, courseAppService,
+		)
+
 		controller.AddRouterForHomeController(
 			v1, courseAppService, competitionAppService, projectService, modelService, datasetService, promotionAppService,
 		)
@@ -451,6 +668,14 @@ func setRouter(engine *gin.Engine, cfg *config.Config) error {
 			v1, cloudAppService, userWhiteListService,
 		)
 
+		controller.AddRouterForImageController(
+			v1, imageAppService, proj,
+		)
+
+		controller.AddRouterForImageInternalController(
+			internal, imageapp.NewImageInternalService(imageRepo),
+		)
+
 		controller.AddRouterForComputilityWebController(
 			v1, computilityWebService,
 		)
@@ -501,6 +726,36 @@ func addRouterForUserPointsController(g *gin.RouterGroup, cfg *config.Config) (
 	return pointsAppService, nil
 }
 
+func startCloudScheduler(s cloudapp.CloudScheduleService) {
+	ticker := time.NewTicker(time.Duration(clouddomain.ScheduleInterval()) * time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.Schedule()
+	}
+}
+
+// startTrainingScheduler checks the training schedules every minute
+// which is the precision of cron expression.
+func startTrainingScheduler(s app.TrainingScheduler) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.Schedule()
+	}
+}
+
+// startConversationCleaner deletes the inactive conversations every hour.
+func startConversationCleaner(s bigmodelapp.ConversationService) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		s.Clean()
+	}
+}
+
 func logRequest() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		startTime := time.Now()